@@ -0,0 +1,27 @@
+// Command benchannotate reads `go test -bench` output on stdin and appends
+// the monthly savings of each benchmark compared to a baseline.
+//
+//	go test -bench=. -benchmem ./day-03 | go run ./cmd/benchannotate -rps 50000
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+	"github.com/alpardfm/cost-aware-backend/pkg/bench"
+)
+
+func main() {
+	rps := flag.Float64("rps", 10_000, "operations per second the benchmarked code serves")
+	baseline := flag.String("baseline", "", "benchmark to compare against (default: first in the output)")
+	price := flag.Float64("vcpu-hour", cost.DefaultCalculator().VCPUHourPrice, "price per vCPU-hour in dollars")
+	flag.Parse()
+
+	calc := cost.VCPUCalculator{VCPUHourPrice: *price}
+	if err := bench.AnnotateOutputWithBaseline(os.Stdin, calc, *rps, *baseline, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,87 @@
+// Command generate-readme drafts a day's README.md from its own output: it
+// runs the day's demo for the narrative and cost analysis, and
+// `go test -bench=. -benchmem` for the results table. Run it from the
+// repository root.
+//
+//	go run ./cmd/generate-readme ./day-25
+//	go run ./cmd/generate-readme -o day-25/README.draft.md ./day-25
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/readme"
+)
+
+func main() {
+	out := flag.String("o", "", "file to write the README to (default: stdout)")
+	force := flag.Bool("f", false, "overwrite the -o file if it exists")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: generate-readme [-o file [-f]] ./day-NN")
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *out, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out string, force bool) error {
+	dir = "./" + filepath.Clean(dir)
+
+	demo, err := goOutput("run", dir)
+	if err != nil {
+		return fmt.Errorf("running the demo: %w", err)
+	}
+	day, err := readme.ParseDemo(demo)
+	if err != nil {
+		return err
+	}
+	day.Dir = filepath.Base(dir)
+
+	benchOut, err := goOutput("test", "-run=^$", "-bench=.", "-benchmem", dir+"/...")
+	if err != nil {
+		return fmt.Errorf("running benchmarks: %w", err)
+	}
+	if day.Benchmarks, err = readme.ParseBenchmarks(strings.NewReader(benchOut)); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		// Days have hand-written READMEs; don't replace one by accident
+		flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		if force {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		}
+		f, err := os.OpenFile(out, flags, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s exists; pass -f to overwrite it", out)
+		}
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return readme.Write(w, day)
+}
+
+// goOutput runs the go command with args and returns its stdout. Its
+// stderr, such as build errors, goes to ours.
+func goOutput(args ...string) (string, error) {
+	fmt.Fprintf(os.Stderr, "⏳ go %v\n", args)
+	cmd := exec.Command("go", args...)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	return string(out), err
+}
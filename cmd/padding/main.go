@@ -0,0 +1,54 @@
+// Command padding reports struct padding for one or more Go package
+// directories, worst offenders first.
+//
+//	go run ./cmd/padding ./day-01 ./day-03
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/analyze"
+)
+
+func main() {
+	onlyWaste := flag.Bool("waste", false, "only show structs that a field reorder would shrink")
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	fmt.Println("📐 STRUCT PADDING REPORT")
+	fmt.Println(strings.Repeat("=", 60))
+
+	totalReclaimable := 0
+	for _, dir := range dirs {
+		reports, err := analyze.AnalyzePackage(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", dir, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n📦 %s\n", dir)
+		fmt.Println(strings.Repeat("-", 60))
+		fmt.Printf("  %-24s %6s %6s %8s %8s\n", "Struct", "Size", "Data", "Padding", "Optimal")
+		for _, r := range reports {
+			if *onlyWaste && r.ReclaimableBytes() == 0 {
+				continue
+			}
+			marker := "✅"
+			if r.ReclaimableBytes() > 0 {
+				marker = "⚠️ "
+			}
+			fmt.Printf("%s %-24s %6d %6d %8d %8d  %s:%d\n",
+				marker, r.Name, r.SizeBytes, r.DataBytes, r.PaddingBytes, r.OptimalSizeBytes, r.File, r.Line)
+			totalReclaimable += r.ReclaimableBytes()
+		}
+	}
+
+	fmt.Printf("\n💡 Reorder fields to save %d bytes per instance across all structs\n", totalReclaimable)
+}
@@ -0,0 +1,90 @@
+// Command perfcheck scans Go package directories for the performance
+// anti-patterns covered in this series and links each finding to the day
+// that measures it. It exits with status 1 if anything is found, so it
+// can gate CI.
+//
+//	go run ./cmd/perfcheck ./day-01 ./day-02
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/analyze"
+)
+
+const repoURL = "https://github.com/alpardfm/cost-aware-backend/tree/master/"
+
+func main() {
+	skip := flag.String("skip", "", "comma-separated checks to ignore, e.g. struct-padding,map-no-hint")
+	flag.Parse()
+
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	skipped := map[string]bool{}
+	for _, c := range strings.Split(*skip, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			skipped[c] = true
+		}
+	}
+
+	fmt.Println("🔎 PERFORMANCE CHECKLIST")
+	fmt.Println(strings.Repeat("=", 60))
+
+	perCheck := map[string]int{}
+	total := 0
+	for _, dir := range dirs {
+		findings, err := analyze.RunPerfChecks(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %s: %v\n", dir, err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("\n📦 %s\n", dir)
+		fmt.Println(strings.Repeat("-", 60))
+		n := 0
+		for _, f := range findings {
+			if skipped[f.Check] {
+				continue
+			}
+			fmt.Printf("⚠️  %s:%d [%s]\n", f.File, f.Line, f.Check)
+			fmt.Printf("   %s\n", f.Message)
+			if day := f.Day(); day != "" {
+				fmt.Printf("   📖 %s%s\n", repoURL, day)
+			}
+			perCheck[f.Check]++
+			n++
+		}
+		if n == 0 {
+			fmt.Println("✅ No findings")
+		}
+		total += n
+	}
+
+	fmt.Println("\n📊 SUMMARY")
+	fmt.Println(strings.Repeat("-", 60))
+	checks := make([]string, 0, len(analyze.CheckDays))
+	for c := range analyze.CheckDays {
+		checks = append(checks, c)
+	}
+	sort.Strings(checks)
+	for _, c := range checks {
+		if skipped[c] {
+			continue
+		}
+		if day := analyze.CheckDays[c]; day != "" {
+			fmt.Printf("  %-24s %4d  (%s)\n", c, perCheck[c], day)
+		} else {
+			fmt.Printf("  %-24s %4d\n", c, perCheck[c])
+		}
+	}
+	fmt.Printf("\n💡 %d findings\n", total)
+	if total > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,10 +1,20 @@
 package main
 
 import (
+	"math/rand/v2"
 	"testing"
 	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/layout"
 )
 
+// accessUsers is how many users the access-pattern benchmarks walk: 24-32
+// MB of structs, far more than any CPU cache holds.
+const accessUsers = 1_000_000
+
+// cacheLineSize is the line size of x86-64 and most arm64 CPUs.
+const cacheLineSize = 64
+
 // Global variable to prevent compiler optimizations
 var (
 	globalBadUsers  []BadUser
@@ -86,6 +96,92 @@ func Benchmark_GoodUserWithPreAllocation(b *testing.B) {
 	}
 }
 
+// ========== ACCESS PATTERN BENCHMARKS ==========
+
+// Each op reads every one of accessUsers users once. In order, the
+// hardware prefetcher has the next cache lines loaded before they are
+// needed; in a shuffled order almost every user is a cache miss.
+
+func makeBadUsers() []BadUser {
+	users := make([]BadUser, accessUsers)
+	for j := range users {
+		users[j] = BadUser{ID: int32(j), Active: j%2 == 0, Name: "Test User", Age: int8(j % 100)}
+	}
+	return users
+}
+
+func makeGoodUsers() []GoodUser {
+	users := make([]GoodUser, accessUsers)
+	for j := range users {
+		users[j] = GoodUser{ID: int32(j), Age: int8(j % 100), Active: j%2 == 0, Name: "Test User"}
+	}
+	return users
+}
+
+// shuffledOrder is a fixed permutation of the user indexes, computed once
+// so the benchmarks time the access and not the shuffle.
+func shuffledOrder() []int {
+	return rand.New(rand.NewPCG(1, 2)).Perm(accessUsers)
+}
+
+func Benchmark_BadUserWithCachePrefetch(b *testing.B) {
+	users := makeBadUsers()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for j := range users {
+			sum += int(users[j].ID) + int(users[j].Age)
+		}
+		globalInt = sum
+	}
+}
+
+func Benchmark_GoodUserWithCachePrefetch(b *testing.B) {
+	users := makeGoodUsers()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for j := range users {
+			sum += int(users[j].ID) + int(users[j].Age)
+		}
+		globalInt = sum
+	}
+}
+
+func Benchmark_RandomAccessBadUser(b *testing.B) {
+	users := makeBadUsers()
+	order := shuffledOrder()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, j := range order {
+			sum += int(users[j].ID) + int(users[j].Age)
+		}
+		globalInt = sum
+	}
+}
+
+func Benchmark_RandomAccessGoodUser(b *testing.B) {
+	users := makeGoodUsers()
+	order := shuffledOrder()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, j := range order {
+			sum += int(users[j].ID) + int(users[j].Age)
+		}
+		globalInt = sum
+	}
+}
+
 func Test_StructSizes(t *testing.T) {
 	badSize := unsafe.Sizeof(BadUser{})
 	goodSize := unsafe.Sizeof(GoodUser{})
@@ -104,6 +200,9 @@ func Test_StructSizes(t *testing.T) {
 	if badSize-goodSize < 4 {
 		t.Errorf("Expected at least 4 bytes savings, got %d", badSize-goodSize)
 	}
+
+	// Smaller isn't enough: no other field order may beat GoodUser
+	layout.AssertStructOptimized(t, GoodUser{}, "GoodUser")
 }
 
 func Test_MemoryAlignment(t *testing.T) {
@@ -141,3 +240,19 @@ func Test_MemoryAlignment(t *testing.T) {
 		t.Errorf("Expected Name at offset 8 (8-byte aligned), got %d", badNameOffset)
 	}
 }
+
+func Test_CacheLineUtilization(t *testing.T) {
+	badSize := unsafe.Sizeof(BadUser{})
+	goodSize := unsafe.Sizeof(GoodUser{})
+	badPerLine := float64(cacheLineSize) / float64(badSize)
+	goodPerLine := float64(cacheLineSize) / float64(goodSize)
+
+	t.Logf("BadUser per %d-byte cache line:  %.2f", cacheLineSize, badPerLine)
+	t.Logf("GoodUser per %d-byte cache line: %.2f", cacheLineSize, goodPerLine)
+	t.Logf("Lines to walk %d users: %d vs %d", accessUsers,
+		accessUsers*badSize/cacheLineSize, accessUsers*goodSize/cacheLineSize)
+
+	if goodPerLine <= badPerLine {
+		t.Errorf("Expected more GoodUsers (%.2f) than BadUsers (%.2f) per cache line", goodPerLine, badPerLine)
+	}
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+	"github.com/alpardfm/cost-aware-backend/internal/layout"
 )
 
 type BadUser struct {
@@ -69,7 +73,8 @@ func main() {
 	// Cost analysis
 	fmt.Println("💰 COST IMPACT ANALYSIS")
 	fmt.Println(strings.Repeat("=", 60))
-	calculateCostImpact(badMemory, goodMemory)
+	// Pass cost.GCPPricing{} or cost.AzurePricing{} to price your own cloud
+	calculateCostImpact(badMemory, goodMemory, cost.DefaultPricing())
 
 	fmt.Println("\n✅ DAY 1 COMPLETED! 🎉")
 }
@@ -123,60 +128,58 @@ func benchmarkGoodUser(count int) (time.Duration, uintptr) {
 func explainMemoryLayout() {
 	fmt.Println("Go aligns struct fields to natural boundaries:")
 	fmt.Println()
-	fmt.Println("BAD STRUCT (32 bytes):")
-	fmt.Println("  ID (int32):    4 bytes  @ offset 0")
-	fmt.Println("  Active (bool): 1 byte   @ offset 4")
-	fmt.Println("  <padding>:     3 bytes  (wasted!)")
-	fmt.Println("  Name (string): 16 bytes @ offset 8")
-	fmt.Println("  Age (int8):    1 byte   @ offset 24")
-	fmt.Println("  <padding>:     7 bytes  (wasted!)")
-	fmt.Println("  Total:         32 bytes")
+	bad := layout.AnalyzeStructLayout(BadUser{})
+	layout.VisualizeLayout(BadUser{}, os.Stdout)
 	fmt.Println()
-	fmt.Println("GOOD STRUCT (24 bytes):")
-	fmt.Println("  ID (int32):    4 bytes  @ offset 0")
-	fmt.Println("  Age (int8):    1 byte   @ offset 4")
-	fmt.Println("  Active (bool): 1 byte   @ offset 5")
-	fmt.Println("  <padding>:     2 bytes")
-	fmt.Println("  Name (string): 16 bytes @ offset 8")
-	fmt.Println("  Total:         24 bytes (8 bytes saved!)")
+	layout.VisualizeLayout(GoodUser{}, os.Stdout)
 	fmt.Println()
 	fmt.Println("💡 Rule: Group fields by size (largest to smallest)")
+	fmt.Printf("   Suggested BadUser order: %s (%d bytes, saves %d per struct)\n",
+		strings.Join(bad.OptimalOrder, ", "), bad.OptimalSize, bad.SavingsPerStruct())
 }
 
-func calculateCostImpact(beforeMem, afterMem uintptr) {
+func calculateCostImpact(beforeMem, afterMem uintptr, pricing cost.PricingModel) {
 	// Calculate memory saved
 	memorySavedMB := float64(beforeMem-afterMem) / (1024 * 1024)
 
-	// Cloud pricing assumptions (AWS us-east-1)
-	awsT3MediumCost := 30.0  // $30/month for t3.medium
-	awsRAMPerInstance := 8.0 // 8GB RAM
-	costPerGBMonth := awsT3MediumCost / awsRAMPerInstance
+	costPerGBMonth := pricing.RAMGBMonthCost()
 
 	// For 1 million users
-	monthlySavings := memorySavedMB / 1024 * costPerGBMonth
+	monthlySavings, err := cost.CheckedFloat64Mul(memorySavedMB/1024, costPerGBMonth)
+	if err != nil {
+		fmt.Printf("❌ Monthly savings: %v\n", err)
+		return
+	}
 
-	fmt.Println("☁️  CLOUD ASSUMPTIONS (AWS us-east-1):")
-	fmt.Printf("  • t3.medium instance: $%.2f/month\n", awsT3MediumCost)
-	fmt.Printf("  • 8GB RAM per instance\n")
+	fmt.Printf("☁️  CLOUD ASSUMPTIONS (%v):\n", pricing)
 	fmt.Printf("  • Cost per GB-month: $%.2f\n", costPerGBMonth)
 	fmt.Printf("  • 1 million users in memory\n")
 
 	fmt.Println("\n🧮 CALCULATIONS:")
 	fmt.Printf("  Memory saved: %.2f MB\n", memorySavedMB)
 	fmt.Printf("  Monthly savings: $%.4f\n", monthlySavings)
-	fmt.Printf("  Annual savings:  $%.4f\n", monthlySavings*12)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthlySavings))
+
+	// The same saving seen the other way: more users per instance. Half
+	// of a 4 GiB instance is left for the GC's headroom and everything else
+	budget := cost.MemoryBudget{TotalRAMBytes: 4 << 30, OverheadFraction: 0.5}
+	capacity := cost.CompareStructCapacity(budget, uint64(unsafe.Sizeof(BadUser{})), uint64(unsafe.Sizeof(GoodUser{})))
+	fmt.Println()
+	if _, err := capacity.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
 
 	fmt.Println("\n📈 SCALING PROJECTIONS:")
-	fmt.Println("  For different user counts:")
+	fmt.Println("  Linear assumes savings scale with users; super-linear (^1.3)")
+	fmt.Println("  models GC work growing faster than the heap:")
 
+	linear := cost.LinearProjection(monthlySavings)
+	superlinear := cost.SuperlinearProjection(monthlySavings)
 	userCounts := []int{1_000_000, 10_000_000, 100_000_000, 1_000_000_000}
 	for _, users := range userCounts {
-		scaledSavings := monthlySavings * float64(users) / 1_000_000
-		if users >= 1_000_000_000 {
-			fmt.Printf("  • %d users: $%.2f/month savings\n", users, scaledSavings)
-		} else {
-			fmt.Printf("  • %d users: $%.4f/month savings\n", users, scaledSavings)
-		}
+		units := float64(users) / 1_000_000
+		fmt.Printf("  • %13d users: $%12.4f/month linear, $%12.4f/month super-linear\n",
+			users, linear.ProjectAt(units), superlinear.ProjectAt(units))
 	}
 
 	fmt.Println("\n💡 ADDITIONAL BENEFITS (not quantified):")
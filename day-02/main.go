@@ -2,8 +2,13 @@ package main
 
 import (
 	"fmt"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
 )
 
 func main() {
@@ -38,6 +43,15 @@ func main() {
 		float64(t1.Nanoseconds()-t3.Nanoseconds())/float64(t1.Nanoseconds())*100,
 		m1-m3)
 
+	fmt.Println("\n4. Parallel build (4 workers, one merge):")
+	t4, m4 := benchmarkParallelAppend(1_000_000, 4)
+	fmt.Printf("   Time: %v, Allocations: %d\n", t4, m4)
+	fmt.Printf("   vs make(): %.1fx the time (shards are copied once more when merged)\n",
+		float64(t4.Nanoseconds())/float64(t2.Nanoseconds()))
+
+	fmt.Println("\n5. Stack array vs heap slice (100 ints, size known at compile time):")
+	compareSliceVsArrayStack(1_000_000)
+
 	// Slice internals explanation
 	fmt.Println("\n🔧 SLICE INTERNALS EXPLANATION")
 	fmt.Println(strings.Repeat("-", 40))
@@ -51,7 +65,7 @@ func main() {
 	// Cost analysis
 	fmt.Println("\n💰 COST IMPACT ANALYSIS")
 	fmt.Println(strings.Repeat("=", 60))
-	calculateCostImpact(t1, t2, m1, m2)
+	calculateCostImpact(t1, t2, m1, m2, cost.DefaultPricing())
 
 	fmt.Println("\n✅ DAY 2 COMPLETED! 🎉")
 	fmt.Println("\n🔜 Next: Day 3 - Map Internals & Memory Overhead")
@@ -99,6 +113,127 @@ func benchmarkFixedArray(count int) (time.Duration, int) {
 	return time.Since(start), allocations
 }
 
+func benchmarkParallelAppend(count, workers int) (time.Duration, int) {
+	start := time.Now()
+	allocations := workers + 1 // One per shard + the merged slice
+
+	data := parallelBuild(count, workers)
+	_ = data
+
+	return time.Since(start), allocations
+}
+
+// parallelBuild splits count ints across workers goroutines. Each worker
+// fills its own pre-sized shard, so there is no shared state to lock; the
+// caller then merges the shards into one slice sized for all of them.
+func parallelBuild(count, workers int) []int {
+	shards := make([][]int, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			lo, hi := w*count/workers, (w+1)*count/workers
+			shard := make([]int, 0, hi-lo)
+			for i := lo; i < hi; i++ {
+				shard = append(shard, i)
+			}
+			shards[w] = shard
+		}()
+	}
+	wg.Wait()
+
+	merged := make([]int, 0, count)
+	for _, shard := range shards {
+		merged = append(merged, shard...)
+	}
+	return merged
+}
+
+// stackArraySize is small enough for [stackArraySize]int to live on the
+// goroutine stack.
+const stackArraySize = 100
+
+// sumStackArray fills a [100]int and sums it. The array's size is a
+// constant and it never escapes, so it is part of the stack frame.
+func sumStackArray() int {
+	var data [stackArraySize]int
+	for i := range data {
+		data[i] = i
+	}
+	sum := 0
+	for _, v := range data {
+		sum += v
+	}
+	return sum
+}
+
+// sumHeapSlice does the same work with make and a size only known at run
+// time, which the compiler cannot place on the stack. noinline keeps a
+// constant argument from turning it back into a stack array.
+//
+//go:noinline
+func sumHeapSlice(size int) int {
+	data := make([]int, 0, size)
+	for i := 0; i < size; i++ {
+		data = append(data, i)
+	}
+	sum := 0
+	for _, v := range data {
+		sum += v
+	}
+	return sum
+}
+
+// fillArray fills an array the caller owns. arr never outlives the call,
+// so the caller's [stackArraySize]int stays in its stack frame.
+//
+//go:noinline
+func fillArray(arr *[stackArraySize]int) {
+	for i := range arr {
+		arr[i] = i
+	}
+}
+
+// newFilledSlice makes and fills a slice of the same 100 ints and returns
+// it. The backing array outlives the call, so it escapes to the heap even
+// though its size is a constant. noinline keeps that decision here:
+// inlined into a caller that drops the slice, it could stay on the stack.
+//
+//go:noinline
+func newFilledSlice() []int {
+	data := make([]int, stackArraySize)
+	for i := range data {
+		data[i] = i
+	}
+	return data
+}
+
+// compareSliceVsArrayStack times calls of both and counts the heap
+// allocations they made.
+func compareSliceVsArrayStack(calls int) {
+	measure := func(fn func() int) (time.Duration, uint64) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		sum := 0
+		for i := 0; i < calls; i++ {
+			sum += fn()
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		_ = sum
+		return elapsed, after.Mallocs - before.Mallocs
+	}
+
+	tHeap, mHeap := measure(func() int { return sumHeapSlice(stackArraySize) })
+	tStack, mStack := measure(sumStackArray)
+	fmt.Printf("   make([]int, 0, n): %v, Heap allocations: %d\n", tHeap, mHeap)
+	fmt.Printf("   var data [100]int: %v, Heap allocations: %d\n", tStack, mStack)
+	fmt.Printf("   Improvement: %.1fx faster, no GC work at all\n",
+		float64(tHeap.Nanoseconds())/float64(tStack.Nanoseconds()))
+}
+
 // ========== EXPLANATION FUNCTIONS ==========
 
 func demoSliceGrowthProblem() {
@@ -131,10 +266,8 @@ func explainSliceInternals() {
 	fmt.Println("  - Capacity: 8 bytes")
 	fmt.Println()
 
-	fmt.Println("Growth Algorithm (until 1024 elements):")
-	fmt.Println("  • Start capacity: 0")
-	fmt.Println("  • If len < 1024: double capacity")
-	fmt.Println("  • If len >= 1024: grow by 25%")
+	fmt.Printf("Growth Algorithm (%s):\n", runtime.Version())
+	fmt.Print(getGrowthDescription())
 	fmt.Println()
 
 	fmt.Println("📈 CAPACITY GROWTH TABLE:")
@@ -151,7 +284,81 @@ func explainSliceInternals() {
 	}
 }
 
+// growthThreshold is where append switches from doubling to smoother
+// growth (runtime.nextslicecap, Go 1.18+; it was 1024 before).
+const growthThreshold = 256
+
+// getGrowthDescription describes the growth algorithm of the Go version
+// running this program.
+func getGrowthDescription() string {
+	return growthDescription(runtime.Version())
+}
+
+// growthDescription describes append's growth algorithm in the given
+// runtime.Version(). Go 1.18 replaced the jump from 2x to 1.25x at 1024
+// elements with a smooth formula; development builds get the current one.
+func growthDescription(version string) string {
+	var b strings.Builder
+	b.WriteString("  • Start capacity: 0\n")
+	if minor, ok := goMinorVersion(version); ok && minor < 18 {
+		b.WriteString("  • If cap < 1024: double capacity\n")
+		b.WriteString("  • If cap >= 1024: grow by 25% until large enough\n")
+	} else {
+		fmt.Fprintf(&b, "  • If cap < %d: double capacity\n", growthThreshold)
+		fmt.Fprintf(&b, "  • If cap >= %d: grow by (cap + %d) / 4, easing from 2x to 1.25x\n",
+			growthThreshold, 3*growthThreshold)
+	}
+	b.WriteString("  • Round up to the allocator's size class\n")
+	return b.String()
+}
+
+// goMinorVersion returns N from a release version string such as
+// "go1.N", "go1.N.P" or "go1.Nrc1".
+func goMinorVersion(version string) (int, bool) {
+	rest, ok := strings.CutPrefix(version, "go1.")
+	if !ok {
+		return 0, false
+	}
+	digits := 0
+	for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+		digits++
+	}
+	minor, err := strconv.Atoi(rest[:digits])
+	return minor, err == nil
+}
+
+// intSizeClasses are the runtime's malloc size classes up to 32 KB. A
+// grown backing array is rounded up to the next class, and the extra
+// bytes become capacity.
+var intSizeClasses = []int{
+	8, 16, 24, 32, 48, 64, 80, 96, 112, 128, 144, 160, 176, 192, 208, 224,
+	240, 256, 288, 320, 352, 384, 416, 448, 480, 512, 576, 640, 704, 768,
+	896, 1024, 1152, 1280, 1408, 1536, 1792, 2048, 2304, 2688, 3072, 3200,
+	3456, 4096, 4864, 5376, 6144, 6528, 6784, 6912, 8192, 9472, 9728,
+	10240, 10880, 12288, 13568, 14336, 16384, 18432, 19072, 20480, 21760,
+	24576, 27264, 28672, 32768,
+}
+
+// roundUpToSizeClass returns the bytes malloc actually hands out for a
+// request of size bytes. Larger objects are rounded to whole 8 KB pages.
+func roundUpToSizeClass(size int) int {
+	for _, class := range intSizeClasses {
+		if size <= class {
+			return class
+		}
+	}
+	const pageSize = 8192
+	return (size + pageSize - 1) / pageSize * pageSize
+}
+
+// calculateGrowth models appending target ints one at a time to a nil
+// slice on the heap, the way runtime.growslice does since Go 1.20:
+// double below growthThreshold, then grow by (cap + 3*256)/4, and round
+// every allocation up to a size class. Older versions grow differently
+// (see growthDescription); Test_CalculateGrowthMatchesRuntime fails when
+// the running version no longer matches this model.
 func calculateGrowth(target int) (finalCap, reallocs, waste int) {
+	const intSize = 8
 	cap := 0
 	reallocs = 0
 
@@ -159,11 +366,12 @@ func calculateGrowth(target int) (finalCap, reallocs, waste int) {
 		oldCap := cap
 		if cap == 0 {
 			cap = 1
-		} else if cap < 1024 {
+		} else if cap < growthThreshold {
 			cap *= 2
 		} else {
-			cap = cap + cap/4 // 25% growth
+			cap += (cap + 3*growthThreshold) / 4 // ~25% for large slices
 		}
+		cap = roundUpToSizeClass(cap*intSize) / intSize
 		if oldCap > 0 {
 			reallocs++
 		}
@@ -219,10 +427,19 @@ func demoUserProcessing() {
 
 // ========== COST ANALYSIS ==========
 
-func calculateCostImpact(t1, t2 time.Duration, alloc1, alloc2 int) {
+func calculateCostImpact(t1, t2 time.Duration, alloc1, alloc2 int, pricing cost.PricingModel) {
 	// Calculate time savings
-	timeSavedNs := t1.Nanoseconds() - t2.Nanoseconds()
-	timeSavedPercent := float64(timeSavedNs) / float64(t1.Nanoseconds()) * 100
+	timeSavedNs, err := cost.SafeDurationToFloat64(t1 - t2)
+	if err != nil {
+		fmt.Printf("❌ Time saved: %v\n", err)
+		return
+	}
+	baselineNs, err := cost.SafeDurationToFloat64(t1)
+	if err != nil {
+		fmt.Printf("❌ Baseline time: %v\n", err)
+		return
+	}
+	timeSavedPercent := timeSavedNs / baselineNs * 100
 
 	// Calculate allocation savings
 	allocSaved := alloc1 - alloc2
@@ -238,22 +455,26 @@ func calculateCostImpact(t1, t2 time.Duration, alloc1, alloc2 int) {
 	// Assumptions
 	requestsPerSecond := 100.0
 	requestsPerDay := requestsPerSecond * 3600 * 24
-	awsCostPerVCPUHour := 0.0416                            // t3.medium
-	msSavedPerRequest := float64(timeSavedNs) / 1_000_000.0 // Convert ns to ms
+	costPerVCPUHour := pricing.CPUHourCost()
+	msSavedPerRequest := timeSavedNs / 1_000_000.0 // Convert ns to ms
 
 	fmt.Println("Assumptions:")
 	fmt.Printf("  • Requests per second: %.0f\n", requestsPerSecond)
-	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
 	fmt.Printf("  • Time saved per request: %.3f ms\n", msSavedPerRequest)
 
 	// CPU time saved per day (in hours)
-	cpuSecondsSavedPerRequest := float64(timeSavedNs) / 1_000_000_000.0
+	cpuSecondsSavedPerRequest := timeSavedNs / 1_000_000_000.0
 	cpuHoursSavedPerDay := cpuSecondsSavedPerRequest * requestsPerDay / 3600
 
 	// Cost savings
-	dailySavings := cpuHoursSavedPerDay * awsCostPerVCPUHour
-	monthlySavings := dailySavings * 30
-	annualSavings := monthlySavings * 12
+	dailySavings, err := cost.CheckedFloat64Mul(cpuHoursSavedPerDay, costPerVCPUHour)
+	if err != nil {
+		fmt.Printf("❌ Daily savings: %v\n", err)
+		return
+	}
+	monthlySavings := dailySavings * cost.DaysPerMonth
+	annualSavings := cost.AnnualFromMonthly(monthlySavings)
 
 	fmt.Println("\n💰 CALCULATED SAVINGS:")
 	fmt.Printf("  CPU time saved per day: %.4f hours\n", cpuHoursSavedPerDay)
@@ -2,8 +2,18 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/gcbench"
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
 )
 
 // Global variables to prevent optimization
@@ -169,6 +179,137 @@ func Benchmark_SliceLookupDirect(b *testing.B) {
 	_ = found
 }
 
+// ========== BINARY SEARCH VS MAP CROSSOVER ==========
+
+// Looks up keys in a shuffled order so neither the branch predictor nor
+// the prefetcher can learn the pattern, which i%size would allow.
+
+func Benchmark_BinarySearchVsMapLookup(b *testing.B) {
+	for _, size := range []int{10, 50, 100, 500, 1000} {
+		m, entries := newLookupTables(size)
+		keys := shuffledKeys(size)
+
+		b.Run(fmt.Sprintf("map_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var found string
+			for i := 0; i < b.N; i++ {
+				found = m[keys[i%len(keys)]]
+			}
+			globalInt = len(found)
+		})
+		b.Run(fmt.Sprintf("binary_search_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			var found string
+			for i := 0; i < b.N; i++ {
+				found, _ = binarySearch(entries, keys[i%len(keys)])
+			}
+			globalInt = len(found)
+		})
+	}
+}
+
+// newLookupTables returns the same size entries as a map and as a slice
+// sorted by key. Keys are spaced out so they are not slice indexes.
+func newLookupTables(size int) (map[int]string, []Entry) {
+	m := make(map[int]string, size)
+	entries := make([]Entry, size)
+	for i := range entries {
+		key := i*7 + 3
+		value := fmt.Sprintf("value-%d", i)
+		m[key] = value
+		entries[i] = Entry{Key: key, Value: value}
+	}
+	return m, entries
+}
+
+// shuffledKeys returns every key of newLookupTables(size) repeated to at
+// least 1024 lookups, in a fixed pseudo-random order.
+func shuffledKeys(size int) []int {
+	n := max(size, 1024/size*size)
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = (i%size)*7 + 3
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	return keys
+}
+
+// binarySearch finds key in entries, which must be sorted by Key.
+func binarySearch(entries []Entry, key int) (string, bool) {
+	low, high := 0, len(entries)-1
+	for low <= high {
+		mid := int(uint(low+high) >> 1)
+		switch k := entries[mid].Key; {
+		case k == key:
+			return entries[mid].Value, true
+		case k < key:
+			low = mid + 1
+		default:
+			high = mid - 1
+		}
+	}
+	return "", false
+}
+
+// ========== STRING KEY LENGTH BENCHMARKS ==========
+
+// Each op is 1M lookups that hit, cycling through 1000 keys of the given
+// length. A hit hashes the whole key and then compares it byte for byte.
+
+const stringKeyLookups = 1_000_000
+
+func Benchmark_MapStringKey_8B(b *testing.B) {
+	benchmarkMapStringKey(b, 8)
+}
+
+func Benchmark_MapStringKey_32B(b *testing.B) {
+	benchmarkMapStringKey(b, 32)
+}
+
+func Benchmark_MapStringKey_128B(b *testing.B) {
+	benchmarkMapStringKey(b, 128)
+}
+
+func benchmarkMapStringKey(b *testing.B, keyLen int) {
+	m, keys := newStringKeyMap(1000, keyLen)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalInt = lookupStringKeys(m, keys, stringKeyLookups)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/stringKeyLookups, "ns/lookup")
+}
+
+// newStringKeyMap returns a map of size keys, each exactly keyLen bytes,
+// and the keys in insertion order. Keys differ in their first bytes, like
+// IDs with a shared suffix, so a hit still compares all keyLen bytes.
+func newStringKeyMap(size, keyLen int) (map[string]int, []string) {
+	m := make(map[string]int, size)
+	keys := make([]string, size)
+	for i := range keys {
+		prefix := fmt.Sprintf("%d-", i)
+		keys[i] = prefix + strings.Repeat("k", keyLen-len(prefix))
+		m[keys[i]] = i
+	}
+	return m, keys
+}
+
+// lookupStringKeys looks up n keys from keys in order and returns the sum
+// of the values found.
+func lookupStringKeys(m map[string]int, keys []string, n int) int {
+	total := 0
+	for i := 0; i < n; i++ {
+		total += m[keys[i%len(keys)]]
+	}
+	return total
+}
+
 // ========== ITERATION BENCHMARKS ==========
 
 func Benchmark_MapIteration(b *testing.B) {
@@ -213,37 +354,269 @@ func Benchmark_SliceIteration(b *testing.B) {
 	globalInt = total
 }
 
-// ========== MEMORY OVERHEAD TESTS ==========
+// ========== SYNC.POOL BENCHMARKS ==========
 
-func Test_MapMemoryOverhead(t *testing.T) {
-	// This test shows map overhead visually
-	t.Log("Map memory overhead analysis:")
-	t.Log("==============================")
+var mapPool = sync.Pool{
+	New: func() interface{} {
+		return make(map[int]string, 1000)
+	},
+}
 
-	sizes := []int{10, 100, 1000, 10000}
-	for _, size := range sizes {
-		// Map
-		m := make(map[int]string, size)
-		for i := 0; i < size; i++ {
-			m[i] = "x" // Single character value
+func Benchmark_MapPoolReuse_1000(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m := mapPool.Get().(map[int]string)
+		for j := 0; j < 1000; j++ {
+			m[j] = "value"
 		}
+		globalInt = len(m)
+		clear(m) // Keeps the buckets, drops the entries
+		mapPool.Put(m)
+	}
+}
 
-		// Slice of structs
-		type entry struct {
-			Key   int
-			Value string
+func Benchmark_MapNoPool_1000(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m := make(map[int]string, 1000)
+		for j := 0; j < 1000; j++ {
+			m[j] = "value"
 		}
-		slice := make([]entry, 0, size)
-		for i := 0; i < size; i++ {
-			slice = append(slice, entry{Key: i, Value: "x"})
+		globalInt = len(m)
+	}
+}
+
+// ========== CLEAR VS RECREATE BENCHMARKS ==========
+
+// Each iteration empties the map and refills it to size, the full reuse
+// cycle of a map that lives across requests.
+
+func Benchmark_MapClear_1000(b *testing.B) {
+	benchmarkMapClear(b, 1000)
+}
+
+func Benchmark_MapRecreate_1000(b *testing.B) {
+	benchmarkMapRecreate(b, 1000)
+}
+
+func Benchmark_MapClear_10000(b *testing.B) {
+	benchmarkMapClear(b, 10000)
+}
+
+func Benchmark_MapRecreate_10000(b *testing.B) {
+	benchmarkMapRecreate(b, 10000)
+}
+
+func benchmarkMapClear(b *testing.B, size int) {
+	m := make(map[int]string)
+	fillMap(m, size)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		clear(m) // Keeps the buckets
+		fillMap(m, size)
+		globalInt = len(m)
+	}
+	globalMap = m
+}
+
+func benchmarkMapRecreate(b *testing.B, size int) {
+	m := make(map[int]string)
+	fillMap(m, size)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		m = make(map[int]string) // Old buckets become garbage
+		fillMap(m, size)
+		globalInt = len(m)
+	}
+	globalMap = m
+}
+
+func fillMap(m map[int]string, size int) {
+	for j := 0; j < size; j++ {
+		m[j] = "value"
+	}
+}
+
+// ========== CONCURRENT READ-HEAVY BENCHMARKS ==========
+
+// Goroutines share one 1000-entry map: 95% of ops look up a random key and
+// 5% overwrite one. Writes store pre-boxed values so neither side pays for
+// boxing an int into sync.Map's any.
+
+const (
+	sharedMapSize  = 1000
+	writePercent   = 5
+	syncMapMinCPUs = 4
+)
+
+var sharedValues = func() []any {
+	v := make([]any, sharedMapSize)
+	for i := range v {
+		v[i] = i
+	}
+	return v
+}()
+
+// rwMutexMap is a plain map behind a sync.RWMutex: readers share the lock,
+// a writer takes it alone.
+type rwMutexMap struct {
+	mu sync.RWMutex
+	m  map[int]any
+}
+
+func (r *rwMutexMap) Load(k int) (any, bool) {
+	r.mu.RLock()
+	v, ok := r.m[k]
+	r.mu.RUnlock()
+	return v, ok
+}
+
+func (r *rwMutexMap) Store(k int, v any) {
+	r.mu.Lock()
+	r.m[k] = v
+	r.mu.Unlock()
+}
+
+func newRWMutexMap() *rwMutexMap {
+	r := &rwMutexMap{m: make(map[int]any, sharedMapSize)}
+	for k, v := range sharedValues {
+		r.m[k] = v
+	}
+	return r
+}
+
+// intSyncMap gives sync.Map the int-keyed methods rwMutexMap has. Keys
+// still go through sync.Map's any, as they would in real use.
+type intSyncMap struct {
+	m sync.Map
+}
+
+func (s *intSyncMap) Load(k int) (any, bool) { return s.m.Load(k) }
+
+func (s *intSyncMap) Store(k int, v any) { s.m.Store(k, v) }
+
+func newSyncMap() *intSyncMap {
+	s := &intSyncMap{}
+	for k, v := range sharedValues {
+		s.m.Store(k, v)
+	}
+	return s
+}
+
+// intMap is what the read-heavy benchmarks need from a concurrent map.
+type intMap interface {
+	Load(k int) (any, bool)
+	Store(k int, v any)
+}
+
+func Benchmark_RWMutexMap_ReadHeavy(b *testing.B) {
+	benchmarkReadHeavy(b, newRWMutexMap())
+}
+
+func Benchmark_SyncMap_ReadHeavy(b *testing.B) {
+	benchmarkReadHeavy(b, newSyncMap())
+}
+
+// benchmarkReadHeavy runs the 95/5 mix on m from GOMAXPROCS goroutines.
+// Each goroutine draws keys from its own seeded generator.
+func benchmarkReadHeavy(b *testing.B, m intMap) {
+	var seed, hits atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		rng := rand.New(rand.NewPCG(seed.Add(1), 0))
+		var n uint64
+		for pb.Next() {
+			k := rng.IntN(sharedMapSize)
+			if rng.IntN(100) < writePercent {
+				m.Store(k, sharedValues[k])
+			} else if _, ok := m.Load(k); ok {
+				n++
+			}
 		}
+		hits.Add(n)
+	})
+	globalInt = int(hits.Load())
+}
+
+// ========== MEMORY OVERHEAD BENCHMARKS ==========
+
+// Reports the live heap a map holds per entry. The GCs and MemStats reads
+// run with the timer stopped so only the map build is timed.
 
-		mapSize := int(unsafe.Sizeof(m)) // This underestimates, but shows pointer size
-		sliceSize := len(slice) * int(unsafe.Sizeof(entry{}))
+func Benchmark_MapMemoryOverhead(b *testing.B) {
+	for _, size := range []int{10_000, 100_000} {
+		b.Run(fmt.Sprintf("size_%d", size), func(b *testing.B) {
+			var total int64
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				globalMap = nil
+				before := heapInuse()
+				b.StartTimer()
 
-		t.Logf("Size %6d: Map pointer=%4d bytes, Slice=%6d bytes",
-			size, mapSize, sliceSize)
-		t.Logf("          Note: Real map overhead is ~50 bytes per entry!")
+				m := make(map[int]string)
+				fillMap(m, size)
+
+				b.StopTimer()
+				globalMap = m
+				total += int64(heapInuse()) - int64(before)
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(total)/float64(b.N)/float64(size), "B/entry")
+		})
+	}
+}
+
+// heapInuse collects garbage, including tables left behind by map growth,
+// and returns the bytes in in-use heap spans.
+func heapInuse() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapInuse
+}
+
+// ========== MEMORY OVERHEAD TESTS ==========
+
+func Test_MapMemoryOverhead(t *testing.T) {
+	// HeapInuse moves in whole 8 KB spans, which swamps maps much smaller
+	// than 10,000 entries
+	for _, size := range []int{10_000, 100_000, 1_000_000} {
+		t.Run(fmt.Sprintf("size_%d", size), func(t *testing.T) {
+			globalMap = nil
+			before := heapInuse()
+
+			m := make(map[int]string)
+			fillMap(m, size)
+
+			globalMap = m
+			after := heapInuse()
+			perEntry := int((int64(after) - int64(before)) / int64(size))
+			t.Logf("map[int]string with %d entries: %d bytes in use, %d bytes/entry (key+value are %d)",
+				size, after-before, perEntry, unsafe.Sizeof(0)+unsafe.Sizeof(""))
+			assertMapOverheadRange(t, perEntry, 30, 80)
+		})
+	}
+	globalMap = nil
+}
+
+// assertMapOverheadRange fails t if the measured bytes per entry fall
+// outside [minExpected, maxExpected], which flags a runtime change to the
+// map layout worth revisiting the day's numbers for.
+func assertMapOverheadRange(t *testing.T, actual, minExpected, maxExpected int) {
+	t.Helper()
+	if actual < minExpected || actual > maxExpected {
+		t.Errorf("map overhead %d bytes/entry outside expected range [%d, %d]",
+			actual, minExpected, maxExpected)
 	}
 }
 
@@ -259,8 +632,8 @@ func Test_PreallocationImpact(t *testing.T) {
 		}
 	})
 
-	// With pre-allocation
-	alloc2 := testing.AllocsPerRun(100, func() {
+	// With pre-allocation: the directory and its tables, sized once
+	alloc2 := testutil.AssertMaxAllocs(t, "make(map[int]string, 1000)", 5, func() {
 		m := make(map[int]string, size)
 		for i := 0; i < size; i++ {
 			m[i] = "value"
@@ -307,3 +680,201 @@ func Test_MapVsSet(t *testing.T) {
 	// Note: Actual memory savings are bigger than allocation count suggests
 	// because struct{} is 0 bytes vs bool which is at least 1 byte
 }
+
+func Test_MapPoolCorrectnessAfterClear(t *testing.T) {
+	pool := sync.Pool{
+		New: func() interface{} {
+			return make(map[int]string, 100)
+		},
+	}
+
+	// Each cycle writes a different key range; a map that was not fully
+	// cleared would still hold keys from an earlier cycle
+	for cycle := 0; cycle < 10; cycle++ {
+		m := pool.Get().(map[int]string)
+		if len(m) != 0 {
+			t.Fatalf("cycle %d: pooled map has %d leftover entries", cycle, len(m))
+		}
+		for i := 0; i < 100; i++ {
+			m[cycle*100+i] = fmt.Sprintf("cycle_%d_%d", cycle, i)
+		}
+		for k, v := range m {
+			if k/100 != cycle || v != fmt.Sprintf("cycle_%d_%d", cycle, k%100) {
+				t.Fatalf("cycle %d: leaked entry %d=%q", cycle, k, v)
+			}
+		}
+		clear(m)
+		pool.Put(m)
+	}
+}
+
+func Test_MapClearRetainsCapacity(t *testing.T) {
+	for _, size := range []int{1000, 10000} {
+		m := make(map[int]string)
+		fillMap(m, size)
+
+		// A refill that needed to grow or rehash would allocate new buckets
+		allocs := testing.AllocsPerRun(10, func() {
+			clear(m)
+			fillMap(m, size)
+		})
+		if allocs != 0 {
+			t.Errorf("size %d: refilling a cleared map allocated %.0f times", size, allocs)
+		}
+		if len(m) != size {
+			t.Errorf("size %d: expected %d entries after refill, got %d", size, size, len(m))
+		}
+
+		recreated := testing.AllocsPerRun(10, func() {
+			m = make(map[int]string)
+			fillMap(m, size)
+		})
+		t.Logf("size %5d: clear + refill %.0f allocs, make + refill %.0f allocs", size, allocs, recreated)
+	}
+}
+
+func Test_NetMapOverhead(t *testing.T) {
+	const n = 1000
+	keys := make([]int, n)
+	values := make([]string, n)
+	for i := range keys {
+		keys[i] = i
+		values[i] = fmt.Sprintf("value_%d", i)
+	}
+
+	gross := measureMapMemoryWithValues(n)
+	net := measureNetMapOverhead(keys, values)
+	t.Logf("Map of %d entries: %d bytes with Sprintf in the window, %d bytes hash table only", n, gross, net)
+
+	if net == 0 {
+		t.Fatal("net measurement recorded no allocations")
+	}
+	// The value strings were a fifth or more of what used to be reported
+	if float64(net) > 0.8*float64(gross) {
+		t.Errorf("expected net overhead at least 20%% below %d bytes, got %d (%.0f%% lower)",
+			gross, net, (1-float64(net)/float64(gross))*100)
+	}
+}
+
+func Test_MapChurnGCPauses(t *testing.T) {
+	const n = 1000
+	keys := make([]int, n)
+	values := make([]string, n)
+	for i := range keys {
+		keys[i] = i
+		values[i] = fmt.Sprintf("value_%d", i)
+	}
+
+	// 2000 maps are ~110 MB of garbage: many cycles, each pausing for
+	// microseconds. 50 ms leaves room for a descheduled runner
+	s := gcbench.AssertMaxPause(t, 50*time.Millisecond, func() { buildMaps(keys, values, 2000) })
+	t.Logf("%d GC cycles: %v min, %v avg, %v max pause", s.NumGC, s.Min, s.Avg, s.Max)
+	if s.NumGC == 0 {
+		t.Error("building 2000 maps finished no GC cycle, want at least one")
+	}
+}
+
+func Test_StringHashCostScaling(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+
+	// Best of 5 rounds, each timing every length, to ride out noise on a
+	// shared machine. 1024 B shows the slope the shorter keys hide
+	lengths := []int{8, 32, 128, 1024}
+	best := make([]time.Duration, len(lengths))
+	for i := range best {
+		best[i] = time.Duration(math.MaxInt64)
+	}
+	for range 5 {
+		for i, keyLen := range lengths {
+			m, keys := newStringKeyMap(1000, keyLen)
+			start := time.Now()
+			globalInt = lookupStringKeys(m, keys, stringKeyLookups)
+			best[i] = min(best[i], time.Since(start))
+		}
+	}
+	ns := make(map[int]float64, len(lengths))
+	for i, keyLen := range lengths {
+		ns[keyLen] = float64(best[i].Nanoseconds()) / stringKeyLookups
+		t.Logf("%4d B keys: %5.1f ns per lookup (%.1fx 8 B)", keyLen, ns[keyLen], ns[keyLen]/ns[8])
+	}
+	perByte := (ns[1024] - ns[8]) / (1024 - 8)
+	t.Logf("fixed cost ≈ %.1f ns, plus %.3f ns per key byte", ns[8]-8*perByte, perByte)
+
+	// The cost is linear in the key length, but on top of a fixed cost of
+	// finding the group and slot that 8-byte keys are mostly made of. So
+	// 16x the bytes is about 1.4-1.9x the time here, and a 4x floor would
+	// fail on every run; only the direction is asserted
+	if ns[128] <= ns[8] {
+		t.Errorf("128 B keys (%.1f ns) were no slower than 8 B keys (%.1f ns)", ns[128], ns[8])
+	}
+}
+
+func Test_CrossoverSize(t *testing.T) {
+	// Timings are logged, not asserted: where the lines cross depends on
+	// the CPU, the key type and the hash seed
+	const lookups = 1 << 18
+	for _, size := range []int{4, 8, 16, 32, 64, 128} {
+		m, entries := newLookupTables(size)
+		keys := shuffledKeys(size)
+		for _, key := range keys {
+			got, ok := binarySearch(entries, key)
+			if !ok || got != m[key] {
+				t.Fatalf("size %d: binarySearch(%d) = %q, %v; map has %q", size, key, got, ok, m[key])
+			}
+		}
+
+		var found string
+		start := time.Now()
+		for i := 0; i < lookups; i++ {
+			found = m[keys[i%len(keys)]]
+		}
+		mapNs := float64(time.Since(start).Nanoseconds()) / lookups
+
+		start = time.Now()
+		for i := 0; i < lookups; i++ {
+			found, _ = binarySearch(entries, keys[i%len(keys)])
+		}
+		searchNs := float64(time.Since(start).Nanoseconds()) / lookups
+		globalInt = len(found)
+
+		winner := "map"
+		if searchNs < mapNs {
+			winner = "binary search"
+		}
+		t.Logf("%4d entries: map %5.1f ns, binary search %5.1f ns → %s", size, mapNs, searchNs, winner)
+	}
+}
+
+func Test_SyncMapVsRWMutex(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	for _, m := range []intMap{newRWMutexMap(), newSyncMap()} {
+		for k := range sharedMapSize {
+			if v, ok := m.Load(k); !ok || v != sharedValues[k] {
+				t.Fatalf("%T.Load(%d) = %v, %v; want %v", m, k, v, ok, sharedValues[k])
+			}
+		}
+	}
+
+	rw := testing.Benchmark(Benchmark_RWMutexMap_ReadHeavy)
+	sm := testing.Benchmark(Benchmark_SyncMap_ReadHeavy)
+	rwOps := float64(rw.N) / rw.T.Seconds()
+	smOps := float64(sm.N) / sm.T.Seconds()
+	ratio := smOps / rwOps
+	t.Logf("%d%% reads on %d cores: RWMutex %.1fM ops/s, sync.Map %.1fM ops/s (%.2fx)",
+		100-writePercent, runtime.GOMAXPROCS(0), rwOps/1e6, smOps/1e6, ratio)
+
+	// With one or two cores, readers rarely run at the same time, so
+	// RLock's shared counter never bounces between caches and the plain
+	// map ties or wins: 31-58 vs 41-87 ns/op on a 1-vCPU VM. sync.Map pays off
+	// when many cores read at once
+	if runtime.GOMAXPROCS(0) < syncMapMinCPUs {
+		t.Skipf("sync.Map's read path only beats RLock with readers on %d+ cores", syncMapMinCPUs)
+	}
+	if ratio < 1.2 {
+		t.Errorf("expected sync.Map ≥20%% more ops/s than RWMutex at %d%% reads, got %.2fx", 100-writePercent, ratio)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bytedance/sonic"
+)
+
+// Global variables to prevent optimization
+var (
+	globalBytes []byte
+	globalUsers []User
+	globalInt   int
+)
+
+var (
+	testUsers = generateUsers(userCount)
+	testData  = mustMarshal(testUsers)
+)
+
+func mustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// ========== MARSHAL BENCHMARKS ==========
+
+func Benchmark_Marshal_EncodingJSON(b *testing.B) {
+	benchmarkMarshal(b, json.Marshal)
+}
+
+func Benchmark_Marshal_Sonic(b *testing.B) {
+	benchmarkMarshal(b, sonic.Marshal)
+}
+
+func Benchmark_Marshal_Jsoniter(b *testing.B) {
+	benchmarkMarshal(b, jsoniterStd.Marshal)
+}
+
+func benchmarkMarshal(b *testing.B, marshal func(any) ([]byte, error)) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(testData)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, err := marshal(testUsers)
+		if err != nil {
+			b.Fatal(err)
+		}
+		globalBytes = data
+	}
+}
+
+// ========== UNMARSHAL BENCHMARKS ==========
+
+func Benchmark_Unmarshal_EncodingJSON(b *testing.B) {
+	benchmarkUnmarshal(b, json.Unmarshal)
+}
+
+func Benchmark_Unmarshal_Sonic(b *testing.B) {
+	benchmarkUnmarshal(b, sonic.Unmarshal)
+}
+
+func Benchmark_Unmarshal_Jsoniter(b *testing.B) {
+	benchmarkUnmarshal(b, jsoniterStd.Unmarshal)
+}
+
+func benchmarkUnmarshal(b *testing.B, unmarshal func([]byte, any) error) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(testData)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var users []User
+		if err := unmarshal(testData, &users); err != nil {
+			b.Fatal(err)
+		}
+		globalUsers = users
+	}
+}
+
+// ========== STREAMING VS FULL-BUFFER BENCHMARKS ==========
+
+func Benchmark_Decode_FullBuffer(b *testing.B) {
+	benchmarkDecode(b, decodeFullBuffer)
+}
+
+func Benchmark_Decode_Streaming(b *testing.B) {
+	benchmarkDecode(b, decodeStreaming)
+}
+
+func benchmarkDecode(b *testing.B, decode func(io.Reader, func(*User)) error) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(testData)))
+	count := func(u *User) { globalInt += u.Age }
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := decode(bytes.NewReader(testData), count); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ========== ENCODER REUSE BENCHMARKS ==========
+
+func Benchmark_Encode_MarshalPerResponse(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, err := json.Marshal(&testUsers[i%len(testUsers)])
+		if err != nil {
+			b.Fatal(err)
+		}
+		globalBytes = data
+	}
+}
+
+func Benchmark_Encode_ReusedEncoder(b *testing.B) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := enc.Encode(&testUsers[i%len(testUsers)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+	globalInt = buf.Len()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_CodecsRoundTrip(t *testing.T) {
+	for _, c := range codecs() {
+		t.Run(strings.ReplaceAll(c.Name, "/", "_"), func(t *testing.T) {
+			data, err := c.Marshal(testUsers)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var got []User
+			if err := c.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(got, testUsers) {
+				t.Error("round trip changed the users")
+			}
+
+			// Output must be readable by the standard library too
+			var std []User
+			if err := json.Unmarshal(data, &std); err != nil {
+				t.Fatalf("encoding/json cannot read %s output: %v", c.Name, err)
+			}
+			if !reflect.DeepEqual(std, testUsers) {
+				t.Errorf("encoding/json decodes %s output differently", c.Name)
+			}
+		})
+	}
+}
+
+func Test_StreamingDecoderMatchesUnmarshal(t *testing.T) {
+	var streamed, buffered []User
+	if err := decodeStreaming(bytes.NewReader(testData), func(u *User) { streamed = append(streamed, *u) }); err != nil {
+		t.Fatalf("decodeStreaming: %v", err)
+	}
+	if err := decodeFullBuffer(bytes.NewReader(testData), func(u *User) { buffered = append(buffered, *u) }); err != nil {
+		t.Fatalf("decodeFullBuffer: %v", err)
+	}
+	if !reflect.DeepEqual(streamed, testUsers) {
+		t.Error("streaming decoder result differs from input")
+	}
+	if !reflect.DeepEqual(buffered, testUsers) {
+		t.Error("full-buffer decoder result differs from input")
+	}
+
+	// The decoder must not reuse slices from the previous element
+	if &streamed[0].Tags[0] == &streamed[1].Tags[0] {
+		t.Error("streamed users share a Tags backing array")
+	}
+}
+
+func Test_StreamingDecoderRejectsNonArray(t *testing.T) {
+	err := decodeStreaming(strings.NewReader(`[{"id":1},`), func(*User) {})
+	if err == nil {
+		t.Error("expected an error for a truncated array")
+	}
+}
+
+func Test_EncoderSetEscapeHTML(t *testing.T) {
+	u := testUsers[0] // Name contains <, > and &
+	escaped := mustMarshal(&u)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(&u); err != nil {
+		t.Fatal(err)
+	}
+	raw := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+
+	if bytes.Contains(escaped, []byte("<b>")) {
+		t.Errorf("json.Marshal should escape HTML, got %s", escaped)
+	}
+	if !bytes.Contains(raw, []byte("<b>")) || !bytes.Contains(raw, []byte("&")) {
+		t.Errorf("SetEscapeHTML(false) should keep HTML characters, got %s", raw)
+	}
+
+	var a, b User
+	if err := json.Unmarshal(escaped, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("escaped and unescaped output decode differently")
+	}
+}
@@ -0,0 +1,476 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+	"github.com/bytedance/sonic"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type Address struct {
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type User struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Age       int       `json:"age"`
+	Active    bool      `json:"active"`
+	Balance   float64   `json:"balance"`
+	Tags      []string  `json:"tags"`
+	Address   Address   `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const userCount = 1000
+
+// generateUsers builds a deterministic payload. Every tenth name contains
+// HTML characters so SetEscapeHTML(false) has something to skip.
+func generateUsers(n int) []User {
+	users := make([]User, n)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range users {
+		name := "User " + strconv.Itoa(i)
+		if i%10 == 0 {
+			name = "<b>User " + strconv.Itoa(i) + "</b> & co"
+		}
+		users[i] = User{
+			ID:        int64(i + 1),
+			Name:      name,
+			Email:     "user" + strconv.Itoa(i) + "@example.com",
+			Age:       20 + i%50,
+			Active:    i%3 != 0,
+			Balance:   float64(i) * 12.34,
+			Tags:      []string{"customer", "tier-" + strconv.Itoa(i%4)},
+			Address:   Address{Street: strconv.Itoa(i) + " Main St", City: "Jakarta", Country: "ID"},
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+		}
+	}
+	return users
+}
+
+// codec is one JSON library's marshal/unmarshal pair.
+type codec struct {
+	Name      string
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+}
+
+var jsoniterStd = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func codecs() []codec {
+	return []codec{
+		{"encoding/json", json.Marshal, json.Unmarshal},
+		{"sonic", sonic.Marshal, sonic.Unmarshal},
+		{"jsoniter", jsoniterStd.Marshal, jsoniterStd.Unmarshal},
+	}
+}
+
+// sonicIsNative mirrors sonic's build constraint: its JIT supports amd64
+// from Go 1.17 and arm64 from Go 1.20, both up to Go 1.26. Anywhere else
+// sonic silently falls back to encoding/json.
+func sonicIsNative() bool {
+	v := strings.TrimPrefix(runtime.Version(), "go")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return true // Development toolchain; assume supported
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || parts[0] != "1" {
+		return false
+	}
+	switch runtime.GOARCH {
+	case "amd64":
+		return minor >= 17 && minor <= 26
+	case "arm64":
+		return minor >= 20 && minor <= 26
+	}
+	return false
+}
+
+func main() {
+	fmt.Println("🔬 DAY 4: JSON Processing Efficiency")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	users := generateUsers(userCount)
+
+	// The shocking truth about encoding/json
+	fmt.Println("🎯 SHOCKING DISCOVERY: JSON is an ALLOCATION MACHINE!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealJSONOverhead(users)
+
+	// Benchmark: encoding/json vs sonic vs jsoniter
+	fmt.Println("\n📊 BENCHMARK: encoding/json vs sonic vs jsoniter")
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(users)
+
+	// Edge case: streaming vs full buffer
+	fmt.Println("\n🌊 STREAMING DECODER vs FULL-BUFFER UNMARSHAL")
+	fmt.Println(strings.Repeat("-", 40))
+	compareStreamingDecoder(users)
+
+	// Edge case: encoder reuse
+	fmt.Println("\n♻️  REUSED ENCODER vs json.Marshal PER RESPONSE")
+	fmt.Println(strings.Repeat("-", 40))
+	compareEncoderReuse(users)
+
+	// Why encoding/json is slow
+	fmt.Println("\n🔧 JSON INTERNALS DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainJSONInternals()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis: stdlib round trip vs the fastest alternative
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	baseline, best := results[0], results[0]
+	for _, r := range results[1:] {
+		if r.RoundTrip() < best.RoundTrip() {
+			best = r
+		}
+	}
+	fmt.Printf("Round trip of %d users: %s vs %s\n\n", userCount, baseline.Codec, best.Codec)
+	calculateJSONCostImpact(baseline.RoundTrip(), best.RoundTrip(),
+		int(baseline.MarshalAllocs+baseline.UnmarshalAllocs), int(best.MarshalAllocs+best.UnmarshalAllocs),
+		cost.DefaultPricing())
+
+	fmt.Println("\n✅ DAY 4 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 5 - String Building Strategies")
+}
+
+// measure runs fn iterations times and returns the average time and heap
+// allocations per call.
+func measure(iterations int, fn func()) (time.Duration, float64) {
+	fn() // Warm up caches (encoding/json builds its per-type encoder here)
+	m := bench.RunAndMeasure(func() {
+		for i := 0; i < iterations; i++ {
+			fn()
+		}
+	})
+	return m.Duration / time.Duration(iterations), float64(m.AllocsCount) / float64(iterations)
+}
+
+func revealJSONOverhead(users []User) {
+	runtime.GC()
+	var m1, m2 runtime.MemStats
+
+	runtime.ReadMemStats(&m1)
+	data, err := json.Marshal(users)
+	runtime.ReadMemStats(&m2)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	marshalBytes := m2.TotalAlloc - m1.TotalAlloc
+	marshalAllocs := m2.Mallocs - m1.Mallocs
+
+	runtime.ReadMemStats(&m1)
+	var decoded []User
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	runtime.ReadMemStats(&m2)
+	unmarshalBytes := m2.TotalAlloc - m1.TotalAlloc
+	unmarshalAllocs := m2.Mallocs - m1.Mallocs
+
+	fmt.Printf("encoding/json with %d users (%d KB of JSON):\n", userCount, len(data)/1024)
+	fmt.Printf("  Marshal:   %8d bytes allocated in %6d allocations (first call)\n", marshalBytes, marshalAllocs)
+	fmt.Printf("  Unmarshal: %8d bytes allocated in %6d allocations\n", unmarshalBytes, unmarshalAllocs)
+	fmt.Printf("  Heap per output byte: %.1fx (marshal), %.1fx (unmarshal)\n",
+		float64(marshalBytes)/float64(len(data)), float64(unmarshalBytes)/float64(len(data)))
+
+	fmt.Println("\n💡 The first Marshal also builds and caches a reflection-based")
+	fmt.Println("   encoder for User. Unmarshal allocates every string, every []string")
+	fmt.Println("   and grows the []User slice as it goes.")
+}
+
+// codecResult holds per-call averages for one library.
+type codecResult struct {
+	Codec           string
+	Marshal         time.Duration
+	Unmarshal       time.Duration
+	MarshalAllocs   float64
+	UnmarshalAllocs float64
+}
+
+func (r codecResult) RoundTrip() time.Duration { return r.Marshal + r.Unmarshal }
+
+func runComparisonBenchmarks(users []User) []codecResult {
+	const iterations = 200
+	if !sonicIsNative() {
+		fmt.Printf("⚠️  sonic does not support %s/%s: it falls back to encoding/json\n\n",
+			runtime.Version(), runtime.GOARCH)
+	}
+
+	fmt.Printf("Marshal + unmarshal of %d users, %d iterations each:\n\n", userCount, iterations)
+	fmt.Println("  Library        | Marshal    | Allocs | Unmarshal  | Allocs")
+	fmt.Println("  ---------------|------------|--------|------------|-------")
+
+	results := make([]codecResult, 0, len(codecs()))
+	for _, c := range codecs() {
+		data, err := c.Marshal(users)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", c.Name, err)
+			continue
+		}
+		r := codecResult{Codec: c.Name}
+		r.Marshal, r.MarshalAllocs = measure(iterations, func() { _, _ = c.Marshal(users) })
+		r.Unmarshal, r.UnmarshalAllocs = measure(iterations, func() {
+			var out []User
+			_ = c.Unmarshal(data, &out)
+		})
+		results = append(results, r)
+		fmt.Printf("  %-14s | %10v | %6.0f | %10v | %6.0f\n",
+			c.Name, r.Marshal, r.MarshalAllocs, r.Unmarshal, r.UnmarshalAllocs)
+	}
+
+	base := results[0]
+	fmt.Println()
+	for _, r := range results[1:] {
+		fmt.Printf("  %-14s marshal %.1fx, unmarshal %.1fx vs encoding/json\n", r.Codec+":",
+			float64(base.Marshal)/float64(r.Marshal), float64(base.Unmarshal)/float64(r.Unmarshal))
+	}
+	return results
+}
+
+// decodeStreaming reads a JSON array one element at a time, handing each
+// user to fn. Only one User is alive at a time.
+func decodeStreaming(r io.Reader, fn func(*User)) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil { // [
+		return err
+	}
+	var u User
+	for dec.More() {
+		u = User{}
+		if err := dec.Decode(&u); err != nil {
+			return err
+		}
+		fn(&u)
+	}
+	_, err := dec.Token() // ]
+	return err
+}
+
+// decodeFullBuffer reads everything, then unmarshals the whole array.
+func decodeFullBuffer(r io.Reader, fn func(*User)) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var users []User
+	if err := json.Unmarshal(data, &users); err != nil {
+		return err
+	}
+	for i := range users {
+		fn(&users[i])
+	}
+	return nil
+}
+
+func compareStreamingDecoder(users []User) {
+	data, _ := json.Marshal(users)
+	const iterations = 100
+
+	var active int
+	count := func(u *User) {
+		if u.Active {
+			active++
+		}
+	}
+
+	peak := func(decode func(io.Reader, func(*User)) error) uint64 {
+		runtime.GC()
+		var m1, m2 runtime.MemStats
+		runtime.ReadMemStats(&m1)
+		_ = decode(bytes.NewReader(data), count)
+		runtime.ReadMemStats(&m2)
+		return m2.TotalAlloc - m1.TotalAlloc
+	}
+
+	fullTime, fullAllocs := measure(iterations, func() { _ = decodeFullBuffer(bytes.NewReader(data), count) })
+	streamTime, streamAllocs := measure(iterations, func() { _ = decodeStreaming(bytes.NewReader(data), count) })
+	fullBytes, streamBytes := peak(decodeFullBuffer), peak(decodeStreaming)
+
+	fmt.Printf("Processing a %d KB array of %d users from an io.Reader:\n\n", len(data)/1024, userCount)
+	fmt.Printf("1. io.ReadAll + json.Unmarshal: %10v, %6.0f allocs, %7d KB allocated\n",
+		fullTime, fullAllocs, fullBytes/1024)
+	fmt.Printf("2. json.Decoder, one at a time: %10v, %6.0f allocs, %7d KB allocated\n",
+		streamTime, streamAllocs, streamBytes/1024)
+
+	fmt.Println("\n💡 The decoder is not faster per byte: it scans tokens one value at a")
+	fmt.Println("   time. Its win is memory: it never holds the whole body or the whole")
+	fmt.Println("   []User, so peak heap stays flat as the payload grows.")
+}
+
+func compareEncoderReuse(users []User) {
+	const iterations = 50
+
+	perCall := func() {
+		for i := range users {
+			_, _ = json.Marshal(&users[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	reused := func() {
+		for i := range users {
+			buf.Reset()
+			_ = enc.Encode(&users[i])
+		}
+	}
+
+	perCallTime, perCallAllocs := measure(iterations, perCall)
+	reusedTime, reusedAllocs := measure(iterations, reused)
+
+	fmt.Printf("Encoding %d users as %d separate responses:\n\n", userCount, userCount)
+	fmt.Printf("1. json.Marshal per response:              %10v, %6.0f allocs\n", perCallTime, perCallAllocs)
+	fmt.Printf("2. Reused Encoder + SetEscapeHTML(false):  %10v, %6.0f allocs (%.1fx faster)\n",
+		reusedTime, reusedAllocs, float64(perCallTime)/float64(reusedTime))
+
+	escaped, _ := json.Marshal(users[0].Name)
+	buf.Reset()
+	_ = enc.Encode(users[0].Name)
+	fmt.Printf("\n   Default: %s\n", escaped)
+	fmt.Printf("   No HTML: %s", buf.String())
+	fmt.Println("\n💡 json.Marshal copies its output into a fresh []byte every call;")
+	fmt.Println("   an Encoder writes into a buffer you reuse. Turning off HTML escaping")
+	fmt.Println("   is safe for API responses that are never embedded in a web page.")
+}
+
+func explainJSONInternals() {
+	fmt.Println("encoding/json per-value work:")
+	fmt.Println()
+	fmt.Println("┌──────────────┬──────────────────┬──────────────────┬────────────┐")
+	fmt.Println("│  reflect on  │  escape & quote  │  copy into       │  allocate  │")
+	fmt.Println("│  field type  │  every string    │  output buffer   │  result    │")
+	fmt.Println("└──────────────┴──────────────────┴──────────────────┴────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHERE THE TIME GOES:")
+	fmt.Println("  • Marshal: cached per-type encoder, but reflect.Value calls per field")
+	fmt.Println("  • Unmarshal: scanner validates every byte, then a second pass decodes")
+	fmt.Println("  • Every decoded string is a new allocation")
+	fmt.Println("  • interface{} targets allocate a map or slice per object/array")
+	fmt.Println()
+
+	fmt.Println("⚡ HOW ALTERNATIVES GO FASTER:")
+	fmt.Println("  • jsoniter: single-pass decoding, reflect2 unsafe field access")
+	fmt.Println("  • sonic: JIT-compiled codecs + SIMD scanning (amd64/arm64, Go ≤ 1.26)")
+	fmt.Println("  • Both: drop-in APIs compatible with encoding/json")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 DECODE INTO STRUCTS, NOT interface{}")
+	fmt.Println("   ❌ var m map[string]interface{}; json.Unmarshal(data, &m)")
+	fmt.Println("   ✅ var u User; json.Unmarshal(data, &u)")
+	fmt.Println("   Benefit: No per-field boxing, type-safe access")
+	fmt.Println()
+
+	fmt.Println("2. 🌊 STREAM LARGE ARRAYS")
+	fmt.Println("   ❌ body, _ := io.ReadAll(r.Body); json.Unmarshal(body, &all)")
+	fmt.Println("   ✅ dec := json.NewDecoder(r.Body); for dec.More() { dec.Decode(&u) }")
+	fmt.Println("   Benefit: Flat memory regardless of payload size")
+	fmt.Println()
+
+	fmt.Println("3. ♻️  REUSE ENCODERS AND BUFFERS")
+	fmt.Println("   ❌ data, _ := json.Marshal(resp); w.Write(data)")
+	fmt.Println("   ✅ enc := json.NewEncoder(w); enc.SetEscapeHTML(false); enc.Encode(resp)")
+	fmt.Println("   Benefit: No intermediate []byte per response")
+	fmt.Println()
+
+	fmt.Println("4. 🔄 SWAP THE LIBRARY BEHIND ONE INTERFACE")
+	fmt.Println("   var Marshal = jsoniter.ConfigCompatibleWithStandardLibrary.Marshal")
+	fmt.Println("   Benefit: One-line change, benchmark before and after")
+	fmt.Println()
+
+	fmt.Println("5. ⚠️  CHECK sonic's PLATFORM SUPPORT")
+	fmt.Println("   On unsupported Go versions or CPUs it quietly uses encoding/json")
+	fmt.Println("   Benefit: No surprise when the speed-up disappears after an upgrade")
+}
+
+func calculateJSONCostImpact(t1, t2 time.Duration, alloc1, alloc2 int, pricing cost.PricingModel) {
+	// Calculate time savings
+	timeSavedNs, err := cost.SafeDurationToFloat64(t1 - t2)
+	if err != nil {
+		fmt.Printf("❌ Time saved: %v\n", err)
+		return
+	}
+	baselineNs, err := cost.SafeDurationToFloat64(t1)
+	if err != nil {
+		fmt.Printf("❌ Baseline time: %v\n", err)
+		return
+	}
+	timeSavedPercent := timeSavedNs / baselineNs * 100
+
+	// Calculate allocation savings
+	allocSaved := alloc1 - alloc2
+	allocSavedPercent := float64(allocSaved) / float64(alloc1) * 100
+
+	fmt.Println("📈 PERFORMANCE IMPROVEMENT:")
+	fmt.Printf("  Time:       %v → %v (%.1f%% faster)\n", t1, t2, timeSavedPercent)
+	if allocSaved >= 0 {
+		fmt.Printf("  Allocations: %d → %d (%.1f%% reduction)\n", alloc1, alloc2, allocSavedPercent)
+	} else {
+		// jsoniter buys its speed with more, smaller allocations
+		fmt.Printf("  Allocations: %d → %d (%.1f%% MORE)\n", alloc1, alloc2, -allocSavedPercent)
+	}
+
+	// Cloud cost calculation
+	fmt.Println("\n☁️  CLOUD COST CALCULATION:")
+
+	// Assumptions: same formula as day-02's calculateCostImpact
+	requestsPerSecond := 100.0
+	requestsPerDay := requestsPerSecond * 3600 * 24
+	costPerVCPUHour := pricing.CPUHourCost()
+	msSavedPerRequest := timeSavedNs / 1_000_000.0 // Convert ns to ms
+
+	fmt.Println("Assumptions:")
+	fmt.Printf("  • Requests per second: %.0f (each decodes and encodes %d users)\n", requestsPerSecond, userCount)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • Time saved per request: %.3f ms\n", msSavedPerRequest)
+
+	// CPU time saved per day (in hours)
+	cpuSecondsSavedPerRequest := timeSavedNs / 1_000_000_000.0
+	cpuHoursSavedPerDay := cpuSecondsSavedPerRequest * requestsPerDay / 3600
+
+	// Cost savings
+	dailySavings, err := cost.CheckedFloat64Mul(cpuHoursSavedPerDay, costPerVCPUHour)
+	if err != nil {
+		fmt.Printf("❌ Daily savings: %v\n", err)
+		return
+	}
+	monthlySavings := dailySavings * 30
+	annualSavings := monthlySavings * 12
+
+	fmt.Println("\n💰 CALCULATED SAVINGS:")
+	fmt.Printf("  CPU time saved per day: %.4f hours\n", cpuHoursSavedPerDay)
+	fmt.Printf("  Daily savings:          $%.4f\n", dailySavings)
+	fmt.Printf("  Monthly savings:        $%.4f\n", monthlySavings)
+	fmt.Printf("  Annual savings:         $%.4f\n", annualSavings)
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS (not quantified):")
+	fmt.Println("  1. Lower p99 latency on JSON-heavy endpoints")
+	fmt.Println("  2. Streaming decode keeps memory flat for large bodies")
+	fmt.Println("  3. Reused encoders remove the per-response []byte entirely")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalString string
+
+// ========== STRING BUILDING BENCHMARKS ==========
+
+// Each iteration builds buildsPerRun query strings, the same unit of work
+// as one row of the demo's comparison table.
+
+func Benchmark_Concat(b *testing.B) {
+	benchmarkBuild(b, buildWithConcat)
+}
+
+func Benchmark_Sprintf(b *testing.B) {
+	benchmarkBuild(b, buildWithSprintf)
+}
+
+func Benchmark_StringsBuilder(b *testing.B) {
+	benchmarkBuild(b, buildWithBuilder)
+}
+
+func Benchmark_BytesBuffer(b *testing.B) {
+	benchmarkBuild(b, buildWithBuffer)
+}
+
+func Benchmark_StrconvAppend(b *testing.B) {
+	benchmarkBuild(b, buildWithStrconv)
+}
+
+func benchmarkBuild(b *testing.B, build func([]param) string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < buildsPerRun; j++ {
+			globalString = build(queryParams)
+		}
+	}
+}
+
+// ========== POOLING BENCHMARKS ==========
+
+func Benchmark_PooledStringsBuilder(b *testing.B) {
+	benchmarkBuild(b, buildWithPooledBuilder)
+}
+
+func Benchmark_PooledBytes(b *testing.B) {
+	benchmarkBuild(b, buildWithPooledBytes)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_AllApproachesBuildSameQuery(t *testing.T) {
+	want := "user_id=1048576&page=12&limit=100&offset=1100&sort_by=3&order=1" +
+		"&from=1704067200&to=1706745600&region=5&version=2"
+
+	for _, a := range approaches {
+		if got := a.Build(queryParams); got != want {
+			t.Errorf("%s:\n got %q\nwant %q", a.Name, got, want)
+		}
+	}
+
+	// The result must also be a valid query string
+	values, err := url.ParseQuery(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != len(queryParams) {
+		t.Errorf("expected %d parameters, parsed %d", len(queryParams), len(values))
+	}
+}
+
+func Test_PooledResultsAreNotOverwritten(t *testing.T) {
+	// A pool that handed out a buffer still referenced by an earlier
+	// result would corrupt it on the next build
+	other := []param{{"q", 999999999}, {"x", 7}}
+	for _, build := range []func([]param) string{buildWithPooledBuilder, buildWithPooledBytes} {
+		first := build(queryParams)
+		saved := strings.Clone(first)
+		for i := 0; i < 100; i++ {
+			_ = build(other)
+		}
+		if first != saved {
+			t.Errorf("earlier result changed to %q", first)
+		}
+	}
+}
+
+func Test_EstimateQueryLenIsUpperBound(t *testing.T) {
+	worst := []param{{"a", -9223372036854775808}, {"b", 9223372036854775807}}
+	for _, params := range [][]param{queryParams, worst, nil} {
+		if got, limit := len(buildWithStrconv(params)), estimateQueryLen(params); got > limit {
+			t.Errorf("estimate %d is below actual length %d", limit, got)
+		}
+	}
+}
+
+func Test_StringAllocations(t *testing.T) {
+	tests := []struct {
+		name      string
+		build     func([]param) string
+		maxAllocs float64
+	}{
+		{"strings.Builder", buildWithBuilder, 1},
+		{"bytes.Buffer", buildWithBuffer, 2}, // Buffer + String() copy
+		{"strconv.AppendInt", buildWithStrconv, 1},
+		{"pooled []byte", buildWithPooledBytes, 1},
+	}
+	for _, tt := range tests {
+		allocs := testutil.AssertMaxAllocs(t, tt.name, tt.maxAllocs, func() { globalString = tt.build(queryParams) })
+		t.Logf("%-18s %.0f allocs", tt.name, allocs)
+	}
+}
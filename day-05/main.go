@@ -0,0 +1,388 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+type param struct {
+	Key   string
+	Value int64
+}
+
+// queryParams is a typical paginated, filtered API call
+var queryParams = []param{
+	{"user_id", 1048576},
+	{"page", 12},
+	{"limit", 100},
+	{"offset", 1100},
+	{"sort_by", 3},
+	{"order", 1},
+	{"from", 1704067200},
+	{"to", 1706745600},
+	{"region", 5},
+	{"version", 2},
+}
+
+// buildsPerRun is how many query strings each benchmark run builds
+const buildsPerRun = 100_000
+
+// Global variable to prevent compiler optimizations
+var sink string
+
+// ========== STRING BUILDING APPROACHES ==========
+
+// Each builder returns the same "user_id=1048576&page=12&..." string.
+
+func buildWithConcat(params []param) string {
+	s := ""
+	for i, p := range params {
+		if i > 0 {
+			s += "&"
+		}
+		s += p.Key + "=" + strconv.FormatInt(p.Value, 10)
+	}
+	return s
+}
+
+func buildWithSprintf(params []param) string {
+	s := ""
+	for i, p := range params {
+		if i > 0 {
+			s += "&"
+		}
+		s += fmt.Sprintf("%s=%d", p.Key, p.Value)
+	}
+	return s
+}
+
+func buildWithBuilder(params []param) string {
+	var b strings.Builder
+	b.Grow(estimateQueryLen(params))
+	var digits [20]byte
+	for i, p := range params {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(p.Key)
+		b.WriteByte('=')
+		b.Write(strconv.AppendInt(digits[:0], p.Value, 10))
+	}
+	return b.String()
+}
+
+func buildWithBuffer(params []param) string {
+	var buf bytes.Buffer
+	buf.Grow(estimateQueryLen(params))
+	var digits [20]byte
+	for i, p := range params {
+		if i > 0 {
+			buf.WriteByte('&')
+		}
+		buf.WriteString(p.Key)
+		buf.WriteByte('=')
+		buf.Write(strconv.AppendInt(digits[:0], p.Value, 10))
+	}
+	return buf.String() // Copies: the buffer stays mutable
+}
+
+// buildWithStrconv appends into a stack array, so the only heap
+// allocation is the final string conversion.
+func buildWithStrconv(params []param) string {
+	var scratch [256]byte
+	return string(appendQuery(scratch[:0], params))
+}
+
+func appendQuery(dst []byte, params []param) []byte {
+	for i, p := range params {
+		if i > 0 {
+			dst = append(dst, '&')
+		}
+		dst = append(dst, p.Key...)
+		dst = append(dst, '=')
+		dst = strconv.AppendInt(dst, p.Value, 10)
+	}
+	return dst
+}
+
+// estimateQueryLen is an upper bound: 20 digits covers any int64.
+func estimateQueryLen(params []param) int {
+	n := 0
+	for _, p := range params {
+		n += len(p.Key) + 1 + 20 + 1
+	}
+	return n
+}
+
+// ========== POOLED BUILDERS ==========
+
+var builderPool = sync.Pool{
+	New: func() any { return new(strings.Builder) },
+}
+
+// buildWithPooledBuilder reuses the Builder struct, but not its memory:
+// String() hands the buffer to the caller, so Reset must drop it.
+func buildWithPooledBuilder(params []param) string {
+	b := builderPool.Get().(*strings.Builder)
+	b.Grow(estimateQueryLen(params))
+	var digits [20]byte
+	for i, p := range params {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(p.Key)
+		b.WriteByte('=')
+		b.Write(strconv.AppendInt(digits[:0], p.Value, 10))
+	}
+	s := b.String()
+	b.Reset()
+	builderPool.Put(b)
+	return s
+}
+
+var bytesPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// buildWithPooledBytes reuses the byte buffer itself and copies out once.
+func buildWithPooledBytes(params []param) string {
+	bp := bytesPool.Get().(*[]byte)
+	buf := appendQuery((*bp)[:0], params)
+	s := string(buf)
+	*bp = buf
+	bytesPool.Put(bp)
+	return s
+}
+
+type approach struct {
+	Name  string
+	Build func([]param) string
+}
+
+var approaches = []approach{
+	{"+ operator", buildWithConcat},
+	{"fmt.Sprintf", buildWithSprintf},
+	{"strings.Builder", buildWithBuilder},
+	{"bytes.Buffer", buildWithBuffer},
+	{"strconv.AppendInt", buildWithStrconv},
+	{"pooled strings.Builder", buildWithPooledBuilder},
+	{"pooled []byte", buildWithPooledBytes},
+}
+
+func main() {
+	fmt.Println("🔬 DAY 5: String Building Strategies")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about string concatenation
+	fmt.Println("🎯 SHOCKING DISCOVERY: Every + ALLOCATES a new string!")
+	fmt.Println(strings.Repeat("-", 40))
+	measureStringEscapes()
+
+	// Benchmark: all approaches
+	fmt.Printf("\n📊 BENCHMARK: %d query strings of %d parameters\n", buildsPerRun, len(queryParams))
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// sync.Pool
+	fmt.Println("\n♻️  POOLING BUILDERS WITH sync.Pool")
+	fmt.Println(strings.Repeat("-", 40))
+	explainBuilderPooling()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis: + operator vs the fastest approach
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	baseline, best := results[0], results[0]
+	for _, r := range results[1:] {
+		if r.NsPerOp < best.NsPerOp {
+			best = r
+		}
+	}
+	perBuild := func(r bench.Result) time.Duration { return time.Duration(r.NsPerOp / buildsPerRun) }
+	fmt.Printf("Per query string: %s vs %s\n\n", baseline.Name, best.Name)
+	monthly := calculateStringCostImpact(perBuild(baseline), perBuild(best),
+		int(baseline.AllocsPerOp/buildsPerRun), int(best.AllocsPerOp/buildsPerRun), cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 5, Topic: "String Building Strategies", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 5 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 6 - Goroutines vs Worker Pools")
+}
+
+// measureStringEscapes counts the heap allocations behind one query string
+// for each approach: every allocation is a value that escaped to the heap.
+func measureStringEscapes() {
+	const iterations = 10_000
+	fmt.Printf("Building %q...\n\n", buildWithConcat(queryParams[:3])+"&...")
+	fmt.Println("  Approach                 | Allocs | Bytes")
+	fmt.Println("  -------------------------|--------|------")
+
+	var m1, m2 runtime.MemStats
+	for _, a := range approaches {
+		sink = a.Build(queryParams) // Warm up pools
+		runtime.GC()
+		runtime.ReadMemStats(&m1)
+		for i := 0; i < iterations; i++ {
+			sink = a.Build(queryParams)
+		}
+		runtime.ReadMemStats(&m2)
+		fmt.Printf("  %-24s | %6.1f | %5d\n", a.Name,
+			float64(m2.Mallocs-m1.Mallocs)/iterations, (m2.TotalAlloc-m1.TotalAlloc)/iterations)
+	}
+
+	fmt.Printf("\n💡 The result is %d bytes. The + operator builds %d intermediate\n", len(sink), 2*len(queryParams)-1)
+	fmt.Println("   strings, each a full copy of everything so far: O(n²) bytes.")
+	fmt.Println("   fmt.Sprintf adds boxing of its arguments on top.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("String building")
+	// Warm the pools and time each approach for at least 100ms
+	config := bench.DefaultConfig()
+	suite.Config = &config
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			for i := 0; i < buildsPerRun; i++ {
+				sink = a.Build(queryParams)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return suite.Results()
+}
+
+func explainBuilderPooling() {
+	fmt.Println("strings.Builder.String() returns its buffer WITHOUT copying:")
+	fmt.Println()
+	fmt.Println("  s := b.String()   // s points into b's buffer")
+	fmt.Println("  b.Reset()         // buf = nil: b must forget it, s still uses it")
+	fmt.Println("  pool.Put(b)       // Only the 32-byte struct is reused")
+	fmt.Println()
+	fmt.Println("So a pooled Builder still allocates a new buffer every time. To")
+	fmt.Println("reuse memory, pool the []byte and pay for exactly one copy:")
+	fmt.Println()
+	fmt.Println("  bp := bytesPool.Get().(*[]byte)")
+	fmt.Println("  buf := appendQuery((*bp)[:0], params)")
+	fmt.Println("  s := string(buf)  // The single allocation")
+	fmt.Println("  *bp = buf")
+	fmt.Println("  bytesPool.Put(bp) // Pointer to slice: Put does not allocate")
+	fmt.Println()
+	fmt.Println("💡 When the buffer fits on the stack (strconv.AppendInt into a")
+	fmt.Println("   [256]byte), the pool buys nothing: no heap buffer to reuse.")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 NEVER + IN A LOOP")
+	fmt.Println("   ❌ for _, p := range params { s += p.Key + \"=\" + v }")
+	fmt.Println("   ✅ var b strings.Builder; b.Grow(n); b.WriteString(p.Key)")
+	fmt.Println("   Benefit: One allocation instead of one per piece")
+	fmt.Println()
+
+	fmt.Println("2. 🚫 KEEP fmt.Sprintf OUT OF HOT PATHS")
+	fmt.Printf("   ❌ fmt.Sprintf(\"%%s=%%d\", k, v)\n")
+	fmt.Println("   ✅ strconv.AppendInt(buf, v, 10)")
+	fmt.Println("   Benefit: No format parsing, no interface boxing")
+	fmt.Println()
+
+	fmt.Println("3. 📏 GROW BEFORE WRITING")
+	fmt.Println("   ✅ b.Grow(estimateQueryLen(params))")
+	fmt.Println("   Benefit: No buffer doubling while building")
+	fmt.Println()
+
+	fmt.Println("4. 📦 APPEND INTO A STACK BUFFER")
+	fmt.Println("   ✅ var scratch [256]byte; string(appendQuery(scratch[:0], params))")
+	fmt.Println("   Benefit: Only the final string reaches the heap")
+	fmt.Println()
+
+	fmt.Println("5. ♻️  POOL []byte, NOT strings.Builder")
+	fmt.Println("   Benefit: Buffer memory is actually reused")
+}
+
+func calculateStringCostImpact(t1, t2 time.Duration, alloc1, alloc2 int, pricing cost.PricingModel) float64 {
+	// Calculate time savings
+	timeSavedNs, err := cost.SafeDurationToFloat64(t1 - t2)
+	if err != nil {
+		fmt.Printf("❌ Time saved: %v\n", err)
+		return 0
+	}
+	baselineNs, err := cost.SafeDurationToFloat64(t1)
+	if err != nil {
+		fmt.Printf("❌ Baseline time: %v\n", err)
+		return 0
+	}
+	timeSavedPercent := timeSavedNs / baselineNs * 100
+
+	// Calculate allocation savings
+	allocSaved := alloc1 - alloc2
+	allocSavedPercent := float64(allocSaved) / float64(alloc1) * 100
+
+	fmt.Println("📈 PERFORMANCE IMPROVEMENT:")
+	fmt.Printf("  Time:       %v → %v (%.1f%% faster)\n", t1, t2, timeSavedPercent)
+	fmt.Printf("  Allocations: %d → %d (%.1f%% reduction)\n", alloc1, alloc2, allocSavedPercent)
+
+	// Cloud cost calculation
+	fmt.Println("\n☁️  CLOUD COST CALCULATION:")
+
+	// Assumptions: same formula as day-02's calculateCostImpact, but an
+	// API builds several strings (outbound URLs, cache keys) per request
+	requestsPerSecond := 10_000.0
+	buildsPerRequest := 10.0
+	requestsPerDay := requestsPerSecond * 3600 * 24
+	costPerVCPUHour := pricing.CPUHourCost()
+	nsSavedPerRequest := timeSavedNs * buildsPerRequest
+
+	fmt.Println("Assumptions:")
+	fmt.Printf("  • Requests per second: %.0f, %.0f strings built per request\n", requestsPerSecond, buildsPerRequest)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • Time saved per request: %.0f ns\n", nsSavedPerRequest)
+
+	// CPU time saved per day (in hours)
+	cpuSecondsSavedPerRequest := nsSavedPerRequest / 1_000_000_000.0
+	cpuHoursSavedPerDay := cpuSecondsSavedPerRequest * requestsPerDay / 3600
+
+	// Cost savings
+	dailySavings, err := cost.CheckedFloat64Mul(cpuHoursSavedPerDay, costPerVCPUHour)
+	if err != nil {
+		fmt.Printf("❌ Daily savings: %v\n", err)
+		return 0
+	}
+	monthlySavings := dailySavings * 30
+	annualSavings := monthlySavings * 12
+
+	fmt.Println("\n💰 CALCULATED SAVINGS:")
+	fmt.Printf("  CPU time saved per day: %.4f hours\n", cpuHoursSavedPerDay)
+	fmt.Printf("  Daily savings:          $%.4f\n", dailySavings)
+	fmt.Printf("  Monthly savings:        $%.4f\n", monthlySavings)
+	fmt.Printf("  Annual savings:         $%.4f\n", annualSavings)
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS (not quantified):")
+	fmt.Println("  1. Fewer, smaller allocations → less GC work")
+	fmt.Println("  2. No O(n²) copying as strings grow longer")
+	fmt.Println("  3. Predictable latency for logging and URL building")
+	return monthlySavings
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalSum atomic.Uint64
+
+// ========== CONCURRENCY BENCHMARKS ==========
+
+func Benchmark_SpawnPerTask(b *testing.B) {
+	benchmarkTasks(b, spawnPerTask)
+}
+
+func Benchmark_WorkerPool(b *testing.B) {
+	benchmarkTasks(b, runWithPool)
+}
+
+func Benchmark_Sequential(b *testing.B) {
+	benchmarkTasks(b, func(tasks int, handle func(int)) {
+		for i := 0; i < tasks; i++ {
+			handle(i)
+		}
+	})
+}
+
+func benchmarkTasks(b *testing.B, run func(int, func(int))) {
+	handle := func(n int) { globalSum.Add(process(n)) }
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		run(taskCount, handle)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_EveryTaskRunsOnce(t *testing.T) {
+	const tasks = 10_000
+	for _, tt := range []struct {
+		name string
+		run  func(int, func(int))
+	}{
+		{"spawn per task", spawnPerTask},
+		{"worker pool", runWithPool},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make([]atomic.Int32, tasks)
+			tt.run(tasks, func(n int) { seen[n].Add(1) })
+			for i := range seen {
+				if c := seen[i].Load(); c != 1 {
+					t.Fatalf("task %d ran %d times", i, c)
+				}
+			}
+		})
+	}
+}
+
+func Test_WorkerPoolBoundsGoroutines(t *testing.T) {
+	const workers = 4
+	base := runtime.NumGoroutine()
+
+	var maxSeen atomic.Int64
+	p := newWorkerPool(workers, workers, func(int) {
+		n := int64(runtime.NumGoroutine())
+		for m := maxSeen.Load(); n > m && !maxSeen.CompareAndSwap(m, n); m = maxSeen.Load() {
+		}
+		runtime.Gosched()
+	})
+	for i := 0; i < 1000; i++ {
+		p.Submit(i)
+	}
+	p.Close()
+
+	if got := maxSeen.Load(); got > int64(base+workers) {
+		t.Errorf("expected at most %d goroutines, saw %d", base+workers, got)
+	}
+}
+
+func Test_WorkerPoolCloseWaitsForQueuedTasks(t *testing.T) {
+	var done atomic.Int64
+	p := newWorkerPool(2, 100, func(int) {
+		runtime.Gosched()
+		done.Add(1)
+	})
+	for i := 0; i < 100; i++ {
+		p.Submit(i)
+	}
+	p.Close()
+	if got := done.Load(); got != 100 {
+		t.Errorf("Close returned with %d of 100 tasks done", got)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variables to prevent compiler optimizations
+var (
+	globalBoxed []interface{}
+	globalInts  []int
+	globalMap   map[string]interface{}
+	globalEvent Event
+)
+
+// ========== BOXING BENCHMARKS ==========
+
+// One op is one insertion, so allocs/op is allocations per value.
+
+func Benchmark_InterfaceBoxing(b *testing.B) {
+	globalBoxed = make([]interface{}, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalBoxed[i%1024] = firstBoxedValue + i
+	}
+}
+
+func Benchmark_InterfaceBoxing_SmallInt(b *testing.B) {
+	globalBoxed = make([]interface{}, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalBoxed[i%1024] = i % firstBoxedValue
+	}
+}
+
+func Benchmark_TypedInt(b *testing.B) {
+	globalInts = make([]int, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalInts[i%1024] = firstBoxedValue + i
+	}
+}
+
+// ========== PAYLOAD BENCHMARKS ==========
+
+func Benchmark_EventAsMap(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalMap = eventAsMap(i)
+	}
+}
+
+func Benchmark_EventAsStruct(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalEvent = eventAsStruct(i)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_BoxingAllocatesPerValue(t *testing.T) {
+	slot := make([]interface{}, 1)
+	v := firstBoxedValue
+	allocs := testing.AllocsPerRun(1000, func() {
+		slot[0] = v
+		v++
+	})
+	if allocs != 1 {
+		t.Errorf("boxing an int >= %d: expected 1 alloc, got %.0f", firstBoxedValue, allocs)
+	}
+
+	// Values below firstBoxedValue come from runtime.staticuint64s
+	small := 0
+	testutil.AssertMaxAllocs(t, fmt.Sprintf("boxing an int < %d", firstBoxedValue), 0, func() {
+		slot[0] = small
+		small = (small + 1) % firstBoxedValue
+	})
+	globalBoxed = slot
+}
+
+func Test_TypedApproachesDoNotAllocatePerValue(t *testing.T) {
+	if allocs := testing.AllocsPerRun(10, func() { globalInts = fillInts(1000) }); allocs != 1 {
+		t.Errorf("fillInts: expected 1 alloc (the slice), got %.0f", allocs)
+	}
+	if allocs := testing.AllocsPerRun(10, func() { globalBoxed = fillInterfaces(1000) }); allocs != 1001 {
+		t.Errorf("fillInterfaces: expected 1001 allocs, got %.0f", allocs)
+	}
+	i := 1
+	testutil.AssertMaxAllocs(t, "eventAsStruct", 0, func() { globalEvent = eventAsStruct(i); i++ })
+}
+
+func Test_MapAndStructHoldSameEvent(t *testing.T) {
+	for _, i := range []int{0, 1, 41, 1_000_000} {
+		m, e := eventAsMap(i), eventAsStruct(i)
+		if m["user_id"].(int64) != e.UserID ||
+			m["amount"].(float64) != e.Amount ||
+			m["timestamp"].(int64) != e.Timestamp ||
+			m["status"].(int) != e.Status {
+			t.Errorf("event %d: map %v does not match struct %+v", i, m, e)
+		}
+	}
+}
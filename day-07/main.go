@@ -0,0 +1,354 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const valueCount = 1_000_000
+
+// firstBoxedValue is the smallest int the runtime must allocate to box.
+// Values 0-255 point into a static table (runtime.staticuint64s) instead.
+const firstBoxedValue = 256
+
+// Event is a typical record passed around as map[string]interface{} when
+// a codebase avoids declaring types.
+type Event struct {
+	UserID    int64
+	Amount    float64
+	Timestamp int64
+	Status    int
+}
+
+// Global variables to prevent compiler optimizations
+var (
+	boxedSink []interface{}
+	typedSink []int
+	mapSink   map[string]interface{}
+	eventSink Event
+)
+
+// ========== APPROACHES ==========
+
+// fillInterfaces stores n ints in a []interface{}. Every element is a
+// (type, data) pair and the data word points at a heap copy of the int.
+func fillInterfaces(n int) []interface{} {
+	values := make([]interface{}, n)
+	for i := range values {
+		values[i] = firstBoxedValue + i
+	}
+	return values
+}
+
+// fillInts stores the same ints inline: one 8-byte word each, no pointers.
+func fillInts(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = firstBoxedValue + i
+	}
+	return values
+}
+
+// eventAsMap builds the untyped form: a map allocation plus one boxed
+// value per non-pointer field.
+func eventAsMap(i int) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":   int64(firstBoxedValue + i),
+		"amount":    float64(i) * 1.5,
+		"timestamp": int64(1704067200 + i),
+		"status":    firstBoxedValue + i%3,
+	}
+}
+
+// eventAsStruct builds the typed form, which lives entirely on the stack.
+func eventAsStruct(i int) Event {
+	return Event{
+		UserID:    int64(firstBoxedValue + i),
+		Amount:    float64(i) * 1.5,
+		Timestamp: int64(1704067200 + i),
+		Status:    firstBoxedValue + i%3,
+	}
+}
+
+func main() {
+	fmt.Println("🔬 DAY 7: Interface Boxing Overhead")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about interface{}
+	fmt.Println("🎯 SHOCKING DISCOVERY: interface{} turns every int into a heap allocation!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealInterfaceOverhead()
+
+	// Memory: 1M values, boxed vs typed
+	fmt.Printf("\n📊 MEMORY: %d values, []interface{} vs []int\n", valueCount)
+	fmt.Println(strings.Repeat("-", 40))
+	boxed, typed := measureSliceAllocations(valueCount)
+
+	fmt.Printf("\n📊 MEMORY: %d events, map[string]interface{} vs struct\n", valueCount)
+	fmt.Println(strings.Repeat("-", 40))
+	measureEventAllocations(valueCount)
+
+	// Benchmark
+	fmt.Println("\n📊 BENCHMARK: Boxed vs typed storage")
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Interface internals
+	fmt.Println("\n🔧 INTERFACE DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainInterfaceInternals()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateInterfaceCostImpact(boxed, typed, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 7, Topic: "Interface Boxing Overhead", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 7 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 8 - Defer Overhead in Hot Paths")
+}
+
+func revealInterfaceOverhead() {
+	var (
+		i     int
+		f     float64
+		b     bool
+		e     Event
+		iface interface{}
+	)
+
+	fmt.Println("  Type            | Concrete size | As interface{} | Heap copy")
+	fmt.Println("  ----------------|---------------|----------------|----------")
+	fmt.Printf("  %-15s | %10d B  | %11d B  | %s\n", "int", unsafe.Sizeof(i), unsafe.Sizeof(iface), "yes (≥ 256)")
+	fmt.Printf("  %-15s | %10d B  | %11d B  | %s\n", "float64", unsafe.Sizeof(f), unsafe.Sizeof(iface), "yes")
+	fmt.Printf("  %-15s | %10d B  | %11d B  | %s\n", "bool", unsafe.Sizeof(b), unsafe.Sizeof(iface), "no (static)")
+	fmt.Printf("  %-15s | %10d B  | %11d B  | %s\n", "Event", unsafe.Sizeof(e), unsafe.Sizeof(iface), "yes")
+	fmt.Printf("  %-15s | %10d B  | %11d B  | %s\n", "*Event", unsafe.Sizeof(&e), unsafe.Sizeof(iface), "no (is a pointer)")
+
+	fmt.Printf("\n💡 An interface value is a fat pointer: %d bytes (type word + data word)\n", unsafe.Sizeof(iface))
+	fmt.Println("   on a 64-bit platform. Anything that isn't already a pointer is copied")
+	fmt.Println("   to the heap so the data word has something to point at.")
+	fmt.Println("   The runtime skips the allocation for 0-255, bools and zero-size values.")
+}
+
+// allocDelta runs fn between two MemStats readings and returns the bytes
+// and objects it allocated.
+func allocDelta(fn func()) (bytes, objects uint64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc, after.Mallocs - before.Mallocs
+}
+
+// allocStats is the allocation cost of one approach over n values.
+type allocStats struct {
+	Bytes   uint64
+	Objects uint64
+}
+
+func measureSliceAllocations(n int) (boxed, typed allocStats) {
+	boxed.Bytes, boxed.Objects = allocDelta(func() { boxedSink = fillInterfaces(n) })
+	boxedSink = nil
+	typed.Bytes, typed.Objects = allocDelta(func() { typedSink = fillInts(n) })
+	typedSink = nil
+
+	printAllocRow("[]interface{}", boxed, n)
+	printAllocRow("[]int", typed, n)
+	fmt.Printf("\n  ❌ Boxing costs %.1fx the memory and %d extra allocations\n",
+		float64(boxed.Bytes)/float64(typed.Bytes), boxed.Objects-typed.Objects)
+	return boxed, typed
+}
+
+func measureEventAllocations(n int) {
+	asMap, _ := allocDelta(func() {
+		for i := 0; i < n; i++ {
+			mapSink = eventAsMap(i)
+		}
+	})
+	mapObjects := allocsPerEvent(func(i int) { mapSink = eventAsMap(i) })
+	asStruct, _ := allocDelta(func() {
+		for i := 0; i < n; i++ {
+			eventSink = eventAsStruct(i)
+		}
+	})
+	structObjects := allocsPerEvent(func(i int) { eventSink = eventAsStruct(i) })
+
+	// The same map literal with nil values: the hash table alone
+	tableObjects := testingAllocs(func() {
+		mapSink = map[string]interface{}{"user_id": nil, "amount": nil, "timestamp": nil, "status": nil}
+	})
+
+	fmt.Printf("  %-24s %8.1f MB total, %3.0f B/event, %.0f allocs/event\n",
+		"map[string]interface{}", mb(asMap), float64(asMap)/float64(n), mapObjects)
+	fmt.Printf("  %-24s %8.1f MB total, %3.0f B/event, %.0f allocs/event\n",
+		"Event struct", mb(asStruct), float64(asStruct)/float64(n), structObjects)
+	fmt.Printf("\n  ❌ Each map event is %.0f allocations for the hash table plus %.0f boxed fields\n",
+		tableObjects, mapObjects-tableObjects)
+	mapSink = nil
+}
+
+func allocsPerEvent(build func(int)) float64 {
+	i := 0
+	return testingAllocs(func() {
+		build(i)
+		i++
+	})
+}
+
+// testingAllocs is the average number of allocations per call of fn,
+// like testing.AllocsPerRun but usable outside tests.
+func testingAllocs(fn func()) float64 {
+	const runs = 1000
+	fn() // warm up
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		fn()
+	}
+	runtime.ReadMemStats(&after)
+	return float64(after.Mallocs-before.Mallocs) / runs
+}
+
+func printAllocRow(name string, s allocStats, n int) {
+	fmt.Printf("  %-14s %8.1f MB, %8d objects (%.1f B/value)\n",
+		name, mb(s.Bytes), s.Objects, float64(s.Bytes)/float64(n))
+}
+
+func mb(b uint64) float64 {
+	return float64(b) / (1024 * 1024)
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Interface boxing")
+	suite.Iterations = 5
+	suite.Register("[]interface{} (1M ints)", func() { boxedSink = fillInterfaces(valueCount) })
+	suite.Register("[]int (1M ints)", func() { typedSink = fillInts(valueCount) })
+	suite.Register("map[string]interface{} (100k events)", func() {
+		for i := 0; i < valueCount/10; i++ {
+			mapSink = eventAsMap(i)
+		}
+	})
+	suite.Register("Event struct (100k events)", func() {
+		for i := 0; i < valueCount/10; i++ {
+			eventSink = eventAsStruct(i)
+		}
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	boxedSink, typedSink, mapSink = nil, nil, nil
+	return suite.Results()
+}
+
+func explainInterfaceInternals() {
+	fmt.Println("var x interface{} = 1000:")
+	fmt.Println()
+	fmt.Println("  interface{} (16 bytes)         heap (8 bytes + header)")
+	fmt.Println("┌──────────────┬──────────────┐   ┌──────────┐")
+	fmt.Println("│ *_type (int) │ data ────────┼──▶│   1000   │")
+	fmt.Println("└──────────────┴──────────────┘   └──────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHAT BOXING COSTS:")
+	fmt.Println("  • A malloc per value (~10-25 ns) via runtime.convT64 / convT")
+	fmt.Println("  • 2x the slot size: 16 bytes instead of 8 for an int")
+	fmt.Println("  • A pointer per element the GC must follow when marking")
+	fmt.Println("  • Type assertions on every read: v.(int)")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHERE BOXING HIDES:")
+	fmt.Println("  • fmt.Println(x), log fields, errors.New wrappers")
+	fmt.Println("  • map[string]interface{} for JSON and \"generic\" payloads")
+	fmt.Println("  • []interface{} arguments to variadic helpers (SQL args!)")
+	fmt.Println("  • container/list, sync.Map and pre-generics containers")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 USE CONCRETE TYPES")
+	fmt.Println("   ❌ values := []interface{}{}")
+	fmt.Println("   ✅ values := []int{}")
+	fmt.Println("   Benefit: No per-value allocation, half the slot size")
+	fmt.Println()
+
+	fmt.Println("2. 📦 DECLARE STRUCTS FOR PAYLOADS")
+	fmt.Println("   ❌ event := map[string]interface{}{\"user_id\": id}")
+	fmt.Println("   ✅ event := Event{UserID: id}")
+	fmt.Println("   Benefit: Stack allocation, compile-time field checks")
+	fmt.Println()
+
+	fmt.Println("3. 🧬 USE GENERICS FOR CONTAINERS")
+	fmt.Println("   ❌ func Max(values []interface{}) interface{}")
+	fmt.Println("   ✅ func Max[T cmp.Ordered](values []T) T")
+	fmt.Println("   Benefit: One implementation, no boxing")
+	fmt.Println()
+
+	fmt.Println("4. 🔗 STORE POINTERS IF YOU MUST BOX")
+	fmt.Println("   ✅ var x interface{} = &event // no copy, pointer fits the data word")
+	fmt.Println("   Benefit: Boxing a pointer never allocates")
+}
+
+func calculateInterfaceCostImpact(boxed, typed allocStats, pricing cost.PricingModel) float64 {
+	// Service building one []interface{} of valuesPerRequest per request
+	requestsPerSecond := 10_000.0
+	valuesPerRequest := 100.0
+	costPerVCPUHour := pricing.CPUHourCost()
+	costPerGBMonth := pricing.RAMGBMonthCost()
+
+	// GC model: with GOGC=100 a cycle runs each time the program allocates
+	// as much as the live heap, and marking costs ~1 ms of CPU per MB live
+	liveHeapMB := 256.0
+	markMsPerMB := 1.0
+	mallocNs := 20.0
+
+	extraObjects := float64(boxed.Objects-typed.Objects) / valueCount
+	extraBytes := float64(boxed.Bytes-typed.Bytes) / valueCount
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %.0f values per request\n", requestsPerSecond, valuesPerRequest)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU, $%.2f/GB-month\n", pricing, costPerVCPUHour, costPerGBMonth)
+	fmt.Printf("  • Live heap %.0f MB, GOGC=100, mark cost ~%.0f ms CPU per MB\n", liveHeapMB, markMsPerMB)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	allocsPerSecond := requestsPerSecond * valuesPerRequest * extraObjects
+	allocMBPerSecond := requestsPerSecond * valuesPerRequest * extraBytes / (1024 * 1024)
+	gcCyclesPerSecond := allocMBPerSecond / liveHeapMB
+	gcVCPUs := gcCyclesPerSecond * liveHeapMB * markMsPerMB / 1000
+	mallocVCPUs := allocsPerSecond * mallocNs / 1e9
+
+	fmt.Printf("  Extra per value: %.1f allocations, %.1f bytes\n", extraObjects, extraBytes)
+	fmt.Printf("  Extra allocation rate: %.2e allocs/s, %.1f MB/s\n", allocsPerSecond, allocMBPerSecond)
+	fmt.Printf("  GC cycles caused: %.2f/s → %.3f vCPUs marking\n", gcCyclesPerSecond, gcVCPUs)
+	fmt.Printf("  Malloc time: %.3f vCPUs\n", mallocVCPUs)
+
+	monthlySavings := (gcVCPUs + mallocVCPUs) * costPerVCPUHour * 24 * 30
+	fmt.Printf("\n💰 Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("   Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Shorter, rarer GC cycles → lower tail latency")
+	fmt.Println("  • Half-size slices → better cache utilization")
+	fmt.Println("  • Compile-time type safety instead of runtime assertions")
+	return monthlySavings
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalCounter counter
+
+// ========== UNLOCK BENCHMARKS ==========
+
+func Benchmark_DeferredUnlock(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalCounter.IncDeferred()
+	}
+}
+
+func Benchmark_ManualUnlock(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalCounter.IncManual()
+	}
+}
+
+// ========== DEFER IN LOOP BENCHMARKS ==========
+
+// Each op releases 16 resources.
+
+func Benchmark_DeferInLoop(b *testing.B) {
+	benchmarkRelease(b, releaseDeferInLoop)
+}
+
+func Benchmark_DeferOnce(b *testing.B) {
+	benchmarkRelease(b, releaseDeferOnce)
+}
+
+func benchmarkRelease(b *testing.B, release func([]resource)) {
+	resources := make([]resource, 16)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		release(resources)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_BothUnlockStylesCount(t *testing.T) {
+	var c counter
+	runDeferred(&c, 1000)
+	runManual(&c, 1000)
+	if c.n != 2000 {
+		t.Errorf("expected 2000 increments, got %d", c.n)
+	}
+	// Both must leave the mutex unlocked
+	if !c.mu.TryLock() {
+		t.Error("mutex still locked")
+	}
+}
+
+func Test_ReleaseClosesEveryResource(t *testing.T) {
+	for name, release := range map[string]func([]resource){
+		"defer in loop": releaseDeferInLoop,
+		"defer once":    releaseDeferOnce,
+	} {
+		resources := make([]resource, 16)
+		release(resources)
+		for i, r := range resources {
+			if !r.closed {
+				t.Errorf("%s: resource %d not closed", name, i)
+			}
+		}
+	}
+}
+
+func Test_DeferInLoopAllocates(t *testing.T) {
+	resources := make([]resource, 16)
+	if allocs := testing.AllocsPerRun(100, func() { releaseDeferInLoop(resources) }); allocs < 16 {
+		t.Errorf("expected a defer record per iteration (16), got %.0f allocs", allocs)
+	}
+	testutil.AssertMaxAllocs(t, "open-coded defer", 0, func() { releaseDeferOnce(resources) })
+}
+
+// medianNsPerOp times fn (which performs ops operations) runs times and
+// returns the median cost per operation.
+func medianNsPerOp(runs, ops int, fn func()) float64 {
+	ns := make([]float64, runs)
+	for i := range ns {
+		start := time.Now()
+		fn()
+		ns[i] = float64(time.Since(start).Nanoseconds()) / float64(ops)
+	}
+	sort.Float64s(ns)
+	return ns[runs/2]
+}
+
+func Test_DeferInLoopOverhead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	const runs = 5
+
+	// Open-coded defer (Go 1.14+) is within a few percent of a manual
+	// unlock, often inside run-to-run noise, so this pair is reported
+	// rather than asserted
+	var c counter
+	deferred := medianNsPerOp(runs, iterations, func() { runDeferred(&c, iterations) })
+	manual := medianNsPerOp(runs, iterations, func() { runManual(&c, iterations) })
+	t.Logf("mutex increment: defer %.2f ns/op, manual %.2f ns/op, difference %+.2f ns/op (%+.1f%%)",
+		deferred, manual, deferred-manual, (deferred-manual)/manual*100)
+
+	// Defers in a loop cannot be open-coded and must be clearly slower
+	const calls = 10_000
+	resources := make([]resource, 16)
+	inLoop := medianNsPerOp(runs, calls, func() {
+		for i := 0; i < calls; i++ {
+			releaseDeferInLoop(resources)
+		}
+	})
+	once := medianNsPerOp(runs, calls, func() {
+		for i := 0; i < calls; i++ {
+			releaseDeferOnce(resources)
+		}
+	})
+	t.Logf("release 16: defer in loop %.2f ns/op, defer once %.2f ns/op, difference %+.2f ns/op (%+.1f%%)",
+		inLoop, once, inLoop-once, (inLoop-once)/once*100)
+	if inLoop < once*1.05 {
+		t.Errorf("expected defer in loop to be ≥5%% slower than one defer, got %.2f vs %.2f ns/op", inLoop, once)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const iterations = 1_000_000
+
+// counter is the smallest realistic critical section: a mutex around an
+// increment, the shape of most request counters and in-memory caches.
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+// IncDeferred unlocks with defer. Since Go 1.14 this is an open-coded
+// defer: the compiler inlines the Unlock call at every return.
+func (c *counter) IncDeferred() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
+
+// IncManual unlocks explicitly. Every return path must remember to.
+func (c *counter) IncManual() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func runDeferred(c *counter, n int) {
+	for i := 0; i < n; i++ {
+		c.IncDeferred()
+	}
+}
+
+func runManual(c *counter, n int) {
+	for i := 0; i < n; i++ {
+		c.IncManual()
+	}
+}
+
+// resource stands in for a file or connection that must be released.
+type resource struct {
+	closed bool
+}
+
+func (r *resource) Close() {
+	r.closed = true
+}
+
+// releaseDeferInLoop defers one Close per resource inside the loop. The
+// compiler cannot open-code a defer whose count is unknown, so each one
+// is a runtime defer record, and nothing is released until return.
+func releaseDeferInLoop(resources []resource) {
+	for i := range resources {
+		r := &resources[i]
+		defer r.Close()
+	}
+}
+
+// releaseDeferOnce defers a single cleanup that walks the resources,
+// which the compiler can open-code.
+func releaseDeferOnce(resources []resource) {
+	defer func() {
+		for i := range resources {
+			resources[i].Close()
+		}
+	}()
+}
+
+func main() {
+	fmt.Println("🔬 DAY 8: Defer Overhead in Hot Paths")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The truth about defer cost
+	fmt.Println("🎯 SHOCKING DISCOVERY: defer is nearly free... until it's in a loop!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealDeferOverhead()
+
+	// Benchmark: deferred vs manual unlock
+	fmt.Printf("\n📊 BENCHMARK: %d mutex-protected increments\n", iterations)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Defer internals
+	fmt.Println("\n🔧 DEFER DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainDeferInternals()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateDeferCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 8, Topic: "Defer Overhead in Hot Paths", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 8 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 9 - sync.Pool Object Reuse")
+}
+
+func revealDeferOverhead() {
+	fmt.Println("How defer has been implemented:")
+	fmt.Println("  Go ≤ 1.12: every defer allocated a record on the heap (~35 ns)")
+	fmt.Println("  Go 1.13:   records moved to the stack when not in a loop (~6 ns)")
+	fmt.Println("  Go 1.14+:  open-coded defers, the call is inlined at each return (~1 ns)")
+	fmt.Println()
+	fmt.Println("Open coding only applies when the function has at most 8 defers and")
+	fmt.Println("none of them is in a loop. A defer in a loop still falls back to a")
+	fmt.Println("runtime record, heap-allocated because the compiler cannot bound how")
+	fmt.Println("many there will be.")
+	fmt.Println()
+
+	resources := make([]resource, 16)
+	inLoop, _ := bench.AllocsPerCall(10_000, func() { releaseDeferInLoop(resources) })
+	once, _ := bench.AllocsPerCall(10_000, func() { releaseDeferOnce(resources) })
+	var c counter
+	unlock, _ := bench.AllocsPerCall(10_000, c.IncDeferred)
+
+	fmt.Printf("  %-36s %5.1f allocs/call\n", "defer mu.Unlock() (open-coded):", unlock)
+	fmt.Printf("  %-36s %5.1f allocs/call\n", "one defer closing 16 resources:", once)
+	fmt.Printf("  %-36s %5.1f allocs/call\n", "defer r.Close() × 16 in a loop:", inLoop)
+
+	fmt.Println("\n💡 The loop version allocates per iteration and holds every resource")
+	fmt.Println("   open until the function returns, not until the iteration ends.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Deferred vs manual unlock")
+	suite.Iterations = 5
+	var c counter
+	suite.Register("defer mu.Unlock()", func() { runDeferred(&c, iterations) })
+	suite.Register("explicit mu.Unlock()", func() { runManual(&c, iterations) })
+	resources := make([]resource, 16)
+	suite.Register("defer r.Close() in loop (×16)", func() {
+		for i := 0; i < iterations/len(resources); i++ {
+			releaseDeferInLoop(resources)
+		}
+	})
+	suite.Register("one defer for 16 resources", func() {
+		for i := 0; i < iterations/len(resources); i++ {
+			releaseDeferOnce(resources)
+		}
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	deferred, manual := results[0].NsPerOp/iterations, results[1].NsPerOp/iterations
+	fmt.Printf("\n  Per increment: %.2f ns (defer) vs %.2f ns (manual) → %.2f ns, %.1f%% overhead\n",
+		deferred, manual, deferred-manual, (deferred-manual)/manual*100)
+	fmt.Printf("  Per resource:  %.2f ns (defer in loop) vs %.2f ns (one defer)\n",
+		results[2].NsPerOp/iterations, results[3].NsPerOp/iterations)
+	return results
+}
+
+func explainDeferInternals() {
+	fmt.Println("func (c *counter) IncDeferred() with an open-coded defer:")
+	fmt.Println()
+	fmt.Println("┌──────────┬──────────────────┬──────────┬──────────────────┐")
+	fmt.Println("│ Lock()   │ set defer bit    │ c.n++    │ bit set? Unlock()│")
+	fmt.Println("│          │ in frame         │          │ at each return   │")
+	fmt.Println("└──────────┴──────────────────┴──────────┴──────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHAT THE OVERHEAD IS:")
+	fmt.Println("  • Open-coded: a bitmask store and test, plus panic-path metadata")
+	fmt.Println("  • Stops Unlock from being inlined into the caller's fast path")
+	fmt.Println("  • In a loop: runtime.deferproc + a heap record per iteration")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHEN DEFER COSTS MORE THAN IT SAVES:")
+	fmt.Println("  • Tight loops where the critical section is a few ns")
+	fmt.Println("  • defer inside for loops (allocation + delayed release)")
+	fmt.Println("  • Functions with more than 8 defers")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 KEEP DEFER FOR CORRECTNESS")
+	fmt.Println("   ✅ mu.Lock(); defer mu.Unlock() in handlers with several returns")
+	fmt.Println("   Benefit: Unlock survives panics and early returns for ~1-5 ns")
+	fmt.Println()
+
+	fmt.Println("2. ⚡ UNLOCK EXPLICITLY IN TINY HOT SECTIONS")
+	fmt.Println("   ✅ mu.Lock(); n++; mu.Unlock()")
+	fmt.Println("   Benefit: No defer bookkeeping when there is one return path")
+	fmt.Println()
+
+	fmt.Println("3. 🔁 NEVER DEFER IN A LOOP")
+	fmt.Println("   ❌ for _, f := range files { defer f.Close() }")
+	fmt.Println("   ✅ for _, f := range files { func() { defer f.Close(); ... }() }")
+	fmt.Println("   Benefit: One open-coded defer per iteration, released immediately")
+	fmt.Println()
+
+	fmt.Println("4. 🧮 USE ATOMICS FOR SINGLE COUNTERS")
+	fmt.Println("   ✅ var n atomic.Int64; n.Add(1)")
+	fmt.Println("   Benefit: No lock, no unlock, nothing to defer")
+}
+
+func calculateDeferCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// API taking lockOpsPerRequest short critical sections and releasing
+	// resourcesPerRequest resources per request
+	requestsPerSecond := 10_000.0
+	lockOpsPerRequest := 50.0
+	resourcesPerRequest := 16.0
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perOp := func(r bench.Result) float64 { return r.NsPerOp / iterations }
+	unlockOverheadNs := perOp(results[0]) - perOp(results[1])
+	loopOverheadNs := perOp(results[2]) - perOp(results[3])
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %.0f lock/unlock pairs per request\n", requestsPerSecond, lockOpsPerRequest)
+	fmt.Printf("  • %.0f resources released per request\n", resourcesPerRequest)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	monthly := func(opsPerRequest, overheadNs float64) float64 {
+		vCPUs := requestsPerSecond * opsPerRequest * overheadNs / 1e9
+		return vCPUs * costPerVCPUHour * 24 * 30
+	}
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	if unlockOverheadNs <= 0 {
+		fmt.Printf("  defer mu.Unlock(): difference %.2f ns is within noise; counting it as 0\n", unlockOverheadNs)
+		unlockOverheadNs = 0
+	}
+	unlockCost := monthly(lockOpsPerRequest, unlockOverheadNs)
+	loopCost := monthly(resourcesPerRequest, loopOverheadNs)
+	fmt.Printf("  defer mu.Unlock():     %6.2f ns × %.0f/request → $%.4f/month\n",
+		unlockOverheadNs, lockOpsPerRequest, unlockCost)
+	fmt.Printf("  defer in a loop:       %6.2f ns × %.0f/request → $%.4f/month\n",
+		loopOverheadNs, resourcesPerRequest, loopCost)
+	fmt.Printf("  Annual cost of both:   $%.2f\n", (unlockCost+loopCost)*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Open-coded defer costs fractions of a cent per month at 10k RPS.")
+	fmt.Println("  One missed Unlock on an error path costs a deadlocked service.")
+	fmt.Println("  Keep defer by default; remove it only in measured hot loops, and")
+	fmt.Println("  move defers out of for loops where they allocate on every pass.")
+	return loopCost
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalSum uint64
+
+// ========== PER-REQUEST BENCHMARKS ==========
+
+func Benchmark_RawAllocation(b *testing.B) {
+	benchmarkRequest(b, allocate, discard)
+}
+
+func Benchmark_GenericPool(b *testing.B) {
+	var pool Pool[RequestContext]
+	benchmarkRequest(b, pool.Get, pool.Put)
+}
+
+func Benchmark_SyncPoolDirect(b *testing.B) {
+	var pool = sync.Pool{New: func() any { return new(RequestContext) }}
+	benchmarkRequest(b,
+		func() *RequestContext { return pool.Get().(*RequestContext) },
+		func(ctx *RequestContext) {
+			*ctx = RequestContext{}
+			pool.Put(ctx)
+		})
+}
+
+func benchmarkRequest(b *testing.B, acquire func() *RequestContext, release func(*RequestContext)) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ctx := acquire()
+		globalSum += handleRequest(ctx, i)
+		release(ctx)
+	}
+}
+
+// ========== POOL API BENCHMARKS ==========
+
+// One Get and one Put of a 4 KB buffer per op. sync.Pool stores any, so a
+// []byte put back by value is boxed: its slice header is copied to the
+// heap on every Put. Pool[T] only takes a *T, which an interface holds
+// without allocating, and the type assertion on Get never allocates.
+
+const bufferSize = 4096
+
+func Benchmark_SyncPoolWithInterface(b *testing.B) {
+	pool := sync.Pool{New: func() any { return make([]byte, bufferSize) }}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf := pool.Get().([]byte)
+		clear(buf)
+		buf[0] = byte(i)
+		pool.Put(buf) // boxes the slice header: 24 B per call
+	}
+}
+
+func Benchmark_SyncPoolWithGenerics(b *testing.B) {
+	var pool Pool[[bufferSize]byte]
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf := pool.Get()
+		buf[0] = byte(i)
+		pool.Put(buf) // zeroes the buffer, like clear above
+	}
+}
+
+// ========== BATCH BENCHMARKS ==========
+
+// Each op serves requestCount requests in waves of inFlight.
+
+func Benchmark_ServeBatch_Raw(b *testing.B) {
+	benchmarkBatch(b, allocate, discard)
+}
+
+func Benchmark_ServeBatch_Pooled(b *testing.B) {
+	var pool Pool[RequestContext]
+	benchmarkBatch(b, pool.Get, pool.Put)
+}
+
+func benchmarkBatch(b *testing.B, acquire func() *RequestContext, release func(*RequestContext)) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalSum += serveBatch(requestCount, acquire, release)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_PoolReturnsZeroedObjects(t *testing.T) {
+	var pool Pool[RequestContext]
+	for i := 0; i < 100; i++ {
+		ctx := pool.Get()
+		if *ctx != (RequestContext{}) {
+			t.Fatalf("Get %d returned a dirty context: user %d, %d bytes written", i, ctx.UserID, ctx.Written)
+		}
+		handleRequest(ctx, i+1)
+		pool.Put(ctx)
+	}
+}
+
+func Test_PoolAvoidsAllocation(t *testing.T) {
+	var pool Pool[RequestContext]
+	pool.Put(pool.Get()) // Prime the pool
+
+	raw := allocsPerRequest(allocate, discard)
+	pooled := allocsPerRequest(pool.Get, pool.Put)
+	t.Logf("allocs per request: %.2f (new) vs %.2f (pool)", raw, pooled)
+	if raw < 1 {
+		t.Errorf("expected new(RequestContext) to allocate, got %.2f allocs", raw)
+	}
+	if pooled >= raw {
+		t.Errorf("expected the pool to allocate less than new: %.2f vs %.2f", pooled, raw)
+	}
+}
+
+// allocsPerRequest calls through function values, as serveBatch does.
+// Calling allocate directly would let the compiler inline it and keep the
+// context on the stack.
+func allocsPerRequest(acquire func() *RequestContext, release func(*RequestContext)) float64 {
+	return testing.AllocsPerRun(1000, func() {
+		ctx := acquire()
+		globalSum += handleRequest(ctx, 1)
+		release(ctx)
+	})
+}
+
+func Test_TypedPoolZeroAllocsOnGet(t *testing.T) {
+	var pool Pool[[bufferSize]byte]
+	pool.Put(pool.Get()) // Prime the pool
+
+	testutil.AssertMaxAllocs(t, "Pool[T].Get after Put", 0, func() {
+		buf := pool.Get()
+		buf[0] = 1
+		pool.Put(buf)
+	})
+}
+
+func Test_InterfacePoolBoxesValues(t *testing.T) {
+	pool := sync.Pool{New: func() any { return make([]byte, bufferSize) }}
+	pool.Put(pool.Get())
+
+	// The same buffer by value: every Put copies its header to the heap
+	byValue := testing.AllocsPerRun(100, func() {
+		buf := pool.Get().([]byte)
+		buf[0] = 1
+		pool.Put(buf)
+	})
+	if byValue < 1 {
+		t.Errorf("Put of a []byte: %.1f allocs, expected the header to be boxed", byValue)
+	}
+
+	// A pointer fits in the interface: no boxing, and the type assertion
+	// on Get doesn't allocate either
+	ptrPool := sync.Pool{New: func() any { return new([bufferSize]byte) }}
+	ptrPool.Put(ptrPool.Get())
+	testutil.AssertMaxAllocs(t, "sync.Pool of *[4096]byte", 0, func() {
+		buf := ptrPool.Get().(*[bufferSize]byte)
+		buf[0] = 1
+		ptrPool.Put(buf)
+	})
+}
+
+func Test_ServeBatchSameResult(t *testing.T) {
+	var pool Pool[RequestContext]
+	raw := serveBatch(25_000, allocate, discard)
+	pooled := serveBatch(25_000, pool.Get, pool.Put)
+	if raw != pooled {
+		t.Errorf("pooled contexts changed the responses: %x vs %x", raw, pooled)
+	}
+}
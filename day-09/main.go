@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	requestCount = 100_000
+	inFlight     = 10_000 // Concurrent requests holding a context
+)
+
+// RequestContext is the per-request scratch state an HTTP handler
+// typically allocates: identifiers, parsed parameters and a buffer for
+// building the response.
+type RequestContext struct {
+	RequestID string
+	UserID    int64
+	Path      string
+	Params    [8]Param
+	NumParams int
+	Scratch   [1024]byte
+	Written   int
+}
+
+// Param is one parsed query parameter.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Pool is a typed wrapper around sync.Pool. Get never returns nil, and Put
+// zeroes the object so no request sees another request's data.
+type Pool[T any] struct {
+	pool sync.Pool
+}
+
+// Get returns a zeroed *T, reused from the pool when one is available.
+func (p *Pool[T]) Get() *T {
+	if v := p.pool.Get(); v != nil {
+		return v.(*T)
+	}
+	return new(T)
+}
+
+// Put zeroes x and returns it to the pool. x must not be used afterwards.
+func (p *Pool[T]) Put(x *T) {
+	var zero T
+	*x = zero
+	p.pool.Put(x)
+}
+
+// handleRequest fills ctx the way a handler would and returns a checksum
+// of the response so the work cannot be optimized away.
+func handleRequest(ctx *RequestContext, i int) uint64 {
+	ctx.RequestID = "req"
+	ctx.UserID = int64(i)
+	ctx.Path = "/api/v1/orders"
+	ctx.Params[0] = Param{"page", "1"}
+	ctx.Params[1] = Param{"limit", "50"}
+	ctx.NumParams = 2
+
+	b := ctx.Scratch[:0]
+	b = append(b, `{"user_id":`...)
+	b = strconv.AppendInt(b, ctx.UserID, 10)
+	b = append(b, `,"path":"`...)
+	b = append(b, ctx.Path...)
+	b = append(b, `"}`...)
+	ctx.Written = len(b)
+
+	h := fnv.New64a()
+	h.Write(b)
+	return h.Sum64()
+}
+
+// serveBatch processes requests in waves of inFlight: every request in a
+// wave holds its context at the same time, as concurrent handlers do,
+// and all of them are released when the wave completes.
+func serveBatch(requests int, acquire func() *RequestContext, release func(*RequestContext)) uint64 {
+	var sum uint64
+	wave := make([]*RequestContext, 0, inFlight)
+	for i := 0; i < requests; i++ {
+		ctx := acquire()
+		sum += handleRequest(ctx, i)
+		wave = append(wave, ctx)
+		if len(wave) == inFlight || i == requests-1 {
+			for _, c := range wave {
+				release(c)
+			}
+			wave = wave[:0]
+		}
+	}
+	return sum
+}
+
+func allocate() *RequestContext { return new(RequestContext) }
+
+func discard(*RequestContext) {}
+
+var contextPool Pool[RequestContext]
+
+// Global variable to prevent compiler optimizations
+var sink uint64
+
+func main() {
+	fmt.Println("🔬 DAY 9: sync.Pool Object Reuse")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about per-request structs
+	fmt.Println("🎯 SHOCKING DISCOVERY: Every request allocates its context from scratch!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealPerRequestAllocations()
+
+	// Memory: TotalAlloc for 100k requests
+	fmt.Printf("\n📊 MEMORY: %d requests, %d in flight\n", requestCount, inFlight)
+	fmt.Println(strings.Repeat("-", 40))
+	measureBatchAllocations()
+
+	// Benchmark
+	fmt.Println("\n📊 BENCHMARK: new(RequestContext) vs Pool[RequestContext]")
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Pool internals
+	fmt.Println("\n🔧 SYNC.POOL DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainPoolInternals()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculatePoolCostImpact(results[0], results[1], cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 9, Topic: "sync.Pool Object Reuse", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 9 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 10 - The Cost of reflect")
+}
+
+func revealPerRequestAllocations() {
+	size := float64(sizeOfContext())
+	fmt.Printf("  RequestContext: %.0f bytes (1 KB scratch buffer + fields)\n", size)
+	fmt.Printf("  %d requests/second × %.0f bytes = %.1f MB/s of garbage\n",
+		10_000, size, 10_000*size/(1024*1024))
+	fmt.Println()
+	fmt.Println("💡 The struct is identical for every request and lives for")
+	fmt.Println("   milliseconds. Allocating it fresh makes the GC sweep up the")
+	fmt.Println("   same shape of object thousands of times per second.")
+}
+
+// sizeOfContext is the heap bytes one new(RequestContext) allocates,
+// including size-class rounding.
+func sizeOfContext() uint64 {
+	bytes, _ := allocDelta(func() {
+		for i := 0; i < 100; i++ {
+			contextSink = allocate()
+		}
+	})
+	contextSink = nil
+	return bytes / 100
+}
+
+var contextSink *RequestContext
+
+// allocDelta runs fn between two MemStats readings and returns the bytes
+// and objects it allocated.
+func allocDelta(fn func()) (bytes, objects uint64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc, after.Mallocs - before.Mallocs
+}
+
+func measureBatchAllocations() {
+	rawBytes, rawObjects := allocDelta(func() {
+		sink = serveBatch(requestCount, allocate, discard)
+	})
+	pooledBytes, pooledObjects := allocDelta(func() {
+		sink = serveBatch(requestCount, contextPool.Get, contextPool.Put)
+	})
+
+	fmt.Printf("  %-22s TotalAlloc %7.1f MB, %7d objects\n", "new(RequestContext):", mb(rawBytes), rawObjects)
+	fmt.Printf("  %-22s TotalAlloc %7.1f MB, %7d objects\n", "Pool[RequestContext]:", mb(pooledBytes), pooledObjects)
+	fmt.Printf("\n  ✅ %.1f%% less allocated: the pool only allocates while the first\n",
+		float64(rawBytes-pooledBytes)/float64(rawBytes)*100)
+	fmt.Printf("     wave of %d contexts fills it; later waves reuse them.\n", inFlight)
+}
+
+func mb(b uint64) float64 {
+	return float64(b) / (1024 * 1024)
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("new vs pooled RequestContext")
+	suite.Iterations = 5
+	suite.Register("new(RequestContext)", func() {
+		sink = serveBatch(requestCount, allocate, discard)
+	})
+	suite.Register("Pool[RequestContext]", func() {
+		sink = serveBatch(requestCount, contextPool.Get, contextPool.Put)
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return suite.Results()
+}
+
+func explainPoolInternals() {
+	fmt.Println("sync.Pool keeps a private slot and a shared list per P:")
+	fmt.Println()
+	fmt.Println("┌──────────────┬─────────────────┬──────────────────┬───────────┐")
+	fmt.Println("│ P's private  │ P's shared list │ steal from other │ New() or  │")
+	fmt.Println("│ slot (fast)  │ (lock-free)     │ Ps / victim cache│ nil       │")
+	fmt.Println("└──────────────┴─────────────────┴──────────────────┴───────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHAT GC DOES TO THE POOL:")
+	fmt.Println("  • Each GC moves pooled objects to a victim cache")
+	fmt.Println("  • Objects still unused at the next GC are freed")
+	fmt.Println("  • So the pool shrinks on its own when load drops")
+	fmt.Println()
+
+	fmt.Println("⚠️  POOL PITFALLS:")
+	fmt.Println("  • Forgetting to reset: one request reads another's data")
+	fmt.Println("  • Using an object after Put: silent corruption")
+	fmt.Println("  • Pooling tiny or stack-allocated values: costs more than it saves")
+	fmt.Println("  • Pooling huge buffers: one big request pins memory")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 POOL LARGE, SHORT-LIVED, SAME-SHAPE OBJECTS")
+	fmt.Println("   ✅ Request contexts, encoders, scratch buffers")
+	fmt.Println("   Benefit: Allocation rate drops to the in-flight peak")
+	fmt.Println()
+
+	fmt.Println("2. 🧼 RESET ON PUT, IN ONE PLACE")
+	fmt.Println("   ✅ func (p *Pool[T]) Put(x *T) { var zero T; *x = zero; ... }")
+	fmt.Println("   Benefit: No caller can forget to clear sensitive fields")
+	fmt.Println()
+
+	fmt.Println("3. 🧬 WRAP sync.Pool WITH GENERICS")
+	fmt.Println("   ❌ ctx := pool.Get().(*RequestContext) // panics if New is unset")
+	fmt.Println("   ✅ ctx := contextPool.Get()             // typed, never nil")
+	fmt.Println("   Benefit: No type assertions or nil checks at call sites")
+	fmt.Println()
+
+	fmt.Println("4. 📏 DON'T POOL WHAT DOESN'T ESCAPE")
+	fmt.Println("   ✅ Check go build -gcflags=-m first: stack values are already free")
+}
+
+func calculatePoolCostImpact(raw, pooled bench.Result, pricing cost.PricingModel) float64 {
+	// One Lambda invocation per request
+	requestsPerSecond := 10_000.0
+	secondsPerMonth := 3600.0 * 24 * 30
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	// AWS Lambda (x86, us-east-1)
+	lambdaPerGBSecond := 0.0000166667
+	lambdaMemoryMB := 512.0
+
+	nsRaw := raw.NsPerOp / requestCount
+	nsPooled := pooled.NsPerOp / requestCount
+	bytesRaw := raw.BytesPerOp / requestCount
+	bytesPooled := pooled.BytesPerOp / requestCount
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, one Lambda invocation each\n", requestsPerSecond)
+	fmt.Printf("  • Lambda: $%.10f/GB-second at %.0f MB\n", lambdaPerGBSecond, lambdaMemoryMB)
+	fmt.Printf("  • EC2 (%v): $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Per request: %.0f ns, %.0f B (new) vs %.0f ns, %.0f B (pool)\n",
+		nsRaw, bytesRaw, nsPooled, bytesPooled)
+
+	invocations := requestsPerSecond * secondsPerMonth
+	lambda := func(ns float64) float64 {
+		return invocations * ns / 1e9 * lambdaMemoryMB / 1024 * lambdaPerGBSecond
+	}
+	lambdaRaw, lambdaPooled := lambda(nsRaw), lambda(nsPooled)
+	fmt.Println("  Lambda memory-duration (handler time only):")
+	fmt.Printf("    new(RequestContext):  $%.2f/month\n", lambdaRaw)
+	fmt.Printf("    Pool[RequestContext]: $%.2f/month\n", lambdaPooled)
+	fmt.Printf("    Monthly savings: $%.2f\n", lambdaRaw-lambdaPooled)
+
+	ec2 := func(ns float64) float64 {
+		return requestsPerSecond * ns / 1e9 * costPerVCPUHour * 24 * 30
+	}
+	fmt.Println("  Always-on EC2:")
+	fmt.Printf("    Monthly savings: $%.2f\n", ec2(nsRaw)-ec2(nsPooled))
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Lambda bills duration in 1 ms steps, so microseconds saved per")
+	fmt.Println("  request only pay off when they cross a boundary. The larger win is")
+	fmt.Println("  memory: a lower allocation rate means fewer GC cycles competing")
+	fmt.Println("  with the handler, and a smaller heap can fit a cheaper memory tier.")
+	return ec2(nsRaw) - ec2(nsPooled)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// Global variable to prevent compiler optimizations
+var globalDest DestUser
+
+// ========== COPY BENCHMARKS ==========
+
+func Benchmark_ManualCopy(b *testing.B) {
+	benchmarkCopy(b, manualCopy)
+}
+
+func Benchmark_ReflectCopy(b *testing.B) {
+	benchmarkCopy(b, func(dst *DestUser, src *SourceUser) { reflectCopy(dst, src) })
+}
+
+func Benchmark_GeneratedCopy(b *testing.B) {
+	benchmarkCopy(b, generatedCopy)
+}
+
+func benchmarkCopy(b *testing.B, copyFn func(*DestUser, *SourceUser)) {
+	src := makeSources(1)[0]
+	var dst DestUser
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		copyFn(&dst, &src)
+	}
+	globalDest = dst
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_AllCopiesAgree(t *testing.T) {
+	migrated := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, src := range makeSources(100) {
+		manual := DestUser{MigratedAt: migrated}
+		reflected := DestUser{MigratedAt: migrated}
+		generated := DestUser{MigratedAt: migrated}
+		manualCopy(&manual, &src)
+		reflectCopy(&reflected, &src)
+		generatedCopy(&generated, &src)
+
+		if reflected != manual {
+			t.Fatalf("reflectCopy differs from manualCopy:\n%+v\n%+v", reflected, manual)
+		}
+		if generated != manual {
+			t.Fatalf("generatedCopy differs from manualCopy:\n%+v\n%+v", generated, manual)
+		}
+		if manual.ID != src.ID || manual.Email != src.Email || !manual.CreatedAt.Equal(src.CreatedAt) {
+			t.Fatalf("fields not copied: %+v from %+v", manual, src)
+		}
+		if !manual.MigratedAt.Equal(migrated) {
+			t.Fatalf("MigratedAt overwritten: %v", manual.MigratedAt)
+		}
+	}
+}
+
+func Test_ReflectCopySkipsMismatchedFields(t *testing.T) {
+	type narrow struct {
+		ID   int32 // Same name, different type
+		Name string
+	}
+	src := makeSources(1)[0]
+	src.ID, src.Name = 42, "ada"
+	var dst narrow
+	reflectCopy(&dst, &src)
+	if dst.ID != 0 || dst.Name != "ada" {
+		t.Errorf("expected only Name copied, got %+v", dst)
+	}
+}
+
+func Test_GeneratedCopyUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go generate")
+	}
+	want, err := os.ReadFile("copy_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.WriteFile("copy_gen.go", want, 0o644) })
+
+	if out, err := exec.Command("go", "run", "gen.go").CombinedOutput(); err != nil {
+		t.Fatalf("go run gen.go: %v\n%s", err, out)
+	}
+	got, err := os.ReadFile("copy_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("copy_gen.go is stale; run go generate in day-10")
+	}
+}
@@ -0,0 +1,98 @@
+//go:build ignore
+
+// gen.go writes copy_gen.go: a generatedCopy function that assigns every
+// field SourceUser and DestUser share by name and type. Run it with
+// go generate after changing either struct.
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"text/template"
+)
+
+var copyTemplate = template.Must(template.New("copy").Parse(`// Code generated by gen.go; DO NOT EDIT.
+
+package main
+
+// generatedCopy copies the fields {{.Src}} and {{.Dst}} have in common.
+func generatedCopy(dst *{{.Dst}}, src *{{.Src}}) {
+{{- range .Fields}}
+	dst.{{.}} = src.{{.}}
+{{- end}}
+}
+`))
+
+func main() {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dstTypes := map[string]string{}
+	for _, fd := range structFields(fset, f, "DestUser") {
+		dstTypes[fd.name] = fd.typ
+	}
+	srcFields := structFields(fset, f, "SourceUser")
+	shared := make([]string, 0, len(srcFields))
+	for _, fd := range srcFields {
+		if typ, ok := dstTypes[fd.name]; ok && typ == fd.typ {
+			shared = append(shared, fd.name)
+		}
+	}
+
+	var buf bytes.Buffer
+	err = copyTemplate.Execute(&buf, struct {
+		Src, Dst string
+		Fields   []string
+	}{"SourceUser", "DestUser", shared})
+	if err != nil {
+		log.Fatal(err)
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("copy_gen.go", out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type field struct {
+	name, typ string
+}
+
+// structFields returns the fields of the named struct in declaration
+// order, with their types as written in the source.
+func structFields(fset *token.FileSet, f *ast.File, name string) []field {
+	var st *ast.StructType
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != name {
+			return st == nil
+		}
+		if st, ok = ts.Type.(*ast.StructType); !ok {
+			log.Fatalf("%s is not a struct", name)
+		}
+		return false
+	})
+	if st == nil {
+		log.Fatalf("struct %s not found in main.go", name)
+	}
+
+	fields := make([]field, 0, st.Fields.NumFields())
+	for _, fl := range st.Fields.List {
+		var typ bytes.Buffer
+		format.Node(&typ, fset, fl.Type)
+		for _, id := range fl.Names {
+			fields = append(fields, field{id.Name, typ.String()})
+		}
+	}
+	return fields
+}
@@ -0,0 +1,284 @@
+package main
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const recordCount = 1_000_000
+
+// SourceUser is a row as read from the old schema.
+type SourceUser struct {
+	ID           int64
+	Name         string
+	Email        string
+	Age          int
+	Active       bool
+	Balance      float64
+	CreatedAt    time.Time
+	PasswordHash string // Not migrated
+}
+
+// DestUser is the same row in the new schema. Fields with the same name
+// and type are copied; MigratedAt is set by the migration itself.
+type DestUser struct {
+	ID         int64
+	Name       string
+	Email      string
+	Age        int
+	Active     bool
+	Balance    float64
+	CreatedAt  time.Time
+	MigratedAt time.Time
+}
+
+// ========== COPY APPROACHES ==========
+
+// manualCopy assigns each field by hand: fastest, but every schema change
+// means editing it.
+func manualCopy(dst *DestUser, src *SourceUser) {
+	dst.ID = src.ID
+	dst.Name = src.Name
+	dst.Email = src.Email
+	dst.Age = src.Age
+	dst.Active = src.Active
+	dst.Balance = src.Balance
+	dst.CreatedAt = src.CreatedAt
+}
+
+// reflectCopy copies every field of src that dst has with the same name
+// and type, the way generic mappers do. Nothing is cached between calls.
+func reflectCopy(dst, src interface{}) {
+	sv := reflect.ValueOf(src).Elem()
+	dv := reflect.ValueOf(dst).Elem()
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		f := st.Field(i)
+		df := dv.FieldByName(f.Name)
+		if !df.IsValid() || !df.CanSet() || df.Type() != f.Type {
+			continue
+		}
+		df.Set(sv.Field(i))
+	}
+}
+
+// Global variables to prevent compiler optimizations
+var (
+	destSink  DestUser
+	valueSink reflect.Value
+	anySink   interface{}
+)
+
+func makeSources(n int) []SourceUser {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := make([]SourceUser, n)
+	for i := range users {
+		users[i] = SourceUser{
+			ID:           int64(i),
+			Name:         "user",
+			Email:        "user@example.com",
+			Age:          20 + i%50,
+			Active:       i%2 == 0,
+			Balance:      float64(i) * 0.5,
+			CreatedAt:    created,
+			PasswordHash: "x",
+		}
+	}
+	return users
+}
+
+func main() {
+	fmt.Println("🔬 DAY 10: The Cost of reflect")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about reflection
+	fmt.Println("🎯 SHOCKING DISCOVERY: A generic struct copier is an order of magnitude slower!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealReflectOverhead()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: Copying %d SourceUser → DestUser records\n", recordCount)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Reflection internals
+	fmt.Println("\n🔧 REFLECTION DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainReflectInternals()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateReflectCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 10, Topic: "The Cost of reflect", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 10 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 11 - False Sharing Between Goroutines")
+}
+
+func revealReflectOverhead() {
+	var v reflect.Value
+	var dst DestUser
+	src := makeSources(1)[0]
+
+	fmt.Printf("  reflect.Value:  %d bytes (type pointer, data pointer, flags)\n", unsafe.Sizeof(v))
+	fmt.Printf("  interface{}:    %d bytes (type pointer, data pointer)\n", unsafe.Sizeof(interface{}(nil)))
+	fmt.Printf("  SourceUser:     %d bytes, %d fields\n", unsafe.Sizeof(src), reflect.TypeOf(src).NumField())
+	fmt.Println()
+
+	fmt.Println("  Allocations per record copy:")
+	for _, c := range []struct {
+		name string
+		copy func()
+	}{
+		{"manual:", func() { manualCopy(&dst, &src) }},
+		{"generated:", func() { generatedCopy(&dst, &src) }},
+		{"reflect:", func() { reflectCopy(&dst, &src) }},
+	} {
+		allocs, _ := bench.AllocsPerCall(1000, c.copy)
+		fmt.Printf("    %-20s %4.1f\n", c.name, allocs)
+	}
+	destSink = dst
+
+	// Pointers keep reflectCopy allocation-free; these common calls don't
+	byValue, _ := bench.AllocsPerCall(1000, func() { valueSink = reflect.ValueOf(src) })
+	sv := reflect.ValueOf(&src).Elem()
+	fields, _ := bench.AllocsPerCall(1000, func() {
+		for i := 0; i < sv.NumField(); i++ {
+			anySink = sv.Field(i).Interface()
+		}
+	})
+	fmt.Println("\n  Allocations per reflect call:")
+	fmt.Printf("    %-32s %4.1f\n", "reflect.ValueOf(src) (by value):", byValue)
+	fmt.Printf("    %-32s %4.1f\n", "sv.Field(i).Interface() × 8:", fields)
+
+	fmt.Println("\n💡 Every reflect.Value carries its type and flags, and every operation")
+	fmt.Println("   re-checks them at run time: kind, exported, addressable, assignable.")
+	fmt.Println("   FieldByName is a linear search by string, and calls like")
+	fmt.Println("   Interface() or ValueOf(non-pointer) box values onto the heap.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	sources := makeSources(recordCount)
+	dests := make([]DestUser, recordCount)
+	for i := range sources { // Fault in dests so the first case isn't penalized
+		manualCopy(&dests[i], &sources[i])
+	}
+
+	suite := bench.NewBenchmarkSuite("Struct copy: manual vs reflect vs generated")
+	suite.Iterations = 3
+	suite.Register("manual field-by-field", func() {
+		for i := range sources {
+			manualCopy(&dests[i], &sources[i])
+		}
+	})
+	suite.Register("reflect.ValueOf + fields", func() {
+		for i := range sources {
+			reflectCopy(&dests[i], &sources[i])
+		}
+	})
+	suite.Register("go:generate copy", func() {
+		for i := range sources {
+			generatedCopy(&dests[i], &sources[i])
+		}
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	destSink = dests[len(dests)-1]
+	return suite.Results()
+}
+
+func explainReflectInternals() {
+	fmt.Println("df.Set(sv.Field(i)) in reflectCopy:")
+	fmt.Println()
+	fmt.Println("┌──────────────┬───────────────┬──────────────┬──────────────┐")
+	fmt.Println("│ FieldByName  │ check kind,   │ check        │ typedmemmove │")
+	fmt.Println("│ (linear scan)│ exported, addr│ assignable   │ + GC barrier │")
+	fmt.Println("└──────────────┴───────────────┴──────────────┴──────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY REFLECTION IS SLOW:")
+	fmt.Println("  • Work the compiler does once is redone on every call")
+	fmt.Println("  • No inlining: every operation is a function call")
+	fmt.Println("  • Values escape: the compiler can't prove where they go")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHERE REFLECTION HIDES:")
+	fmt.Println("  • encoding/json, encoding/xml, database/sql Scan into structs")
+	fmt.Println("  • ORMs and struct mappers (copier, mapstructure)")
+	fmt.Printf("  • fmt printing structs with %%v or %%+v\n")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🎯 WRITE THE COPY BY HAND FOR HOT PATHS")
+	fmt.Println("   ✅ dst.ID = src.ID; dst.Name = src.Name; ...")
+	fmt.Println("   Benefit: Inlined, zero allocations")
+	fmt.Println()
+
+	fmt.Println("2. 🏭 GENERATE IT WITH go:generate")
+	fmt.Println("   ✅ //go:generate go run gen.go")
+	fmt.Println("   Benefit: Manual-copy speed, stays in sync with the structs")
+	fmt.Println()
+
+	fmt.Println("3. 🗂️ CACHE REFLECTION METADATA IF YOU MUST REFLECT")
+	fmt.Println("   ✅ Compute field index pairs once per type pair, reuse them")
+	fmt.Println("   Benefit: Removes FieldByName and type checks from the loop")
+	fmt.Println()
+
+	fmt.Println("4. 🧬 USE GENERICS FOR TYPE-PARAMETRIC CODE")
+	fmt.Println("   ✅ func Map[S, D any](src []S, f func(*D, *S)) []D")
+	fmt.Println("   Benefit: Compile-time types, no reflect.Value")
+}
+
+func calculateReflectCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// Schema-migration service re-copying the full table on a schedule
+	migrationsPerDay := 24.0 * 12 // Every 5 minutes
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %d records per migration run, %.0f runs/day (every 5 min)\n", recordCount, migrationsPerDay)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	calc := cost.VCPUCalculator{VCPUHourPrice: costPerVCPUHour}
+	recordsPerSecond := recordCount * migrationsPerDay / (24 * 3600)
+	monthly := make([]float64, len(results))
+	for i, r := range results {
+		monthly[i] = calc.MonthlyCPUCost(r.NsPerOp/recordCount, recordsPerSecond)
+		fmt.Printf("  %-26s %6.1f ns/record, %5.0f ms/run → $%.2f/month\n",
+			r.Name+":", r.NsPerOp/recordCount, r.NsPerOp/1e6, monthly[i])
+	}
+
+	savings := monthly[1] - monthly[2]
+	fmt.Printf("\n  Monthly savings (reflect → generated): $%.2f\n", savings)
+	fmt.Printf("  Annual savings:                        $%.2f\n", cost.AnnualFromMonthly(savings))
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Shorter migration windows and lock times")
+	fmt.Println("  • Compile errors instead of silently skipped fields")
+	fmt.Println("  • No hidden boxing when the copier grows Interface() calls")
+	return savings
+}
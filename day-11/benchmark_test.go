@@ -0,0 +1,164 @@
+package main
+
+import (
+	"math"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// ========== PARALLEL INCREMENT BENCHMARKS ==========
+
+// RunParallel starts 8×GOMAXPROCS goroutines, assigned to the 8 counters
+// round-robin. With GOMAXPROCS > 1 several goroutines share each counter
+// in both layouts; only the packed one adds contention between counters.
+
+func Benchmark_PackedCounters(b *testing.B) {
+	benchmarkCounters(b, new(Counters).Counter)
+}
+
+func Benchmark_PaddedCounters(b *testing.B) {
+	benchmarkCounters(b, new(PaddedCounters).Counter)
+}
+
+func benchmarkCounters(b *testing.B, counter func(worker int) *atomic.Int64) {
+	var next atomic.Int64
+	b.SetParallelism(numWorkers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		c := counter(int(next.Add(1)-1) % numWorkers)
+		for pb.Next() {
+			c.Add(1)
+		}
+	})
+}
+
+// ========== DATA LAYOUT BENCHMARKS ==========
+
+// Global variable to prevent compiler optimizations
+var checksum int64
+
+// elements/ns is throughput; ns/element is the time per ID read, which
+// rises with every cache line loaded for it (an L1 miss rate proxy).
+func Benchmark_StructOfArrays_vs_ArrayOfStructs(b *testing.B) {
+	users := newUsers(numUsers)
+	db := newUserDatabase(users)
+
+	b.Run("ArrayOfStructs", func(b *testing.B) {
+		benchmarkScan(b, func() int64 { return sumIDsAoS(users) })
+	})
+	b.Run("StructOfArrays", func(b *testing.B) {
+		benchmarkScan(b, func() int64 { return sumIDsSoA(db) })
+	})
+}
+
+func benchmarkScan(b *testing.B, scan func() int64) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		checksum += scan()
+	}
+	elements := float64(b.N) * numUsers
+	b.ReportMetric(elements/float64(b.Elapsed().Nanoseconds()), "elements/ns")
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/elements, "ns/element")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_CounterLayouts(t *testing.T) {
+	packed := new(Counters)
+	padded := new(PaddedCounters)
+	// A stack-allocated Counters is only 8-byte aligned and may straddle
+	// two lines; on the heap it lands on one
+	if span := cacheLine(packed.Counter(numWorkers-1)) - cacheLine(packed.Counter(0)); span > 1 {
+		t.Errorf("packed counters span %d cache lines, expected at most 2", span+1)
+	}
+	for i := 1; i < numWorkers; i++ {
+		if cacheLine(padded.Counter(i)) != cacheLine(padded.Counter(i-1))+1 {
+			t.Errorf("padded counter %d is not on the line after counter %d", i, i-1)
+		}
+	}
+}
+
+func Test_IncrementAllCountsEveryIncrement(t *testing.T) {
+	const n = 10_000
+	packed := new(Counters)
+	padded := new(PaddedCounters)
+	incrementAll(packed.Counter, n)
+	incrementAll(padded.Counter, n)
+	for i := 0; i < numWorkers; i++ {
+		if got := packed.Counter(i).Load(); got != n {
+			t.Errorf("packed counter %d: expected %d, got %d", i, n, got)
+		}
+		if got := padded.Counter(i).Load(); got != n {
+			t.Errorf("padded counter %d: expected %d, got %d", i, n, got)
+		}
+	}
+}
+
+// medianDuration times fn runs times and returns the median.
+func medianDuration(runs int, fn func()) time.Duration {
+	d := make([]time.Duration, runs)
+	for i := range d {
+		start := time.Now()
+		fn()
+		d[i] = time.Since(start)
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	return d[runs/2]
+}
+
+func Test_PaddingImprovement(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	if runtime.GOMAXPROCS(0) < 2 {
+		t.Skip("false sharing needs goroutines running on at least 2 cores")
+	}
+	const runs, n = 5, 1_000_000
+
+	packed := medianDuration(runs, func() { incrementAll(new(Counters).Counter, n) })
+	padded := medianDuration(runs, func() { incrementAll(new(PaddedCounters).Counter, n) })
+	t.Logf("%d goroutines × %d increments on %d cores: packed %v, padded %v (%.1fx)",
+		numWorkers, n, runtime.GOMAXPROCS(0), packed, padded, float64(packed)/float64(padded))
+	if float64(packed) < 1.2*float64(padded) {
+		t.Errorf("expected padded counters ≥20%% faster, got packed %v vs padded %v", packed, padded)
+	}
+}
+
+func Test_LayoutsSumSameIDs(t *testing.T) {
+	const n = 10_000
+	users := newUsers(n)
+	db := newUserDatabase(users)
+	want := int64(n * (n - 1) / 2)
+	if got := sumIDsAoS(users); got != want {
+		t.Errorf("AoS: expected %d, got %d", want, got)
+	}
+	if got := sumIDsSoA(db); got != want {
+		t.Errorf("SoA: expected %d, got %d", want, got)
+	}
+	for i, u := range users {
+		if db.IDs[i] != u.ID || db.Ages[i] != u.Age || db.Actives[i] != u.Active || db.Names[i] != u.Name {
+			t.Fatalf("user %d differs between layouts", i)
+		}
+	}
+}
+
+func Test_CacheLineUtilization(t *testing.T) {
+	idSize := unsafe.Sizeof(User{}.ID)
+	if got := cacheLineUtilization(idSize, idSize); got != 100 {
+		t.Errorf("SoA: expected 100%%, got %.1f%%", got)
+	}
+	// On 64-bit User is 24 bytes: 4 of every 24 bytes loaded are IDs
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		if got := cacheLineUtilization(idSize, unsafe.Sizeof(User{})); math.Abs(got-100.0/6) > 0.01 {
+			t.Errorf("AoS: expected 16.7%%, got %.1f%%", got)
+		}
+	}
+}
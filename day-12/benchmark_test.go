@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalRecorder responseRecorder
+
+// ========== BODY ASSEMBLY BENCHMARKS ==========
+
+func Benchmark_BytesBuffer(b *testing.B) {
+	benchmarkWrite(b, writeWithBuffer)
+}
+
+func Benchmark_StringsBuilder(b *testing.B) {
+	benchmarkWrite(b, writeWithBuilder)
+}
+
+func Benchmark_ByteSliceAppend(b *testing.B) {
+	benchmarkWrite(b, writeWithAppend)
+}
+
+// ========== POOLED BENCHMARKS ==========
+
+func Benchmark_BuilderWithPool(b *testing.B) {
+	benchmarkWrite(b, writeWithPooledBuilder)
+}
+
+func Benchmark_BufferWithPool(b *testing.B) {
+	benchmarkWrite(b, writeWithPooledBuffer)
+}
+
+func benchmarkWrite(b *testing.B, write func(io.Writer, []string)) {
+	fragments := responseFragments(sampleOrder)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		globalRecorder.Reset()
+		write(&globalRecorder, fragments)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_FragmentsPerResponse(t *testing.T) {
+	if n := len(responseFragments(sampleOrder)); n != fragmentsPerResponse {
+		t.Errorf("expected %d fragments, got %d", fragmentsPerResponse, n)
+	}
+}
+
+func Test_AllApproachesWriteSameBody(t *testing.T) {
+	fragments := responseFragments(sampleOrder)
+	var want responseRecorder
+	approaches[0].Write(&want, fragments)
+	if !json.Valid(want.body) {
+		t.Fatalf("%s wrote invalid JSON: %s", approaches[0].Name, want.body)
+	}
+
+	for _, a := range approaches[1:] {
+		// Twice, so pooled approaches are checked with a reused object
+		for i := 0; i < 2; i++ {
+			var got responseRecorder
+			a.Write(&got, fragments)
+			if string(got.body) != string(want.body) {
+				t.Errorf("%s (call %d):\n got %s\nwant %s", a.Name, i+1, got.body, want.body)
+			}
+		}
+	}
+}
+
+func Test_BodyAllocations(t *testing.T) {
+	fragments := responseFragments(sampleOrder)
+	var w responseRecorder
+	allocs := func(write func(io.Writer, []string)) float64 {
+		return testing.AllocsPerRun(1000, func() {
+			w.Reset()
+			write(&w, fragments)
+		})
+	}
+
+	if got := allocs(writeWithAppend); got != 1 {
+		t.Errorf("pre-sized append: expected 1 alloc, got %.1f", got)
+	}
+	testutil.AssertMaxAllocs(t, "pooled bytes.Buffer", 0, func() {
+		w.Reset()
+		writeWithPooledBuffer(&w, fragments)
+	})
+	// Reset drops a Builder's buffer, so pooling it saves nothing
+	builder, pooled := allocs(writeWithBuilder), allocs(writeWithPooledBuilder)
+	if pooled < builder {
+		t.Errorf("expected pooled strings.Builder to allocate as much as a new one, got %.1f vs %.1f", pooled, builder)
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	fragmentsPerResponse = 20
+	responsesPerRun      = 100_000
+)
+
+// Order is the resource the handler returns.
+type Order struct {
+	ID        int64
+	Customer  string
+	Email     string
+	Status    string
+	Currency  string
+	Total     float64
+	Items     int
+	CreatedAt time.Time
+	Tracking  string
+}
+
+// responseFragments splits o's JSON body into the pieces a handler writes
+// one at a time: the braces plus a key and a value for each of 9 fields.
+func responseFragments(o Order) []string {
+	return []string{
+		"{",
+		`"id":`, strconv.FormatInt(o.ID, 10),
+		`,"customer":`, strconv.Quote(o.Customer),
+		`,"email":`, strconv.Quote(o.Email),
+		`,"status":`, strconv.Quote(o.Status),
+		`,"currency":`, strconv.Quote(o.Currency),
+		`,"total":`, strconv.FormatFloat(o.Total, 'f', 2, 64),
+		`,"items":`, strconv.Itoa(o.Items),
+		`,"created_at":`, strconv.Quote(o.CreatedAt.Format(time.RFC3339)),
+		`,"tracking":`, strconv.Quote(o.Tracking),
+		"}",
+	}
+}
+
+// ========== BODY ASSEMBLY APPROACHES ==========
+
+// Each approach assembles the fragments into one body and writes it to w
+// with a single call, as a handler does before setting Content-Length.
+
+func writeWithBuffer(w io.Writer, fragments []string) {
+	var buf bytes.Buffer
+	for _, f := range fragments {
+		buf.WriteString(f)
+	}
+	w.Write(buf.Bytes())
+}
+
+func writeWithBuilder(w io.Writer, fragments []string) {
+	var sb strings.Builder
+	for _, f := range fragments {
+		sb.WriteString(f)
+	}
+	io.WriteString(w, sb.String())
+}
+
+// writeWithAppend sizes the slice up front, so the body is one allocation.
+func writeWithAppend(w io.Writer, fragments []string) {
+	n := 0
+	for _, f := range fragments {
+		n += len(f)
+	}
+	body := make([]byte, 0, n)
+	for _, f := range fragments {
+		body = append(body, f...)
+	}
+	w.Write(body)
+}
+
+var builderPool = sync.Pool{
+	New: func() any { return new(strings.Builder) },
+}
+
+// writeWithPooledBuilder reuses the Builder struct, but Reset sets its
+// buffer to nil, so every response still grows a new one (see day 5).
+func writeWithPooledBuilder(w io.Writer, fragments []string) {
+	sb := builderPool.Get().(*strings.Builder)
+	sb.Reset()
+	for _, f := range fragments {
+		sb.WriteString(f)
+	}
+	io.WriteString(w, sb.String())
+	builderPool.Put(sb)
+}
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// writeWithPooledBuffer reuses the Buffer and its memory: Reset keeps the
+// capacity, and w.Write copies the bytes out before the Buffer goes back.
+func writeWithPooledBuffer(w io.Writer, fragments []string) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	for _, f := range fragments {
+		buf.WriteString(f)
+	}
+	w.Write(buf.Bytes())
+	bufferPool.Put(buf)
+}
+
+type approach struct {
+	Name  string
+	Write func(io.Writer, []string)
+}
+
+var approaches = []approach{
+	{"bytes.Buffer", writeWithBuffer},
+	{"strings.Builder", writeWithBuilder},
+	{"pre-sized []byte append", writeWithAppend},
+	{"pooled strings.Builder", writeWithPooledBuilder},
+	{"pooled bytes.Buffer", writeWithPooledBuffer},
+}
+
+// responseRecorder stands in for http.ResponseWriter: it copies what it is
+// given into a body it reuses across responses, and like net/http's
+// response it implements io.StringWriter so strings are not converted.
+type responseRecorder struct {
+	body []byte
+}
+
+func (r *responseRecorder) Reset() { r.body = r.body[:0] }
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body = append(r.body, p...)
+	return len(p), nil
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body = append(r.body, s...)
+	return len(s), nil
+}
+
+var sampleOrder = Order{
+	ID:        1042,
+	Customer:  "Ada Lovelace",
+	Email:     "ada@example.com",
+	Status:    "shipped",
+	Currency:  "EUR",
+	Total:     129.9,
+	Items:     3,
+	CreatedAt: time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC),
+	Tracking:  "1Z999AA10123456784",
+}
+
+func main() {
+	fmt.Println("🔬 DAY 12: Assembling HTTP Response Bodies")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	fragments := responseFragments(sampleOrder)
+
+	// The shocking truth about response buffers
+	fmt.Println("🎯 SHOCKING DISCOVERY: A 192-byte body takes 6 allocations to build!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealBodyAllocations(fragments)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d responses of %d fragments each\n", responsesPerRun, len(fragments))
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(fragments)
+
+	// Growth internals
+	fmt.Println("\n🔧 BUFFER GROWTH DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainBufferGrowth()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateResponseCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 12, Topic: "Assembling HTTP Response Bodies", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 12 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 13 - Atomic Operations vs Mutex")
+}
+
+func revealBodyAllocations(fragments []string) {
+	var w responseRecorder
+	bodyLen := 0
+	for _, f := range fragments {
+		bodyLen += len(f)
+	}
+	fmt.Printf("  Response body: %d bytes from %d fragments\n\n", bodyLen, len(fragments))
+
+	fmt.Printf("  %-26s %s\n", "Approach", "allocs/response")
+	for _, a := range approaches {
+		allocs, _ := bench.AllocsPerCall(10_000, func() {
+			w.Reset()
+			a.Write(&w, fragments)
+		})
+		fmt.Printf("  %-26s %5.1f\n", a.Name+":", allocs)
+	}
+
+	fmt.Println("\n💡 Unsized buffers grow by doubling: every WriteString that doesn't")
+	fmt.Println("   fit allocates a bigger array and copies what was written so far.")
+	fmt.Println("   strings.Builder starts at 8 bytes, bytes.Buffer at 64.")
+}
+
+func runComparisonBenchmarks(fragments []string) []bench.Result {
+	suite := bench.NewBenchmarkSuite("Response body assembly")
+	suite.Iterations = 3
+	var w responseRecorder
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			for i := 0; i < responsesPerRun; i++ {
+				w.Reset()
+				a.Write(&w, fragments)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return suite.Results()
+}
+
+func explainBufferGrowth() {
+	fmt.Println("strings.Builder growing to a 192-byte body:")
+	fmt.Println()
+	fmt.Println("┌──────┬──────┬──────┬──────┬───────┬───────┐")
+	fmt.Println("│ 8 B  │ 16 B │ 32 B │ 64 B │ 128 B │ 256 B │  6 arrays, 5 copies")
+	fmt.Println("└──────┴──────┴──────┴──────┴───────┴───────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHAT EACH TYPE IS GOOD AT:")
+	fmt.Println("  • strings.Builder: String() without a copy, when you need a string")
+	fmt.Println("  • bytes.Buffer: Bytes() without a copy, Reset() keeps capacity")
+	fmt.Println("  • []byte + append: full control, one allocation if pre-sized")
+	fmt.Println()
+
+	fmt.Println("⚠️  POOLING GOTCHA:")
+	fmt.Println("  • strings.Builder.Reset() sets the buffer to nil: nothing is reused")
+	fmt.Println("  • bytes.Buffer.Reset() keeps the array: the pool pays off")
+	fmt.Println("  • Don't pool huge buffers: cap what you Put back")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📏 PRE-SIZE THE BODY")
+	fmt.Println("   ✅ body := make([]byte, 0, n) or sb.Grow(n)")
+	fmt.Println("   Benefit: One allocation instead of one per doubling")
+	fmt.Println()
+
+	fmt.Println("2. ♻️  POOL bytes.Buffer, NOT strings.Builder")
+	fmt.Println("   ✅ buf := pool.Get().(*bytes.Buffer); buf.Reset(); ...; pool.Put(buf)")
+	fmt.Println("   Benefit: Zero allocations per response in steady state")
+	fmt.Println()
+
+	fmt.Println("3. 🎯 END WITH THE TYPE THE WRITER WANTS")
+	fmt.Println("   ✅ w.Write(buf.Bytes()) for []byte, io.WriteString(w, s) for strings")
+	fmt.Println("   Benefit: No []byte(s) or string(b) conversion copies")
+	fmt.Println()
+
+	fmt.Println("4. 🚰 STREAM LARGE BODIES")
+	fmt.Println("   ✅ json.NewEncoder(w).Encode(v) when Content-Length isn't needed")
+	fmt.Println("   Benefit: No full-body buffer at all")
+}
+
+func calculateResponseCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// API server assembling one body per request
+	requestsPerSecond := 10_000.0
+	requestsPerDay := requestsPerSecond * 24 * 3600
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perResponse := func(r bench.Result) (ns, bytes float64) {
+		return r.NsPerOp / responsesPerRun, r.BytesPerOp / responsesPerRun
+	}
+	// Compare the usual handler code, an unsized bytes.Buffer, to the best
+	baseline, fastest := results[0], results[0]
+	for _, r := range results[1:] {
+		if r.NsPerOp < fastest.NsPerOp {
+			fastest = r
+		}
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, one %d-fragment body each\n", requestsPerSecond, fragmentsPerResponse)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, r := range results {
+		ns, b := perResponse(r)
+		fmt.Printf("  %-26s %6.1f ns, %5.0f B/response → %6.2f MB/s allocated\n",
+			r.Name+":", ns, b, b*requestsPerSecond/1e6)
+	}
+	baseNs, _ := perResponse(baseline)
+	fastNs, _ := perResponse(fastest)
+	monthly := cost.CPUSavingsMonthly(time.Duration(baseNs-fastNs), requestsPerDay, costPerVCPUHour)
+	fmt.Printf("\n  %s → %s saves %.0f ns/request\n", baseline.Name, fastest.Name, baseNs-fastNs)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Lower allocation rate → fewer GC cycles at peak traffic")
+	fmt.Println("  • Fewer copies of the body on its way to the socket")
+	fmt.Println("  • Predictable memory per request")
+	return monthly
+}
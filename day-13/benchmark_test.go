@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== PARALLEL COUNTER BENCHMARKS ==========
+
+// Each reports ops/s: increments per second across all goroutines.
+
+func Benchmark_MutexCounter(b *testing.B) {
+	benchmarkCounter(b, new(mutexCounter))
+}
+
+func Benchmark_RWMutexCounter(b *testing.B) {
+	benchmarkCounter(b, new(rwMutexCounter))
+}
+
+func Benchmark_AtomicCounter(b *testing.B) {
+	benchmarkCounter(b, new(atomicCounter))
+}
+
+func benchmarkCounter(b *testing.B, c Counter) {
+	b.SetParallelism(numWorkers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Inc()
+		}
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+}
+
+func Benchmark_LocalAccumulation(b *testing.B) {
+	var total atomic.Int64
+	b.SetParallelism(numWorkers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var local int64
+		for pb.Next() {
+			local++
+		}
+		total.Add(local)
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+}
+
+func Benchmark_CASIncrement(b *testing.B) {
+	var c, retries atomic.Int64
+	b.SetParallelism(numWorkers)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		r := 0
+		for pb.Next() {
+			r += casIncrement(&c)
+		}
+		retries.Add(int64(r))
+	})
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+	b.ReportMetric(float64(retries.Load())/float64(b.N), "retries/op")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_SharedCountersCountEveryIncrement(t *testing.T) {
+	const n = 10_000
+	for name, c := range map[string]Counter{
+		"mutex":   new(mutexCounter),
+		"rwmutex": new(rwMutexCounter),
+		"atomic":  new(atomicCounter),
+	} {
+		runShared(c, n)
+		if got := c.Load(); got != numWorkers*n {
+			t.Errorf("%s: expected %d, got %d", name, numWorkers*n, got)
+		}
+	}
+}
+
+func Test_LocalAccumulationSumsAllWorkers(t *testing.T) {
+	const n = 10_000
+	var total atomic.Int64
+	runLocal(&total, n)
+	if got := total.Load(); got != numWorkers*n {
+		t.Errorf("expected %d, got %d", numWorkers*n, got)
+	}
+}
+
+func Test_CASIncrementNeverLosesUpdates(t *testing.T) {
+	const n = 10_000
+	var c atomic.Int64
+	retries := runCAS(&c, n)
+	if got := c.Load(); got != numWorkers*n {
+		t.Errorf("expected %d, got %d", numWorkers*n, got)
+	}
+	t.Logf("%d retries for %d increments", retries, numWorkers*n)
+}
+
+func Test_AtomicCounterDoesNotAllocate(t *testing.T) {
+	var c atomicCounter
+	testutil.AssertMaxAllocs(t, "atomicCounter.Inc", 0, c.Inc)
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	numWorkers             = 8
+	incrementsPerGoroutine = 1_000_000
+)
+
+// ========== SHARED COUNTERS ==========
+
+// Counter is a request counter shared by every worker.
+type Counter interface {
+	Inc()
+	Load() int64
+}
+
+// mutexCounter serializes every increment behind a lock.
+type mutexCounter struct {
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *mutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *mutexCounter) Load() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// rwMutexCounter is what a counter read by dashboards often becomes. Every
+// Inc still takes the write lock, which costs more than a plain Mutex.
+type rwMutexCounter struct {
+	mu sync.RWMutex
+	n  int64
+}
+
+func (c *rwMutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *rwMutexCounter) Load() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.n
+}
+
+// atomicCounter is one LOCK XADD per increment on x86: no lock to acquire,
+// no goroutine ever parked.
+type atomicCounter struct {
+	n atomic.Int64
+}
+
+func (c *atomicCounter) Inc()        { c.n.Add(1) }
+func (c *atomicCounter) Load() int64 { return c.n.Load() }
+
+// runShared starts numWorkers goroutines that each call c.Inc n times.
+func runShared(c Counter, n int) {
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				c.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// runLocal has each goroutine count in a local variable and publish its
+// total with one atomic add at the end: numWorkers shared writes in all.
+func runLocal(total *atomic.Int64, n int) {
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local int64
+			for i := 0; i < n; i++ {
+				local++
+			}
+			total.Add(local)
+		}()
+	}
+	wg.Wait()
+}
+
+// casIncrement adds 1 with a CompareAndSwap loop, the shape of any atomic
+// update that Add can't express (max, saturating add, state machines), and
+// returns how many times another goroutine got there first.
+func casIncrement(c *atomic.Int64) (retries int) {
+	for {
+		old := c.Load()
+		if c.CompareAndSwap(old, old+1) {
+			return retries
+		}
+		retries++
+	}
+}
+
+// runCAS runs casIncrement n times on each of numWorkers goroutines and
+// returns the total number of retries.
+func runCAS(c *atomic.Int64, n int) int64 {
+	var retries atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := 0
+			for i := 0; i < n; i++ {
+				r += casIncrement(c)
+			}
+			retries.Add(int64(r))
+		}()
+	}
+	wg.Wait()
+	return retries.Load()
+}
+
+func main() {
+	fmt.Println("🔬 DAY 13: Atomic Operations vs Mutex")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about counters
+	fmt.Println("🎯 SHOCKING DISCOVERY: Your request counter may be your most contended lock!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealAtomicCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d goroutines × %d increments of one counter\n",
+		numWorkers, incrementsPerGoroutine)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Atomic internals
+	fmt.Println("\n🔧 ATOMICS DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainAtomics()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateAtomicCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 13, Topic: "Atomic Operations vs Mutex", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 13 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 14 - Reading Request Bodies Without io.ReadAll")
+}
+
+func revealAtomicCost() {
+	fmt.Printf("  CPUs available: %d (GOMAXPROCS %d)\n\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+
+	fmt.Println("  What one increment does:")
+	fmt.Println("    mutex:   Lock (CAS), n++, Unlock (atomic); park and wake when contended")
+	fmt.Println("    atomic:  one LOCK XADD; the cache line moves, no goroutine waits")
+	fmt.Println("    local:   a register add; one shared write per goroutine at the end")
+	fmt.Println()
+
+	var c atomic.Int64
+	attempts := int64(numWorkers * incrementsPerGoroutine)
+	retries := runCAS(&c, incrementsPerGoroutine)
+	fmt.Printf("  CompareAndSwap loop, %d goroutines × %d increments:\n", numWorkers, incrementsPerGoroutine)
+	fmt.Printf("    successful CAS: %d\n", c.Load())
+	fmt.Printf("    retries:        %d (%.2f%% of attempts)\n",
+		retries, float64(retries)/float64(attempts+retries)*100)
+
+	fmt.Println("\n💡 Every retry is a CAS that lost the race: the value changed between")
+	fmt.Println("   Load and CompareAndSwap, so the work is thrown away and redone.")
+	fmt.Println("   Add never retries; prefer it whenever the update is a plain sum.")
+	if runtime.GOMAXPROCS(0) < 2 {
+		fmt.Println("   ⚠️  With one CPU a retry needs a preemption between Load and CAS,")
+		fmt.Println("      so the rate here is a floor. Multi-core machines retry far more.")
+	}
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Shared counter: mutex vs RWMutex vs atomic vs local")
+	suite.Iterations = 3
+	suite.Register("sync.Mutex", func() {
+		runShared(new(mutexCounter), incrementsPerGoroutine)
+	})
+	suite.Register("sync.RWMutex (writes)", func() {
+		runShared(new(rwMutexCounter), incrementsPerGoroutine)
+	})
+	suite.Register("atomic.Int64", func() {
+		runShared(new(atomicCounter), incrementsPerGoroutine)
+	})
+	suite.Register("local + one atomic add", func() {
+		runLocal(new(atomic.Int64), incrementsPerGoroutine)
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		opsPerSec := numWorkers * incrementsPerGoroutine / r.Duration().Seconds()
+		fmt.Printf("  %-24s %8.1f M increments/sec\n", r.Name+":", opsPerSec/1e6)
+	}
+	return results
+}
+
+func explainAtomics() {
+	fmt.Println("sync.Mutex.Lock() under contention:")
+	fmt.Println()
+	fmt.Println("┌──────────────┬──────────────┬──────────────┬──────────────┐")
+	fmt.Println("│ CAS fast     │ spin a few   │ park on      │ woken by     │")
+	fmt.Println("│ path (free?) │ times        │ semaphore    │ Unlock       │")
+	fmt.Println("└──────────────┴──────────────┴──────────────┴──────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY ATOMICS WIN FOR COUNTERS:")
+	fmt.Println("  • One instruction instead of Lock + Unlock (two atomics)")
+	fmt.Println("  • No parking: the scheduler is never involved")
+	fmt.Println("  • RWMutex.Lock also waits for readers: slower than Mutex for writes")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHERE ATOMICS DON'T FIT:")
+	fmt.Println("  • Updating several fields together (use a mutex)")
+	fmt.Println("  • Read-modify-write beyond Add: CAS loops retry under contention")
+	fmt.Println("  • Very hot counters: the cache line still bounces (see day 11)")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. ⚛️  USE atomic.Int64 FOR SINGLE COUNTERS")
+	fmt.Println("   ✅ var requests atomic.Int64; requests.Add(1)")
+	fmt.Println("   Benefit: No lock, no parking, type-safe since Go 1.19")
+	fmt.Println()
+
+	fmt.Println("2. 🧮 ACCUMULATE LOCALLY, PUBLISH ONCE")
+	fmt.Println("   ✅ local++ in the loop, total.Add(local) after it")
+	fmt.Println("   Benefit: One shared write per batch instead of per event")
+	fmt.Println()
+
+	fmt.Println("3. 🔀 SHARD HOT COUNTERS")
+	fmt.Println("   ✅ One padded counter per worker, summed when read")
+	fmt.Println("   Benefit: Writers never share a cache line")
+	fmt.Println()
+
+	fmt.Println("4. 🔒 KEEP MUTEXES FOR MULTI-FIELD INVARIANTS")
+	fmt.Println("   ✅ mu.Lock(); count++; sum += v; mu.Unlock()")
+	fmt.Println("   Benefit: Readers never see count and sum out of step")
+}
+
+func calculateAtomicCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// Metrics collection: every request bumps a handful of counters
+	// (requests, status class, route, bytes in, bytes out)
+	requestsPerSecond := 50_000.0
+	countersPerRequest := 5.0
+	requestsPerDay := requestsPerSecond * 24 * 3600
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	// The workers keep min(GOMAXPROCS, numWorkers) cores busy for the whole
+	// wall time; that CPU time is spread over every increment
+	busyCores := float64(min(runtime.GOMAXPROCS(0), numWorkers))
+	perIncrement := func(r bench.Result) float64 {
+		return r.NsPerOp * busyCores / (numWorkers * incrementsPerGoroutine)
+	}
+	mutexNs, atomicNs := perIncrement(results[0]), perIncrement(results[2])
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %.0f counter increments per request\n",
+		requestsPerSecond, countersPerRequest)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, r := range results {
+		fmt.Printf("  %-24s %6.2f ns CPU/increment\n", r.Name+":", perIncrement(r))
+	}
+	savedNs := (mutexNs - atomicNs) * countersPerRequest
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.2f ns/request is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), requestsPerDay, costPerVCPUHour)
+	fmt.Printf("\n  sync.Mutex → atomic.Int64 saves %.0f ns/request\n", savedNs)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • No goroutines parked on a metrics lock at peak traffic")
+	fmt.Println("  • Handler latency no longer depends on how many others are counting")
+	fmt.Println("  • Simpler code: no Lock/Unlock pairs to get wrong")
+	return monthly
+}
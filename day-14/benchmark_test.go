@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== REQUEST BODY BENCHMARKS ==========
+
+func Benchmark_ReadAll(b *testing.B) {
+	benchmarkBodyHandler(b, readAllHandler(consume))
+}
+
+func Benchmark_PooledBuffer(b *testing.B) {
+	benchmarkBodyHandler(b, pooledHandler(consume))
+}
+
+func benchmarkBodyHandler(b *testing.B, h http.Handler) {
+	for _, size := range bodySizes {
+		b.Run(sizeLabel(size), func(b *testing.B) {
+			req := newBodyRequest(makeBody(size))
+			req.serve(h) // warm up
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				req.serve(h)
+			}
+		})
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_HandlersSeeWholeBody(t *testing.T) {
+	for _, size := range bodySizes {
+		body := makeBody(size)
+		req := newBodyRequest(body)
+		for name, handler := range map[string]func(func([]byte)) http.HandlerFunc{
+			"io.ReadAll":          readAllHandler,
+			"pooled bytes.Buffer": pooledHandler,
+		} {
+			var got []byte
+			req.serve(handler(func(b []byte) { got = bytes.Clone(b) }))
+			if !bytes.Equal(got, body) {
+				t.Errorf("%s, %s: handler saw %d bytes, want the %d-byte body", name, sizeLabel(size), len(got), len(body))
+			}
+			if req.rec.Code != http.StatusAccepted {
+				t.Errorf("%s, %s: expected status %d, got %d", name, sizeLabel(size), http.StatusAccepted, req.rec.Code)
+			}
+		}
+	}
+}
+
+func Test_PooledBufferResetBeforeReuse(t *testing.T) {
+	// A buffer left dirty by another user of the pool
+	bufferPool.Put(bytes.NewBufferString("stale bytes from the last request"))
+
+	// A large body followed by a small one must not leave a tail behind
+	for _, body := range [][]byte{makeBody(64 << 10), []byte(`{"event":"small"}`), makeBody(1 << 10)} {
+		var got []byte
+		newBodyRequest(body).serve(pooledHandler(func(b []byte) { got = bytes.Clone(b) }))
+		if !bytes.Equal(got, body) {
+			t.Fatalf("expected the %d-byte body, got %d bytes starting %q", len(body), len(got), got[:min(len(got), 40)])
+		}
+	}
+}
+
+func Test_PooledBufferDoesNotAllocate(t *testing.T) {
+	req := newBodyRequest(makeBody(64 << 10))
+	h := pooledHandler(consume)
+	req.serve(h) // warm up: grows the pooled buffer once
+
+	testutil.AssertMaxAllocs(t, "pooled handler once warm", 0, func() { req.serve(h) })
+}
+
+func Test_ReadAllAllocatesMoreThanBody(t *testing.T) {
+	size := 64 << 10
+	req := newBodyRequest(makeBody(size))
+	h := readAllHandler(consume)
+	bytesPerReq, _ := perRequest(req, h)
+	if bytesPerReq < float64(size) {
+		t.Errorf("expected io.ReadAll to allocate at least the %d-byte body, got %.0f B", size, bytesPerReq)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ========== HTTP CLIENT BENCHMARKS ==========
+
+// Each op is one request. conns/op is TCP connections dialed per request.
+
+func Benchmark_DefaultClientSequential(b *testing.B) {
+	benchmarkClient(b, defaultClient(), runSequential)
+}
+
+func Benchmark_TunedClientSequential(b *testing.B) {
+	benchmarkClient(b, tunedClient(), runSequential)
+}
+
+func Benchmark_DefaultClientPipelined(b *testing.B) {
+	benchmarkClient(b, defaultClient(), runPipelined)
+}
+
+func Benchmark_TunedClientPipelined(b *testing.B) {
+	benchmarkClient(b, tunedClient(), runPipelined)
+}
+
+func benchmarkClient(b *testing.B, client *http.Client, run func(*http.Client, string, int) error) {
+	srv := newCountingServer()
+	defer srv.Close()
+	defer client.CloseIdleConnections()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := run(client, srv.URL, b.N); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(float64(srv.conns.Load())/float64(b.N), "conns/op")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_TunedClientReusesConnections(t *testing.T) {
+	client := tunedClient()
+	defer client.CloseIdleConnections()
+	u, err := measureUsage(func(url string) error {
+		return runPipelined(client, url, 20*concurrency)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.conns > concurrency {
+		t.Errorf("expected at most %d connections for %d requests, got %d", concurrency, 20*concurrency, u.conns)
+	}
+}
+
+func Test_DefaultClientChurnsBetweenWaves(t *testing.T) {
+	const waves = 20
+	client := defaultClient()
+	defer client.CloseIdleConnections()
+	u, err := measureUsage(func(url string) error {
+		return runPipelined(client, url, waves*concurrency)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only 2 connections per host survive a wave; most of the next one dials
+	if want := int64(waves * concurrency / 2); u.conns < want {
+		t.Errorf("expected at least %d connections for %d requests, got %d", want, waves*concurrency, u.conns)
+	}
+	t.Logf("%d connections for %d requests", u.conns, waves*concurrency)
+}
+
+func Test_UnclosedBodiesLeakConnections(t *testing.T) {
+	const n = 20
+	var resps []*http.Response
+	client := defaultClient()
+	u, err := measureUsage(func(url string) error {
+		var err error
+		resps, err = leakResponses(client, url, n)
+		return err
+	})
+	for _, resp := range resps {
+		resp.Body.Close()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.conns != n {
+		t.Errorf("expected a new connection per unclosed response, got %d for %d requests", u.conns, n)
+	}
+	if u.goroutines < 2*n {
+		t.Errorf("expected at least %d goroutines kept alive by %d connections, got %d", 2*n, n, u.goroutines)
+	}
+}
+
+func Test_ServerReadsBodyAndReplies(t *testing.T) {
+	srv := newCountingServer()
+	defer srv.Close()
+	client := tunedClient()
+	defer client.CloseIdleConnections()
+	if err := runSequential(client, srv.URL, 10); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.conns.Load(); got != 1 {
+		t.Errorf("expected sequential requests to share 1 connection, got %d", got)
+	}
+}
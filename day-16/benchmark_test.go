@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+// ========== INTEGER CONVERSION BENCHMARKS ==========
+
+// Each op converts one ID into a reused log line.
+
+func Benchmark_Sprintf(b *testing.B) {
+	benchmarkConversion(b, convertSprintf)
+}
+
+func Benchmark_Itoa(b *testing.B) {
+	benchmarkConversion(b, convertItoa)
+}
+
+func Benchmark_AppendInt(b *testing.B) {
+	benchmarkConversion(b, convertAppendInt)
+}
+
+func Benchmark_FprintfBuilder(b *testing.B) {
+	benchmarkConversion(b, convertFprintf)
+}
+
+func benchmarkConversion(b *testing.B, convert func(*logLine, int)) {
+	var l logLine
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Reset()
+		convert(&l, sampleID+i)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_AllApproachesWriteSameDigits(t *testing.T) {
+	var l logLine
+	for _, n := range []int{0, 7, 42, 255, 256, sampleID, -sampleID, math.MaxInt64, math.MinInt64} {
+		want := strconv.Itoa(n)
+		for _, a := range approaches {
+			l.Reset()
+			a.Convert(&l, n)
+			if got := l.String(); got != want {
+				t.Errorf("%s(%d): expected %q, got %q", a.Name, n, want, got)
+			}
+		}
+	}
+}
+
+func Test_ConversionAllocations(t *testing.T) {
+	var l logLine
+	l.buf = make([]byte, 0, 64)
+	want := map[string]float64{
+		"fmt.Sprintf":            2, // boxing the int, the result string
+		"strconv.Itoa":           1, // the result string
+		"strconv.AppendInt":      0,
+		"fmt.Fprintf to Builder": 3, // boxing, the Builder, its buffer
+	}
+	for _, a := range approaches {
+		allocs := testing.AllocsPerRun(1000, func() {
+			l.Reset()
+			a.Convert(&l, sampleID)
+		})
+		if allocs != want[a.Name] {
+			t.Errorf("%s: expected %.0f allocs per conversion, got %.1f", a.Name, want[a.Name], allocs)
+		}
+	}
+}
+
+func Test_SmallIntegersSkipBoxing(t *testing.T) {
+	// Below 256 the runtime boxes ints without allocating
+	var l logLine
+	l.buf = make([]byte, 0, 64)
+	if allocs := testing.AllocsPerRun(1000, func() {
+		l.Reset()
+		convertSprintf(&l, 42)
+	}); allocs != 1 {
+		t.Errorf("expected only the result string to allocate for 42, got %.1f allocs", allocs)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const conversionsPerRun = 1_000_000
+
+// sampleID is a typical user or order ID: above 255, so fmt can't use the
+// runtime's preallocated small integers when boxing it.
+const sampleID = 48_213_907
+
+// ========== INTEGER CONVERSION APPROACHES ==========
+
+// Each approach adds n's decimal digits to a log line, the way a logger
+// formats an ID field.
+
+func convertSprintf(l *logLine, n int) {
+	l.WriteString(fmt.Sprintf("%d", n))
+}
+
+func convertItoa(l *logLine, n int) {
+	l.WriteString(strconv.Itoa(n))
+}
+
+// convertAppendInt writes the digits straight into the line's buffer,
+// which the logger reuses from line to line.
+func convertAppendInt(l *logLine, n int) {
+	l.buf = strconv.AppendInt(l.buf, int64(n), 10)
+}
+
+// convertFprintf builds the field in a strings.Builder first, as code
+// that assembles a message piece by piece does.
+func convertFprintf(l *logLine, n int) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d", n)
+	l.WriteString(sb.String())
+}
+
+type approach struct {
+	Name    string
+	Convert func(*logLine, int)
+}
+
+var approaches = []approach{
+	{"fmt.Sprintf", convertSprintf},
+	{"strconv.Itoa", convertItoa},
+	{"strconv.AppendInt", convertAppendInt},
+	{"fmt.Fprintf to Builder", convertFprintf},
+}
+
+// logLine stands in for a logger's line buffer: it copies what it is
+// given into memory it reuses for every line.
+type logLine struct {
+	buf []byte
+}
+
+func (l *logLine) Reset() { l.buf = l.buf[:0] }
+
+func (l *logLine) WriteString(s string) (int, error) {
+	l.buf = append(l.buf, s...)
+	return len(s), nil
+}
+
+func (l *logLine) String() string { return string(l.buf) }
+
+func main() {
+	fmt.Println("🔬 DAY 16: fmt.Sprintf vs strconv for Integers")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about fmt.Sprintf
+	fmt.Printf("🎯 SHOCKING DISCOVERY: fmt.Sprintf(\"%%d\", id) allocates twice to print one number!\n")
+	fmt.Println(strings.Repeat("-", 40))
+	revealFmtReflectionCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d integer-to-string conversions\n", conversionsPerRun)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// fmt internals
+	fmt.Println("\n🔧 FMT DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainFmtPipeline()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateConversionCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 16, Topic: "fmt.Sprintf vs strconv for Integers", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 16 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 17 - Protobuf vs JSON vs Gob")
+}
+
+func revealFmtReflectionCost() {
+	var l logLine
+	fmt.Printf("  %-24s %16s %22s\n", "Approach", "allocs (id=42)", fmt.Sprintf("allocs (id=%d)", sampleID))
+	for _, a := range approaches {
+		small, _ := bench.AllocsPerCall(10_000, func() {
+			l.Reset()
+			a.Convert(&l, 42)
+		})
+		large, _ := bench.AllocsPerCall(10_000, func() {
+			l.Reset()
+			a.Convert(&l, sampleID)
+		})
+		fmt.Printf("  %-24s %16.1f %22.1f\n", a.Name+":", small, large)
+	}
+
+	fmt.Println("\n💡 fmt takes its arguments as ...any. Storing an int in an interface")
+	fmt.Println("   allocates (runtime.convT64) unless it's below 256, and the result")
+	fmt.Println("   string is a second allocation. fmt then finds the int with a type")
+	fmt.Println("   switch; only types the switch doesn't know go through reflect, which")
+	fmt.Println("   costs more. strconv takes an int directly: no interface, no parsing.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Integer to string")
+	suite.Iterations = 3
+	var l logLine
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			for i := 0; i < conversionsPerRun; i++ {
+				l.Reset()
+				a.Convert(&l, sampleID+i)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	return suite.Results()
+}
+
+func explainFmtPipeline() {
+	fmt.Printf("fmt.Sprintf(\"%%d\", id):\n")
+	fmt.Println()
+	fmt.Println("┌──────────────┬──────────────┬──────────────┬────────────────┬──────────────┐")
+	fmt.Println("│ box id into  │ printer from │ parse the    │ type switch    │ copy buffer  │")
+	fmt.Printf("│ any (alloc)  │ sync.Pool    │ verb \"%%d\"    │ → fmtInteger   │ to a string  │\n")
+	fmt.Println("│              │              │              │ (else reflect) │ (alloc)      │")
+	fmt.Println("└──────────────┴──────────────┴──────────────┴────────────────┴──────────────┘")
+	fmt.Println()
+	fmt.Println("strconv.AppendInt(buf, id, 10):")
+	fmt.Println()
+	fmt.Println("┌────────────────────────────────────────────────────────────────────────────┐")
+	fmt.Println("│ write digits into buf, two at a time from a lookup table (no alloc)        │")
+	fmt.Println("└────────────────────────────────────────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY STRCONV WINS:")
+	fmt.Println("  • No interface boxing: the int is passed as an int")
+	fmt.Println("  • No format string to parse at run time")
+	fmt.Println("  • AppendInt reuses the caller's buffer: zero allocations")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHERE fmt STILL FITS:")
+	fmt.Println("  • Error messages and cold paths, where readability wins")
+	fmt.Println("  • Padding, precision and other verbs strconv doesn't offer")
+	fmt.Println("  • Values of arbitrary type, where reflect is the point")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Printf("1. 🔢 strconv.Itoa INSTEAD OF fmt.Sprintf(\"%%d\")\n")
+	fmt.Println("   ✅ id := strconv.Itoa(n)")
+	fmt.Println("   Benefit: Half the allocations, several times faster")
+	fmt.Println()
+
+	fmt.Println("2. 📝 APPEND INTO THE LINE YOU'RE BUILDING")
+	fmt.Println("   ✅ buf = strconv.AppendInt(buf, int64(n), 10)")
+	fmt.Println("   Benefit: Zero allocations per field")
+	fmt.Println()
+
+	fmt.Println("3. 🏷️  USE TYPED FIELDS IN STRUCTURED LOGGERS")
+	fmt.Println("   ✅ slog.Int(\"user_id\", n) instead of slog.String(\"user_id\", fmt.Sprint(n))")
+	fmt.Println("   Benefit: The logger formats into its own pooled buffer")
+	fmt.Println()
+
+	fmt.Println("4. 💤 DON'T FORMAT WHAT ISN'T LOGGED")
+	fmt.Println("   ✅ if logger.Enabled(ctx, slog.LevelDebug) { ... }")
+	fmt.Println("   Benefit: Disabled debug lines cost nothing")
+}
+
+func calculateConversionCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// A service logging 1M events a day, each with one integer ID
+	eventsPerDay := 1_000_000.0
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perConversion := func(r bench.Result) (ns, bytes float64) {
+		return r.NsPerOp / conversionsPerRun, r.BytesPerOp / conversionsPerRun
+	}
+	// Compare the usual log code, fmt.Sprintf, to the best
+	baseline, fastest := results[0], results[0]
+	for _, r := range results[1:] {
+		if r.NsPerOp < fastest.NsPerOp {
+			fastest = r
+		}
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f log events/day, one integer ID each\n", eventsPerDay)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, r := range results {
+		ns, b := perConversion(r)
+		fmt.Printf("  %-24s %6.1f ns, %4.0f B/conversion\n", r.Name+":", ns, b)
+	}
+	baseNs, baseBytes := perConversion(baseline)
+	fastNs, fastBytes := perConversion(fastest)
+	monthly := cost.CPUSavingsMonthly(time.Duration(baseNs-fastNs), eventsPerDay, costPerVCPUHour)
+	fmt.Printf("\n  %s → %s saves %.0f ns and %.0f B per event\n",
+		baseline.Name, fastest.Name, baseNs-fastNs, baseBytes-fastBytes)
+	fmt.Printf("  Monthly savings: $%.6f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.6f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: eventsPerDay, Unit: "events/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • One ID is a small part of a log line: fix every field and the")
+	fmt.Println("    whole line can be built without allocating")
+	fmt.Println("  • Less garbage on the paths that log the most")
+	fmt.Println("  • No format strings to get out of sync with their arguments")
+	return monthly
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ========== SERIALIZATION BENCHMARKS ==========
+
+// Global variable to prevent compiler optimizations
+var encoded []byte
+
+// Each op is one message of 1, 10 or 100 events.
+
+func Benchmark_Marshal(b *testing.B) {
+	for _, n := range batchSizes {
+		for _, c := range newCodecs(newEventBatch(n)) {
+			b.Run(c.Name+"/"+strconv.Itoa(n), func(b *testing.B) {
+				benchmarkMarshal(b, c)
+			})
+		}
+	}
+}
+
+func Benchmark_Unmarshal(b *testing.B) {
+	for _, n := range batchSizes {
+		for _, c := range newCodecs(newEventBatch(n)) {
+			b.Run(c.Name+"/"+strconv.Itoa(n), func(b *testing.B) {
+				benchmarkUnmarshal(b, c)
+			})
+		}
+	}
+}
+
+func benchmarkMarshal(b *testing.B, c codec) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		encoded = mustMarshal(c)
+	}
+	b.ReportMetric(float64(len(encoded)), "B/msg")
+}
+
+func benchmarkUnmarshal(b *testing.B, c codec) {
+	data := mustMarshal(c)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := c.Unmarshal(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(data)), "B/msg")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_JSONAndGobRoundTrip(t *testing.T) {
+	want := newEventBatch(10)
+	for _, f := range []struct {
+		name   string
+		encode func(*EventBatch) ([]byte, error)
+		decode func([]byte) (*EventBatch, error)
+	}{
+		{"encoding/json", encodeJSON, decodeJSON},
+		{"encoding/gob", encodeGob, decodeGob},
+	} {
+		data, err := f.encode(want)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		got, err := f.decode(data)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: round trip changed the batch", f.name)
+		}
+	}
+}
+
+func Test_ProtoRoundTrip(t *testing.T) {
+	want := newEventBatch(10).toProto()
+	data, err := encodeProto(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeProto(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("round trip changed the batch:\nwant %v\ngot  %v", want, got)
+	}
+	if e := got.Events[3]; len(e.Payload) != 64 || len(e.Tags) != 3 {
+		t.Errorf("bytes or repeated string field lost: %d payload bytes, %d tags", len(e.Payload), len(e.Tags))
+	}
+}
+
+func Test_WireSizes(t *testing.T) {
+	for _, n := range batchSizes {
+		codecs := newCodecs(newEventBatch(n))
+		jsonSize, gobSize, protoSize := len(mustMarshal(codecs[0])), len(mustMarshal(codecs[1])), len(mustMarshal(codecs[2]))
+		if protoSize >= jsonSize {
+			t.Errorf("%d events: protobuf %d B, expected less than JSON's %d B", n, protoSize, jsonSize)
+		}
+		if n == 1 && gobSize <= jsonSize {
+			t.Errorf("1 event: gob %d B, expected its type description to make it bigger than JSON's %d B", gobSize, jsonSize)
+		}
+		t.Logf("%3d events: json %6d B, gob %6d B, protobuf %6d B", n, jsonSize, gobSize, protoSize)
+	}
+}
+
+func Test_ProtoDecodeAllocatesPerTag(t *testing.T) {
+	const n = 10
+	decodeAllocs := func(tags []string) float64 {
+		b := newEventBatch(n)
+		for i := range b.Events {
+			b.Events[i].Tags = tags
+		}
+		data, err := encodeProto(b.toProto())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return testing.AllocsPerRun(100, func() {
+			if _, err := decodeProto(data); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	three := decodeAllocs([]string{"web", "eu-west-1", "beta"})
+	six := decodeAllocs([]string{"web", "eu-west-1", "beta", "ios", "17.4", "ab:checkout-v2"})
+	// Each extra tag is one more string per event
+	if extra := (six - three) / n; extra < 3 {
+		t.Errorf("expected at least 3 more allocs per event for 3 more tags, got %.1f", extra)
+	}
+}
@@ -0,0 +1,390 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative userevent.proto
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// batchSizes are the events per message each format is measured at.
+var batchSizes = []int{1, 10, 100}
+
+// headlineBatch is the batch size the suites and cost analysis use.
+const headlineBatch = 100
+
+const messagesPerRun = 2_000
+
+// Event is the plain Go struct JSON and gob encode. UserEvent, generated
+// from userevent.proto, is its protobuf twin.
+type Event struct {
+	UserID            int64    `json:"user_id"`
+	EventType         string   `json:"event_type"`
+	TimestampUnixNano int64    `json:"timestamp_unix_nano"`
+	SessionID         string   `json:"session_id"`
+	Payload           []byte   `json:"payload"`
+	Tags              []string `json:"tags"`
+}
+
+// EventBatch is the Go twin of UserEventBatch.
+type EventBatch struct {
+	Events []Event `json:"events"`
+}
+
+var eventTypes = []string{"page_view", "add_to_cart", "checkout", "search"}
+
+// newEventBatch returns n events that look like production traffic: large
+// IDs and timestamps, a 64-byte payload and three tags each.
+func newEventBatch(n int) *EventBatch {
+	start := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC).UnixNano()
+	b := &EventBatch{Events: make([]Event, n)}
+	for i := range b.Events {
+		payload := make([]byte, 64)
+		for j := range payload {
+			payload[j] = byte(i*31 + j)
+		}
+		b.Events[i] = Event{
+			UserID:            48_213_907 + int64(i)*7919,
+			EventType:         eventTypes[i%len(eventTypes)],
+			TimestampUnixNano: start + int64(i)*int64(time.Millisecond),
+			SessionID:         "sess-" + strconv.FormatInt(9_000_000_000+int64(i), 36),
+			Payload:           payload,
+			Tags:              []string{"web", "eu-west-1", "ab:checkout-v2"},
+		}
+	}
+	return b
+}
+
+// toProto copies b into the generated types. Services that speak protobuf
+// build these directly, so the copy is not part of any measurement.
+func (b *EventBatch) toProto() *UserEventBatch {
+	pb := &UserEventBatch{Events: make([]*UserEvent, len(b.Events))}
+	for i, e := range b.Events {
+		pb.Events[i] = &UserEvent{
+			UserId:            e.UserID,
+			EventType:         e.EventType,
+			TimestampUnixNano: e.TimestampUnixNano,
+			SessionId:         e.SessionID,
+			Payload:           e.Payload,
+			Tags:              e.Tags,
+		}
+	}
+	return pb
+}
+
+// ========== SERIALIZATION FORMATS ==========
+
+func encodeJSON(b *EventBatch) ([]byte, error) { return json.Marshal(b) }
+
+func decodeJSON(data []byte) (*EventBatch, error) {
+	var b EventBatch
+	err := json.Unmarshal(data, &b)
+	return &b, err
+}
+
+// encodeGob uses a new Encoder per message, as a queue or cache payload
+// must: each message carries its own type description.
+func encodeGob(b *EventBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(b)
+	return buf.Bytes(), err
+}
+
+func decodeGob(data []byte) (*EventBatch, error) {
+	var b EventBatch
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&b)
+	return &b, err
+}
+
+func encodeProto(pb *UserEventBatch) ([]byte, error) { return proto.Marshal(pb) }
+
+func decodeProto(data []byte) (*UserEventBatch, error) {
+	var pb UserEventBatch
+	err := proto.Unmarshal(data, &pb)
+	return &pb, err
+}
+
+// codec marshals one prepared message and unmarshals its encoding into a
+// fresh value, as a service does per request.
+type codec struct {
+	Name      string
+	Marshal   func() ([]byte, error)
+	Unmarshal func([]byte) error
+}
+
+func newCodecs(b *EventBatch) []codec {
+	pb := b.toProto()
+	return []codec{
+		{
+			Name:      "encoding/json",
+			Marshal:   func() ([]byte, error) { return encodeJSON(b) },
+			Unmarshal: func(data []byte) error { _, err := decodeJSON(data); return err },
+		},
+		{
+			Name:      "encoding/gob",
+			Marshal:   func() ([]byte, error) { return encodeGob(b) },
+			Unmarshal: func(data []byte) error { _, err := decodeGob(data); return err },
+		},
+		{
+			Name:      "protobuf",
+			Marshal:   func() ([]byte, error) { return encodeProto(pb) },
+			Unmarshal: func(data []byte) error { _, err := decodeProto(data); return err },
+		},
+	}
+}
+
+// mustMarshal returns c's encoding of its message. Every codec here
+// encodes plain data, so an error is a bug in the demo.
+func mustMarshal(c codec) []byte {
+	data, err := c.Marshal()
+	if err != nil {
+		panic(fmt.Sprintf("%s: %v", c.Name, err))
+	}
+	return data
+}
+
+func main() {
+	fmt.Println("🔬 DAY 17: Protobuf vs JSON vs Gob")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about wire size
+	fmt.Println("🎯 SHOCKING DISCOVERY: A single gob message is bigger than JSON!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealWireSizes()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d messages of %d events each\n", messagesPerRun, headlineBatch)
+	fmt.Println(strings.Repeat("-", 40))
+	marshal, unmarshal := runComparisonBenchmarks()
+
+	// Wire format internals
+	fmt.Println("\n🔧 WIRE FORMAT DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainWireFormats()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateSerializationCostImpact(marshal, unmarshal, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	saved := append(bench.WithPrefix("Marshal", marshal), bench.WithPrefix("Unmarshal", unmarshal)...)
+	run := bench.DayResult{Day: 17, Topic: "Protobuf vs JSON vs Gob", Date: time.Now(), Benchmarks: saved, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 17 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 18 - RWMutex vs Sharded Mutex vs Atomic Config")
+}
+
+func revealWireSizes() {
+	fmt.Printf("  %-16s", "Events/message")
+	for _, n := range batchSizes {
+		fmt.Printf(" %14d", n)
+	}
+	fmt.Println()
+	for i, name := range []string{"encoding/json", "encoding/gob", "protobuf"} {
+		fmt.Printf("  %-16s", name+":")
+		for _, n := range batchSizes {
+			data := mustMarshal(newCodecs(newEventBatch(n))[i])
+			fmt.Printf(" %8d B/msg", len(data))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\n💡 A gob stream describes each type once, before the first value.")
+	fmt.Println("   Encode one message per Encoder, as a queue or cache entry must, and")
+	fmt.Println("   every message pays for that description. JSON repeats field names")
+	fmt.Println("   in every event and base64-encodes bytes; protobuf sends field")
+	fmt.Println("   numbers and varints.")
+}
+
+func runComparisonBenchmarks() (marshal, unmarshal []bench.Result) {
+	codecs := newCodecs(newEventBatch(headlineBatch))
+
+	marshalSuite := bench.NewBenchmarkSuite(fmt.Sprintf("Marshal %d events", headlineBatch))
+	marshalSuite.Iterations = 3
+	for _, c := range codecs {
+		marshalSuite.Register(c.Name, func() {
+			for i := 0; i < messagesPerRun; i++ {
+				mustMarshal(c)
+			}
+		})
+	}
+	if err := marshalSuite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println()
+	unmarshalSuite := bench.NewBenchmarkSuite(fmt.Sprintf("Unmarshal %d events", headlineBatch))
+	unmarshalSuite.Iterations = 3
+	for _, c := range codecs {
+		data := mustMarshal(c)
+		unmarshalSuite.Register(c.Name, func() {
+			for i := 0; i < messagesPerRun; i++ {
+				if err := c.Unmarshal(data); err != nil {
+					panic(err)
+				}
+			}
+		})
+	}
+	if err := unmarshalSuite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n📏 Allocations per event, by events per message:")
+	fmt.Printf("  %-16s", "")
+	for _, n := range batchSizes {
+		fmt.Printf(" %17s", fmt.Sprintf("%d (enc / dec)", n))
+	}
+	fmt.Println()
+	for i := range codecs {
+		fmt.Printf("  %-16s", codecs[i].Name+":")
+		for _, n := range batchSizes {
+			c := newCodecs(newEventBatch(n))[i]
+			data := mustMarshal(c)
+			enc, _ := bench.AllocsPerCall(100, func() { mustMarshal(c) })
+			dec, _ := bench.AllocsPerCall(100, func() { _ = c.Unmarshal(data) })
+			fmt.Printf(" %8.1f / %6.1f", enc/float64(n), dec/float64(n))
+		}
+		fmt.Println()
+	}
+	return marshalSuite.Results(), unmarshalSuite.Results()
+}
+
+func explainWireFormats() {
+	fmt.Println("One event's user_id (48213907) and tags on the wire:")
+	fmt.Println()
+	fmt.Println("JSON:")
+	fmt.Println("┌─────────────────────┬─────────────────────────────────────────────┐")
+	fmt.Printf("│ %-19s │ %-43s │\n", `"user_id":48213907,`, `"tags":["web","eu-west-1","ab:checkout-v2"]`)
+	fmt.Printf("│ %-19s │ %-43s │\n", "19 bytes", "43 bytes, names repeated in every event")
+	fmt.Println("└─────────────────────┴─────────────────────────────────────────────┘")
+	fmt.Println()
+	fmt.Println("Protobuf:")
+	fmt.Println("┌─────────────────────┬─────────────────────────────────────────────┐")
+	fmt.Printf("│ %-19s │ %-43s │\n", "0x08 + varint (4 B)", "0x32 + length + bytes, per tag")
+	fmt.Printf("│ %-19s │ %-43s │\n", "5 bytes", "32 bytes, no names, no quotes")
+	fmt.Println("└─────────────────────┴─────────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHERE DECODING ALLOCATES:")
+	fmt.Println("  • bytes fields: every format copies the payload out of the buffer")
+	fmt.Println("  • repeated string: one string per element, plus the slice")
+	fmt.Println("  • JSON payloads are base64 text: 64 bytes travel as 88")
+	fmt.Println("  • Protobuf allocates each *UserEvent separately: more allocs than JSON")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHAT PROTOBUF COSTS:")
+	fmt.Println("  • A .proto file and a code generation step")
+	fmt.Println("  • Binary payloads: no curl | jq when debugging")
+	fmt.Println("  • Field numbers must never be reused once deployed")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📦 PROTOBUF BETWEEN SERVICES")
+	fmt.Println("   ✅ data, err := proto.Marshal(batch)")
+	fmt.Println("   Benefit: Smallest wire size and fastest encoding here")
+	fmt.Println()
+
+	fmt.Println("2. 🧺 BATCH SMALL MESSAGES")
+	fmt.Println("   ✅ UserEventBatch{Events: events} instead of one message per event")
+	fmt.Println("   Benefit: Per-message overhead is paid once per batch")
+	fmt.Println()
+
+	fmt.Println("3. 🌊 STREAM GOB, DON'T ENCODE PER MESSAGE")
+	fmt.Println("   ✅ enc := gob.NewEncoder(conn) // once per connection")
+	fmt.Println("   Benefit: Type descriptions are sent once, not per message")
+	fmt.Println()
+
+	fmt.Println("4. 🌐 KEEP JSON AT THE EDGE")
+	fmt.Println("   ✅ JSON for browsers and public APIs, protobuf behind them")
+	fmt.Println("   Benefit: Debuggable where people read it, compact where machines do")
+}
+
+func calculateSerializationCostImpact(marshal, unmarshal []bench.Result, pricing cost.PricingModel) float64 {
+	// 1B events a month sent between services, 100 per message
+	eventsPerMonth := 1_000_000_000.0
+	eventsPerDay := eventsPerMonth / 30
+	egressPerGB := pricing.NetworkGBCost()
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	codecs := newCodecs(newEventBatch(headlineBatch))
+	type formatCost struct {
+		name          string
+		bytesPerEvent float64
+		nsPerEvent    float64
+		egress, cpu   float64
+	}
+	costs := make([]formatCost, len(codecs))
+	for i, c := range codecs {
+		bytesPerEvent := float64(len(mustMarshal(c))) / headlineBatch
+		nsPerEvent := (marshal[i].NsPerOp + unmarshal[i].NsPerOp) / (messagesPerRun * headlineBatch)
+		costs[i] = formatCost{
+			name:          c.Name,
+			bytesPerEvent: bytesPerEvent,
+			nsPerEvent:    nsPerEvent,
+			egress:        bytesPerEvent * eventsPerMonth / 1e9 * egressPerGB,
+			cpu:           cost.CPUSavingsMonthly(time.Duration(nsPerEvent), eventsPerDay, costPerVCPUHour),
+		}
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f events/month, %d per message, leaving the region\n", eventsPerMonth, headlineBatch)
+	fmt.Printf("  • %v: $%.2f/GB egress, $%.4f/hour per vCPU\n", pricing, egressPerGB, costPerVCPUHour)
+	fmt.Println("  • CPU is one marshal and one unmarshal per event")
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, c := range costs {
+		fmt.Printf("  %-15s %6.1f B, %6.0f ns/event → egress $%8.2f + CPU $%6.2f/month\n",
+			c.name+":", c.bytesPerEvent, c.nsPerEvent, c.egress, c.cpu)
+	}
+
+	base, best := costs[0], costs[len(costs)-1]
+	monthly := (base.egress + base.cpu) - (best.egress + best.cpu)
+	if monthly < 0 {
+		fmt.Println("\n  (protobuf measured no cheaper this run: within noise)")
+		monthly = 0
+	}
+	fmt.Printf("\n  %s → %s saves %.0f%% of egress and %.0f%% of CPU\n", base.name, best.name,
+		(1-best.egress/base.egress)*100, (1-best.cpu/base.cpu)*100)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: eventsPerMonth, Unit: "events/month"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Egress is paid per byte whatever the instance size: it doesn't")
+	fmt.Println("    shrink with better hardware")
+	fmt.Println("  • Smaller messages mean less broker storage and faster replication")
+	fmt.Println("  • A .proto file is a schema both teams can review")
+	return monthly
+}
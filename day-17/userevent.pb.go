@@ -0,0 +1,221 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: userevent.proto
+
+package main
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UserEvent is one user action as services pass it to each other.
+type UserEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EventType         string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	TimestampUnixNano int64                  `protobuf:"varint,3,opt,name=timestamp_unix_nano,json=timestampUnixNano,proto3" json:"timestamp_unix_nano,omitempty"`
+	SessionId         string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// payload is opaque client data; bytes fields are copied on unmarshal.
+	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// tags allocate one string each on unmarshal.
+	Tags          []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEvent) Reset() {
+	*x = UserEvent{}
+	mi := &file_userevent_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEvent) ProtoMessage() {}
+
+func (x *UserEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_userevent_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return file_userevent_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserEvent) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *UserEvent) GetTimestampUnixNano() int64 {
+	if x != nil {
+		return x.TimestampUnixNano
+	}
+	return 0
+}
+
+func (x *UserEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *UserEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UserEvent) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// UserEventBatch is how events travel: several per message.
+type UserEventBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*UserEvent           `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEventBatch) Reset() {
+	*x = UserEventBatch{}
+	mi := &file_userevent_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEventBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEventBatch) ProtoMessage() {}
+
+func (x *UserEventBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_userevent_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEventBatch.ProtoReflect.Descriptor instead.
+func (*UserEventBatch) Descriptor() ([]byte, []int) {
+	return file_userevent_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserEventBatch) GetEvents() []*UserEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_userevent_proto protoreflect.FileDescriptor
+
+const file_userevent_proto_rawDesc = "" +
+	"\n" +
+	"\x0fuserevent.proto\x12\x0fcostaware.day17\"\xc0\x01\n" +
+	"\tUserEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12.\n" +
+	"\x13timestamp_unix_nano\x18\x03 \x01(\x03R\x11timestampUnixNano\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\"D\n" +
+	"\x0eUserEventBatch\x122\n" +
+	"\x06events\x18\x01 \x03(\v2\x1a.costaware.day17.UserEventR\x06eventsB4Z2github.com/alpardfm/cost-aware-backend/day-17;mainb\x06proto3"
+
+var (
+	file_userevent_proto_rawDescOnce sync.Once
+	file_userevent_proto_rawDescData []byte
+)
+
+func file_userevent_proto_rawDescGZIP() []byte {
+	file_userevent_proto_rawDescOnce.Do(func() {
+		file_userevent_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_userevent_proto_rawDesc), len(file_userevent_proto_rawDesc)))
+	})
+	return file_userevent_proto_rawDescData
+}
+
+var file_userevent_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_userevent_proto_goTypes = []any{
+	(*UserEvent)(nil),      // 0: costaware.day17.UserEvent
+	(*UserEventBatch)(nil), // 1: costaware.day17.UserEventBatch
+}
+var file_userevent_proto_depIdxs = []int32{
+	0, // 0: costaware.day17.UserEventBatch.events:type_name -> costaware.day17.UserEvent
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_userevent_proto_init() }
+func file_userevent_proto_init() {
+	if File_userevent_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_userevent_proto_rawDesc), len(file_userevent_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_userevent_proto_goTypes,
+		DependencyIndexes: file_userevent_proto_depIdxs,
+		MessageInfos:      file_userevent_proto_msgTypes,
+	}.Build()
+	File_userevent_proto = out.File
+	file_userevent_proto_goTypes = nil
+	file_userevent_proto_depIdxs = nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalInt int
+
+// ========== TOKENIZER BENCHMARKS ==========
+
+func Benchmark_StdDecoder(b *testing.B) {
+	doc := generateDocument(rand.New(rand.NewSource(1)), 100*1024)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(doc)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n, err := countStdTokens(doc)
+		if err != nil {
+			b.Fatal(err)
+		}
+		globalInt = n
+	}
+}
+
+func Benchmark_ZeroAllocTokenizer(b *testing.B) {
+	doc := generateDocument(rand.New(rand.NewSource(1)), 100*1024)
+	z := NewTokenizer(doc)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(doc)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		z.Reset(doc)
+		n, err := countZeroAllocTokens(z)
+		if err != nil {
+			b.Fatal(err)
+		}
+		globalInt = n
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+// stdTokenStrings renders json.Decoder tokens in a comparable form.
+func stdTokenStrings(doc []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var out []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch v := tok.(type) {
+		case json.Delim:
+			out = append(out, "delim:"+v.String())
+		case string:
+			out = append(out, "string:"+v)
+		case json.Number:
+			out = append(out, "number:"+v.String())
+		case bool:
+			out = append(out, fmt.Sprintf("bool:%t", v))
+		case nil:
+			out = append(out, "null")
+		}
+	}
+}
+
+// zeroAllocTokenStrings renders Tokenizer tokens in the same form.
+func zeroAllocTokenStrings(doc []byte) ([]string, error) {
+	z := NewTokenizer(doc)
+
+	var out []string
+	for {
+		tok, err := z.Next()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok.Kind {
+		case TokenBeginObject, TokenEndObject, TokenBeginArray, TokenEndArray:
+			out = append(out, "delim:"+string(tok.Bytes(doc)))
+		case TokenString:
+			s, err := tok.Text(doc)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, "string:"+s)
+		case TokenNumber:
+			out = append(out, "number:"+string(tok.Bytes(doc)))
+		case TokenTrue, TokenFalse:
+			out = append(out, "bool:"+string(tok.Bytes(doc)))
+		case TokenNull:
+			out = append(out, "null")
+		}
+	}
+}
+
+func Test_TokenizerMatchesDecoderCorpus(t *testing.T) {
+	rng := rand.New(rand.NewSource(177))
+
+	for i := 0; i < 100; i++ {
+		doc := generateDocument(rng, 512+rng.Intn(4096))
+
+		want, err := stdTokenStrings(doc)
+		if err != nil {
+			t.Fatalf("doc %d: decoder failed: %v", i, err)
+		}
+		got, err := zeroAllocTokenStrings(doc)
+		if err != nil {
+			t.Fatalf("doc %d: tokenizer failed: %v", i, err)
+		}
+
+		if len(got) != len(want) {
+			t.Fatalf("doc %d: expected %d tokens, got %d", i, len(want), len(got))
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Fatalf("doc %d token %d: expected %q, got %q", i, j, want[j], got[j])
+			}
+		}
+	}
+}
+
+func Test_TokenizerEscapedStrings(t *testing.T) {
+	doc := []byte(`{"msg":"line1\nline2 \"quoted\" \u00e9","path":"C:\\tmp","n":-1.5e3,"empty":""}`)
+
+	want, err := stdTokenStrings(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := zeroAllocTokenStrings(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("escaped tokens differ:\n  want %q\n  got  %q", want, got)
+	}
+}
+
+func Test_TokenizerZeroAllocs(t *testing.T) {
+	doc := generateDocument(rand.New(rand.NewSource(2)), 16*1024)
+	z := NewTokenizer(doc)
+
+	allocs := testutil.AssertMaxAllocs(t, "Tokenizer", 0, func() {
+		z.Reset(doc)
+		if _, err := countZeroAllocTokens(z); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Logf("Tokenizer allocations per document: %.1f", allocs)
+}
+
+func Test_TokenizerRejectsTruncatedString(t *testing.T) {
+	z := NewTokenizer([]byte(`{"key":"unterminated`))
+
+	var err error
+	for err == nil {
+		_, err = z.Next()
+	}
+	if err == io.EOF {
+		t.Error("expected an error for an unterminated string, got io.EOF")
+	}
+}
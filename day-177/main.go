@@ -0,0 +1,329 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TokenKind identifies the type of a JSON token.
+type TokenKind uint8
+
+const (
+	TokenInvalid TokenKind = iota
+	TokenBeginObject
+	TokenEndObject
+	TokenBeginArray
+	TokenEndArray
+	TokenString
+	TokenNumber
+	TokenTrue
+	TokenFalse
+	TokenNull
+)
+
+// Token is a window into the original input. For strings, Start/Length
+// cover the content between the quotes, so no bytes are copied.
+type Token struct {
+	Start   int
+	Length  int
+	Kind    TokenKind
+	Escaped bool // String contains backslash escapes and needs decoding
+}
+
+// Bytes returns the raw token bytes, sharing memory with input.
+func (t Token) Bytes(input []byte) []byte {
+	return input[t.Start : t.Start+t.Length]
+}
+
+// Text materializes the token value as a string. This is the only method
+// that allocates, so hot paths should compare Bytes instead.
+func (t Token) Text(input []byte) (string, error) {
+	if t.Kind != TokenString || !t.Escaped {
+		return string(t.Bytes(input)), nil
+	}
+	var s string
+	err := json.Unmarshal(input[t.Start-1:t.Start+t.Length+1], &s)
+	return s, err
+}
+
+// Tokenizer scans JSON input without allocating per token.
+type Tokenizer struct {
+	input []byte
+	pos   int
+}
+
+var errUnexpectedEOF = errors.New("tokenizer: unexpected end of input")
+
+// NewTokenizer returns a tokenizer over input. The input must not be
+// modified while tokens are in use.
+func NewTokenizer(input []byte) *Tokenizer {
+	return &Tokenizer{input: input}
+}
+
+// Reset points the tokenizer at new input so it can be reused.
+func (z *Tokenizer) Reset(input []byte) {
+	z.input = input
+	z.pos = 0
+}
+
+// Next returns the next token, skipping ':' and ',' separators like
+// json.Decoder.Token does. It returns io.EOF at the end of input.
+func (z *Tokenizer) Next() (Token, error) {
+	for z.pos < len(z.input) {
+		switch z.input[z.pos] {
+		case ' ', '\t', '\n', '\r', ':', ',':
+			z.pos++
+			continue
+		}
+		break
+	}
+	if z.pos >= len(z.input) {
+		return Token{}, io.EOF
+	}
+
+	start := z.pos
+	c := z.input[z.pos]
+	switch c {
+	case '{':
+		z.pos++
+		return Token{Kind: TokenBeginObject, Start: start, Length: 1}, nil
+	case '}':
+		z.pos++
+		return Token{Kind: TokenEndObject, Start: start, Length: 1}, nil
+	case '[':
+		z.pos++
+		return Token{Kind: TokenBeginArray, Start: start, Length: 1}, nil
+	case ']':
+		z.pos++
+		return Token{Kind: TokenEndArray, Start: start, Length: 1}, nil
+	case '"':
+		return z.scanString()
+	case 't':
+		return z.scanLiteral("true", TokenTrue)
+	case 'f':
+		return z.scanLiteral("false", TokenFalse)
+	case 'n':
+		return z.scanLiteral("null", TokenNull)
+	}
+
+	if c == '-' || (c >= '0' && c <= '9') {
+		return z.scanNumber()
+	}
+	return Token{}, fmt.Errorf("tokenizer: invalid character %q at offset %d", c, start)
+}
+
+func (z *Tokenizer) scanString() (Token, error) {
+	z.pos++ // Opening quote
+	tok := Token{Kind: TokenString, Start: z.pos}
+
+	for z.pos < len(z.input) {
+		switch z.input[z.pos] {
+		case '\\':
+			tok.Escaped = true
+			z.pos += 2
+		case '"':
+			tok.Length = z.pos - tok.Start
+			z.pos++
+			return tok, nil
+		default:
+			z.pos++
+		}
+	}
+	return Token{}, errUnexpectedEOF
+}
+
+func (z *Tokenizer) scanLiteral(lit string, kind TokenKind) (Token, error) {
+	end := z.pos + len(lit)
+	if end > len(z.input) || string(z.input[z.pos:end]) != lit {
+		return Token{}, fmt.Errorf("tokenizer: invalid literal at offset %d", z.pos)
+	}
+	tok := Token{Kind: kind, Start: z.pos, Length: len(lit)}
+	z.pos = end
+	return tok, nil
+}
+
+func (z *Tokenizer) scanNumber() (Token, error) {
+	start := z.pos
+	for z.pos < len(z.input) {
+		c := z.input[z.pos]
+		if (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E' {
+			z.pos++
+			continue
+		}
+		break
+	}
+	return Token{Kind: TokenNumber, Start: start, Length: z.pos - start}, nil
+}
+
+func main() {
+	fmt.Println("🔬 DAY 177: Copy-free JSON Tokenizer")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	doc := generateDocument(rand.New(rand.NewSource(177)), 100*1024)
+	fmt.Printf("📄 Test document: %d KB of JSON log records\n\n", len(doc)/1024)
+
+	fmt.Println("📊 BENCHMARK: Scanning every token")
+	fmt.Println(strings.Repeat("-", 40))
+
+	stdTime, stdTokens, err := benchmarkStdDecoder(doc)
+	if err != nil {
+		fmt.Printf("❌ json.Decoder failed: %v\n", err)
+		return
+	}
+	fmt.Printf("1. json.Decoder.Token():  %v (%d tokens)\n", stdTime, stdTokens)
+
+	zeroTime, zeroTokens, err := benchmarkZeroAllocTokenizer(doc)
+	if err != nil {
+		fmt.Printf("❌ Tokenizer failed: %v\n", err)
+		return
+	}
+	fmt.Printf("2. Zero-alloc tokenizer:  %v (%d tokens)\n", zeroTime, zeroTokens)
+	fmt.Printf("   Speedup: %.1fx\n", float64(stdTime)/float64(zeroTime))
+
+	fmt.Println("\n🔧 ALLOCATION ELIMINATION")
+	fmt.Println(strings.Repeat("-", 40))
+	stdAllocs, zeroAllocs := analyzeTokenizerAllocationElimination(doc)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateZeroAllocTokenizerCostImpact(stdTime, zeroTime, len(doc), stdAllocs, zeroAllocs)
+
+	fmt.Println("\n✅ DAY 177 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 178 - Compile-time Constant Folding")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkStdDecoder(doc []byte) (time.Duration, int, error) {
+	start := time.Now()
+	tokens, err := countStdTokens(doc)
+	return time.Since(start), tokens, err
+}
+
+func benchmarkZeroAllocTokenizer(doc []byte) (time.Duration, int, error) {
+	start := time.Now()
+	tokens, err := countZeroAllocTokens(NewTokenizer(doc))
+	return time.Since(start), tokens, err
+}
+
+func countStdTokens(doc []byte) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	tokens := 0
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return tokens, err
+		}
+		tokens++
+	}
+}
+
+func countZeroAllocTokens(z *Tokenizer) (int, error) {
+	tokens := 0
+	for {
+		_, err := z.Next()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return tokens, err
+		}
+		tokens++
+	}
+}
+
+// generateDocument builds a JSON array of log records of roughly size bytes.
+func generateDocument(rng *rand.Rand, size int) []byte {
+	levels := []string{"debug", "info", "warn", "error"}
+	services := []string{"api", "billing", "auth", "search", "worker"}
+
+	var buf bytes.Buffer
+	buf.Grow(size + 256)
+	buf.WriteByte('[')
+	for i := 0; buf.Len() < size; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf,
+			`{"ts":%d,"level":"%s","service":"%s","latency_ms":%.2f,"ok":%t,"trace":null,"tags":["region-%d","pod-%d"]}`,
+			1700000000+i, levels[rng.Intn(len(levels))], services[rng.Intn(len(services))],
+			rng.Float64()*250, rng.Intn(10) != 0, rng.Intn(4), rng.Intn(100))
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeTokenizerAllocationElimination(doc []byte) (float64, float64) {
+	stdAllocs := testing.AllocsPerRun(5, func() {
+		_, _ = countStdTokens(doc)
+	})
+
+	z := NewTokenizer(doc)
+	zeroAllocs := testing.AllocsPerRun(5, func() {
+		z.Reset(doc)
+		_, _ = countZeroAllocTokens(z)
+	})
+
+	tokens, _ := countZeroAllocTokens(NewTokenizer(doc))
+
+	fmt.Printf("Allocations per %d KB document:\n", len(doc)/1024)
+	fmt.Printf("  json.Decoder.Token():  %8.0f allocs (%.2f per token)\n", stdAllocs, stdAllocs/float64(tokens))
+	fmt.Printf("  Zero-alloc tokenizer:  %8.0f allocs (%.2f per token)\n", zeroAllocs, zeroAllocs/float64(tokens))
+	fmt.Println()
+	fmt.Println("💡 Why the decoder allocates:")
+	fmt.Println("  • Token() returns interface{} → every string/number is boxed")
+	fmt.Println("  • Each string token is copied out of the read buffer")
+	fmt.Println("  • Token is a 24-byte (int, int, kind) value → stays on the stack")
+
+	return stdAllocs, zeroAllocs
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateZeroAllocTokenizerCostImpact(stdTime, zeroTime time.Duration, docBytes int, stdAllocs, zeroAllocs float64) {
+	// High-throughput log parser
+	logBytesPerSecond := 200.0 * 1024 * 1024 // 200 MB/s of JSON logs
+	awsCostPerVCPUHour := 0.0416             // t3.medium
+
+	docsPerSecond := logBytesPerSecond / float64(docBytes)
+	// CPU-seconds per second of wall time = vCPUs kept busy
+	vCPUsStd := stdTime.Seconds() * docsPerSecond
+	vCPUsZero := zeroTime.Seconds() * docsPerSecond
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Log ingest: %.0f MB/s of JSON\n", logBytesPerSecond/(1024*1024))
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	monthlyStd := vCPUsStd * awsCostPerVCPUHour * 24 * 30
+	monthlyZero := vCPUsZero * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  json.Decoder:  %.2f vCPUs busy → $%.2f/month\n", vCPUsStd, monthlyStd)
+	fmt.Printf("  Zero-alloc:    %.2f vCPUs busy → $%.2f/month\n", vCPUsZero, monthlyZero)
+	fmt.Printf("  Allocations avoided: %.0f/second\n", (stdAllocs-zeroAllocs)*docsPerSecond)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlyStd-monthlyZero)
+	fmt.Printf("  Annual savings:  $%.2f\n", (monthlyStd-monthlyZero)*12)
+
+	fmt.Println("\n💡 ADDITIONAL BENEFITS (not quantified):")
+	fmt.Println("  • No GC pressure from short-lived token strings")
+	fmt.Println("  • Tokens can be compared against []byte keys directly")
+
+	fmt.Println("\n⚠️  TRADE-OFF:")
+	fmt.Println("  • Tokens are only valid while the input buffer is unchanged")
+	fmt.Println("  • Escaped strings still need decoding via Token.Text")
+}
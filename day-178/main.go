@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/analyze"
+)
+
+// Folded by the compiler: the binary contains the literal 1024.
+const kbPerMBConst = 1 << 20 / 1024
+
+// Initialized at program start: every use is a memory load.
+var kbPerMBVar = computeKBPerMB()
+
+// Built by init code: the table is computed on every process start.
+var costTable = buildCostTable()
+
+func computeKBPerMB() int {
+	return (1 << 20) / 1024
+}
+
+// buildCostTable precomputes $/GB-month for 1..1024 GB at runtime.
+func buildCostTable() []float64 {
+	table := make([]float64, 1024)
+	for i := range table {
+		table[i] = float64(i+1) * 30.0 / 8.0
+	}
+	return table
+}
+
+func main() {
+	fmt.Println("🔬 DAY 178: Compile-time Constant Folding")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const iterations = 100_000_000
+
+	fmt.Println("📊 BENCHMARK: const vs var vs runtime computation")
+	fmt.Println(strings.Repeat("-", 40))
+
+	constTime, constSum := benchmarkConstantComputation(iterations)
+	fmt.Printf("1. const kbPerMB:            %v (sum=%d)\n", constTime, constSum)
+
+	varTime, varSum := benchmarkVariableComputation(iterations)
+	fmt.Printf("2. var kbPerMB:              %v (sum=%d)\n", varTime, varSum)
+
+	initTime, initSum := benchmarkRuntimeInit(iterations / 1000)
+	fmt.Printf("3. recompute per call (1/1000 iters): %v (sum=%.0f)\n", initTime, initSum)
+
+	fmt.Printf("\n   var vs const: %.2fx\n", float64(varTime)/float64(constTime))
+
+	fmt.Println("\n🔧 COMPILE-TIME OPTIMIZATION OPPORTUNITIES")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeCompileTimeOptimizationOpportunities([]string{"../day-01", "../day-02", "../day-03", "../day-06"})
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateConstFoldingCostImpact(constTime, varTime, iterations)
+
+	fmt.Println("\n✅ DAY 178 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 179 - RWMutex vs atomic.Value vs Seqlock")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// benchmarkConstantComputation divides by a folded constant. The compiler
+// turns i/1024 into a shift.
+func benchmarkConstantComputation(iterations int) (time.Duration, int) {
+	start := time.Now()
+	sum := 0
+	for i := 0; i < iterations; i++ {
+		sum += i / kbPerMBConst
+	}
+	return time.Since(start), sum
+}
+
+// benchmarkVariableComputation uses the same value stored in a package
+// variable. The compiler must load it and emit a real IDIV instruction.
+func benchmarkVariableComputation(iterations int) (time.Duration, int) {
+	start := time.Now()
+	sum := 0
+	for i := 0; i < iterations; i++ {
+		sum += i / kbPerMBVar
+	}
+	return time.Since(start), sum
+}
+
+// benchmarkRuntimeInit rebuilds the lookup table on every call, the
+// worst case of "computing constants" at runtime.
+func benchmarkRuntimeInit(iterations int) (time.Duration, float64) {
+	start := time.Now()
+	sum := 0.0
+	for i := 0; i < iterations; i++ {
+		table := buildCostTable()
+		sum += table[i%len(table)]
+	}
+	return time.Since(start), sum
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeCompileTimeOptimizationOpportunities(dirs []string) {
+	fmt.Println("Folding candidate expressions with go/constant:")
+	exprs := []string{
+		"1<<20 / 1024",
+		"30.0 / 8.0",
+		"100.0 * 3600 * 24",
+		"1000 * (8 + 16)",
+	}
+	for _, src := range exprs {
+		expr, err := parser.ParseExpr(src)
+		if err != nil {
+			continue
+		}
+		v, ok := analyze.EvalConstExpr(expr, nil)
+		if !ok {
+			fmt.Printf("  %-20s → not constant\n", src)
+			continue
+		}
+		fmt.Printf("  %-20s → %s (folded at compile time)\n", src, v)
+	}
+
+	fmt.Println("\nvar/:= declarations that could be const:")
+	total := 0
+	for _, dir := range dirs {
+		candidates := analyze.FindExpensiveRuntimeConsts(dir)
+		for _, c := range candidates {
+			fmt.Printf("  %s:%d  %s := %s  →  const %s = %s\n",
+				c.File, c.Line, c.Name, c.Expr, c.Name, c.Value)
+		}
+		total += len(candidates)
+	}
+	if total == 0 {
+		fmt.Println("  (run from the day-178 directory to scan day-01..03)")
+	}
+
+	fmt.Println("\n💡 Why const matters:")
+	fmt.Println("  • const values are inlined as immediates (no memory load)")
+	fmt.Println("  • Arithmetic on consts is folded: 1<<20/1024 → 1024")
+	fmt.Println("  • Dividing by a const becomes a shift or multiply, never IDIV")
+	fmt.Println("  • var values can change, so the compiler must re-read them")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateConstFoldingCostImpact(constTime, varTime time.Duration, iterations int) {
+	// A billing path that applies unit conversions per request
+	requestsPerSecond := 50_000.0
+	conversionsPerRequest := 200.0
+	awsCostPerVCPUHour := 0.0416
+
+	nsSavedPerOp := float64(varTime.Nanoseconds()-constTime.Nanoseconds()) / float64(iterations)
+	if nsSavedPerOp < 0 {
+		nsSavedPerOp = 0
+	}
+
+	cpuSecondsPerSecond := nsSavedPerOp * conversionsPerRequest * requestsPerSecond / 1e9
+	monthlySavings := cpuSecondsPerSecond * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %.0f unit conversions each\n", requestsPerSecond, conversionsPerRequest)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Time saved per conversion: %.3f ns\n", nsSavedPerOp)
+	fmt.Printf("  vCPUs freed:               %.4f\n", cpuSecondsPerSecond)
+	fmt.Printf("  Monthly savings:           $%.4f\n", monthlySavings)
+	fmt.Printf("  Annual savings:            $%.4f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 ACTION ITEMS:")
+	fmt.Println("  1. Declare pricing assumptions as const, not :=")
+	fmt.Println("  2. Move table-building out of hot paths (package init or sync.Once)")
+	fmt.Println("  3. Run pkg/analyze.FindExpensiveRuntimeConsts in code review")
+}
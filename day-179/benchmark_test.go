@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variable to prevent compiler optimizations
+var globalRoute Route
+
+// ========== 99% READ / 1% WRITE BENCHMARKS ==========
+
+func Benchmark_RWMutexStore(b *testing.B) {
+	benchmarkReadMostlyHelper(b, NewRWMutexStore(newRoute(0)))
+}
+
+func Benchmark_AtomicValueStore(b *testing.B) {
+	benchmarkReadMostlyHelper(b, NewAtomicValueStore(newRoute(0)))
+}
+
+func Benchmark_SeqLockStore(b *testing.B) {
+	benchmarkReadMostlyHelper(b, NewSeqLockStore(newRoute(0)))
+}
+
+func benchmarkReadMostlyHelper(b *testing.B, s Store[Route]) {
+	var version atomic.Uint64
+	b.ReportAllocs()
+	b.SetParallelism(16)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var r Route
+		i := 0
+		for pb.Next() {
+			if i%100 == 0 {
+				s.Store(newRoute(version.Add(1)))
+			} else {
+				r = s.Load()
+			}
+			i++
+		}
+		globalRoute = r
+	})
+}
+
+// ========== CONSISTENCY TESTS ==========
+
+func Test_StoresNeverExposePartialWrites(t *testing.T) {
+	stores := map[string]Store[Route]{
+		"RWMutex":      NewRWMutexStore(newRoute(0)),
+		"atomic.Value": NewAtomicValueStore(newRoute(0)),
+		"Seqlock":      NewSeqLockStore(newRoute(0)),
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			var (
+				wg      sync.WaitGroup
+				stop    atomic.Bool
+				torn    atomic.Int64
+				reads   atomic.Int64
+				writers = 2
+				readers = 8
+			)
+
+			for w := 0; w < writers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for v := uint64(w); v < 20_000; v += uint64(writers) {
+						s.Store(newRoute(v))
+					}
+				}(w)
+			}
+
+			var readWG sync.WaitGroup
+			for r := 0; r < readers; r++ {
+				readWG.Add(1)
+				go func() {
+					defer readWG.Done()
+					for !stop.Load() {
+						if !s.Load().consistent() {
+							torn.Add(1)
+						}
+						reads.Add(1)
+					}
+				}()
+			}
+
+			wg.Wait()
+			stop.Store(true)
+			readWG.Wait()
+
+			t.Logf("%s: %d reads, %d torn", name, reads.Load(), torn.Load())
+			if torn.Load() != 0 {
+				t.Errorf("%s exposed %d partially written values", name, torn.Load())
+			}
+		})
+	}
+}
+
+func Test_StoresRoundTrip(t *testing.T) {
+	stores := map[string]Store[Route]{
+		"RWMutex":      NewRWMutexStore(newRoute(1)),
+		"atomic.Value": NewAtomicValueStore(newRoute(1)),
+		"Seqlock":      NewSeqLockStore(newRoute(1)),
+	}
+
+	for name, s := range stores {
+		if got := s.Load(); got != newRoute(1) {
+			t.Errorf("%s: initial value mismatch: %+v", name, got)
+		}
+		s.Store(newRoute(42))
+		if got := s.Load(); got != newRoute(42) {
+			t.Errorf("%s: stored value mismatch: %+v", name, got)
+		}
+	}
+}
+
+func Test_SeqLockRejectsPointerTypes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewSeqLockStore to panic for a type with pointers")
+		}
+	}()
+	NewSeqLockStore(struct{ Name string }{"x"})
+}
+
+func Test_SeqLockLoadZeroAllocs(t *testing.T) {
+	s := NewSeqLockStore(newRoute(7))
+	testutil.AssertMaxAllocs(t, "SeqLockStore.Load", 0, func() {
+		globalRoute = s.Load()
+	})
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Store is a single-value container shared by many readers and rare writers.
+type Store[T any] interface {
+	Load() T
+	Store(v T)
+}
+
+// ========== RWMutex ==========
+
+// RWMutexStore guards the value with a reader/writer lock. Every read
+// writes to the lock's reader counter, so readers contend on one cache line.
+type RWMutexStore[T any] struct {
+	mu sync.RWMutex
+	v  T
+}
+
+func NewRWMutexStore[T any](initial T) *RWMutexStore[T] {
+	return &RWMutexStore[T]{v: initial}
+}
+
+func (s *RWMutexStore[T]) Load() T {
+	s.mu.RLock()
+	v := s.v
+	s.mu.RUnlock()
+	return v
+}
+
+func (s *RWMutexStore[T]) Store(v T) {
+	s.mu.Lock()
+	s.v = v
+	s.mu.Unlock()
+}
+
+// ========== atomic.Value ==========
+
+// AtomicValueStore publishes immutable copies through atomic.Value. Reads
+// are a single atomic load; every write allocates a new boxed value.
+type AtomicValueStore[T any] struct {
+	v atomic.Value
+}
+
+func NewAtomicValueStore[T any](initial T) *AtomicValueStore[T] {
+	s := &AtomicValueStore[T]{}
+	s.v.Store(initial)
+	return s
+}
+
+func (s *AtomicValueStore[T]) Load() T {
+	return s.v.Load().(T)
+}
+
+func (s *AtomicValueStore[T]) Store(v T) {
+	s.v.Store(v)
+}
+
+// ========== Seqlock ==========
+
+// maxSeqLockWords bounds T so Load can copy through a stack buffer.
+const maxSeqLockWords = 32 // 256 bytes
+
+// SeqLockStore lets readers copy the value without taking any lock or
+// writing shared memory. Writers bump the sequence to an odd number, write,
+// then bump it back to even; readers retry if the sequence was odd or
+// changed while they were copying.
+//
+// The value is stored in atomic words so the race detector (and the Go
+// memory model) accept the concurrent copy. T must therefore be plain data
+// with no pointers, or the GC would lose track of them.
+type SeqLockStore[T any] struct {
+	mu    sync.Mutex // Serializes writers
+	seq   atomic.Uint64
+	words []atomic.Uint64
+	size  uintptr
+}
+
+// NewSeqLockStore panics if T contains pointers or exceeds 256 bytes.
+func NewSeqLockStore[T any](initial T) *SeqLockStore[T] {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if hasPointers(typ) {
+		panic(fmt.Sprintf("SeqLockStore: %s contains pointers", typ))
+	}
+	size := typ.Size()
+	n := (size + 7) / 8
+	if n > maxSeqLockWords {
+		panic(fmt.Sprintf("SeqLockStore: %s is %d bytes, max %d", typ, size, maxSeqLockWords*8))
+	}
+
+	s := &SeqLockStore[T]{words: make([]atomic.Uint64, n), size: size}
+	s.Store(initial)
+	return s
+}
+
+func (s *SeqLockStore[T]) Load() T {
+	var buf [maxSeqLockWords]uint64
+	for {
+		start := s.seq.Load()
+		if start&1 == 1 {
+			runtime.Gosched() // Writer in progress
+			continue
+		}
+		for i := range s.words {
+			buf[i] = s.words[i].Load()
+		}
+		if s.seq.Load() == start {
+			break
+		}
+	}
+
+	var v T
+	if s.size > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&v)), s.size),
+			unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), s.size))
+	}
+	return v
+}
+
+func (s *SeqLockStore[T]) Store(v T) {
+	var buf [maxSeqLockWords]uint64
+	if s.size > 0 {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), s.size),
+			unsafe.Slice((*byte)(unsafe.Pointer(&v)), s.size))
+	}
+
+	s.mu.Lock()
+	s.seq.Add(1) // Odd: write in progress
+	for i := range s.words {
+		s.words[i].Store(buf[i])
+	}
+	s.seq.Add(1) // Even: value is consistent again
+	s.mu.Unlock()
+}
+
+func hasPointers(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasPointers(t.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Route is a routing table entry: every field must change together.
+type Route struct {
+	Version  uint64
+	Backend  [4]byte // IPv4
+	Port     uint16
+	Weight   uint16
+	Checksum uint64 // Version ^ port ^ weight, lets readers detect torn reads
+}
+
+func newRoute(version uint64) Route {
+	r := Route{
+		Version: version,
+		Backend: [4]byte{10, 0, byte(version >> 8), byte(version)},
+		Port:    uint16(8000 + version%1000),
+		Weight:  uint16(version % 100),
+	}
+	r.Checksum = r.Version ^ uint64(r.Port) ^ uint64(r.Weight)
+	return r
+}
+
+func (r Route) consistent() bool {
+	return r.Checksum == r.Version^uint64(r.Port)^uint64(r.Weight) &&
+		r.Backend[2] == byte(r.Version>>8) && r.Backend[3] == byte(r.Version)
+}
+
+func main() {
+	fmt.Println("🔬 DAY 179: RWMutex vs atomic.Value vs Seqlock")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		goroutines = 16
+		totalOps   = 10_000_000
+		writeEvery = 100 // 1% writes
+	)
+
+	fmt.Printf("📊 BENCHMARK: %d goroutines, %d ops, 99%% reads\n", goroutines, totalOps)
+	fmt.Println(strings.Repeat("-", 40))
+
+	rwTime := benchmarkStore(NewRWMutexStore(newRoute(0)), goroutines, totalOps, writeEvery)
+	fmt.Printf("1. sync.RWMutex:   %v (%.1f ns/op)\n", rwTime, float64(rwTime.Nanoseconds())/totalOps)
+
+	avTime := benchmarkStore(NewAtomicValueStore(newRoute(0)), goroutines, totalOps, writeEvery)
+	fmt.Printf("2. atomic.Value:   %v (%.1f ns/op)\n", avTime, float64(avTime.Nanoseconds())/totalOps)
+
+	slTime := benchmarkStore(NewSeqLockStore(newRoute(0)), goroutines, totalOps, writeEvery)
+	fmt.Printf("3. Seqlock:        %v (%.1f ns/op)\n", slTime, float64(slTime.Nanoseconds())/totalOps)
+
+	fmt.Println("\n🔧 SEQLOCK READ PATH")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeSeqlockContentionFreeRead()
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateSeqlockCostImpact(rwTime, slTime, totalOps)
+
+	fmt.Println("\n✅ DAY 179 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 180 - Type Assertion Caching")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// benchmarkStore splits totalOps across goroutines; every writeEvery-th
+// operation is a Store, the rest are Loads.
+func benchmarkStore(s Store[Route], goroutines, totalOps, writeEvery int) time.Duration {
+	var wg sync.WaitGroup
+	perWorker := totalOps / goroutines
+
+	start := time.Now()
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			var sink uint64
+			for i := 0; i < perWorker; i++ {
+				if i%writeEvery == 0 {
+					s.Store(newRoute(uint64(g*perWorker + i)))
+					continue
+				}
+				sink += s.Load().Version
+			}
+			_ = sink
+		}(g)
+	}
+	wg.Wait()
+
+	return time.Since(start)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeSeqlockContentionFreeRead() {
+	fmt.Println("Read path comparison (per Load):")
+	fmt.Println("  Store         | Shared writes     | Blocks on writer | Allocs per Store")
+	fmt.Println("  --------------|-------------------|------------------|-----------------")
+	fmt.Println("  RWMutex       | 2 (RLock/RUnlock) | yes              | 0")
+	fmt.Println("  atomic.Value  | 0                 | no               | 1 (boxing)")
+	fmt.Println("  Seqlock       | 0                 | retries only     | 0")
+	fmt.Println()
+	fmt.Println("Seqlock read algorithm:")
+	fmt.Println("  1. s1 := seq.Load()       // odd → writer active, retry")
+	fmt.Println("  2. copy value words       // plain atomic loads")
+	fmt.Println("  3. s2 := seq.Load()       // s1 != s2 → torn copy, retry")
+	fmt.Println()
+	fmt.Println("💡 Readers never write shared memory, so they never bounce a")
+	fmt.Println("   cache line between cores. With 1% writes, retries are rare.")
+	fmt.Printf("   Route size: %d bytes → %d atomic words per copy\n",
+		unsafe.Sizeof(Route{}), (unsafe.Sizeof(Route{})+7)/8)
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateSeqlockCostImpact(rwTime, seqTime time.Duration, totalOps int) {
+	// Routing table consulted on every request, updated a few times a minute
+	lookupsPerSecond := 2_000_000.0
+	awsCostPerVCPUHour := 0.0416
+	cores := 16.0
+
+	nsRW := float64(rwTime.Nanoseconds()) / float64(totalOps)
+	nsSeq := float64(seqTime.Nanoseconds()) / float64(totalOps)
+
+	// Wall ns/op measured across all goroutines → CPU ns/op on a busy box
+	vCPUsRW := nsRW * lookupsPerSecond / 1e9 * float64(runtime.GOMAXPROCS(0))
+	vCPUsSeq := nsSeq * lookupsPerSecond / 1e9 * float64(runtime.GOMAXPROCS(0))
+
+	monthlyRW := vCPUsRW * awsCostPerVCPUHour * 24 * 30
+	monthlySeq := vCPUsSeq * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Routing lookups: %.0f/second across %.0f cores\n", lookupsPerSecond, cores)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+	fmt.Printf("  • Measured with GOMAXPROCS=%d\n", runtime.GOMAXPROCS(0))
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  RWMutex: %.1f ns/lookup → %.3f vCPUs → $%.2f/month\n", nsRW, vCPUsRW, monthlyRW)
+	fmt.Printf("  Seqlock: %.1f ns/lookup → %.3f vCPUs → $%.2f/month\n", nsSeq, vCPUsSeq, monthlySeq)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlyRW-monthlySeq)
+	fmt.Printf("  Annual savings:  $%.2f\n", (monthlyRW-monthlySeq)*12)
+
+	fmt.Println("\n⚠️  NOTE:")
+	fmt.Println("  RWMutex reader-count contention grows with core count; the")
+	fmt.Println("  gap on a 16+ core host is far larger than on a laptop.")
+}
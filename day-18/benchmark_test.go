@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ========== CONFIG STORE BENCHMARKS ==========
+
+// Each op is one Get or, once per writeEvery ops per goroutine, one Set,
+// from about numWorkers goroutines.
+
+// Global variable to prevent compiler optimizations
+var value string
+
+func Benchmark_ConfigStore(b *testing.B) {
+	for _, st := range stores {
+		for _, every := range writeRatios {
+			b.Run(fmt.Sprintf("%s/writes_1_per_%d", st.ID, every), func(b *testing.B) {
+				benchmarkConfigStore(b, st.New(), every)
+			})
+		}
+	}
+}
+
+func benchmarkConfigStore(b *testing.B, s ConfigStore, every int) {
+	b.SetParallelism((numWorkers + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var v string
+		for i := 0; pb.Next(); i++ {
+			k := i % numKeys
+			if i%every == 0 {
+				s.Set(keys[k], values[k])
+			} else {
+				v, _ = s.Get(keys[k])
+			}
+		}
+		value = v
+	})
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_StoresReadTheirWrites(t *testing.T) {
+	for _, st := range stores {
+		s := st.New()
+		if v, ok := s.Get(keys[7]); !ok || v != values[7] {
+			t.Errorf("%s: Get(%q) = %q, %v; expected the initial %q", st.Name, keys[7], v, ok, values[7])
+		}
+		s.Set(keys[7], "off")
+		s.Set("feature.new", "on")
+		if v, _ := s.Get(keys[7]); v != "off" {
+			t.Errorf("%s: expected the updated value, got %q", st.Name, v)
+		}
+		if v, ok := s.Get("feature.new"); !ok || v != "on" {
+			t.Errorf("%s: new key missing after Set, got %q, %v", st.Name, v, ok)
+		}
+		if _, ok := s.Get("feature.missing"); ok {
+			t.Errorf("%s: found a key that was never set", st.Name)
+		}
+	}
+}
+
+func Test_ConcurrentWritesAreNotLost(t *testing.T) {
+	const writers, perWriter = 8, 50
+	for _, st := range stores {
+		s := st.New()
+		var wg sync.WaitGroup
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perWriter; i++ {
+					s.Set("w"+strconv.Itoa(w)+"."+strconv.Itoa(i), "on")
+				}
+			}()
+		}
+		runWorkload(s, numWorkers, 100, writeEvery) // readers alongside
+		wg.Wait()
+
+		for w := 0; w < writers; w++ {
+			for i := 0; i < perWriter; i++ {
+				if _, ok := s.Get("w" + strconv.Itoa(w) + "." + strconv.Itoa(i)); !ok {
+					t.Fatalf("%s: write %d of writer %d was lost", st.Name, i, w)
+				}
+			}
+		}
+	}
+}
+
+func Test_ShardsSpreadKeys(t *testing.T) {
+	s := newShardedStore()
+	for i := range s.shards {
+		if n := len(s.shards[i].values); n == 0 || n > 2*numKeys/numShards {
+			t.Errorf("shard %d holds %d of %d keys; expected about %d", i, n, numKeys, numKeys/numShards)
+		}
+	}
+}
+
+func Test_WriterStallsNewReaders(t *testing.T) {
+	const hold = time.Millisecond
+	quiet := measureReaderStall(10, hold, 50*time.Millisecond, false)
+	busy := measureReaderStall(10, hold, 50*time.Millisecond, true)
+	// Readers never block each other; behind a writer they wait for the
+	// reads already inside to finish
+	if quiet.max >= hold/2 {
+		t.Errorf("readers waited up to %v with no writer, expected well under %v", quiet.max, hold)
+	}
+	if busy.max < hold/2 {
+		t.Errorf("readers waited at most %v with a writer, expected about %v", busy.max, hold)
+	}
+}
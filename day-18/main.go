@@ -0,0 +1,498 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	numWorkers   = 100
+	opsPerWorker = 20_000
+	numKeys      = 256
+	numShards    = 16
+	// writeEvery is the share of writes in the workload: one Set per
+	// writeEvery operations, 1% writes and 99% reads
+	writeEvery = 100
+)
+
+// writeRatios are the contention levels swept after the suite, from a
+// write every 10 operations to a reload-like one every 10,000.
+var writeRatios = []int{10, 100, 1_000, 10_000}
+
+// keys and values are built once so the workload measures the stores, not
+// string formatting.
+var keys, values = func() ([]string, []string) {
+	k, v := make([]string, numKeys), make([]string, numKeys)
+	for i := range k {
+		k[i] = "feature." + strconv.Itoa(i)
+		v[i] = "on"
+	}
+	return k, v
+}()
+
+// ========== CONFIG STORES ==========
+
+// ConfigStore holds the settings every request reads and an operator's
+// hot reload occasionally changes.
+type ConfigStore interface {
+	Get(key string) (string, bool)
+	Set(key, value string)
+}
+
+// Config is one immutable version of every setting.
+type Config struct {
+	Values map[string]string
+}
+
+func newValues() map[string]string {
+	m := make(map[string]string, numKeys)
+	for i, k := range keys {
+		m[k] = values[i]
+	}
+	return m
+}
+
+// rwMutexStore guards one map with one RWMutex: readers share it, a
+// writer excludes everyone.
+type rwMutexStore struct {
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+func newRWMutexStore() *rwMutexStore { return &rwMutexStore{values: newValues()} }
+
+func (s *rwMutexStore) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.values[key]
+	return v, ok
+}
+
+func (s *rwMutexStore) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// shard is one lock and the keys that hash to it, padded to a cache line
+// so neighbouring shards' locks don't false-share (day 11).
+type shard struct {
+	mu     sync.Mutex
+	values map[string]string
+	_      [64 - 16]byte
+}
+
+// shardedStore spreads keys over numShards mutexes, so goroutines only
+// contend when they touch keys in the same shard.
+type shardedStore struct {
+	shards [numShards]shard
+}
+
+func newShardedStore() *shardedStore {
+	s := &shardedStore{}
+	for i := range s.shards {
+		s.shards[i].values = make(map[string]string, numKeys/numShards)
+	}
+	for i, k := range keys {
+		s.Set(k, values[i])
+	}
+	return s
+}
+
+// shardFor hashes key with FNV-1a.
+func (s *shardedStore) shardFor(key string) *shard {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return &s.shards[h%numShards]
+}
+
+func (s *shardedStore) Get(key string) (string, bool) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	v, ok := sh.values[key]
+	return v, ok
+}
+
+func (s *shardedStore) Set(key, value string) {
+	sh := s.shardFor(key)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	sh.values[key] = value
+}
+
+// atomicStore publishes immutable Configs through an atomic pointer.
+// Readers never lock; writers copy the whole map, change the copy and
+// swap it in.
+type atomicStore struct {
+	current atomic.Pointer[Config]
+	writeMu sync.Mutex // Serializes writers so no update is lost
+}
+
+func newAtomicStore() *atomicStore {
+	s := &atomicStore{}
+	s.current.Store(&Config{Values: newValues()})
+	return s
+}
+
+func (s *atomicStore) Get(key string) (string, bool) {
+	v, ok := s.current.Load().Values[key]
+	return v, ok
+}
+
+func (s *atomicStore) Set(key, value string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	old := s.current.Load().Values
+	next := make(map[string]string, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[key] = value
+	s.current.Store(&Config{Values: next})
+}
+
+type store struct {
+	Name string
+	ID   string // Sub-benchmark name
+	New  func() ConfigStore
+}
+
+var stores = []store{
+	{"sync.RWMutex", "RWMutex", func() ConfigStore { return newRWMutexStore() }},
+	{"16 sharded mutexes", "Sharded", func() ConfigStore { return newShardedStore() }},
+	{"atomic.Pointer (COW)", "AtomicPointer", func() ConfigStore { return newAtomicStore() }},
+}
+
+// runWorkload starts workers goroutines that each do ops operations on s,
+// one Set per writeEvery and Gets otherwise, walking the keys from a
+// different starting point each.
+func runWorkload(s ConfigStore, workers, ops, writeEvery int) {
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops; i++ {
+				k := (w*31 + i) % numKeys
+				if i%writeEvery == 0 {
+					s.Set(keys[k], values[k])
+				} else {
+					s.Get(keys[k])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func main() {
+	fmt.Println("🔬 DAY 18: RWMutex vs Sharded Mutex vs Atomic Config")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about RWMutex
+	fmt.Println("🎯 SHOCKING DISCOVERY: One config reload stalls every reader!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealRWMutexInternals()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d goroutines × %d operations, 1 write per %d\n",
+		numWorkers, opsPerWorker, writeEvery)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+	sweep := sweepWriteRatios()
+
+	// Lock internals
+	fmt.Println("\n🔧 READ PATH DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainReadPaths()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateConfigCostImpact(results, sweep, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 18, Topic: "RWMutex vs Sharded Mutex vs Atomic Config", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 18 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 19 - Channel Sizing: Buffered vs Unbuffered")
+}
+
+// readerStall is how long RLock calls took during one measurement.
+type readerStall struct {
+	reads      int
+	p99, max   time.Duration
+	writerWait time.Duration // Longest Lock call, 0 without writers
+}
+
+// measureReaderStall runs readers goroutines that each hold the read lock
+// for hold per Get (a read that calls out while locked) for duration d.
+// With writes, one writer takes the lock every d/10.
+func measureReaderStall(readers int, hold, d time.Duration, writes bool) readerStall {
+	var mu sync.RWMutex
+	var stop atomic.Bool
+	waits := make([][]time.Duration, readers)
+	var wg sync.WaitGroup
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				start := time.Now()
+				mu.RLock()
+				waits[r] = append(waits[r], time.Since(start))
+				time.Sleep(hold)
+				mu.RUnlock()
+			}
+		}()
+	}
+
+	var s readerStall
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		time.Sleep(d / 10)
+		if writes {
+			start := time.Now()
+			mu.Lock()
+			s.writerWait = max(s.writerWait, time.Since(start))
+			mu.Unlock()
+		}
+	}
+	stop.Store(true)
+	wg.Wait()
+
+	n := 0
+	for _, w := range waits {
+		n += len(w)
+	}
+	all := make([]time.Duration, 0, n)
+	for _, w := range waits {
+		all = append(all, w...)
+	}
+	slices.Sort(all)
+	s.reads = len(all)
+	if len(all) > 0 {
+		s.p99 = all[len(all)*99/100]
+		s.max = all[len(all)-1]
+	}
+	return s
+}
+
+func revealRWMutexInternals() {
+	fmt.Println("  What sync.RWMutex.Lock does while readers hold the lock:")
+	fmt.Println("    1. subtracts 2^30 from readerCount: every new RLock now blocks")
+	fmt.Println("    2. waits for the readers already inside to RUnlock")
+	fmt.Println("    3. runs the write, then wakes every blocked reader")
+	fmt.Println()
+
+	const hold, d = time.Millisecond, 200 * time.Millisecond
+	fmt.Printf("  %d readers holding RLock for %v per read, %v:\n", numWorkers, hold, d)
+	fmt.Printf("  %-12s %8s %10s %10s %12s\n", "", "reads", "p99 RLock", "max RLock", "writer wait")
+	for _, writes := range []bool{false, true} {
+		label := "no writer:"
+		if writes {
+			label = "10 writes:"
+		}
+		s := measureReaderStall(numWorkers, hold, d, writes)
+		fmt.Printf("  %-12s %8d %10v %10v %12v\n", label, s.reads,
+			s.p99.Round(time.Microsecond), s.max.Round(time.Microsecond), s.writerWait.Round(time.Microsecond))
+	}
+
+	fmt.Println("\n💡 Go's RWMutex doesn't let writers starve: once Lock is called, new")
+	fmt.Println("   readers queue behind the writer. The cost moves to the readers:")
+	fmt.Println("   each write stalls every request until the readers already inside")
+	fmt.Println("   finish. And a reader that calls RLock twice deadlocks if a writer")
+	fmt.Println("   arrives in between. Writers do starve with TryLock retry loops or")
+	fmt.Println("   reader-preferring locks, which never stop new readers.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Config store: 99% reads, 1% writes")
+	suite.Iterations = 3
+	for _, st := range stores {
+		s := st.New()
+		suite.Register(st.Name, func() {
+			runWorkload(s, numWorkers, opsPerWorker, writeEvery)
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		opsPerSec := numWorkers * opsPerWorker / r.Duration().Seconds()
+		fmt.Printf("  %-22s %8.1f M ops/sec\n", r.Name+":", opsPerSec/1e6)
+	}
+	return results
+}
+
+// sweepWriteRatios runs every store at each of writeRatios and returns the
+// best wall time per operation, indexed like stores.
+func sweepWriteRatios() map[int][]time.Duration {
+	fmt.Println("\n📏 Wall time per operation by write ratio:")
+	fmt.Printf("  %-22s", "")
+	for _, every := range writeRatios {
+		fmt.Printf(" %10s", fmt.Sprintf("1/%d", every))
+	}
+	fmt.Println()
+
+	sweep := make(map[int][]time.Duration, len(writeRatios))
+	for i, st := range stores {
+		fmt.Printf("  %-22s", st.Name+":")
+		for _, every := range writeRatios {
+			// Best of 3, so a stray GC cycle doesn't pick the winner
+			var best time.Duration
+			for run := 0; run < 3; run++ {
+				s := st.New()
+				m := bench.RunAndMeasure(func() {
+					runWorkload(s, numWorkers, opsPerWorker, every)
+				})
+				if run == 0 || m.Duration < best {
+					best = m.Duration
+				}
+			}
+			perOp := best / (numWorkers * opsPerWorker)
+			if sweep[every] == nil {
+				sweep[every] = make([]time.Duration, len(stores))
+			}
+			sweep[every][i] = perOp
+			fmt.Printf(" %10v", perOp)
+		}
+		fmt.Println()
+	}
+	return sweep
+}
+
+func explainReadPaths() {
+	fmt.Println("One Get on each store:")
+	fmt.Println()
+	fmt.Println("┌──────────────────────┬──────────────────────────────────────────────┐")
+	fmt.Println("│ sync.RWMutex         │ RLock: atomic add on one shared counter      │")
+	fmt.Println("│                      │ map lookup, RUnlock: atomic add again        │")
+	fmt.Println("├──────────────────────┼──────────────────────────────────────────────┤")
+	fmt.Println("│ 16 sharded mutexes   │ hash key, Lock one of 16 (CAS), lookup,      │")
+	fmt.Println("│                      │ Unlock: 1/16 of the traffic per lock         │")
+	fmt.Println("├──────────────────────┼──────────────────────────────────────────────┤")
+	fmt.Println("│ atomic.Pointer (COW) │ one plain load of the pointer, lookup:       │")
+	fmt.Println("│                      │ no shared writes at all                      │")
+	fmt.Println("└──────────────────────┴──────────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY THE ATOMIC POINTER WINS FOR READS:")
+	fmt.Println("  • RLock writes a shared counter: every core's reads bounce one line")
+	fmt.Println("  • Loading a pointer writes nothing, so reads scale with cores")
+	fmt.Println("  • A reader keeps a consistent snapshot for as long as it needs")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHAT COPY-ON-WRITE COSTS:")
+	fmt.Println("  • Every write copies the whole config: O(size) per Set")
+	fmt.Println("  • Frequent writes to a big config create garbage fast")
+	fmt.Println("  • Batch a reload into one swap instead of one per key")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. ⚛️  PUBLISH CONFIG THROUGH atomic.Pointer")
+	fmt.Println("   ✅ cfg := store.current.Load(); cfg.Values[key]")
+	fmt.Println("   Benefit: Lock-free reads that never wait for a reload")
+	fmt.Println()
+
+	fmt.Println("2. 📦 SWAP WHOLE RELOADS, NOT KEYS")
+	fmt.Println("   ✅ build the new Config from the file, then one Store(next)")
+	fmt.Println("   Benefit: One copy per reload; readers never see half of one")
+	fmt.Println()
+
+	fmt.Println("3. 🔀 SHARD WHEN WRITES ARE FREQUENT")
+	fmt.Println("   ✅ shards[hash(key)%16].mu.Lock()")
+	fmt.Println("   Benefit: Writers only block readers of the same shard")
+	fmt.Println()
+
+	fmt.Println("4. 🔒 KEEP RLOCK SECTIONS TINY")
+	fmt.Println("   ✅ copy the value out, RUnlock, then do the work")
+	fmt.Println("   Benefit: A waiting writer stalls readers for less time")
+}
+
+func calculateConfigCostImpact(results []bench.Result, sweep map[int][]time.Duration, pricing cost.PricingModel) float64 {
+	// A global config service: every request reads its settings, and an
+	// operator's reload writes them
+	queriesPerSecond := 10_000.0
+	queriesPerDay := queriesPerSecond * 24 * 3600
+	reloadEvery := writeRatios[len(writeRatios)-1]
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	// The workers keep every core busy for the whole wall time; that CPU
+	// time is spread over every operation
+	busyCores := float64(min(runtime.GOMAXPROCS(0), numWorkers))
+	atOnePercent := func(r bench.Result) float64 {
+		return r.NsPerOp * busyCores / (numWorkers * opsPerWorker)
+	}
+	atReload := func(i int) float64 {
+		return float64(sweep[reloadEvery][i].Nanoseconds()) * busyCores
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f config queries/second per instance\n", queriesPerSecond)
+	fmt.Printf("  • Writes: 1%% (the benchmark) or 1 per %d (a reload)\n", reloadEvery)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  %-22s %12s %14s\n", "", "1% writes", fmt.Sprintf("1/%d writes", reloadEvery))
+	fastest := 0
+	for i, r := range results {
+		fmt.Printf("  %-22s %9.1f ns %11.1f ns\n", r.Name+":", atOnePercent(r), atReload(i))
+		if atReload(i) < atReload(fastest) {
+			fastest = i
+		}
+	}
+	savedNs := atReload(0) - atReload(fastest)
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/query is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), queriesPerDay, costPerVCPUHour)
+	fmt.Printf("\n  At reload rates, %s → %s saves %.0f ns/query\n", results[0].Name, results[fastest].Name, savedNs)
+	fmt.Printf("  Monthly savings: $%.4f per instance\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f per instance\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: queriesPerDay, Unit: "queries/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • A reload never adds latency to the requests in flight")
+	fmt.Println("  • Readers see one consistent version, never a half-applied reload")
+	fmt.Println("  • No lock ordering to get wrong in the hot path")
+	return monthly
+}
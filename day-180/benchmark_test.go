@@ -0,0 +1,99 @@
+package main
+
+import "testing"
+
+// Global variable to prevent compiler optimizations
+var globalInt int
+
+// ========== TYPE ASSERTION BENCHMARKS ==========
+
+func Benchmark_RepeatedConcreteAssertion(b *testing.B) {
+	var h Handler = &FastHandler{Factor: 3}
+	b.ReportAllocs()
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		if f, ok := h.(*FastHandler); ok {
+			sum += f.Factor
+		}
+	}
+	globalInt = sum
+}
+
+func Benchmark_RepeatedInterfaceAssertion(b *testing.B) {
+	var h Handler = &FastHandler{Factor: 3}
+	b.ReportAllocs()
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		if f, ok := h.(Flusher); ok {
+			sum += f.Flush()
+		}
+	}
+	globalInt = sum
+}
+
+func Benchmark_CachedAssertion(b *testing.B) {
+	c := NewCachingHandler(&FastHandler{Factor: 3})
+	b.ReportAllocs()
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		if f, ok := c.Flusher(); ok {
+			sum += f.Flush()
+		}
+	}
+	globalInt = sum
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_CachedMatchesUncached(t *testing.T) {
+	var h Handler = &FastHandler{Factor: 7}
+	c := NewCachingHandler(h)
+
+	direct, ok := h.(*FastHandler)
+	if !ok {
+		t.Fatal("expected *FastHandler")
+	}
+	cached, ok := c.Fast()
+	if !ok {
+		t.Fatal("expected cached *FastHandler")
+	}
+	if direct != cached {
+		t.Errorf("expected same pointer, got %p and %p", direct, cached)
+	}
+
+	_, s1 := benchmarkRepeatedConcreteAssertion(h, 1000)
+	_, s2 := benchmarkRepeatedInterfaceAssertion(h, 1000)
+	_, s3 := benchmarkCachedAssertion(c, 1000)
+	if s1 != s3 || s2 != s3 {
+		t.Errorf("expected identical sums, got %d, %d, %d", s1, s2, s3)
+	}
+}
+
+func Test_CacheHandlesConcreteTypeChange(t *testing.T) {
+	fast := &FastHandler{Factor: 2}
+	slow := &SlowHandler{Offset: 5}
+	c := NewCachingHandler(fast)
+
+	// Switch to a different concrete type
+	if f, ok := c.Resolve(slow); ok || f != nil {
+		t.Fatalf("expected cache miss after switching to *SlowHandler, got %v", f)
+	}
+	if _, ok := c.Flusher(); ok {
+		t.Error("*SlowHandler does not implement Flusher, cache is stale")
+	}
+
+	// Switch back to the same type but a different instance
+	other := &FastHandler{Factor: 9}
+	f, ok := c.Resolve(other)
+	if !ok || f != other {
+		t.Fatalf("expected cache to return the new *FastHandler, got %v", f)
+	}
+	if got := f.Handle(2); got != 18 {
+		t.Errorf("expected 18, got %d", got)
+	}
+
+	// Resolving the current handler again keeps the cached value
+	if f2, _ := c.Resolve(other); f2 != other {
+		t.Error("expected cached value to be reused")
+	}
+}
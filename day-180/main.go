@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Handler is the interface middleware receives.
+type Handler interface {
+	Handle(n int) int
+}
+
+// Flusher is an optional capability, discovered via interface assertion
+// (the same pattern as http.ResponseWriter → http.Flusher).
+type Flusher interface {
+	Flush() int
+}
+
+// FastHandler is the concrete type the hot path wants to special-case.
+type FastHandler struct {
+	Factor int
+}
+
+func (h *FastHandler) Handle(n int) int { return n * h.Factor }
+func (h *FastHandler) Flush() int       { return h.Factor }
+
+// SlowHandler is any other implementation.
+type SlowHandler struct {
+	Offset int
+}
+
+func (h *SlowHandler) Handle(n int) int { return n + h.Offset }
+
+// CachingHandler performs the type assertions once, when the handler is
+// set, and serves the cached results afterwards.
+type CachingHandler struct {
+	current Handler
+	fast    *FastHandler // Non-nil when current is a *FastHandler
+	flusher Flusher      // Non-nil when current implements Flusher
+}
+
+func NewCachingHandler(h Handler) *CachingHandler {
+	c := &CachingHandler{}
+	c.Set(h)
+	return c
+}
+
+// Set replaces the wrapped handler and refreshes the cached assertions.
+func (c *CachingHandler) Set(h Handler) {
+	c.current = h
+	c.fast, _ = h.(*FastHandler)
+	c.flusher, _ = h.(Flusher)
+}
+
+// Resolve returns the cached *FastHandler for h. If h differs from the
+// cached handler (different concrete type or different pointer), the
+// cache is refreshed first, so a type change is never missed.
+func (c *CachingHandler) Resolve(h Handler) (*FastHandler, bool) {
+	if h != c.current {
+		c.Set(h)
+	}
+	return c.fast, c.fast != nil
+}
+
+// Fast returns the cached concrete handler without any assertion.
+func (c *CachingHandler) Fast() (*FastHandler, bool) {
+	return c.fast, c.fast != nil
+}
+
+// Flusher returns the cached optional capability.
+func (c *CachingHandler) Flusher() (Flusher, bool) {
+	return c.flusher, c.flusher != nil
+}
+
+func main() {
+	fmt.Println("🔬 DAY 180: Type Assertion Caching")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const iterations = 100_000_000
+	var h Handler = &FastHandler{Factor: 3}
+
+	fmt.Printf("📊 BENCHMARK: %d type assertions\n", iterations)
+	fmt.Println(strings.Repeat("-", 40))
+
+	concreteTime, s1 := benchmarkRepeatedConcreteAssertion(h, iterations)
+	fmt.Printf("1. h.(*FastHandler) every call:  %v (%.2f ns/op)\n", concreteTime, nsPerOp(concreteTime, iterations))
+
+	ifaceTime, s2 := benchmarkRepeatedInterfaceAssertion(h, iterations)
+	fmt.Printf("2. h.(Flusher) every call:       %v (%.2f ns/op)\n", ifaceTime, nsPerOp(ifaceTime, iterations))
+
+	cachedTime, s3 := benchmarkCachedAssertion(NewCachingHandler(h), iterations)
+	fmt.Printf("3. CachingHandler (cached):      %v (%.2f ns/op)\n", cachedTime, nsPerOp(cachedTime, iterations))
+
+	if s1 != s3 || s2 != s3 {
+		fmt.Println("❌ Results differ between cached and uncached paths!")
+	}
+
+	fmt.Println("\n🔧 WHAT AN ASSERTION COSTS")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeTypeAssertionCachingCost()
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateTypeAssertionCostImpact(ifaceTime, cachedTime, iterations)
+
+	fmt.Println("\n✅ DAY 180 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 181 - Rate-adaptive Compression")
+}
+
+func nsPerOp(d time.Duration, ops int) float64 {
+	return float64(d.Nanoseconds()) / float64(ops)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// benchmarkRepeatedConcreteAssertion asserts to a concrete type each time.
+// This compiles to an itab pointer comparison.
+func benchmarkRepeatedConcreteAssertion(h Handler, iterations int) (time.Duration, int) {
+	start := time.Now()
+	sum := 0
+	for i := 0; i < iterations; i++ {
+		if f, ok := h.(*FastHandler); ok {
+			sum += f.Factor
+		}
+	}
+	return time.Since(start), sum
+}
+
+// benchmarkRepeatedInterfaceAssertion asserts to another interface each
+// time, which needs an itab lookup in the runtime.
+func benchmarkRepeatedInterfaceAssertion(h Handler, iterations int) (time.Duration, int) {
+	start := time.Now()
+	sum := 0
+	for i := 0; i < iterations; i++ {
+		if f, ok := h.(Flusher); ok {
+			sum += f.Flush()
+		}
+	}
+	return time.Since(start), sum
+}
+
+// benchmarkCachedAssertion reads the pre-asserted values.
+func benchmarkCachedAssertion(c *CachingHandler, iterations int) (time.Duration, int) {
+	start := time.Now()
+	sum := 0
+	for i := 0; i < iterations; i++ {
+		if f, ok := c.Flusher(); ok {
+			sum += f.Flush()
+		}
+	}
+	return time.Since(start), sum
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeTypeAssertionCachingCost() {
+	fmt.Println("An interface value is two words: (itab pointer, data pointer).")
+	fmt.Println()
+	fmt.Println("  Assertion kind          | Runtime work")
+	fmt.Println("  ------------------------|---------------------------------------")
+	fmt.Println("  h.(*FastHandler)        | compare itab pointer to a constant")
+	fmt.Println("  h.(Flusher)             | find/construct itab for (type, Flusher)")
+	fmt.Println("                          | via per-call-site cache or global hash")
+	fmt.Println("  cached Flusher field    | load a pointer, no type check at all")
+	fmt.Println()
+	fmt.Println("💡 CachingHandler stores the itab-carrying interface once, so the")
+	fmt.Println("   hot path skips the runtime lookup entirely. Resolve() re-checks")
+	fmt.Println("   with a two-word comparison, so a swapped handler is never missed.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateTypeAssertionCostImpact(uncached, cached time.Duration, iterations int) {
+	// Middleware chain making 5 interface assertions per request
+	requestsPerSecond := 200_000.0
+	assertionsPerRequest := 5.0
+	awsCostPerVCPUHour := 0.0416
+
+	nsSaved := nsPerOp(uncached, iterations) - nsPerOp(cached, iterations)
+	if nsSaved < 0 {
+		nsSaved = 0
+	}
+
+	vCPUsSaved := nsSaved * assertionsPerRequest * requestsPerSecond / 1e9
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second\n", requestsPerSecond)
+	fmt.Printf("  • %.0f interface assertions per request (ResponseWriter upgrades, etc.)\n", assertionsPerRequest)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Saved per assertion: %.2f ns\n", nsSaved)
+	fmt.Printf("  vCPUs freed:         %.4f\n", vCPUsSaved)
+	fmt.Printf("  Monthly savings:     $%.4f\n", monthlySavings)
+	fmt.Printf("  Annual savings:      $%.4f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Concrete-type assertions are nearly free; cache interface-to-")
+	fmt.Println("  interface assertions only on the very hottest paths.")
+}
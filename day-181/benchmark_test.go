@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalFrame []byte
+
+var testBatch = generateLogBatch(rand.New(rand.NewSource(181)), 500)
+
+// ========== CODEC BENCHMARKS ==========
+
+func benchmarkCodec(b *testing.B, codec Codec) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(testBatch)))
+	var frame []byte
+	for i := 0; i < b.N; i++ {
+		frame = CompressWith(codec, frame[:0], testBatch)
+	}
+	globalFrame = frame
+	b.ReportMetric(float64(len(testBatch))/float64(len(frame)), "ratio")
+}
+
+func Benchmark_CodecNone(b *testing.B)   { benchmarkCodec(b, CodecNone) }
+func Benchmark_CodecSnappy(b *testing.B) { benchmarkCodec(b, CodecSnappy) }
+func Benchmark_CodecGzip(b *testing.B)   { benchmarkCodec(b, CodecGzip) }
+
+func Benchmark_AdaptiveCompressor(b *testing.B) {
+	c := NewAdaptiveCompressor()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(testBatch)))
+	var frame []byte
+	for i := 0; i < b.N; i++ {
+		frame = c.Compress(frame[:0], testBatch)
+	}
+	globalFrame = frame
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_EachCodecRoundTrips(t *testing.T) {
+	for _, codec := range []Codec{CodecNone, CodecSnappy, CodecGzip} {
+		frame := CompressWith(codec, nil, testBatch)
+		if Codec(frame[0]) != codec {
+			t.Errorf("%s: expected header byte %d, got %d", codec, codec, frame[0])
+		}
+
+		got, err := Decompress(frame)
+		if err != nil {
+			t.Fatalf("%s: %v", codec, err)
+		}
+		if !bytes.Equal(got, testBatch) {
+			t.Errorf("%s: round trip mismatch (%d vs %d bytes)", codec, len(got), len(testBatch))
+		}
+		t.Logf("%-6s %6d → %6d bytes", codec, len(testBatch), len(frame))
+	}
+}
+
+func Test_DecompressWithoutKnowingCodec(t *testing.T) {
+	// The compressor switches codec as pressure changes; the reader only
+	// sees a stream of frames and must decode all of them
+	loads := []float64{0.1, 0.7, 0.95, 0.3}
+	var frames [][]byte
+	seen := make(map[Codec]bool)
+
+	for _, load := range loads {
+		c := NewAdaptiveCompressor()
+		c.Sample = func() LoadSample {
+			return LoadSample{Goroutines: int(load * float64(c.MaxGoroutines))}
+		}
+		frame := c.Compress(nil, testBatch)
+		seen[Codec(frame[0])] = true
+		frames = append(frames, frame)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("expected all three codecs to be chosen, got %v", seen)
+	}
+
+	for i, frame := range frames {
+		got, err := Decompress(frame)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if !bytes.Equal(got, testBatch) {
+			t.Errorf("frame %d: payload mismatch", i)
+		}
+	}
+}
+
+func Test_ChooseRespectsThresholds(t *testing.T) {
+	c := NewAdaptiveCompressor()
+	tests := []struct {
+		sample LoadSample
+		want   Codec
+	}{
+		{LoadSample{Goroutines: 100}, CodecGzip},
+		{LoadSample{Goroutines: 600}, CodecSnappy},
+		{LoadSample{Goroutines: 950}, CodecNone},
+		{LoadSample{Goroutines: 10, GCCPUFraction: 0.20}, CodecSnappy}, // GC pressure alone
+		{LoadSample{Goroutines: 5000}, CodecNone},
+	}
+	for _, tt := range tests {
+		if got := c.Choose(c.Pressure(tt.sample)); got != tt.want {
+			t.Errorf("%+v: expected %s, got %s", tt.sample, tt.want, got)
+		}
+	}
+}
+
+func Test_DecompressRejectsBadFrames(t *testing.T) {
+	if _, err := Decompress(nil); err == nil {
+		t.Error("expected error for empty frame")
+	}
+	if _, err := Decompress([]byte{9, 1, 2}); err == nil {
+		t.Error("expected error for unknown codec")
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalLive [][]byte
+
+// ========== GC CONFIG BENCHMARKS ==========
+
+func benchmarkWorkload(b *testing.B, cfg gcConfig) {
+	restore := applyGCConfig(cfg)
+	defer restore()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalLive = runWorkload(32*MiB, 256*MiB, nil)
+	}
+}
+
+func Benchmark_GOGC100(b *testing.B) {
+	benchmarkWorkload(b, gcConfig{GCPercent: 100, MemoryLimit: math.MaxInt64})
+}
+
+func Benchmark_GOGCOffMemoryLimit(b *testing.B) {
+	benchmarkWorkload(b, gcConfig{GCPercent: -1, MemoryLimit: 128 * MiB})
+}
+
+func Benchmark_Ballast(b *testing.B) {
+	benchmarkWorkload(b, gcConfig{GCPercent: 100, MemoryLimit: math.MaxInt64, BallastBytes: 64 * MiB})
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_MemoryLimitBoundsHeapDuringBurst(t *testing.T) {
+	const limit = 64 * MiB
+
+	// A burst allocating 8x the limit: with GOGC=off and no limit the heap
+	// just grows (the OOM scenario); with GOMEMLIMIT the GC kicks in
+	unbounded := benchmarkGCConfig(gcConfig{GCPercent: -1, MemoryLimit: math.MaxInt64}, 16*MiB, 512*MiB)
+	bounded := benchmarkGCConfig(gcConfig{GCPercent: -1, MemoryLimit: limit}, 16*MiB, 512*MiB)
+
+	t.Logf("GOGC=off, no limit:  peak heap %d MB, %d GCs", unbounded.PeakHeap/MiB, unbounded.GCCycles)
+	t.Logf("GOGC=off, 64 MiB:    peak heap %d MB, %d GCs", bounded.PeakHeap/MiB, bounded.GCCycles)
+
+	if unbounded.PeakHeap < 4*limit {
+		t.Errorf("expected unbounded heap to grow past %d MB, peaked at %d MB", 4*limit/MiB, unbounded.PeakHeap/MiB)
+	}
+	// The limit is soft: HeapAlloc includes garbage not yet swept while the
+	// concurrent GC runs, so allow 50% slack (still far below unbounded)
+	if bounded.PeakHeap > limit*3/2 {
+		t.Errorf("expected heap to stay near %d MB limit, peaked at %d MB", limit/MiB, bounded.PeakHeap/MiB)
+	}
+	if bounded.GCCycles == 0 {
+		t.Error("expected the memory limit to trigger GC cycles")
+	}
+}
+
+func Test_MemoryLimitReducesGCCycles(t *testing.T) {
+	base := benchmarkGCConfig(gcConfig{GCPercent: 100, MemoryLimit: math.MaxInt64}, 16*MiB, 512*MiB)
+	tuned := benchmarkGCConfig(gcConfig{GCPercent: -1, MemoryLimit: 96 * MiB}, 16*MiB, 512*MiB)
+
+	t.Logf("GOGC=100:              %d GCs, peak %d MB", base.GCCycles, base.PeakHeap/MiB)
+	t.Logf("GOGC=off + 96 MiB:     %d GCs, peak %d MB", tuned.GCCycles, tuned.PeakHeap/MiB)
+
+	if tuned.GCCycles >= base.GCCycles {
+		t.Errorf("expected fewer GC cycles with GOGC=off+GOMEMLIMIT, got %d vs %d", tuned.GCCycles, base.GCCycles)
+	}
+}
+
+func Test_ApplyGCConfigRestoresSettings(t *testing.T) {
+	percent := debug.SetGCPercent(100)
+	limit := debug.SetMemoryLimit(-1)
+
+	restore := applyGCConfig(gcConfig{GCPercent: -1, MemoryLimit: 32 * MiB})
+	restore()
+
+	if got := debug.SetGCPercent(percent); got != 100 {
+		t.Errorf("expected GOGC restored to 100, got %d", got)
+	}
+	if got := debug.SetMemoryLimit(limit); got != limit {
+		t.Errorf("expected memory limit restored to %d, got %d", limit, got)
+	}
+}
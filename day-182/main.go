@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	MiB        = 1 << 20
+	objectSize = 8 << 10 // 8KB per live object
+)
+
+// gcConfig is one tuning strategy. The same settings can be applied with
+// environment variables (GOGC=off GOMEMLIMIT=500MiB); here they are set
+// in-process with runtime/debug so all three run in one binary.
+type gcConfig struct {
+	Name         string
+	GCPercent    int   // -1 disables proportional GC (GOGC=off)
+	MemoryLimit  int64 // math.MaxInt64 means no limit
+	BallastBytes int
+}
+
+// gcResult captures what the GC did while the workload ran.
+type gcResult struct {
+	Config    gcConfig
+	Elapsed   time.Duration
+	GCCycles  uint32
+	PauseP99  time.Duration
+	PauseMax  time.Duration
+	PeakHeap  uint64
+	Allocated uint64
+}
+
+func (r gcResult) MBPerSec() float64 {
+	return float64(r.Allocated) / MiB / r.Elapsed.Seconds()
+}
+
+// applyGCConfig installs cfg and returns a func that restores the previous
+// settings, so benchmarks never leak tuning into each other.
+func applyGCConfig(cfg gcConfig) (restore func()) {
+	oldPercent := debug.SetGCPercent(cfg.GCPercent)
+	oldLimit := debug.SetMemoryLimit(cfg.MemoryLimit)
+
+	var ballast []byte
+	if cfg.BallastBytes > 0 {
+		// Never touched, so the OS backs it lazily; the GC still counts it
+		// as live heap and scales the next target accordingly
+		ballast = make([]byte, cfg.BallastBytes)
+	}
+
+	return func() {
+		runtime.KeepAlive(ballast)
+		debug.SetGCPercent(oldPercent)
+		debug.SetMemoryLimit(oldLimit)
+		runtime.GC()
+	}
+}
+
+// runWorkload keeps liveBytes of 8KB objects reachable and replaces random
+// ones until churnBytes have been allocated. onAlloc, if set, is called
+// every 64 allocations (used to track peak heap).
+func runWorkload(liveBytes, churnBytes int, onAlloc func()) [][]byte {
+	rng := rand.New(rand.NewSource(182))
+	live := make([][]byte, liveBytes/objectSize)
+	for i := range live {
+		live[i] = make([]byte, objectSize)
+	}
+
+	for n := 0; n < churnBytes/objectSize; n++ {
+		obj := make([]byte, objectSize)
+		obj[0] = byte(n)
+		live[rng.Intn(len(live))] = obj
+		if onAlloc != nil && n%64 == 0 {
+			onAlloc()
+		}
+	}
+	return live
+}
+
+func main() {
+	fmt.Println("🔬 DAY 182: GOGC vs GOMEMLIMIT vs Ballast")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		liveBytes  = 200 * MiB
+		churnBytes = 4096 * MiB
+	)
+
+	configs := []gcConfig{
+		{Name: "GOGC=100 (default)", GCPercent: 100, MemoryLimit: math.MaxInt64},
+		{Name: "GOGC=off + GOMEMLIMIT=500MiB", GCPercent: -1, MemoryLimit: 500 * MiB},
+		{Name: "GOGC=100 + 256MB ballast", GCPercent: 100, MemoryLimit: math.MaxInt64, BallastBytes: 256 * MiB},
+	}
+
+	fmt.Printf("📊 BENCHMARK: %d MB live heap, %d MB allocated\n", liveBytes/MiB, churnBytes/MiB)
+	fmt.Println(strings.Repeat("-", 40))
+
+	var results []gcResult
+	for i, cfg := range configs {
+		r := benchmarkGCConfig(cfg, liveBytes, churnBytes)
+		results = append(results, r)
+		fmt.Printf("%d. %s\n", i+1, cfg.Name)
+		fmt.Printf("   %v, %.0f MB/s, %d GCs, pause p99 %v, peak heap %d MB\n",
+			r.Elapsed.Round(time.Millisecond), r.MBPerSec(), r.GCCycles, r.PauseP99, r.PeakHeap/MiB)
+	}
+
+	fmt.Println("\n🔧 STRATEGY TRADE-OFFS")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeGCTuningStrategies(results)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateGCTuningCostImpact(results[0], results[1])
+
+	fmt.Println("\n✅ DAY 182 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 183 - Map Deletion During Range")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkGCConfig(cfg gcConfig, liveBytes, churnBytes int) gcResult {
+	restore := applyGCConfig(cfg)
+	defer restore()
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	var peak uint64
+	var ms runtime.MemStats
+	trackPeak := func() {
+		// ReadMemStats is too slow to call per allocation; every 64 is enough
+		runtime.ReadMemStats(&ms)
+		if ms.HeapAlloc > peak {
+			peak = ms.HeapAlloc
+		}
+	}
+
+	start := time.Now()
+	live := runWorkload(liveBytes, churnBytes, trackPeak)
+	elapsed := time.Since(start)
+	runtime.KeepAlive(live)
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	pauses := gcPauses(&after, before.NumGC)
+	return gcResult{
+		Config:    cfg,
+		Elapsed:   elapsed,
+		GCCycles:  after.NumGC - before.NumGC,
+		PauseP99:  percentile(pauses, 0.99),
+		PauseMax:  percentile(pauses, 1.0),
+		PeakHeap:  peak,
+		Allocated: after.TotalAlloc - before.TotalAlloc,
+	}
+}
+
+// gcPauses returns the STW pauses for cycles after sinceGC. MemStats keeps
+// only the last 256, which is plenty for these runs.
+func gcPauses(m *runtime.MemStats, sinceGC uint32) []time.Duration {
+	n := m.NumGC - sinceGC
+	if n > uint32(len(m.PauseNs)) {
+		n = uint32(len(m.PauseNs))
+	}
+	pauses := make([]time.Duration, 0, n)
+	for i := uint32(0); i < n; i++ {
+		idx := (m.NumGC - 1 - i) % uint32(len(m.PauseNs))
+		pauses = append(pauses, time.Duration(m.PauseNs[idx]))
+	}
+	return pauses
+}
+
+func percentile(d []time.Duration, p float64) time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), d...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeGCTuningStrategies(results []gcResult) {
+	fmt.Println("  Strategy            | Next GC at            | Risk")
+	fmt.Println("  --------------------|-----------------------|------------------------------")
+	fmt.Println("  GOGC=100            | 2 × live heap         | many GCs when live heap is big")
+	fmt.Println("  GOGC=off+GOMEMLIMIT | limit (500 MiB)       | GC thrash if live nears limit")
+	fmt.Println("  Ballast + GOGC=100  | 2 × (live + ballast)  | no hard cap, hack since 1.19")
+	fmt.Println()
+
+	base := results[0]
+	for _, r := range results[1:] {
+		fmt.Printf("  %-30s %5.1fx fewer GCs, %5.2fx throughput\n",
+			r.Config.Name+":", float64(base.GCCycles)/math.Max(1, float64(r.GCCycles)),
+			r.MBPerSec()/base.MBPerSec())
+	}
+	fmt.Println()
+	fmt.Println("💡 GOMEMLIMIT gives the ballast's benefit (fewer GCs while memory is")
+	fmt.Println("   plentiful) plus a hard ceiling: as the heap nears the limit, the GC")
+	fmt.Println("   runs as often as needed instead of letting the process OOM.")
+	fmt.Println("   Leave 10-20% headroom below the container limit for non-heap memory.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateGCTuningCostImpact(baseline, tuned gcResult) {
+	// p99-sensitive API fleet whose heap is dominated by a 200 MB cache
+	instances := 20.0
+	vCPUsPerInstance := 2.0
+	gcCPUShare := 0.25 // Share of CPU the default GC config spends on this workload
+	awsCostPerVCPUHour := 0.0416
+
+	cycleRatio := float64(tuned.GCCycles) / math.Max(1, float64(baseline.GCCycles))
+	cpuSaved := gcCPUShare * (1 - cycleRatio)
+	vCPUsSaved := instances * vCPUsPerInstance * cpuSaved
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f instances × %.0f vCPUs, 200 MB live heap each\n", instances, vCPUsPerInstance)
+	fmt.Printf("  • GC uses %.0f%% of CPU with GOGC=100\n", gcCPUShare*100)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  GC cycles: %d → %d (%.0f%% of baseline)\n", baseline.GCCycles, tuned.GCCycles, cycleRatio*100)
+	fmt.Printf("  Pause p99: %v → %v\n", baseline.PauseP99, tuned.PauseP99)
+	fmt.Printf("  CPU freed: %.1f%% per instance → %.2f vCPUs fleet-wide\n", cpuSaved*100, vCPUsSaved)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Replace ballasts with GOMEMLIMIT: fewer GCs without doubling the")
+	fmt.Println("  heap, and a ceiling that keeps p99 stable instead of OOM-killing pods.")
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalDeleted int
+
+const benchSessions = 200_000
+
+// ========== SWEEP BENCHMARKS ==========
+
+func benchmarkSweepPattern(b *testing.B, sweep func(map[uint64]Session, int64) int) {
+	const now = 1_700_000_000
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		m := buildSessions(benchSessions, now)
+		b.StartTimer()
+		globalDeleted = sweep(m, now)
+	}
+}
+
+func Benchmark_SweepInPlace(b *testing.B) {
+	benchmarkSweepPattern(b, sweepInPlace)
+}
+
+func Benchmark_SweepCollectFirst(b *testing.B) {
+	benchmarkSweepPattern(b, sweepCollectFirst)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_SweepDeletesExactlyExpired(t *testing.T) {
+	const now = 1_700_000_000
+	sweeps := map[string]func(map[uint64]Session, int64) int{
+		"in-place":      sweepInPlace,
+		"collect-first": sweepCollectFirst,
+	}
+
+	for name, sweep := range sweeps {
+		original := buildSessions(10_000, now)
+		m := buildSessions(10_000, now)
+
+		if n := sweep(m, now); n != 5_000 {
+			t.Errorf("%s: expected 5000 deletions, got %d", name, n)
+		}
+		for id, s := range original {
+			_, present := m[id]
+			if s.ExpiresAt <= now && present {
+				t.Errorf("%s: expired session %d survived", name, id)
+			}
+			if s.ExpiresAt > now && !present {
+				t.Errorf("%s: live session %d was removed", name, id)
+			}
+		}
+	}
+}
+
+func Test_ChaosReadDeleteDuringRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(183))
+
+	for round := 0; round < 50; round++ {
+		m := make(map[int]int, 2_000)
+		for i := 0; i < 2_000; i++ {
+			m[i] = i * i
+		}
+		deleted := make(map[int]bool)
+		visited := make(map[int]bool)
+
+		for k, v := range m {
+			// The spec: each entry is produced at most once, and entries
+			// deleted before they are reached are not produced at all
+			if visited[k] {
+				t.Fatalf("round %d: key %d produced twice", round, k)
+			}
+			if deleted[k] {
+				t.Fatalf("round %d: deleted key %d was produced", round, k)
+			}
+			if v != k*k {
+				t.Fatalf("round %d: key %d has corrupt value %d", round, k, v)
+			}
+			visited[k] = true
+
+			// Random reads must see consistent values or a clean miss
+			probe := rng.Intn(2_000)
+			if pv, ok := m[probe]; ok && pv != probe*probe {
+				t.Fatalf("round %d: read of %d returned %d", round, probe, pv)
+			} else if ok && deleted[probe] {
+				t.Fatalf("round %d: read found deleted key %d", round, probe)
+			}
+
+			// Randomly delete the current entry and/or some other entry
+			switch rng.Intn(3) {
+			case 0:
+				delete(m, k)
+				deleted[k] = true
+			case 1:
+				other := rng.Intn(2_000)
+				delete(m, other)
+				deleted[other] = true
+			}
+		}
+
+		// Survivors are exactly the keys that were never deleted
+		for i := 0; i < 2_000; i++ {
+			if _, ok := m[i]; ok == deleted[i] {
+				t.Fatalf("round %d: key %d present=%v, deleted=%v", round, i, ok, deleted[i])
+			}
+		}
+		// Everything not deleted before being reached was visited
+		for i := 0; i < 2_000; i++ {
+			if !deleted[i] && !visited[i] {
+				t.Fatalf("round %d: surviving key %d was never produced", round, i)
+			}
+		}
+	}
+}
+
+func Test_InPlaceSweepDoesNotAllocate(t *testing.T) {
+	const now = 1_700_000_000
+	// AllocsPerRun would re-sweep an already-swept map, so count mallocs
+	// around a single sweep of a fresh map instead
+	m := buildSessions(10_000, now)
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	n := sweepInPlace(m, now)
+	runtime.ReadMemStats(&after)
+
+	if n != 5_000 {
+		t.Fatalf("expected 5000 deletions, got %d", n)
+	}
+	if mallocs := after.Mallocs - before.Mallocs; mallocs != 0 {
+		t.Errorf("expected 0 allocations, got %d", mallocs)
+	}
+}
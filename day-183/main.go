@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Session is a minimal session record keyed by session ID.
+type Session struct {
+	UserID    uint64
+	ExpiresAt int64 // Unix seconds
+}
+
+// buildSessions creates n sessions; every other one is already expired
+// at time now, so a sweep deletes exactly n/2 entries.
+func buildSessions(n int, now int64) map[uint64]Session {
+	m := make(map[uint64]Session, n)
+	for i := 0; i < n; i++ {
+		exp := now + 3600
+		if i%2 == 0 {
+			exp = now - 1
+		}
+		m[uint64(i)*2654435761] = Session{UserID: uint64(i), ExpiresAt: exp}
+	}
+	return m
+}
+
+// sweepInPlace deletes expired sessions while ranging over the map. The Go
+// spec allows this: a deleted entry that has not been reached yet will not
+// be produced, and the current entry can always be removed.
+func sweepInPlace(m map[uint64]Session, now int64) int {
+	deleted := 0
+	for id, s := range m {
+		if s.ExpiresAt <= now {
+			delete(m, id)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// sweepCollectFirst is the common defensive pattern: collect keys into a
+// slice, then delete in a second pass. Correct, but it allocates a slice
+// proportional to the number of deletions and walks twice.
+func sweepCollectFirst(m map[uint64]Session, now int64) int {
+	var expired []uint64
+	for id, s := range m {
+		if s.ExpiresAt <= now {
+			expired = append(expired, id)
+		}
+	}
+	for _, id := range expired {
+		delete(m, id)
+	}
+	return len(expired)
+}
+
+func main() {
+	fmt.Println("🔬 DAY 183: Map Deletion During Range")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		sessions = 200_000 // Half expire → 100,000 deletions per sweep
+		rounds   = 20
+	)
+	now := time.Now().Unix()
+
+	fmt.Printf("📊 BENCHMARK: %d sweeps deleting %d sessions each\n", rounds, sessions/2)
+	fmt.Println(strings.Repeat("-", 40))
+
+	inPlaceTime, inPlaceBytes := benchmarkSweep(sweepInPlace, sessions, rounds, now)
+	fmt.Printf("1. delete during range:  %v (%.1f ns/delete, %d B/sweep)\n",
+		inPlaceTime, nsPerDelete(inPlaceTime, sessions, rounds), inPlaceBytes)
+
+	collectTime, collectBytes := benchmarkSweep(sweepCollectFirst, sessions, rounds, now)
+	fmt.Printf("2. collect keys, delete: %v (%.1f ns/delete, %d B/sweep)\n",
+		collectTime, nsPerDelete(collectTime, sessions, rounds), collectBytes)
+
+	fmt.Println("\n🔧 WHY IN-PLACE DELETE IS SAFE AND CHEAPER")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeInPlaceDeleteCost(inPlaceBytes, collectBytes)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateInPlaceDeleteCostImpact(
+		nsPerDelete(inPlaceTime, sessions, rounds),
+		nsPerDelete(collectTime, sessions, rounds))
+
+	fmt.Println("\n✅ DAY 183 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 184 - Prefetching Cache")
+}
+
+func nsPerDelete(d time.Duration, sessions, rounds int) float64 {
+	return float64(d.Nanoseconds()) / float64(sessions/2*rounds)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// benchmarkSweep times only the sweep (map construction is excluded) and
+// returns the bytes the sweep itself allocated, averaged per round.
+func benchmarkSweep(sweep func(map[uint64]Session, int64) int, sessions, rounds int, now int64) (time.Duration, uint64) {
+	var total time.Duration
+	var allocated uint64
+	var before, after runtime.MemStats
+
+	for r := 0; r < rounds; r++ {
+		m := buildSessions(sessions, now)
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		if n := sweep(m, now); n != sessions/2 {
+			panic(fmt.Sprintf("expected %d deletions, got %d", sessions/2, n))
+		}
+		total += time.Since(start)
+		runtime.ReadMemStats(&after)
+		allocated += after.TotalAlloc - before.TotalAlloc
+	}
+	return total, allocated / uint64(rounds)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeInPlaceDeleteCost(inPlaceBytes, collectBytes uint64) {
+	fmt.Println("The Go spec (For statements with range clause):")
+	fmt.Println("  \"If a map entry that has not yet been reached is removed during")
+	fmt.Println("   iteration, the corresponding iteration value will not be produced.\"")
+	fmt.Println()
+	fmt.Println("  Pattern              | Passes | Temporary allocation")
+	fmt.Println("  ---------------------|--------|-----------------------------")
+	fmt.Printf("  delete during range  | 1      | %d B\n", inPlaceBytes)
+	fmt.Printf("  collect then delete  | 2      | %d B (key slice + growth)\n", collectBytes)
+	fmt.Println()
+	fmt.Println("💡 Collecting keys first is only needed when the decision depends on")
+	fmt.Println("   entries that the deletes would change, or when a shared map's lock")
+	fmt.Println("   must be released between finding and deleting.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateInPlaceDeleteCostImpact(inPlaceNs, collectNs float64) {
+	// Session store expiring 10k sessions/second
+	deletesPerSecond := 10_000.0
+	awsCostPerVCPUHour := 0.0416
+	instances := 50.0
+
+	nsSaved := collectNs - inPlaceNs
+	if nsSaved < 0 {
+		nsSaved = 0
+	}
+	vCPUsSaved := nsSaved * deletesPerSecond * instances / 1e9
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Session cleanup: %.0f deletions/second per instance\n", deletesPerSecond)
+	fmt.Printf("  • %.0f instances\n", instances)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Saved per deletion: %.1f ns\n", nsSaved)
+	fmt.Printf("  vCPUs freed:        %.4f\n", vCPUsSaved)
+	fmt.Printf("  Monthly savings:    $%.4f\n", monthlySavings)
+	fmt.Printf("  Annual savings:     $%.4f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  The CPU win is small; the real gain is no sweep-sized garbage")
+	fmt.Println("  spike every cleanup cycle. Delete in place unless you need a snapshot.")
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Global variable to prevent compiler optimizations
+var globalValue string
+
+// ========== PREFETCH BENCHMARKS ==========
+
+func benchmarkCacheWalk(b *testing.B, predict Predictor[int]) {
+	var loads atomic.Int64
+	loader := slowLoader(100*time.Microsecond, &loads)
+	pattern := localAccessPattern(rand.New(rand.NewSource(184)), 1_000, 1_000_000, 0.8)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := NewPrefetchingCache(loader, predict, 8, 64)
+		for _, k := range pattern {
+			globalValue, _ = c.Get(k)
+		}
+		c.Close()
+	}
+	b.ReportMetric(float64(loads.Load())/float64(b.N*len(pattern)), "loads/access")
+}
+
+func Benchmark_NoPrefetch(b *testing.B) {
+	benchmarkCacheWalk(b, nil)
+}
+
+func Benchmark_PrefetchDepth3(b *testing.B) {
+	benchmarkCacheWalk(b, nextKeys(3))
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_PrefetchReturnsCorrectValues(t *testing.T) {
+	var loads atomic.Int64
+	c := NewPrefetchingCache(slowLoader(time.Millisecond, &loads), nextKeys(3), 4, 16)
+	defer c.Close()
+
+	for k := 0; k < 50; k++ {
+		v, err := c.Get(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "recs-for-" + strconv.Itoa(k); v != want {
+			t.Fatalf("key %d: expected %q, got %q", k, want, v)
+		}
+	}
+	// Sequential walk: only the first key misses
+	if hr := c.HitRate(); hr < 0.95 {
+		t.Errorf("expected hit rate ≥95%% for a sequential walk, got %.1f%%", hr*100)
+	}
+}
+
+func Test_PrefetchGoroutinesAreBounded(t *testing.T) {
+	const workers = 4
+	var active, peak atomic.Int64
+	loader := func(k int) (int, error) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		active.Add(-1)
+		return k, nil
+	}
+
+	before := runtime.NumGoroutine()
+	// A predictor that asks for far more keys than the queue can hold
+	c := NewPrefetchingCache(loader, nextKeys(50), workers, 8)
+	if got := runtime.NumGoroutine() - before; got != workers {
+		t.Errorf("expected %d worker goroutines, got %d", workers, got)
+	}
+
+	for k := 0; k < 200; k += 60 {
+		if _, err := c.Get(k); err != nil {
+			t.Fatal(err)
+		}
+		// Callers never block on prefetch, so every Get spawns nothing
+		if got := runtime.NumGoroutine() - before; got > workers {
+			t.Fatalf("goroutines grew to %d, expected at most %d", got, workers)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// Synchronous misses run on the caller; only workers load in background.
+	// One caller + workers is the ceiling on concurrent loads
+	if p := peak.Load(); p > workers+1 {
+		t.Errorf("expected at most %d concurrent loads, saw %d", workers+1, p)
+	}
+	if c.dropped.Load() == 0 {
+		t.Error("expected predictions to be dropped when the queue is full")
+	}
+	c.Close()
+}
+
+func Test_NoGoroutineLeakAfterClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var loads atomic.Int64
+	c := NewPrefetchingCache(slowLoader(5*time.Millisecond, &loads), nextKeys(10), 8, 32)
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for k := 0; k < 20; k++ {
+				c.Get(g*1000 + k)
+			}
+		}(g)
+	}
+	wg.Wait()
+	c.Close()
+	c.Close() // Idempotent
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines leaked: %d before, %d after Close", before, after)
+	}
+
+	// Gets after Close still work, synchronously and without prefetch
+	if v, err := c.Get(99_999); err != nil || v != "recs-for-99999" {
+		t.Errorf("expected Get after Close to load synchronously, got %q, %v", v, err)
+	}
+}
+
+func Test_LoaderErrorIsRetried(t *testing.T) {
+	fail := true
+	c := NewPrefetchingCache(func(k int) (int, error) {
+		if fail {
+			return 0, errors.New("backend down")
+		}
+		return k * 2, nil
+	}, nil, 0, 0)
+	defer c.Close()
+
+	if _, err := c.Get(7); err == nil {
+		t.Fatal("expected loader error")
+	}
+	fail = false
+	if v, err := c.Get(7); err != nil || v != 14 {
+		t.Errorf("expected retry to succeed with 14, got %d, %v", v, err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// Global variable to prevent compiler optimizations
+var globalFlushed int
+
+// ========== BATCHER BENCHMARKS ==========
+
+func benchmarkAdd(b *testing.B, size int) {
+	flushed := 0
+	batcher := NewDeadlineBatcher(size, time.Second, func(batch []int) { flushed += len(batch) })
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		batcher.Add(i)
+	}
+	batcher.Close()
+	globalFlushed = flushed
+}
+
+func Benchmark_AddBatch10(b *testing.B)   { benchmarkAdd(b, 10) }
+func Benchmark_AddBatch100(b *testing.B)  { benchmarkAdd(b, 100) }
+func Benchmark_AddBatch1000(b *testing.B) { benchmarkAdd(b, 1000) }
+
+// ========== CORRECTNESS TESTS ==========
+
+// recorder collects flushed batches with their flush time.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]time.Time
+	flushed chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{flushed: make(chan struct{}, 100)}
+}
+
+func (r *recorder) flush(batch []time.Time) {
+	r.mu.Lock()
+	r.batches = append(r.batches, batch)
+	r.mu.Unlock()
+	r.flushed <- struct{}{}
+}
+
+func Test_NoMessageWaitsLongerThanDeadline(t *testing.T) {
+	const deadline = 20 * time.Millisecond
+	const slack = 15 * time.Millisecond // Timer and scheduler jitter
+
+	var mu sync.Mutex
+	var worst time.Duration
+	b := NewDeadlineBatcher(1000, deadline, func(batch []time.Time) {
+		now := time.Now()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, created := range batch {
+			if w := now.Sub(created); w > worst {
+				worst = w
+			}
+		}
+	})
+
+	// A trickle far too slow to ever fill a batch of 1000
+	for i := 0; i < 30; i++ {
+		b.Add(time.Now())
+		time.Sleep(3 * time.Millisecond)
+	}
+	time.Sleep(deadline + slack)
+
+	mu.Lock()
+	got := worst
+	mu.Unlock()
+	b.Close()
+
+	t.Logf("worst wait: %v (deadline %v)", got, deadline)
+	if got == 0 {
+		t.Fatal("expected the deadline to flush partial batches")
+	}
+	if got > deadline+slack {
+		t.Errorf("message waited %v, deadline is %v", got, deadline)
+	}
+}
+
+func Test_FullBatchFlushesImmediately(t *testing.T) {
+	r := newRecorder()
+	b := NewDeadlineBatcher(5, time.Hour, r.flush)
+	defer b.Close()
+
+	for i := 0; i < 5; i++ {
+		b.Add(time.Now())
+	}
+	select {
+	case <-r.flushed:
+	case <-time.After(time.Second):
+		t.Fatal("full batch was not flushed")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.batches) != 1 || len(r.batches[0]) != 5 {
+		t.Errorf("expected one batch of 5, got %d batches", len(r.batches))
+	}
+}
+
+func Test_CloseFlushesRemainingItems(t *testing.T) {
+	r := newRecorder()
+	b := NewDeadlineBatcher(100, time.Hour, r.flush)
+
+	for i := 0; i < 42; i++ {
+		b.Add(time.Now())
+	}
+	b.Close()
+
+	if len(r.batches) != 1 || len(r.batches[0]) != 42 {
+		t.Fatalf("expected Close to flush 42 items in one batch, got %v", len(r.batches))
+	}
+	if err := b.Add(time.Now()); err != ErrBatcherClosed {
+		t.Errorf("expected ErrBatcherClosed after Close, got %v", err)
+	}
+	b.Close() // Idempotent
+}
+
+func Test_ExplicitFlushAndOrdering(t *testing.T) {
+	var got []int
+	b := NewDeadlineBatcher(3, time.Hour, func(batch []int) { got = append(got, batch...) })
+
+	for i := 0; i < 7; i++ {
+		b.Add(i)
+		if i == 4 {
+			b.Flush() // Partial batch [3 4]
+		}
+	}
+	b.Close()
+
+	if len(got) != 7 {
+		t.Fatalf("expected 7 items, got %v", got)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("batches delivered out of order: %v", got)
+		}
+	}
+}
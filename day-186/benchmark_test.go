@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalBuf []byte
+
+var benchTree = buildGameState(10)
+
+// ========== SERIALIZATION BENCHMARKS ==========
+
+func Benchmark_ReflectSerialize(b *testing.B) {
+	buf := SerializeReflect(nil, benchTree)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		buf = SerializeReflect(buf[:0], benchTree)
+	}
+	globalBuf = buf
+}
+
+func Benchmark_VisitorSerialize(b *testing.B) {
+	buf := SerializeVisitor(nil, benchTree)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		buf = SerializeVisitor(buf[:0], benchTree)
+	}
+	globalBuf = buf
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_RoundTripAllNodeTypes(t *testing.T) {
+	trees := map[string]Visitable{
+		"item":  &Item{ID: 7, Kind: 3, Count: 65535},
+		"stat":  &Stat{Key: "crit_chance", Value: -0.125},
+		"empty": &Entity{ID: 1, Name: "", Health: -5, Children: []Visitable{}},
+		"mixed": &Entity{
+			ID: 2, Name: "player-ü", X: 1.5, Y: -2.25, Z: 1e6, Health: 100,
+			Children: []Visitable{
+				&Item{ID: 3, Kind: 255, Count: 1},
+				&Stat{Key: "hp", Value: 99.9},
+				&Entity{ID: 4, Name: "pet", Children: []Visitable{&Item{ID: 5}}},
+			},
+		},
+		"full": buildGameState(10),
+	}
+
+	for name, tree := range trees {
+		data := SerializeVisitor(nil, tree)
+		got, err := Deserialize(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, tree) {
+			t.Errorf("%s: round trip mismatch", name)
+		}
+	}
+}
+
+func Test_VisitorOutputIsDeterministic(t *testing.T) {
+	tree := buildGameState(10)
+	first := SerializeVisitor(nil, tree)
+	second := SerializeVisitor(nil, tree)
+	if !bytes.Equal(first, second) {
+		t.Fatal("expected byte-identical output for the same tree")
+	}
+
+	// Reusing a dirty buffer must not leak old bytes into the output
+	reused := SerializeVisitor(append([]byte(nil), first...)[:0], tree)
+	if !bytes.Equal(first, reused) {
+		t.Error("expected identical output when reusing the buffer")
+	}
+}
+
+func Test_ReflectAndVisitorAgree(t *testing.T) {
+	tree := buildGameState(10)
+	if !bytes.Equal(SerializeReflect(nil, tree), SerializeVisitor(nil, tree)) {
+		t.Error("expected reflect and visitor serializers to produce the same bytes")
+	}
+}
+
+func Test_DeserializeRejectsTruncatedInput(t *testing.T) {
+	data := SerializeVisitor(nil, buildGameState(4))
+	for _, n := range []int{0, 1, 5, len(data) / 2, len(data) - 1} {
+		if _, err := Deserialize(data[:n]); err == nil {
+			t.Errorf("expected error for %d of %d bytes", n, len(data))
+		}
+	}
+	if _, err := Deserialize(append(data, 0)); err == nil {
+		t.Error("expected error for trailing bytes")
+	}
+}
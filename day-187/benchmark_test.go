@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalSum int
+
+const benchN = 1_000_000
+
+// ========== BRANCH BENCHMARKS ==========
+
+func Benchmark_PredictableBranch(b *testing.B) {
+	conds, values := predictableConds(benchN), sequentialValues(benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalSum = branchKernel(conds, values)
+	}
+}
+
+func Benchmark_UnpredictableBranch(b *testing.B) {
+	conds, values := randomConds(rand.New(rand.NewSource(187)), benchN), sequentialValues(benchN)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalSum = branchKernel(conds, values)
+	}
+}
+
+func benchmarkFilter(b *testing.B, sorted bool) {
+	rng := rand.New(rand.NewSource(187))
+	scores := make([]uint8, benchN)
+	for i := range scores {
+		scores[i] = uint8(rng.Intn(256))
+	}
+	if sorted {
+		slices.Sort(scores)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h, l := filterRequests(scores, 128)
+		globalSum = h + l
+	}
+}
+
+func Benchmark_FilterUnsorted(b *testing.B) { benchmarkFilter(b, false) }
+func Benchmark_FilterSorted(b *testing.B)   { benchmarkFilter(b, true) }
+
+// ========== CORRECTNESS TESTS ==========
+
+// referenceKernel computes branchKernel's result without a data-dependent
+// branch order, to check both variants against.
+func referenceKernel(conds []bool, values []int) int {
+	sum := 0
+	for i := range conds {
+		if conds[i] {
+			sum += values[i] * 3
+			continue
+		}
+		sum ^= values[i]
+	}
+	return sum
+}
+
+func Test_OrderedAndRandomBranchesMatchReference(t *testing.T) {
+	const n = 10_000
+	values := sequentialValues(n)
+	for name, conds := range map[string][]bool{
+		"ordered": predictableConds(n),
+		"random":  randomConds(rand.New(rand.NewSource(1)), n),
+	} {
+		if got, want := branchKernel(conds, values), referenceKernel(conds, values); got != want {
+			t.Errorf("%s: expected %d, got %d", name, want, got)
+		}
+	}
+}
+
+func Test_SortedAndUnsortedFilterIdentical(t *testing.T) {
+	rng := rand.New(rand.NewSource(187))
+	for _, threshold := range []uint8{0, 1, 64, 128, 200, 255} {
+		scores := make([]uint8, 50_000)
+		for i := range scores {
+			scores[i] = uint8(rng.Intn(256))
+		}
+		sorted := slices.Clone(scores)
+		slices.Sort(sorted)
+
+		h1, l1 := filterRequests(scores, threshold)
+		h2, l2 := filterRequests(sorted, threshold)
+		// heavy is a sum and light an XOR: both are order-independent
+		if h1 != h2 || l1 != l2 {
+			t.Errorf("threshold %d: unsorted (%d, %d) != sorted (%d, %d)", threshold, h1, l1, h2, l2)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalCount int
+
+func randomKeys(n int, seed int64) []int {
+	rng := rand.New(rand.NewSource(seed))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = rng.Intn(1 << 31)
+	}
+	return keys
+}
+
+// ========== INSERT + SCAN BENCHMARKS ==========
+
+func benchmarkOrderedSet(b *testing.B, newSet func() OrderedSet) {
+	keys := randomKeys(20_000, 188)
+	starts := randomKeys(20_000, 189)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		runWorkload(newSet(), keys, starts, 1<<16)
+	}
+	globalCount = sinkFound
+}
+
+func Benchmark_InsertSorted(b *testing.B) {
+	benchmarkOrderedSet(b, func() OrderedSet { return &SortedSlice{} })
+}
+
+func Benchmark_AppendThenSort(b *testing.B) {
+	benchmarkOrderedSet(b, func() OrderedSet { return &AppendSorted{} })
+}
+
+func Benchmark_BTreeInsert(b *testing.B) {
+	benchmarkOrderedSet(b, func() OrderedSet { return &BTree{} })
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func allSets() map[string]OrderedSet {
+	return map[string]OrderedSet{
+		"sorted-slice":  &SortedSlice{},
+		"append-sorted": &AppendSorted{},
+		"btree":         &BTree{},
+	}
+}
+
+func Test_AllApproachesReturnIdenticalSortedResults(t *testing.T) {
+	keys := randomKeys(50_000, 1)
+	want := slices.Clone(keys)
+	slices.Sort(want)
+
+	for name, s := range allSets() {
+		for _, k := range keys {
+			s.Insert(k)
+		}
+		if got := collect(s); !slices.Equal(got, want) {
+			t.Errorf("%s: full scan differs from sorted input (len %d vs %d)", name, len(got), len(want))
+		}
+
+		// Range scans agree with a reference filter over the sorted keys
+		for _, lo := range randomKeys(200, 2) {
+			hi := lo + 1<<24
+			var got []int
+			s.Range(lo, hi, func(v int) bool { got = append(got, v); return true })
+			var expect []int
+			for _, v := range want {
+				if v >= lo && v < hi {
+					expect = append(expect, v)
+				}
+			}
+			if !slices.Equal(got, expect) {
+				t.Fatalf("%s: Range(%d, %d) = %v, want %v", name, lo, hi, got, expect)
+			}
+		}
+	}
+}
+
+func Test_OutOfOrderInsertions(t *testing.T) {
+	inputs := map[string][]int{
+		"descending": func() []int {
+			out := make([]int, 5_000)
+			for i := range out {
+				out[i] = len(out) - i
+			}
+			return out
+		}(),
+		"duplicates":  {5, 3, 5, 1, 5, 3, 3, 9, 1, 5, 5, 5, 0, 0},
+		"extremes":    {0, -1, 1, -100, 100, minInt, maxInt - 1, -7, 7},
+		"interleaved": randomKeys(3_000, 3),
+	}
+
+	for inputName, keys := range inputs {
+		want := slices.Clone(keys)
+		slices.Sort(want)
+		for name, s := range allSets() {
+			// Interleave reads with writes so AppendSorted re-sorts mid-stream
+			for i, k := range keys {
+				s.Insert(k)
+				if i%97 == 0 {
+					collect(s)
+				}
+			}
+			if got := collect(s); !slices.Equal(got, want) {
+				t.Errorf("%s/%s: got %v..., want %v...", inputName, name, head(got), head(want))
+			}
+			if s.Len() != len(keys) {
+				t.Errorf("%s/%s: Len() = %d, want %d", inputName, name, s.Len(), len(keys))
+			}
+		}
+	}
+}
+
+func Test_RangeStopsEarly(t *testing.T) {
+	for name, s := range allSets() {
+		for _, k := range randomKeys(10_000, 4) {
+			s.Insert(k)
+		}
+		n := 0
+		s.Range(minInt, maxInt, func(int) bool { n++; return n < 20 })
+		if n != 20 {
+			t.Errorf("%s: expected Range to stop after 20 values, visited %d", name, n)
+		}
+	}
+}
+
+func head(s []int) []int {
+	if len(s) > 8 {
+		return s[:8]
+	}
+	return s
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OrderedSet is what all three strategies implement: inserts in any order,
+// and in-order range scans over [lo, hi).
+type OrderedSet interface {
+	Insert(v int)
+	Range(lo, hi int, fn func(v int) bool)
+	Len() int
+}
+
+// ========== SORTED SLICE ==========
+
+// SortedSlice keeps data sorted on every insert: binary search for the
+// position, then shift the tail right by one. O(log n) compares + O(n) copy.
+type SortedSlice struct {
+	data []int
+}
+
+func (s *SortedSlice) Insert(v int) {
+	i := sort.SearchInts(s.data, v)
+	s.data = append(s.data, 0)
+	copy(s.data[i+1:], s.data[i:])
+	s.data[i] = v
+}
+
+func (s *SortedSlice) Range(lo, hi int, fn func(int) bool) {
+	for _, v := range s.data[sort.SearchInts(s.data, lo):] {
+		if v >= hi || !fn(v) {
+			return
+		}
+	}
+}
+
+func (s *SortedSlice) Len() int { return len(s.data) }
+
+// ========== APPEND THEN SORT ==========
+
+// AppendSorted appends in O(1) and sorts lazily on the first read after a
+// write. Cheap when writes and reads come in phases, terrible when they
+// interleave (every read after a write pays a full sort).
+type AppendSorted struct {
+	data  []int
+	dirty bool
+}
+
+func (s *AppendSorted) Insert(v int) {
+	s.data = append(s.data, v)
+	s.dirty = true
+}
+
+func (s *AppendSorted) Range(lo, hi int, fn func(int) bool) {
+	if s.dirty {
+		slices.Sort(s.data)
+		s.dirty = false
+	}
+	for _, v := range s.data[sort.SearchInts(s.data, lo):] {
+		if v >= hi || !fn(v) {
+			return
+		}
+	}
+}
+
+func (s *AppendSorted) Len() int { return len(s.data) }
+
+// ========== B-TREE ==========
+
+// btreeDegree is the minimum degree t: nodes hold t-1..2t-1 keys, so an
+// insert moves at most 2t-1 keys instead of half the collection.
+const btreeDegree = 32
+
+type btreeNode struct {
+	keys     []int
+	children []*btreeNode // nil for leaves
+}
+
+func (n *btreeNode) leaf() bool { return n.children == nil }
+
+// BTree is a minimal in-memory B-tree (CLRS, with preemptive splits) that
+// allows duplicate keys.
+type BTree struct {
+	root *btreeNode
+	size int
+}
+
+func (t *BTree) Insert(v int) {
+	if t.root == nil {
+		t.root = &btreeNode{keys: make([]int, 0, 2*btreeDegree-1)}
+	}
+	if len(t.root.keys) == 2*btreeDegree-1 {
+		old := t.root
+		t.root = &btreeNode{children: []*btreeNode{old}}
+		t.root.splitChild(0)
+	}
+	t.root.insertNonFull(v)
+	t.size++
+}
+
+// splitChild splits the full child i around its median key.
+func (n *btreeNode) splitChild(i int) {
+	child := n.children[i]
+	mid := btreeDegree - 1
+	median := child.keys[mid]
+
+	right := &btreeNode{keys: make([]int, 0, 2*btreeDegree-1)}
+	right.keys = append(right.keys, child.keys[mid+1:]...)
+	if !child.leaf() {
+		right.children = append(make([]*btreeNode, 0, 2*btreeDegree), child.children[mid+1:]...)
+		child.children = child.children[:mid+1]
+	}
+	child.keys = child.keys[:mid]
+
+	n.keys = slices.Insert(n.keys, i, median)
+	n.children = slices.Insert(n.children, i+1, right)
+}
+
+func (n *btreeNode) insertNonFull(v int) {
+	for !n.leaf() {
+		i := upperBound(n.keys, v)
+		if len(n.children[i].keys) == 2*btreeDegree-1 {
+			n.splitChild(i)
+			if v >= n.keys[i] {
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+	n.keys = slices.Insert(n.keys, upperBound(n.keys, v), v)
+}
+
+// upperBound returns the index of the first key > v, so equal keys are
+// inserted after existing ones.
+func upperBound(keys []int, v int) int {
+	return sort.Search(len(keys), func(i int) bool { return keys[i] > v })
+}
+
+func (t *BTree) Range(lo, hi int, fn func(int) bool) {
+	if t.root != nil {
+		t.root.ascend(lo, hi, fn)
+	}
+}
+
+// ascend visits keys in [lo, hi) in order; returns false to stop early.
+// Child i holds keys between keys[i-1] and keys[i] (inclusive, because of
+// duplicates), so children before the first key >= lo can be skipped.
+func (n *btreeNode) ascend(lo, hi int, fn func(int) bool) bool {
+	i := sort.SearchInts(n.keys, lo)
+	for ; i < len(n.keys); i++ {
+		if !n.leaf() && !n.children[i].ascend(lo, hi, fn) {
+			return false
+		}
+		if n.keys[i] >= hi || !fn(n.keys[i]) {
+			return false
+		}
+	}
+	if !n.leaf() {
+		return n.children[i].ascend(lo, hi, fn)
+	}
+	return true
+}
+
+func (t *BTree) Len() int { return t.size }
+
+// ========== WORKLOAD ==========
+
+// collect returns every value below maxInt in the set, in order.
+func collect(s OrderedSet) []int {
+	out := make([]int, 0, s.Len())
+	s.Range(minInt, maxInt, func(v int) bool {
+		out = append(out, v)
+		return true
+	})
+	return out
+}
+
+const (
+	minInt = -1 << 63
+	maxInt = 1<<63 - 1
+)
+
+func main() {
+	fmt.Println("🔬 DAY 188: Sorted Insert vs Append-then-Sort vs B-tree")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		inserts   = 100_000
+		scans     = 100_000
+		scanWidth = 1 << 16 // ~3 keys per scan with keys in [0, 2^31)
+	)
+	rng := rand.New(rand.NewSource(188))
+	keys := make([]int, inserts)
+	for i := range keys {
+		keys[i] = rng.Intn(1 << 31)
+	}
+	scanStarts := make([]int, scans)
+	for i := range scanStarts {
+		scanStarts[i] = rng.Intn(1 << 31)
+	}
+
+	fmt.Printf("📊 BENCHMARK: %d random inserts, then %d range scans\n", inserts, scans)
+	fmt.Println(strings.Repeat("-", 40))
+
+	sortedIns, sortedScan := benchmarkInsertSorted(keys, scanStarts, scanWidth)
+	fmt.Printf("1. Sorted slice:       insert %v, scan %v\n", sortedIns, sortedScan)
+
+	appendIns, appendScan := benchmarkAppendThenSort(keys, scanStarts, scanWidth)
+	fmt.Printf("2. Append then sort:   insert %v, scan %v\n", appendIns, appendScan)
+
+	btreeIns, btreeScan := benchmarkBTreeInsert(keys, scanStarts, scanWidth)
+	fmt.Printf("3. B-tree (t=%d):      insert %v, scan %v\n", btreeDegree, btreeIns, btreeScan)
+
+	fmt.Println("\n🔧 COPY OVERHEAD COST MODEL")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeSortedInsertCostModel(rng)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateSortedInsertCostImpact(rng)
+
+	fmt.Println("\n✅ DAY 188 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 189 - io.WriterTo Forwarding")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func runWorkload(s OrderedSet, keys, scanStarts []int, width int) (insert, scan time.Duration) {
+	start := time.Now()
+	for _, k := range keys {
+		s.Insert(k)
+	}
+	insert = time.Since(start)
+
+	found := 0
+	start = time.Now()
+	for _, lo := range scanStarts {
+		s.Range(lo, lo+width, func(int) bool {
+			found++
+			return true
+		})
+	}
+	scan = time.Since(start)
+	sinkFound = found
+	return insert, scan
+}
+
+var sinkFound int
+
+func benchmarkInsertSorted(keys, scanStarts []int, width int) (time.Duration, time.Duration) {
+	return runWorkload(&SortedSlice{}, keys, scanStarts, width)
+}
+
+func benchmarkAppendThenSort(keys, scanStarts []int, width int) (time.Duration, time.Duration) {
+	return runWorkload(&AppendSorted{}, keys, scanStarts, width)
+}
+
+func benchmarkBTreeInsert(keys, scanStarts []int, width int) (time.Duration, time.Duration) {
+	return runWorkload(&BTree{}, keys, scanStarts, width)
+}
+
+// ========== ANALYSIS ==========
+
+// analyzeSortedInsertCostModel measures one random insert into a sorted
+// slice of size n: on average n/2 elements (8 bytes each) are shifted.
+func analyzeSortedInsertCostModel(rng *rand.Rand) {
+	fmt.Println("  Size      | Avg bytes moved | Sorted slice | B-tree")
+	fmt.Println("  ----------|-----------------|--------------|--------")
+	for _, n := range []int{1_000, 10_000, 100_000, 1_000_000} {
+		base := make([]int, n)
+		for i := range base {
+			base[i] = rng.Intn(1 << 31)
+		}
+		slices.Sort(base)
+		probes := make([]int, 200)
+		for i := range probes {
+			probes[i] = rng.Intn(1 << 31)
+		}
+
+		s := &SortedSlice{data: slices.Clone(base)}
+		start := time.Now()
+		for _, p := range probes {
+			s.Insert(p)
+		}
+		sliceNs := float64(time.Since(start).Nanoseconds()) / float64(len(probes))
+
+		t := &BTree{}
+		for _, v := range base {
+			t.Insert(v)
+		}
+		start = time.Now()
+		for _, p := range probes {
+			t.Insert(p)
+		}
+		treeNs := float64(time.Since(start).Nanoseconds()) / float64(len(probes))
+
+		fmt.Printf("  %9d | %15s | %9.0f ns | %4.0f ns\n", n, formatBytes(n/2*8), sliceNs, treeNs)
+	}
+	fmt.Println()
+	fmt.Println("💡 Sorted-slice inserts are O(n) memmoves: fine up to ~10k elements")
+	fmt.Printf("   (memmove is fast), painful beyond. A B-tree moves at most %d keys.\n", 2*btreeDegree-1)
+	fmt.Println("   Append-then-sort wins only when writes and reads come in phases.")
+}
+
+func formatBytes(b int) string {
+	switch {
+	case b >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(b)/(1<<20))
+	case b >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(b)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", b)
+}
+
+// ========== COST ANALYSIS ==========
+
+// calculateSortedInsertCostImpact models a priority inbox where new messages
+// and "top N" reads interleave, which is the worst case for append-then-sort.
+func calculateSortedInsertCostImpact(rng *rand.Rand) {
+	inboxSize := 50_000
+	opsPerSecond := 20_000.0 // Inserts and reads, 50/50, across all inboxes
+	awsCostPerVCPUHour := 0.0416
+
+	measure := func(s OrderedSet) float64 {
+		for i := 0; i < inboxSize; i++ {
+			s.Insert(rng.Intn(1 << 31))
+		}
+		const ops = 2_000
+		start := time.Now()
+		for i := 0; i < ops; i++ {
+			if i%2 == 0 {
+				s.Insert(rng.Intn(1 << 31))
+				continue
+			}
+			n := 0
+			s.Range(minInt, maxInt, func(int) bool { n++; return n < 20 }) // Top 20
+		}
+		return float64(time.Since(start).Nanoseconds()) / ops
+	}
+
+	results := []struct {
+		name string
+		ns   float64
+	}{
+		{"Sorted slice", measure(&SortedSlice{})},
+		{"Append then sort", measure(&AppendSorted{})},
+		{"B-tree", measure(&BTree{})},
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Priority inbox with %d messages\n", inboxSize)
+	fmt.Printf("  • %.0f ops/second, inserts and top-20 reads interleaved\n", opsPerSecond)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	worst, best := 0.0, results[0].ns
+	for _, r := range results {
+		vCPUs := r.ns * opsPerSecond / 1e9
+		fmt.Printf("  %-17s %10.0f ns/op → %6.3f vCPUs → $%8.2f/month\n",
+			r.name+":", r.ns, vCPUs, vCPUs*awsCostPerVCPUHour*24*30)
+		worst = max(worst, r.ns)
+		best = min(best, r.ns)
+	}
+	monthlySavings := (worst - best) * opsPerSecond / 1e9 * awsCostPerVCPUHour * 24 * 30
+	fmt.Printf("  Monthly savings (best vs worst): $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:                  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Interleaved writes and reads make append-then-sort re-sort on")
+	fmt.Println("  every read. Keep it sorted; reach for a B-tree once n > ~10k.")
+}
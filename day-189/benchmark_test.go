@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalN int64
+
+// ========== FORWARDING BENCHMARKS ==========
+
+func benchmarkForward(b *testing.B, forward forwardFunc) {
+	body := bytes.Repeat([]byte("x"), 1<<20)
+	upstream, err := startUpstream()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer upstream.Close()
+	proxy, err := startProxy(upstream.Addr().String(), forward)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer proxy.Close()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	if err := sendRequests(proxy.Addr().String(), body, b.N); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func Benchmark_CopyBody(b *testing.B)        { benchmarkForward(b, copyBody) }
+func Benchmark_BufferedForward(b *testing.B) { benchmarkForward(b, bufferedForward) }
+func Benchmark_DirectForward(b *testing.B)   { benchmarkForward(b, directForward) }
+
+// ========== CORRECTNESS TESTS ==========
+
+// tcpPair returns two ends of a loopback TCP connection.
+func tcpPair(t *testing.T) (net.Conn, net.Conn) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		c, _ := ln.Accept()
+		accepted <- c
+	}()
+	c, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, <-accepted
+}
+
+func Test_ForwardingIsByteIdentical(t *testing.T) {
+	body := make([]byte, 3<<20+17) // Not a multiple of any buffer size
+	rand.New(rand.NewSource(189)).Read(body)
+	trailer := []byte("next-request")
+
+	for name, forward := range map[string]forwardFunc{
+		"copy":     copyBody,
+		"buffered": bufferedForward,
+		"direct":   directForward,
+	} {
+		srcClient, srcServer := tcpPair(t)
+		dstClient, dstServer := tcpPair(t)
+
+		// Body is followed by more data: forwarding must stop at exactly n
+		go func() {
+			srcClient.Write(body)
+			srcClient.Write(trailer)
+		}()
+		received := make(chan []byte)
+		go func() {
+			got, _ := io.ReadAll(dstServer)
+			received <- got
+		}()
+
+		n, err := forward(&countingConn{Conn: dstClient}, &countingConn{Conn: srcServer}, int64(len(body)))
+		if err != nil || n != int64(len(body)) {
+			t.Fatalf("%s: forwarded %d bytes, err %v", name, n, err)
+		}
+		dstClient.Close()
+		if got := <-received; !bytes.Equal(got, body) {
+			t.Errorf("%s: forwarded body differs (%d vs %d bytes)", name, len(got), len(body))
+		}
+
+		rest := make([]byte, len(trailer))
+		if _, err := io.ReadFull(srcServer, rest); err != nil || !bytes.Equal(rest, trailer) {
+			t.Errorf("%s: forwarding consumed bytes past the body: %q, %v", name, rest, err)
+		}
+		srcClient.Close()
+		srcServer.Close()
+		dstServer.Close()
+	}
+}
+
+// writerToSource implements io.WriterTo and records which path was used.
+type writerToSource struct {
+	data         []byte
+	readCalls    int
+	writeToCalls int
+}
+
+func (s *writerToSource) Read(p []byte) (int, error) {
+	s.readCalls++
+	if len(s.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, s.data)
+	s.data = s.data[n:]
+	return n, nil
+}
+
+func (s *writerToSource) WriteTo(w io.Writer) (int64, error) {
+	s.writeToCalls++
+	n, err := w.Write(s.data)
+	s.data = s.data[n:]
+	return int64(n), err
+}
+
+func (s *writerToSource) Len() int { return len(s.data) }
+
+func Test_DirectForwardUsesWriterTo(t *testing.T) {
+	body := bytes.Repeat([]byte("abc"), 100_000)
+	src := &writerToSource{data: bytes.Clone(body)}
+	var dst bytes.Buffer
+
+	n, err := directForward(&dst, src, int64(len(body)))
+	if err != nil || n != int64(len(body)) {
+		t.Fatalf("forwarded %d bytes, err %v", n, err)
+	}
+	if src.writeToCalls != 1 || src.readCalls != 0 {
+		t.Errorf("expected WriteTo path (1 WriteTo, 0 Read), got %d WriteTo, %d Read", src.writeToCalls, src.readCalls)
+	}
+	if !bytes.Equal(dst.Bytes(), body) {
+		t.Error("WriteTo path produced different bytes")
+	}
+
+	// The generic strategies never see WriteTo and fall back to Read
+	src = &writerToSource{data: bytes.Clone(body)}
+	dst.Reset()
+	if _, err := bufferedForward(&dst, src, int64(len(body))); err != nil {
+		t.Fatal(err)
+	}
+	if src.writeToCalls != 0 || src.readCalls == 0 {
+		t.Errorf("expected buffered path to Read, got %d WriteTo, %d Read", src.writeToCalls, src.readCalls)
+	}
+}
+
+func Test_DirectForwardSkipsWriterToWhenSourceIsLonger(t *testing.T) {
+	// WriteTo would drain the whole source; with extra bytes after the
+	// body, directForward must fall back to a length-limited copy
+	src := &writerToSource{data: []byte("bodyEXTRA")}
+	var dst bytes.Buffer
+	if _, err := directForward(&dst, src, 4); err != nil {
+		t.Fatal(err)
+	}
+	if dst.String() != "body" || src.writeToCalls != 0 {
+		t.Errorf("expected only %q via Read, got %q (%d WriteTo calls)", "body", dst.String(), src.writeToCalls)
+	}
+}
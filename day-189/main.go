@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// forwardFunc copies exactly n body bytes from src to dst.
+type forwardFunc func(dst io.Writer, src io.Reader, n int64) (int64, error)
+
+// ========== FORWARDING STRATEGIES ==========
+
+// copyBody is what a proxy usually ends up doing: io.Copy between a reader
+// and writer that middleware has wrapped (metrics, logging, limits). The
+// wrappers hide io.WriterTo/io.ReaderFrom, so io.Copy falls back to a
+// freshly allocated 32KB buffer and a user-space read/write loop.
+func copyBody(dst io.Writer, src io.Reader, n int64) (int64, error) {
+	return io.Copy(dst, io.LimitReader(src, n))
+}
+
+// bufferedForward keeps the generic loop but reuses pooled buffers, so the
+// 32KB allocation per request disappears.
+func bufferedForward(dst io.Writer, src io.Reader, n int64) (int64, error) {
+	bp := copyBufPool.Get().(*[]byte)
+	defer copyBufPool.Put(bp)
+	return io.CopyBuffer(onlyWriter{dst}, onlyReader{io.LimitReader(src, n)}, *bp)
+}
+
+var copyBufPool = sync.Pool{New: func() any {
+	b := make([]byte, 32<<10)
+	return &b
+}}
+
+// directForward unwraps to the underlying connection types and hands the
+// copy to them. If the source implements io.WriterTo it writes itself to
+// dst; otherwise a *net.TCPConn destination's ReadFrom uses splice(2) on
+// Linux, moving bytes socket→pipe→socket without entering user space.
+func directForward(dst io.Writer, src io.Reader, n int64) (int64, error) {
+	dst, src = unwrapWriter(dst), unwrapReader(src)
+	if wt, ok := src.(io.WriterTo); ok {
+		// WriteTo has no length limit: only safe when src ends at the body
+		if sized, ok := src.(interface{ Len() int }); ok && int64(sized.Len()) == n {
+			return wt.WriteTo(dst)
+		}
+	}
+	if rf, ok := dst.(io.ReaderFrom); ok {
+		return rf.ReadFrom(&io.LimitedReader{R: src, N: n})
+	}
+	return bufferedForward(dst, src, n)
+}
+
+// onlyReader/onlyWriter strip every interface except Read/Write.
+type onlyReader struct{ io.Reader }
+type onlyWriter struct{ io.Writer }
+
+// countingConn is a typical middleware wrapper: it records bytes for
+// metrics and, as a side effect, hides the fast-path interfaces.
+type countingConn struct {
+	net.Conn
+	read, written int64
+}
+
+func (c *countingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.read += int64(n)
+	return n, err
+}
+
+func (c *countingConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+func (c *countingConn) Unwrap() net.Conn { return c.Conn }
+
+func unwrapReader(r io.Reader) io.Reader {
+	if u, ok := r.(interface{ Unwrap() net.Conn }); ok {
+		return u.Unwrap()
+	}
+	return r
+}
+
+func unwrapWriter(w io.Writer) io.Writer {
+	if u, ok := w.(interface{ Unwrap() net.Conn }); ok {
+		return u.Unwrap()
+	}
+	return w
+}
+
+// ========== LOCAL PROXY ==========
+
+// Wire protocol: 8-byte big-endian body length, body, then a 1-byte ack
+// from upstream that the proxy relays back to the client.
+
+func startUpstream() (net.Listener, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				var hdr [8]byte
+				for {
+					if _, err := io.ReadFull(conn, hdr[:]); err != nil {
+						return
+					}
+					n := int64(binary.BigEndian.Uint64(hdr[:]))
+					if _, err := io.CopyN(io.Discard, conn, n); err != nil {
+						return
+					}
+					if _, err := conn.Write([]byte{1}); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln, nil
+}
+
+func startProxy(upstreamAddr string, forward forwardFunc) (net.Listener, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			client, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go proxyConn(client, upstreamAddr, forward)
+		}
+	}()
+	return ln, nil
+}
+
+func proxyConn(client net.Conn, upstreamAddr string, forward forwardFunc) {
+	defer client.Close()
+	upstream, err := net.Dial("tcp", upstreamAddr)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+
+	in := &countingConn{Conn: client}
+	out := &countingConn{Conn: upstream}
+	var hdr [8]byte
+	var ack [1]byte
+	for {
+		if _, err := io.ReadFull(client, hdr[:]); err != nil {
+			return
+		}
+		if _, err := upstream.Write(hdr[:]); err != nil {
+			return
+		}
+		n := int64(binary.BigEndian.Uint64(hdr[:]))
+		if _, err := forward(out, in, n); err != nil {
+			return
+		}
+		if _, err := io.ReadFull(upstream, ack[:]); err != nil {
+			return
+		}
+		if _, err := client.Write(ack[:]); err != nil {
+			return
+		}
+	}
+}
+
+// sendRequests pushes requests bodies through the proxy, one at a time.
+func sendRequests(proxyAddr string, body []byte, requests int) error {
+	conn, err := net.Dial("tcp", proxyAddr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var hdr [8]byte
+	binary.BigEndian.PutUint64(hdr[:], uint64(len(body)))
+	var ack [1]byte
+	for i := 0; i < requests; i++ {
+		if _, err := conn.Write(hdr[:]); err != nil {
+			return err
+		}
+		if _, err := conn.Write(body); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(conn, ack[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ioCounters reads this process's syscall and user-space byte counters.
+// splice(2) moves data without being counted as read/write.
+type ioCounters struct {
+	Rchar, Wchar, Syscr, Syscw int64
+}
+
+func readIOCounters() (ioCounters, bool) {
+	data, err := os.ReadFile("/proc/self/io")
+	if err != nil {
+		return ioCounters{}, false
+	}
+	var c ioCounters
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		var key string
+		var val int64
+		if _, err := fmt.Sscanf(sc.Text(), "%s %d", &key, &val); err != nil {
+			continue
+		}
+		switch key {
+		case "rchar:":
+			c.Rchar = val
+		case "wchar:":
+			c.Wchar = val
+		case "syscr:":
+			c.Syscr = val
+		case "syscw:":
+			c.Syscw = val
+		}
+	}
+	return c, true
+}
+
+// forwardResult is one strategy's measurement.
+type forwardResult struct {
+	Name      string
+	Elapsed   time.Duration
+	Requests  int
+	BodyBytes int
+	IO        ioCounters // Deltas over the run, whole process
+	HaveIO    bool
+}
+
+func (r forwardResult) GbitPerSec() float64 {
+	return float64(r.Requests*r.BodyBytes) * 8 / 1e9 / r.Elapsed.Seconds()
+}
+
+func main() {
+	fmt.Println("🔬 DAY 189: Zero-copy Body Forwarding with io.WriterTo")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		bodySize = 1 << 20
+		requests = 1_000
+	)
+	body := bytes.Repeat([]byte("0123456789abcdef"), bodySize/16)
+
+	fmt.Printf("📊 BENCHMARK: %d × %d KB bodies through a local TCP proxy\n", requests, bodySize/1024)
+	fmt.Println(strings.Repeat("-", 40))
+
+	var results []forwardResult
+	for i, run := range []func([]byte, int) (forwardResult, error){
+		benchmarkCopyBody, benchmarkBufferedForward, benchmarkDirectForward,
+	} {
+		r, err := run(body, requests)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		results = append(results, r)
+		fmt.Printf("%d. %-18s %v (%.2f Gbit/s)\n", i+1, r.Name+":", r.Elapsed.Round(time.Millisecond), r.GbitPerSec())
+	}
+
+	fmt.Println("\n🔧 SYSTEM CALLS PER REQUEST")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeProxyForwardingCost(results)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateProxyForwardingCostImpact(results[0], results[2])
+
+	fmt.Println("\n✅ DAY 189 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 190 - Shifts vs Division")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func runProxyBenchmark(name string, forward forwardFunc, body []byte, requests int) (forwardResult, error) {
+	upstream, err := startUpstream()
+	if err != nil {
+		return forwardResult{}, err
+	}
+	defer upstream.Close()
+	proxy, err := startProxy(upstream.Addr().String(), forward)
+	if err != nil {
+		return forwardResult{}, err
+	}
+	defer proxy.Close()
+
+	// Warm up connections so setup is not measured
+	if err := sendRequests(proxy.Addr().String(), body[:1], 1); err != nil {
+		return forwardResult{}, err
+	}
+
+	before, haveIO := readIOCounters()
+	start := time.Now()
+	err = sendRequests(proxy.Addr().String(), body, requests)
+	elapsed := time.Since(start)
+	after, _ := readIOCounters()
+
+	return forwardResult{
+		Name:      name,
+		Elapsed:   elapsed,
+		Requests:  requests,
+		BodyBytes: len(body),
+		HaveIO:    haveIO,
+		IO: ioCounters{
+			Rchar: after.Rchar - before.Rchar,
+			Wchar: after.Wchar - before.Wchar,
+			Syscr: after.Syscr - before.Syscr,
+			Syscw: after.Syscw - before.Syscw,
+		},
+	}, err
+}
+
+func benchmarkCopyBody(body []byte, requests int) (forwardResult, error) {
+	return runProxyBenchmark("io.Copy", copyBody, body, requests)
+}
+
+func benchmarkBufferedForward(body []byte, requests int) (forwardResult, error) {
+	return runProxyBenchmark("Pooled buffer", bufferedForward, body, requests)
+}
+
+func benchmarkDirectForward(body []byte, requests int) (forwardResult, error) {
+	return runProxyBenchmark("Direct (splice)", directForward, body, requests)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeProxyForwardingCost(results []forwardResult) {
+	if !results[0].HaveIO {
+		fmt.Println("  /proc/self/io not available on this OS")
+		return
+	}
+	fmt.Println("Whole-process counters (client + proxy + upstream share the process;")
+	fmt.Println("client and upstream work is identical across strategies):")
+	fmt.Println()
+	fmt.Println("  Strategy         | read() | write() | Bytes via user space")
+	fmt.Println("  -----------------|--------|---------|---------------------")
+	for _, r := range results {
+		per := func(v int64) float64 { return float64(v) / float64(r.Requests) }
+		fmt.Printf("  %-16s | %6.0f | %7.0f | %8.2f MB\n",
+			r.Name, per(r.IO.Syscr), per(r.IO.Syscw), per(r.IO.Rchar+r.IO.Wchar)/(1<<20))
+	}
+	fmt.Println()
+	fmt.Println("💡 The generic loop reads into a user buffer and writes it back out:")
+	fmt.Println("   two syscalls and two copies per 32KB. splice(2) moves pages")
+	fmt.Println("   socket→pipe→socket inside the kernel and never counts as read/write.")
+	fmt.Println("   Middleware wrappers that hide ReaderFrom/WriterTo silently disable it.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateProxyForwardingCostImpact(generic, direct forwardResult) {
+	// Reverse proxy tier forwarding 10 Gbps of request bodies
+	targetGbps := 10.0
+	awsCostPerVCPUHour := 0.0416
+
+	// The benchmark is CPU-bound on one box, so Gbit/s per busy core is the
+	// throughput ceiling of each strategy
+	vCPUsGeneric := targetGbps / generic.GbitPerSec()
+	vCPUsDirect := targetGbps / direct.GbitPerSec()
+	monthlySavings := (vCPUsGeneric - vCPUsDirect) * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Reverse proxy forwarding %.0f Gbps of bodies\n", targetGbps)
+	fmt.Println("  • Measured throughput ≈ per-core capacity (client/upstream included)")
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  io.Copy (wrapped): %.2f Gbit/s → %.1f vCPUs\n", generic.GbitPerSec(), vCPUsGeneric)
+	fmt.Printf("  Direct (splice):   %.2f Gbit/s → %.1f vCPUs\n", direct.GbitPerSec(), vCPUsDirect)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Let the kernel move the bytes: pass the real *net.TCPConn (or an")
+	fmt.Println("  Unwrap method) to io.Copy instead of an opaque wrapper.")
+}
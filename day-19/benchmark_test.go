@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+)
+
+// ========== CHANNEL PIPELINE BENCHMARKS ==========
+
+// Each op sends one item from the producer to the consumer.
+
+// Global variable to prevent compiler optimizations
+var sum int64
+
+func Benchmark_Pipeline(b *testing.B) {
+	for _, size := range bufferSizes {
+		b.Run(fmt.Sprintf("cap_%d", size), func(b *testing.B) {
+			benchmarkPipeline(b, size)
+		})
+	}
+}
+
+func benchmarkPipeline(b *testing.B, size int) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	sum = runPipeline(size, b.N).sum
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_PipelineSumsEveryItem(t *testing.T) {
+	const n = 10_000
+	want := int64(n) * (n - 1) / 2
+	for _, size := range bufferSizes {
+		if got := runPipeline(size, n).sum; got != want {
+			t.Errorf("%s: sum = %d, expected %d", bufferName(size), got, want)
+		}
+	}
+}
+
+func Test_BufferAtLeastBurstStopsFullSends(t *testing.T) {
+	const n = 100 * producerBurst
+	if got := runPipeline(0, n).fullSends; got != n {
+		t.Errorf("unbuffered: %d full sends, expected every one of %d", got, n)
+	}
+	// A buffer bigger than the burst only fills if the consumer falls
+	// behind, which a bare sum never does for long
+	if got := runPipeline(1000, n).fullSends; got > n/10 {
+		t.Errorf("buffer 1000: %d full sends of %d, expected almost none", got, n)
+	}
+}
+
+func Test_ChannelValueIsOnePointer(t *testing.T) {
+	var ch chan int
+	if got, want := unsafe.Sizeof(ch), unsafe.Sizeof(uintptr(0)); got != want {
+		t.Errorf("unsafe.Sizeof(chan int) = %d, expected a pointer's %d", got, want)
+	}
+}
+
+func Test_BufferWrapsAroundInOrder(t *testing.T) {
+	ch := make(chan int, 4)
+	next := 0
+	// Fill, drain half, refill: sendx wraps past the end of the buffer
+	for i := 0; i < 4; i++ {
+		ch <- i
+	}
+	for i := 0; i < 2; i++ {
+		if v := <-ch; v != next {
+			t.Fatalf("received %d, expected %d", v, next)
+		}
+		next++
+	}
+	for i := 4; i < 6; i++ {
+		ch <- i
+	}
+	close(ch)
+	for v := range ch {
+		if v != next {
+			t.Fatalf("received %d after wrapping, expected %d", v, next)
+		}
+		next++
+	}
+	if next != 6 {
+		t.Errorf("received %d items, expected 6", next)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalUint uint64
+
+// ========== COST CALCULATION BENCHMARKS ==========
+
+func Benchmark_Division(b *testing.B) {
+	usages := generateUsage(rand.New(rand.NewSource(1)), 1024)
+	b.ReportAllocs()
+	var sum uint64
+	for i := 0; i < b.N; i++ {
+		x := usages[i&1023]
+		sum += calculateMapCostImpactDiv(x.Bytes, x.Seconds, &defaultUnits)
+	}
+	globalUint = sum
+}
+
+func Benchmark_ShiftMultiply(b *testing.B) {
+	usages := generateUsage(rand.New(rand.NewSource(1)), 1024)
+	fast, err := newFastBillingUnits(defaultUnits)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	var sum uint64
+	for i := 0; i < b.N; i++ {
+		x := usages[i&1023]
+		sum += calculateMapCostImpactFast(x.Bytes, x.Seconds, &fast)
+	}
+	globalUint = sum
+}
+
+func Benchmark_ConstDivision(b *testing.B) {
+	usages := generateUsage(rand.New(rand.NewSource(1)), 1024)
+	b.ReportAllocs()
+	var sum uint64
+	for i := 0; i < b.N; i++ {
+		x := usages[i&1023]
+		sum += calculateMapCostImpactConst(x.Bytes, x.Seconds)
+	}
+	globalUint = sum
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_FastMatchesDivision(t *testing.T) {
+	fast, err := newFastBillingUnits(defaultUnits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range generateUsage(rand.New(rand.NewSource(2)), 100_000) {
+		want := calculateMapCostImpactDiv(x.Bytes, x.Seconds, &defaultUnits)
+		got := calculateMapCostImpactFast(x.Bytes, x.Seconds, &fast)
+		if want == 0 {
+			if got != 0 {
+				t.Fatalf("usage %d (%+v): expected 0, got %d", i, x, got)
+			}
+			continue
+		}
+		if diff := math.Abs(float64(got)-float64(want)) / float64(want); diff > 1e-6 {
+			t.Fatalf("usage %d (%+v): fast %d differs from division %d by %.6f%%", i, x, got, want, diff*100)
+		}
+	}
+}
+
+func Test_ReciprocalIsExact(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, d := range []uint64{3, 7, 1000, 3600, 86_400, 1<<32 + 1, math.MaxUint64 >> 1} {
+		r, err := newReciprocal(d)
+		if err != nil {
+			t.Fatalf("divisor %d: %v", d, err)
+		}
+
+		edges := []uint64{0, 1, d - 1, d, d + 1, 2*d - 1, 1<<63 - 1}
+		for i := 0; i < 10_000; i++ {
+			edges = append(edges, uint64(rng.Int63()))
+		}
+		for _, n := range edges {
+			if n >= 1<<63 {
+				continue // Outside the documented range
+			}
+			if got, want := r.divide(n), n/d; got != want {
+				t.Fatalf("%d / %d: expected %d, got %d", n, d, want, got)
+			}
+		}
+	}
+}
+
+func Test_RejectsPowerOfTwoDivisor(t *testing.T) {
+	if _, err := newReciprocal(4096); err == nil {
+		t.Error("expected error for power-of-two divisor")
+	}
+	if _, err := newFastBillingUnits(billingUnits{BytesPerGB: 1_000_000_000, SecondsPerHour: 3600}); err == nil {
+		t.Error("expected error for non-power-of-two BytesPerGB")
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/analyze"
+)
+
+const (
+	bytesPerGB     = 1024 * 1024 * 1024
+	secondsPerHour = 3600
+	nanosPerGBHour = 5_208_333 // $3.75 per GB-month (day 3) / 720 hours, in nano-dollars
+)
+
+// billingUnits holds the unit conversions of a billing pipeline. In
+// production they come from configuration, so the compiler sees variables
+// and has to emit real DIV instructions for them.
+type billingUnits struct {
+	BytesPerGB     uint64
+	SecondsPerHour uint64
+	NanosPerGBHour uint64
+}
+
+var defaultUnits = billingUnits{
+	BytesPerGB:     bytesPerGB,
+	SecondsPerHour: secondsPerHour,
+	NanosPerGBHour: nanosPerGBHour,
+}
+
+// reciprocal is n / d computed as a 128-bit multiply and a shift:
+// n / d == (n * magic) >> (64 + shift) for every n < 2^63.
+type reciprocal struct {
+	magic uint64
+	shift uint
+}
+
+// newReciprocal precomputes magic = floor(2^(64+s) / d) + 1 with
+// s = floor(log2(d)). The rounding error of magic is below 1, so the
+// estimate exceeds n/d by less than n / 2^(64+s) < 1/d for n < 2^63 and
+// the floor never moves to the next integer.
+func newReciprocal(d uint64) (reciprocal, error) {
+	if d < 3 || d&(d-1) == 0 {
+		return reciprocal{}, fmt.Errorf("divisor %d: use a shift for powers of two", d)
+	}
+	s := uint(bits.Len64(d) - 1)
+	magic, _ := bits.Div64(1<<s, 0, d) // 2^s < d, so the quotient fits
+	return reciprocal{magic: magic + 1, shift: s}, nil
+}
+
+func (r reciprocal) divide(n uint64) uint64 {
+	hi, _ := bits.Mul64(n, r.magic)
+	return hi >> r.shift
+}
+
+// fastBillingUnits is billingUnits with the divisions precomputed once at
+// startup: a shift for the power-of-two byte unit and a fixed-point
+// reciprocal for seconds per hour.
+type fastBillingUnits struct {
+	gbShift        uint
+	perHour        reciprocal
+	nanosPerGBHour uint64
+}
+
+func newFastBillingUnits(u billingUnits) (fastBillingUnits, error) {
+	if u.BytesPerGB == 0 || u.BytesPerGB&(u.BytesPerGB-1) != 0 {
+		return fastBillingUnits{}, errors.New("BytesPerGB must be a power of two")
+	}
+	perHour, err := newReciprocal(u.SecondsPerHour)
+	if err != nil {
+		return fastBillingUnits{}, err
+	}
+	return fastBillingUnits{
+		gbShift:        uint(bits.TrailingZeros64(u.BytesPerGB)),
+		perHour:        perHour,
+		nanosPerGBHour: u.NanosPerGBHour,
+	}, nil
+}
+
+// calculateMapCostImpactDiv is day 3's map cost formula in integer
+// nano-dollars: bytes held for seconds, billed per GB-hour.
+// bytes*seconds must stay below 2^64 (1 TB for 30 days is ~2^61).
+func calculateMapCostImpactDiv(bytes, seconds uint64, u *billingUnits) uint64 {
+	gbSeconds := bytes * seconds / u.BytesPerGB
+	return gbSeconds * u.NanosPerGBHour / u.SecondsPerHour
+}
+
+// calculateMapCostImpactFast returns the same result as
+// calculateMapCostImpactDiv with the divisions replaced by >> 30 and a
+// multiply by the reciprocal of 3600.
+func calculateMapCostImpactFast(bytes, seconds uint64, f *fastBillingUnits) uint64 {
+	gbSeconds := bytes * seconds >> f.gbShift
+	return f.perHour.divide(gbSeconds * f.nanosPerGBHour)
+}
+
+// calculateMapCostImpactConst hard-codes the units, which lets the Go
+// compiler do the same strength reduction on its own.
+func calculateMapCostImpactConst(bytes, seconds uint64) uint64 {
+	gbSeconds := bytes * seconds / bytesPerGB
+	return gbSeconds * nanosPerGBHour / secondsPerHour
+}
+
+// usage is one billable (bytes, seconds) pair.
+type usage struct {
+	Bytes   uint64
+	Seconds uint64
+}
+
+// generateUsage returns n random usages between 1 MB and 64 GB held for
+// up to 30 days.
+func generateUsage(rng *rand.Rand, n int) []usage {
+	out := make([]usage, n)
+	for i := range out {
+		out[i] = usage{
+			Bytes:   1<<20 + uint64(rng.Int63n(64<<30)),
+			Seconds: 1 + uint64(rng.Int63n(30*24*secondsPerHour)),
+		}
+	}
+	return out
+}
+
+func main() {
+	fmt.Println("🔬 DAY 190: Shifts vs Division")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const calculations = 10_000_000
+	rng := rand.New(rand.NewSource(190))
+	usages := generateUsage(rng, 1024)
+
+	fast, err := newFastBillingUnits(defaultUnits)
+	if err != nil {
+		fmt.Println("❌", err)
+		return
+	}
+
+	fmt.Printf("📊 BENCHMARK: %d cost calculations\n", calculations)
+	fmt.Println(strings.Repeat("-", 40))
+
+	divTime, divSum := benchmarkDivision(usages, &defaultUnits, calculations)
+	fmt.Printf("1. Division (config units):   %v (%.2f ns/calc)\n", divTime, nsPerCalc(divTime, calculations))
+
+	fastTime, fastSum := benchmarkShiftMultiply(usages, &fast, calculations)
+	fmt.Printf("2. Shift + reciprocal:        %v (%.2f ns/calc)\n", fastTime, nsPerCalc(fastTime, calculations))
+
+	constTime, constSum := benchmarkConstDivision(usages, calculations)
+	fmt.Printf("3. Division (const units):    %v (%.2f ns/calc)\n", constTime, nsPerCalc(constTime, calculations))
+
+	if divSum != fastSum || divSum != constSum {
+		fmt.Printf("❌ Results differ: %d vs %d vs %d\n", divSum, fastSum, constSum)
+		return
+	}
+	fmt.Printf("   All three agree: $%.2f billed\n", float64(divSum)/1e9)
+
+	fmt.Println("\n🔧 DIVISION REPLACEMENT OPPORTUNITIES")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeDivisionReplacementOpportunities("..")
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateBitManipCostImpact(nsPerCalc(divTime, calculations), nsPerCalc(fastTime, calculations))
+
+	fmt.Println("\n✅ DAY 190 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 191 - Overflow-Safe Cost Math")
+}
+
+func nsPerCalc(d time.Duration, n int) float64 {
+	return float64(d.Nanoseconds()) / float64(n)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkDivision(usages []usage, u *billingUnits, n int) (time.Duration, uint64) {
+	var sum uint64
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		x := usages[i&(len(usages)-1)]
+		sum += calculateMapCostImpactDiv(x.Bytes, x.Seconds, u)
+	}
+	return time.Since(start), sum
+}
+
+func benchmarkShiftMultiply(usages []usage, f *fastBillingUnits, n int) (time.Duration, uint64) {
+	var sum uint64
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		x := usages[i&(len(usages)-1)]
+		sum += calculateMapCostImpactFast(x.Bytes, x.Seconds, f)
+	}
+	return time.Since(start), sum
+}
+
+func benchmarkConstDivision(usages []usage, n int) (time.Duration, uint64) {
+	var sum uint64
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		x := usages[i&(len(usages)-1)]
+		sum += calculateMapCostImpactConst(x.Bytes, x.Seconds)
+	}
+	return time.Since(start), sum
+}
+
+// ========== ANALYSIS ==========
+
+// analyzeDivisionReplacementOpportunities scans every day-* package under
+// root for integer divisions by constants.
+func analyzeDivisionReplacementOpportunities(root string) {
+	dirs, _ := filepath.Glob(filepath.Join(root, "day-*"))
+
+	var sites []analyze.DivisionSite
+	for _, dir := range dirs {
+		found, err := analyze.FindConstantDivisions(dir)
+		if err != nil {
+			continue
+		}
+		sites = append(sites, found...)
+	}
+
+	var unsignedPow2, signedPow2, other int
+	for _, s := range sites {
+		switch {
+		case s.PowerOfTwo && s.Signed:
+			signedPow2++
+		case s.PowerOfTwo:
+			unsignedPow2++
+		default:
+			other++
+		}
+	}
+	fmt.Printf("  Scanned %d packages: %d integer divisions by a constant\n", len(dirs), len(sites))
+	fmt.Printf("  • Unsigned, power of two: %3d (compiled to a shift already)\n", unsignedPow2)
+	fmt.Printf("  • Signed, power of two:   %3d (shift plus sign fix-up)\n", signedPow2)
+	fmt.Printf("  • Other constants:        %3d (compiled to a reciprocal multiply)\n", other)
+
+	shown := 0
+	for _, s := range sites {
+		if !s.PowerOfTwo || !s.Signed || shown == 8 {
+			continue
+		}
+		rel, err := filepath.Rel(root, s.File)
+		if err != nil {
+			rel = s.File
+		}
+		fmt.Printf("    %s:%d  %s  →  %s\n", rel, s.Line, s.Expr, s.Suggestion())
+		shown++
+	}
+
+	fmt.Println()
+	fmt.Println("💡 The Go compiler already rewrites division by a constant. The")
+	fmt.Println("   expensive DIV is left for divisors it cannot see: units loaded")
+	fmt.Println("   from config, struct fields, function parameters. Precompute a")
+	fmt.Println("   shift or reciprocal for those once, outside the hot loop.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateBitManipCostImpact(divNs, fastNs float64) {
+	// Usage-based billing service pricing every metered event
+	calculationsPerSecond := 1_000_000.0
+	awsCostPerVCPUHour := 0.0416
+
+	nsSaved := divNs - fastNs
+	vCPUsSaved := nsSaved * calculationsPerSecond / 1e9
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Billing system computing %.0f costs/second\n", calculationsPerSecond)
+	fmt.Println("  • Units (bytes per GB, seconds per hour) loaded from config")
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Division:           %.2f ns/calc\n", divNs)
+	fmt.Printf("  Shift + reciprocal: %.2f ns/calc\n", fastNs)
+	fmt.Printf("  vCPUs freed:        %.4f\n", vCPUsSaved)
+	fmt.Printf("  Monthly savings:    $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:     $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	if nsSaved <= 0 {
+		fmt.Println("  No measurable gain on this CPU; keep the readable division.")
+		return
+	}
+	fmt.Println("  A 64-bit DIV costs tens of cycles, but a million per second is")
+	fmt.Println("  still a sliver of one core. Precompute reciprocals in kernels that")
+	fmt.Println("  divide billions of times; elsewhere, make the divisor a const and")
+	fmt.Println("  let the compiler do it.")
+}
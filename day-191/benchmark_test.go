@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// Global variables to prevent compiler optimizations
+var (
+	globalFloat   float64
+	globalDecimal Decimal
+)
+
+// ========== COST CALCULATION BENCHMARKS ==========
+
+func Benchmark_Float64Cost(b *testing.B) {
+	b.ReportAllocs()
+	var total float64
+	for i := 0; i < b.N; i++ {
+		c, _ := monthlyCPUCostFloat(time.Duration(1000+i%1000), 1000, 0.0416)
+		total += c
+	}
+	globalFloat = total
+}
+
+func Benchmark_DecimalCost(b *testing.B) {
+	rps := DecimalFromInt(1000)
+	price, _ := NewDecimal(0.0416)
+	b.ReportAllocs()
+	var d Decimal
+	for i := 0; i < b.N; i++ {
+		d = monthlyCPUCostDecimal(time.Duration(1000+i%1000), rps, price)
+	}
+	globalDecimal = d
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_DecimalMatchesFloat64(t *testing.T) {
+	rps := DecimalFromInt(1000)
+	price, _ := NewDecimal(0.0416)
+	for _, d := range []time.Duration{0, 1, time.Microsecond, 37 * time.Millisecond} {
+		f, err := monthlyCPUCostFloat(d, 1000, 0.0416)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec := monthlyCPUCostDecimal(d, rps, price).Float64()
+		if math.Abs(f-dec) > 1e-12*math.Max(1, dec) {
+			t.Errorf("%v: float64 $%v, Decimal $%v", d, f, dec)
+		}
+	}
+}
+
+func Test_DecimalKeepsLargeDurationsExact(t *testing.T) {
+	for _, d := range []time.Duration{math.MaxInt64, math.MinInt64, cost.MaxExactDuration + 1} {
+		got, _ := DecimalFromDuration(d).f.Int64()
+		if got != int64(d) {
+			t.Errorf("expected %d ns, got %d", int64(d), got)
+		}
+	}
+
+	// float64 cost math refuses the same inputs instead of rounding them
+	if _, err := monthlyCPUCostFloat(math.MaxInt64, 1, 0.0416); !errors.Is(err, cost.ErrPrecisionLoss) {
+		t.Errorf("expected ErrPrecisionLoss, got %v", err)
+	}
+}
+
+func Test_DecimalEdgeCases(t *testing.T) {
+	if _, err := NewDecimal(math.NaN()); !errors.Is(err, cost.ErrNaN) {
+		t.Errorf("expected ErrNaN, got %v", err)
+	}
+	if _, err := DecimalFromInt(1).Quo(DecimalFromInt(0)); !errors.Is(err, cost.ErrInfinity) {
+		t.Errorf("expected ErrInfinity, got %v", err)
+	}
+
+	neg := DecimalFromDuration(-time.Second)
+	if got := neg.Mul(DecimalFromInt(-1)).Float64(); got != 1e9 {
+		t.Errorf("expected 1e9, got %v", got)
+	}
+	if got := DecimalFromInt(0).String(); got != "0.0000000000" {
+		t.Errorf("expected zero formatted with 10 places, got %q", got)
+	}
+}
+
+func Test_MonthlyCostOverflow(t *testing.T) {
+	_, err := monthlyCPUCostFloat(time.Second, math.MaxFloat64, 0.0416)
+	if !errors.Is(err, cost.ErrInfinity) {
+		t.Errorf("expected ErrInfinity, got %v", err)
+	}
+	_, err = monthlyCPUCostFloat(time.Second, math.NaN(), 0.0416)
+	if !errors.Is(err, cost.ErrNaN) {
+		t.Errorf("expected NaN to propagate as ErrNaN, got %v", err)
+	}
+}
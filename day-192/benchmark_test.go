@@ -0,0 +1,186 @@
+package main
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// Global variables to prevent compiler optimizations
+var (
+	globalInt   int
+	globalBool  bool
+	globalSlice []int
+)
+
+// ========== SORT BENCHMARKS ==========
+
+func benchmarkSortFn(b *testing.B, sortFn func([]int)) {
+	input := randomInts(rand.New(rand.NewSource(1)), 10_000, 5_000)
+	work := make([]int, len(input))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(work, input)
+		b.StartTimer()
+		sortFn(work)
+	}
+	globalSlice = work
+}
+
+func Benchmark_SlicesSort(b *testing.B) {
+	benchmarkSortFn(b, slices.Sort[[]int])
+}
+
+func Benchmark_SortSlice(b *testing.B) {
+	benchmarkSortFn(b, func(a []int) {
+		sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+	})
+}
+
+func Benchmark_Pdqsort(b *testing.B) {
+	benchmarkSortFn(b, pdqsortInts)
+}
+
+// ========== SEARCH BENCHMARKS ==========
+
+func Benchmark_SlicesBinarySearch(b *testing.B) {
+	sorted := randomInts(rand.New(rand.NewSource(1)), 10_000, 5_000)
+	slices.Sort(sorted)
+	b.ReportAllocs()
+	found := false
+	for i := 0; i < b.N; i++ {
+		_, found = slices.BinarySearch(sorted, i%5_000)
+	}
+	globalBool = found
+}
+
+func Benchmark_SortSearch(b *testing.B) {
+	sorted := randomInts(rand.New(rand.NewSource(1)), 10_000, 5_000)
+	slices.Sort(sorted)
+	b.ReportAllocs()
+	idx := 0
+	for i := 0; i < b.N; i++ {
+		t := i % 5_000
+		idx = sort.Search(len(sorted), func(k int) bool { return sorted[k] >= t })
+	}
+	globalInt = idx
+}
+
+// ========== DEDUP BENCHMARKS ==========
+
+func benchmarkDedupFn(b *testing.B, dedup func([]int) []int) {
+	sorted := randomInts(rand.New(rand.NewSource(1)), 10_000, 5_000)
+	slices.Sort(sorted)
+	work := make([]int, len(sorted))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copy(work, sorted)
+		globalSlice = dedup(work)
+	}
+}
+
+func Benchmark_SlicesCompact(b *testing.B) { benchmarkDedupFn(b, slices.Compact[[]int]) }
+func Benchmark_DedupSorted(b *testing.B)   { benchmarkDedupFn(b, dedupSorted) }
+func Benchmark_DedupWithMap(b *testing.B)  { benchmarkDedupFn(b, dedupWithMap) }
+
+// ========== CORRECTNESS TESTS ==========
+
+type scored struct {
+	Score int
+	ID    int
+}
+
+func Test_SlicesSortMatchesSortSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for _, n := range []int{0, 1, 12, 13, 100, 10_000} {
+		ints := randomInts(rng, n, n/3+1)
+		a, b, c := slices.Clone(ints), slices.Clone(ints), slices.Clone(ints)
+		slices.Sort(a)
+		sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+		pdqsortInts(c)
+		if !slices.Equal(a, b) || !slices.Equal(a, c) {
+			t.Fatalf("n=%d: sorts disagree", n)
+		}
+	}
+
+	// With a total-order comparator the outputs are identical
+	items := make([]scored, 5_000)
+	for i := range items {
+		items[i] = scored{Score: rng.Intn(50), ID: i}
+	}
+	rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+
+	total := func(a, b scored) int { return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(a.ID, b.ID)) }
+	a, b := slices.Clone(items), slices.Clone(items)
+	slices.SortFunc(a, total)
+	sort.Slice(b, func(i, j int) bool { return total(b[i], b[j]) < 0 })
+	if !slices.Equal(a, b) {
+		t.Error("slices.SortFunc and sort.Slice disagree for a total order")
+	}
+
+	// Comparing on Score alone, neither sort is stable; only the stable
+	// variants are guaranteed to agree on the order of equal scores
+	byScore := func(a, b scored) int { return cmp.Compare(a.Score, b.Score) }
+	a, b = slices.Clone(items), slices.Clone(items)
+	slices.SortStableFunc(a, byScore)
+	sort.SliceStable(b, func(i, j int) bool { return b[i].Score < b[j].Score })
+	if !slices.Equal(a, b) {
+		t.Error("slices.SortStableFunc and sort.SliceStable disagree")
+	}
+}
+
+func Test_PdqsortAdversarialInputs(t *testing.T) {
+	inputs := map[string][]int{
+		"sorted":   make([]int, 1000),
+		"reversed": make([]int, 1000),
+		"equal":    make([]int, 1000),
+		"sawtooth": make([]int, 1000),
+	}
+	for i := 0; i < 1000; i++ {
+		inputs["sorted"][i] = i
+		inputs["reversed"][i] = 1000 - i
+		inputs["equal"][i] = 7
+		inputs["sawtooth"][i] = i % 17
+	}
+	for name, in := range inputs {
+		pdqsortInts(in)
+		if !slices.IsSorted(in) {
+			t.Errorf("%s: not sorted", name)
+		}
+	}
+}
+
+func Test_BinarySearchEqualElements(t *testing.T) {
+	sorted := []int{1, 3, 3, 3, 3, 5, 5, 8}
+	for target := 0; target <= 9; target++ {
+		idx, found := slices.BinarySearch(sorted, target)
+		want := sort.SearchInts(sorted, target)
+		if idx != want {
+			t.Errorf("target %d: expected index %d, got %d", target, want, idx)
+		}
+		// The first of a run of equal elements is returned
+		if found && (sorted[idx] != target || (idx > 0 && sorted[idx-1] == target)) {
+			t.Errorf("target %d: index %d is not the first match", target, idx)
+		}
+		if found != slices.Contains(sorted, target) {
+			t.Errorf("target %d: found=%v", target, found)
+		}
+	}
+}
+
+func Test_DedupVariantsAgree(t *testing.T) {
+	sorted := randomInts(rand.New(rand.NewSource(3)), 1_000, 100)
+	slices.Sort(sorted)
+
+	want := slices.Compact(slices.Clone(sorted))
+	if got := dedupSorted(slices.Clone(sorted)); !slices.Equal(got, want) {
+		t.Errorf("dedupSorted: expected %d values, got %d", len(want), len(got))
+	}
+	if got := dedupWithMap(slices.Clone(sorted)); !slices.Equal(got, want) {
+		t.Errorf("dedupWithMap: expected %d values, got %d", len(want), len(got))
+	}
+}
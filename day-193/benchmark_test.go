@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// Global variables to prevent compiler optimizations
+var (
+	globalString string
+	globalBytes  []byte
+)
+
+// ========== STRING BUILDING BENCHMARKS ==========
+
+func Benchmark_SmallBytesBuffer(b *testing.B) {
+	b.ReportAllocs()
+	var s string
+	for i := 0; i < b.N; i++ {
+		k := sampleKeys[i&3]
+		s = buildKeyBytesBuffer(k.Prefix, k.ID, k.Field)
+	}
+	globalString = s
+}
+
+func Benchmark_SmallStringBuilder(b *testing.B) {
+	b.ReportAllocs()
+	var s string
+	for i := 0; i < b.N; i++ {
+		k := sampleKeys[i&3]
+		s = buildKeySmallBuilder(k.Prefix, k.ID, k.Field)
+	}
+	globalString = s
+}
+
+func Benchmark_FmtSprintf(b *testing.B) {
+	b.ReportAllocs()
+	var s string
+	for i := 0; i < b.N; i++ {
+		k := sampleKeys[i&3]
+		s = buildKeySprintf(k.Prefix, k.ID, k.Field)
+	}
+	globalString = s
+}
+
+func Benchmark_SmallStringBuilderAppendTo(b *testing.B) {
+	out := make([]byte, 0, 256)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		k := sampleKeys[i&3]
+		out = appendKey(out[:0], k.Prefix, k.ID, k.Field)
+	}
+	globalBytes = out
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_BuildersProduceIdenticalStrings(t *testing.T) {
+	keys := append([]keyInput{
+		{"", 0, ""},
+		{"neg", -42, "x"},
+		// 70 bytes: forces the builder past its inline array
+		{strings.Repeat("p", 40), 1234567890, strings.Repeat("f", 18)},
+	}, sampleKeys...)
+
+	for _, k := range keys {
+		want := buildKeySprintf(k.Prefix, k.ID, k.Field)
+		if got := buildKeyBytesBuffer(k.Prefix, k.ID, k.Field); got != want {
+			t.Errorf("bytes.Buffer: expected %q, got %q", want, got)
+		}
+		if got := buildKeySmallBuilder(k.Prefix, k.ID, k.Field); got != want {
+			t.Errorf("SmallStringBuilder: expected %q, got %q", want, got)
+		}
+		if got := string(appendKey([]byte("{"), k.Prefix, k.ID, k.Field)); got != "{"+want {
+			t.Errorf("AppendTo: expected %q, got %q", "{"+want, got)
+		}
+	}
+}
+
+func Test_SmallStringBuilderZeroAllocs(t *testing.T) {
+	out := make([]byte, 0, 256)
+	for _, k := range sampleKeys {
+		if n := len(buildKeySprintf(k.Prefix, k.ID, k.Field)); n >= smallBufSize {
+			t.Fatalf("sample key is %d bytes, expected < %d", n, smallBufSize)
+		}
+		testutil.AssertMaxAllocs(t, fmt.Sprintf("%+v", k), 0, func() {
+			out = appendKey(out[:0], k.Prefix, k.ID, k.Field)
+		})
+	}
+}
+
+func Test_SmallStringBuilderSpill(t *testing.T) {
+	var b SmallStringBuilder
+	b.WriteString(strings.Repeat("a", smallBufSize))
+	if b.large != nil {
+		t.Fatal("exactly 64 bytes should still fit inline")
+	}
+	b.WriteByte('b')
+	b.Write([]byte("cd"))
+	if b.large == nil {
+		t.Fatal("expected spill to bytes.Buffer after 64 bytes")
+	}
+	if want := strings.Repeat("a", smallBufSize) + "bcd"; b.String() != want || b.Len() != len(want) {
+		t.Errorf("expected %q, got %q (len %d)", want, b.String(), b.Len())
+	}
+
+	b.Reset()
+	b.WriteString("short")
+	if b.large != nil || b.String() != "short" {
+		t.Errorf("expected Reset to return to the inline array, got %q", b.String())
+	}
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smallBufSize matches bytes.Buffer's minimum allocation, so anything the
+// inline array can hold would have cost bytes.Buffer a 64-byte heap block.
+const smallBufSize = 64
+
+// SmallStringBuilder builds strings in an inline [64]byte array. Declared
+// as a local variable it lives on the stack, so building a short string
+// allocates nothing. Past 64 bytes it moves its contents into a
+// bytes.Buffer and keeps going there.
+//
+// The zero value is ready to use. Like strings.Builder it must not be
+// copied after the first write.
+type SmallStringBuilder struct {
+	buf   [smallBufSize]byte
+	n     int
+	large *bytes.Buffer
+}
+
+func (b *SmallStringBuilder) spill(extra int) {
+	b.large = bytes.NewBuffer(make([]byte, 0, 2*(b.n+extra)))
+	b.large.Write(b.buf[:b.n])
+}
+
+// WriteString appends s. It never returns an error.
+func (b *SmallStringBuilder) WriteString(s string) (int, error) {
+	if b.large == nil {
+		if b.n+len(s) <= smallBufSize {
+			b.n += copy(b.buf[b.n:], s)
+			return len(s), nil
+		}
+		b.spill(len(s))
+	}
+	return b.large.WriteString(s)
+}
+
+// Write appends p. It never returns an error.
+func (b *SmallStringBuilder) Write(p []byte) (int, error) {
+	if b.large == nil {
+		if b.n+len(p) <= smallBufSize {
+			b.n += copy(b.buf[b.n:], p)
+			return len(p), nil
+		}
+		b.spill(len(p))
+	}
+	return b.large.Write(p)
+}
+
+// WriteByte appends c. It never returns an error.
+func (b *SmallStringBuilder) WriteByte(c byte) error {
+	if b.large == nil {
+		if b.n < smallBufSize {
+			b.buf[b.n] = c
+			b.n++
+			return nil
+		}
+		b.spill(1)
+	}
+	return b.large.WriteByte(c)
+}
+
+// WriteInt appends the decimal form of v without boxing it.
+func (b *SmallStringBuilder) WriteInt(v int64) {
+	var tmp [20]byte
+	b.Write(strconv.AppendInt(tmp[:0], v, 10))
+}
+
+// Len returns the number of bytes written.
+func (b *SmallStringBuilder) Len() int {
+	if b.large != nil {
+		return b.large.Len()
+	}
+	return b.n
+}
+
+// Bytes returns the contents, aliasing the builder's storage until the
+// next write.
+func (b *SmallStringBuilder) Bytes() []byte {
+	if b.large != nil {
+		return b.large.Bytes()
+	}
+	return b.buf[:b.n]
+}
+
+// AppendTo appends the contents to dst. This is the zero-allocation way
+// out: serializers append the key straight into their output buffer.
+func (b *SmallStringBuilder) AppendTo(dst []byte) []byte {
+	return append(dst, b.Bytes()...)
+}
+
+// String returns a copy of the contents: one exactly sized allocation,
+// where bytes.Buffer pays for its 64-byte buffer and then the copy.
+func (b *SmallStringBuilder) String() string {
+	return string(b.Bytes())
+}
+
+// Reset empties the builder and returns to the inline array.
+func (b *SmallStringBuilder) Reset() {
+	b.n = 0
+	b.large = nil
+}
+
+// ========== KEY BUILDERS ==========
+
+// The workload: cache/JSON keys like "user_48213_last_login", 10-50 bytes.
+
+func buildKeyBytesBuffer(prefix string, id int64, field string) string {
+	var buf bytes.Buffer
+	buf.WriteString(prefix)
+	buf.WriteByte('_')
+	var tmp [20]byte
+	buf.Write(strconv.AppendInt(tmp[:0], id, 10))
+	buf.WriteByte('_')
+	buf.WriteString(field)
+	return buf.String()
+}
+
+func writeKey(b *SmallStringBuilder, prefix string, id int64, field string) {
+	b.WriteString(prefix)
+	b.WriteByte('_')
+	b.WriteInt(id)
+	b.WriteByte('_')
+	b.WriteString(field)
+}
+
+func buildKeySmallBuilder(prefix string, id int64, field string) string {
+	var b SmallStringBuilder
+	writeKey(&b, prefix, id, field)
+	return b.String()
+}
+
+func buildKeySprintf(prefix string, id int64, field string) string {
+	return fmt.Sprintf("%s_%d_%s", prefix, id, field)
+}
+
+// appendKey is how a serializer uses the builder: no string is created
+func appendKey(dst []byte, prefix string, id int64, field string) []byte {
+	var b SmallStringBuilder
+	writeKey(&b, prefix, id, field)
+	return b.AppendTo(dst)
+}
+
+type keyInput struct {
+	Prefix string
+	ID     int64
+	Field  string
+}
+
+var sampleKeys = []keyInput{
+	{"user", 48213, "last_login"},
+	{"order", 9_120_331, "shipping_address"},
+	{"sess", 7, "ttl"},
+	{"inventory", 1_000_000_123, "warehouse_eu_central_1"},
+}
+
+func main() {
+	fmt.Println("🔬 DAY 193: Small String Builder")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const iterations = 5_000_000
+
+	fmt.Printf("📊 BENCHMARK: %d keys (10-50 bytes)\n", iterations)
+	fmt.Println(strings.Repeat("-", 40))
+
+	bufTime, bufBytes := benchmarkSmallBytesBuffer(iterations)
+	fmt.Printf("1. bytes.Buffer:        %v (%.1f ns/key)\n", bufTime, nsPerKey(bufTime, iterations))
+
+	sbTime, sbBytes := benchmarkSmallStringBuilder(iterations)
+	fmt.Printf("2. SmallStringBuilder:  %v (%.1f ns/key)\n", sbTime, nsPerKey(sbTime, iterations))
+
+	fmtTime, fmtBytes := benchmarkFmtSprintf(iterations)
+	fmt.Printf("3. fmt.Sprintf:         %v (%.1f ns/key)\n", fmtTime, nsPerKey(fmtTime, iterations))
+
+	appendTime := benchmarkSmallBuilderAppend(iterations)
+	fmt.Printf("4. SmallStringBuilder → output buffer: %v (%.1f ns/key)\n", appendTime, nsPerKey(appendTime, iterations))
+
+	if bufBytes != sbBytes || bufBytes != fmtBytes {
+		fmt.Printf("❌ Builders disagree: %d vs %d vs %d bytes\n", bufBytes, sbBytes, fmtBytes)
+		return
+	}
+
+	fmt.Println("\n🔧 ALLOCATIONS PER KEY")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeSmallStringBuildingCost()
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateSmallStringBuilderCostImpact(nsPerKey(bufTime, iterations), nsPerKey(appendTime, iterations))
+
+	fmt.Println("\n✅ DAY 193 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 194 - Atomic Snapshots")
+}
+
+func nsPerKey(d time.Duration, n int) float64 {
+	return float64(d.Nanoseconds()) / float64(n)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkSmallBytesBuffer(n int) (time.Duration, int) {
+	total := 0
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		k := sampleKeys[i&3]
+		total += len(buildKeyBytesBuffer(k.Prefix, k.ID, k.Field))
+	}
+	return time.Since(start), total
+}
+
+func benchmarkSmallStringBuilder(n int) (time.Duration, int) {
+	total := 0
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		k := sampleKeys[i&3]
+		total += len(buildKeySmallBuilder(k.Prefix, k.ID, k.Field))
+	}
+	return time.Since(start), total
+}
+
+func benchmarkFmtSprintf(n int) (time.Duration, int) {
+	total := 0
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		k := sampleKeys[i&3]
+		total += len(buildKeySprintf(k.Prefix, k.ID, k.Field))
+	}
+	return time.Since(start), total
+}
+
+func benchmarkSmallBuilderAppend(n int) time.Duration {
+	out := make([]byte, 0, 4096)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		k := sampleKeys[i&3]
+		if len(out) > 4000 {
+			out = out[:0] // Flushed to the network in a real serializer
+		}
+		out = appendKey(out, k.Prefix, k.ID, k.Field)
+	}
+	return time.Since(start)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeSmallStringBuildingCost() {
+	k := sampleKeys[0]
+	var sink string
+	out := make([]byte, 0, 256)
+
+	results := []struct {
+		name   string
+		allocs float64
+	}{
+		{"bytes.Buffer + String()", testing.AllocsPerRun(1000, func() {
+			sink = buildKeyBytesBuffer(k.Prefix, k.ID, k.Field)
+		})},
+		{"SmallStringBuilder + String()", testing.AllocsPerRun(1000, func() {
+			sink = buildKeySmallBuilder(k.Prefix, k.ID, k.Field)
+		})},
+		{"fmt.Sprintf", testing.AllocsPerRun(1000, func() {
+			sink = buildKeySprintf(k.Prefix, k.ID, k.Field)
+		})},
+		{"SmallStringBuilder + AppendTo", testing.AllocsPerRun(1000, func() {
+			out = appendKey(out[:0], k.Prefix, k.ID, k.Field)
+		})},
+	}
+	_ = sink
+
+	for _, r := range results {
+		fmt.Printf("  %-31s %.0f allocs/key\n", r.name+":", r.allocs)
+	}
+	fmt.Println()
+	fmt.Println("💡 bytes.Buffer's first write allocates 64 bytes on the heap, then")
+	fmt.Println("   String() copies them again. The inline array skips the first")
+	fmt.Println("   allocation; appending into the caller's buffer skips both.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateSmallStringBuilderCostImpact(bufferNs, builderNs float64) {
+	// JSON serializer emitting object keys for an events API
+	keysPerSecond := 5_000_000.0
+	awsCostPerVCPUHour := 0.0416
+
+	nsSaved := bufferNs - builderNs
+	vCPUsSaved := nsSaved * keysPerSecond / 1e9
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+	allocsSaved := 2 * keysPerSecond
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • JSON key serializer: %.0f small strings/second\n", keysPerSecond)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  bytes.Buffer:        %.1f ns/key\n", bufferNs)
+	fmt.Printf("  Builder + AppendTo:  %.1f ns/key\n", builderNs)
+	fmt.Printf("  Allocations avoided: %.0fM/second\n", allocsSaved/1e6)
+	fmt.Printf("  vCPUs freed:         %.2f (excluding GC)\n", vCPUsSaved)
+	fmt.Printf("  Monthly savings:     $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:      $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  For short strings on hot paths, build on the stack and append to")
+	fmt.Println("  the output. Keep bytes.Buffer for payloads of unknown size.")
+}
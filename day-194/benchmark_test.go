@@ -0,0 +1,98 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalStats Stats
+
+// ========== PAIR READ BENCHMARKS ==========
+
+func benchmarkStatsStore(b *testing.B, s StatsStore) {
+	b.ReportAllocs()
+	b.SetParallelism(16)
+	b.RunParallel(func(pb *testing.PB) {
+		var st Stats
+		i := 0
+		for pb.Next() {
+			if i%20 == 0 {
+				s.Record(int64(i % 13))
+			} else {
+				st = s.Snapshot()
+			}
+			i++
+		}
+		globalStats = st
+	})
+}
+
+func Benchmark_MutexPairRead(b *testing.B) {
+	benchmarkStatsStore(b, &MutexStats{})
+}
+
+func Benchmark_AtomicSnapshotRead(b *testing.B) {
+	benchmarkStatsStore(b, NewSnapshotStats())
+}
+
+func Benchmark_ChannelPairRead(b *testing.B) {
+	c := NewChannelStats()
+	defer c.Close()
+	benchmarkStatsStore(b, c)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_AtomicSnapshotConsistentUnderConcurrency(t *testing.T) {
+	// Run with -race: 1M snapshot reads and writes across 16 goroutines
+	s := NewSnapshotStats()
+	_, torn := runStatsWorkload(s, 16, 1_000_000, 20)
+	if torn != 0 {
+		t.Errorf("expected every snapshot to satisfy count*average == sum, %d did not", torn)
+	}
+
+	final := s.Snapshot()
+	if want := int64(1_000_000 / 20); final.Count != want {
+		t.Errorf("expected %d recorded samples, got %d (lost CAS updates?)", want, final.Count)
+	}
+	if !final.Consistent() {
+		t.Errorf("final snapshot inconsistent: %+v", final)
+	}
+}
+
+func Test_MutexAndChannelConsistent(t *testing.T) {
+	c := NewChannelStats()
+	defer c.Close()
+	for name, s := range map[string]StatsStore{"mutex": &MutexStats{}, "channel": c} {
+		if _, torn := runStatsWorkload(s, 16, 100_000, 20); torn != 0 {
+			t.Errorf("%s: %d inconsistent snapshots", name, torn)
+		}
+	}
+}
+
+func Test_SplitAtomicsTear(t *testing.T) {
+	_, torn := runStatsWorkload(&SplitAtomicStats{}, 16, 100_000, 20)
+	if torn == 0 {
+		t.Error("expected separate atomics to expose inconsistent snapshots")
+	}
+	t.Logf("separate atomics: %d inconsistent snapshots", torn)
+}
+
+func Test_UpdateRetriesOnConflict(t *testing.T) {
+	snap := NewAtomicSnapshot(0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10_000; i++ {
+				snap.Update(func(v int) int { return v + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	if got := snap.Load(); got != 80_000 {
+		t.Errorf("expected 80000, got %d", got)
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicSnapshot publishes an immutable T through an atomic.Pointer. A
+// reader gets every field from the same version with one atomic load, and
+// writers never block readers. T should be a small struct: every update
+// allocates a new copy.
+type AtomicSnapshot[T any] struct {
+	p atomic.Pointer[T]
+}
+
+func NewAtomicSnapshot[T any](initial T) *AtomicSnapshot[T] {
+	s := &AtomicSnapshot[T]{}
+	s.p.Store(&initial)
+	return s
+}
+
+// Load returns a copy of the current snapshot.
+func (s *AtomicSnapshot[T]) Load() T {
+	return *s.p.Load()
+}
+
+// Store replaces the snapshot unconditionally.
+func (s *AtomicSnapshot[T]) Store(v T) {
+	s.p.Store(&v)
+}
+
+// Update applies fn to the current snapshot and publishes the result,
+// retrying if another writer got there first. fn may run more than once
+// and must not have side effects.
+func (s *AtomicSnapshot[T]) Update(fn func(T) T) T {
+	for {
+		old := s.p.Load()
+		next := fn(*old)
+		if s.p.CompareAndSwap(old, &next) {
+			return next
+		}
+	}
+}
+
+// Stats is a running aggregate. Average is derived from Count and Sum, so
+// a reader that sees fields from two different updates can tell.
+type Stats struct {
+	Count   int64
+	Sum     int64
+	Average float64
+}
+
+func (s Stats) Add(v int64) Stats {
+	s.Count++
+	s.Sum += v
+	s.Average = float64(s.Sum) / float64(s.Count)
+	return s
+}
+
+// Consistent reports whether Count * Average == Sum (within float
+// rounding), i.e. all three fields come from the same update.
+func (s Stats) Consistent() bool {
+	if s.Count == 0 {
+		return s.Sum == 0 && s.Average == 0
+	}
+	return math.Abs(float64(s.Count)*s.Average-float64(s.Sum)) <= 1e-9*math.Max(1, math.Abs(float64(s.Sum)))
+}
+
+// StatsStore is a shared aggregate read far more often than it is updated.
+type StatsStore interface {
+	Snapshot() Stats
+	Record(v int64)
+}
+
+// ========== Mutex ==========
+
+type MutexStats struct {
+	mu sync.Mutex
+	s  Stats
+}
+
+func (m *MutexStats) Snapshot() Stats {
+	m.mu.Lock()
+	s := m.s
+	m.mu.Unlock()
+	return s
+}
+
+func (m *MutexStats) Record(v int64) {
+	m.mu.Lock()
+	m.s = m.s.Add(v)
+	m.mu.Unlock()
+}
+
+// ========== AtomicSnapshot ==========
+
+type SnapshotStats struct {
+	snap *AtomicSnapshot[Stats]
+}
+
+func NewSnapshotStats() *SnapshotStats {
+	return &SnapshotStats{snap: NewAtomicSnapshot(Stats{})}
+}
+
+func (a *SnapshotStats) Snapshot() Stats {
+	return a.snap.Load()
+}
+
+func (a *SnapshotStats) Record(v int64) {
+	a.snap.Update(func(s Stats) Stats { return s.Add(v) })
+}
+
+// ========== Channel ==========
+
+// ChannelStats confines the aggregate to one goroutine; reads and writes
+// are messages to it.
+type ChannelStats struct {
+	reads   chan chan Stats
+	records chan int64
+	done    chan struct{}
+}
+
+func NewChannelStats() *ChannelStats {
+	c := &ChannelStats{
+		reads:   make(chan chan Stats),
+		records: make(chan int64, 64),
+		done:    make(chan struct{}),
+	}
+	go c.loop()
+	return c
+}
+
+func (c *ChannelStats) loop() {
+	var s Stats
+	for {
+		select {
+		case reply := <-c.reads:
+			reply <- s
+		case v := <-c.records:
+			s = s.Add(v)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *ChannelStats) Snapshot() Stats {
+	reply := make(chan Stats, 1)
+	c.reads <- reply
+	return <-reply
+}
+
+func (c *ChannelStats) Record(v int64) {
+	c.records <- v
+}
+
+// Close stops the owner goroutine. Records still buffered are dropped.
+func (c *ChannelStats) Close() {
+	close(c.done)
+}
+
+// ========== Separate atomics (broken) ==========
+
+// SplitAtomicStats keeps each field in its own atomic. Every field is
+// race-free, but a reader can combine Count from one update with Sum from
+// another. Used only to show the inconsistency.
+type SplitAtomicStats struct {
+	count   atomic.Int64
+	sum     atomic.Int64
+	average atomic.Uint64 // math.Float64bits
+}
+
+func (s *SplitAtomicStats) Snapshot() Stats {
+	return Stats{
+		Count:   s.count.Load(),
+		Sum:     s.sum.Load(),
+		Average: math.Float64frombits(s.average.Load()),
+	}
+}
+
+func (s *SplitAtomicStats) Record(v int64) {
+	count := s.count.Add(1)
+	// Yield between fields to widen the race window, so the tear shows up
+	// even on a single core where preemption between two adds is rare
+	runtime.Gosched()
+	sum := s.sum.Add(v)
+	s.average.Store(math.Float64bits(float64(sum) / float64(count)))
+}
+
+func main() {
+	fmt.Println("🔬 DAY 194: Atomic Snapshots")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		goroutines = 16
+		totalOps   = 4_000_000
+		writeEvery = 20 // 5% writes
+	)
+
+	fmt.Printf("📊 BENCHMARK: %d goroutines, %d ops, 95%% reads\n", goroutines, totalOps)
+	fmt.Println(strings.Repeat("-", 40))
+
+	mutexTime := benchmarkMutexPairRead(goroutines, totalOps, writeEvery)
+	fmt.Printf("1. sync.Mutex:      %v (%.1f ns/op)\n", mutexTime, nsPerOp(mutexTime, totalOps))
+
+	snapTime := benchmarkAtomicSnapshotRead(goroutines, totalOps, writeEvery)
+	fmt.Printf("2. AtomicSnapshot:  %v (%.1f ns/op)\n", snapTime, nsPerOp(snapTime, totalOps))
+
+	chanTime := benchmarkChannelPairRead(goroutines, totalOps, writeEvery)
+	fmt.Printf("3. Channel owner:   %v (%.1f ns/op)\n", chanTime, nsPerOp(chanTime, totalOps))
+
+	fmt.Println("\n🔧 CONSISTENCY CHECK: count × average == sum")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeAtomicSnapshotConsistency(goroutines, 1_000_000, writeEvery)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateAtomicSnapshotCostImpact(nsPerOp(mutexTime, totalOps), nsPerOp(snapTime, totalOps))
+
+	fmt.Println("\n✅ DAY 194 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 195 - HPACK Header Compression")
+}
+
+func nsPerOp(d time.Duration, n int) float64 {
+	return float64(d.Nanoseconds()) / float64(n)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkMutexPairRead(goroutines, totalOps, writeEvery int) time.Duration {
+	d, _ := runStatsWorkload(&MutexStats{}, goroutines, totalOps, writeEvery)
+	return d
+}
+
+func benchmarkAtomicSnapshotRead(goroutines, totalOps, writeEvery int) time.Duration {
+	d, _ := runStatsWorkload(NewSnapshotStats(), goroutines, totalOps, writeEvery)
+	return d
+}
+
+func benchmarkChannelPairRead(goroutines, totalOps, writeEvery int) time.Duration {
+	c := NewChannelStats()
+	defer c.Close()
+	d, _ := runStatsWorkload(c, goroutines, totalOps, writeEvery)
+	return d
+}
+
+// runStatsWorkload splits totalOps across goroutines; every writeEvery-th
+// operation records a sample, the rest read a snapshot. It returns the
+// elapsed time and the number of inconsistent snapshots observed.
+func runStatsWorkload(s StatsStore, goroutines, totalOps, writeEvery int) (time.Duration, int64) {
+	var wg sync.WaitGroup
+	var torn atomic.Int64
+	perWorker := totalOps / goroutines
+
+	start := time.Now()
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			var bad int64
+			for i := 0; i < perWorker; i++ {
+				if i%writeEvery == 0 {
+					s.Record(int64(g*7 + i%13 + 1))
+					continue
+				}
+				if !s.Snapshot().Consistent() {
+					bad++
+				}
+			}
+			torn.Add(bad)
+		}(g)
+	}
+	wg.Wait()
+
+	return time.Since(start), torn.Load()
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeAtomicSnapshotConsistency(goroutines, totalOps, writeEvery int) {
+	c := NewChannelStats()
+	defer c.Close()
+
+	stores := []struct {
+		name string
+		s    StatsStore
+	}{
+		{"sync.Mutex", &MutexStats{}},
+		{"AtomicSnapshot", NewSnapshotStats()},
+		{"Channel owner", c},
+		{"Separate atomics", &SplitAtomicStats{}},
+	}
+
+	reads := totalOps - totalOps/writeEvery
+	for _, st := range stores {
+		_, torn := runStatsWorkload(st.s, goroutines, totalOps, writeEvery)
+		marker := "✅"
+		if torn > 0 {
+			marker = "❌"
+		}
+		fmt.Printf("  %s %-17s %7d / %d snapshots inconsistent\n", marker, st.name+":", torn, reads)
+	}
+	fmt.Println()
+	fmt.Println("💡 Each field of the separate-atomics version is race-free, yet")
+	fmt.Println("   readers still combine fields from different updates. Publishing")
+	fmt.Println("   one immutable struct through one pointer makes the whole record")
+	fmt.Println("   the unit of atomicity. Writers pay one allocation per update.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateAtomicSnapshotCostImpact(mutexNs, snapshotNs float64) {
+	// Real-time statistics aggregator behind a dashboard and alerting API
+	opsPerSecond := 2_000_000.0
+	awsCostPerVCPUHour := 0.0416
+
+	nsSaved := mutexNs - snapshotNs
+	vCPUsSaved := nsSaved * opsPerSecond / 1e9
+	monthlySavings := vCPUsSaved * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • Statistics aggregator: %.0f ops/second, 95%% reads\n", opsPerSecond)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  sync.Mutex:      %.1f ns/op\n", mutexNs)
+	fmt.Printf("  AtomicSnapshot:  %.1f ns/op\n", snapshotNs)
+	fmt.Printf("  vCPUs freed:     %.2f\n", vCPUsSaved)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	if nsSaved <= 0 {
+		fmt.Println("  No gain at this core count: an uncontended mutex is as cheap.")
+		fmt.Println("  The snapshot still wins on multi-core hosts where readers stop")
+		fmt.Println("  bouncing the lock's cache line.")
+		return
+	}
+	fmt.Println("  Read-mostly aggregates should be published as immutable snapshots:")
+	fmt.Println("  readers never wait and never see a half-applied update.")
+}
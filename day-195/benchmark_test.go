@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/http2/hpack"
+)
+
+// Global variable to prevent compiler optimizations
+var globalBytes []byte
+
+// ========== HPACK BENCHMARKS ==========
+
+func Benchmark_HPACKTableSize(b *testing.B) {
+	// More unique IDs than a 64KB table holds, so none are re-used
+	reqs := generateRequests(1024)
+	for _, size := range tableSizes {
+		b.Run(formatSize(size), func(b *testing.B) {
+			enc := NewEncoder(size)
+			buf := enc.AppendHeaderBlock(nil, reqs[0])
+			b.ReportAllocs()
+			total := 0
+			for i := 0; i < b.N; i++ {
+				buf = enc.AppendHeaderBlock(buf[:0], reqs[i&1023])
+				total += len(buf)
+			}
+			b.ReportMetric(float64(total)/float64(b.N), "B/request")
+			globalBytes = buf
+		})
+	}
+}
+
+func Benchmark_StdlibHPACKEncoder(b *testing.B) {
+	reqs := generateRequests(256)
+	var buf sliceWriter
+	enc := hpack.NewEncoder(&buf)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, f := range reqs[i&255] {
+			enc.WriteField(hpack.HeaderField{Name: f.Name, Value: f.Value, Sensitive: f.Sensitive})
+		}
+	}
+	globalBytes = buf
+}
+
+type sliceWriter []byte
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	*w = append(*w, p...)
+	return len(p), nil
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+// decodeBlock decodes one header block with the x/net decoder, which
+// keeps its dynamic table across calls like a real HTTP/2 peer.
+func decodeBlock(t *testing.T, dec *hpack.Decoder, block []byte) []hpack.HeaderField {
+	t.Helper()
+	fields, err := dec.DecodeFull(block)
+	if err != nil {
+		t.Fatalf("x/net hpack rejected block: %v", err)
+	}
+	return fields
+}
+
+func Test_StdlibDecoderRoundTrip(t *testing.T) {
+	reqs := generateRequests(300)
+	for _, size := range tableSizes {
+		t.Run(formatSize(size), func(t *testing.T) {
+			enc := NewEncoder(size)
+			// The decoder's limit is what we advertised in SETTINGS
+			dec := hpack.NewDecoder(uint32(size), nil)
+
+			for i, req := range reqs {
+				block := enc.AppendHeaderBlock(nil, req)
+				got := decodeBlock(t, dec, block)
+				if len(got) != len(req) {
+					t.Fatalf("request %d: expected %d fields, got %d", i, len(req), len(got))
+				}
+				for j, f := range req {
+					if got[j].Name != f.Name || got[j].Value != f.Value || got[j].Sensitive != f.Sensitive {
+						t.Fatalf("request %d field %d: expected %+v, got %+v", i, j, f, got[j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_SensitiveAndOversizedFields(t *testing.T) {
+	enc := NewEncoder(256)
+	dec := hpack.NewDecoder(256, nil)
+
+	long := fmt.Sprintf("%0300d", 7) // Larger than the whole table
+	fields := []headerField{
+		{Name: "authorization", Value: "secret", Sensitive: true},
+		{Name: "x-big", Value: long},
+		{Name: "x-big", Value: long},
+		{Name: "custom-name", Value: "v"},
+		{Name: "custom-name", Value: "v"},
+	}
+	for round := 0; round < 3; round++ {
+		block := enc.AppendHeaderBlock(nil, fields)
+		got := decodeBlock(t, dec, block)
+		for j, f := range fields {
+			if got[j].Name != f.Name || got[j].Value != f.Value || got[j].Sensitive != f.Sensitive {
+				t.Fatalf("round %d field %d: expected %+v, got %+v", round, j, f, got[j])
+			}
+		}
+	}
+
+	// The sensitive field must never enter the table
+	if _, ok := enc.table.byPair[nameValue{"authorization", "secret"}]; ok {
+		t.Error("sensitive field was indexed")
+	}
+}
+
+func Test_LargerTableCompressesRepeatedHeaders(t *testing.T) {
+	reqs := generateRequests(512)
+	small := benchmarkHPACKTableSize(reqs, 256, 500)
+	def := benchmarkHPACKTableSize(reqs, 4<<10, 500)
+	if def.BytesPerRequest >= small.BytesPerRequest/2 {
+		t.Errorf("expected 4KB table to at least halve bytes vs 256B, got %.1f vs %.1f",
+			def.BytesPerRequest, small.BytesPerRequest)
+	}
+}
+
+func Test_AppendInt(t *testing.T) {
+	// RFC 7541 C.1 examples
+	tests := []struct {
+		n    uint
+		v    uint64
+		want []byte
+	}{
+		{5, 10, []byte{0x0a}},
+		{5, 1337, []byte{0x1f, 0x9a, 0x0a}},
+		{8, 42, []byte{0x2a}},
+	}
+	for _, tt := range tests {
+		if got := appendInt(nil, tt.n, 0, tt.v); string(got) != string(tt.want) {
+			t.Errorf("appendInt(%d, %d): expected %x, got %x", tt.n, tt.v, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import "golang.org/x/net/http2/hpack"
+
+// headerField is one name/value pair. Sensitive fields are never added
+// to the dynamic table (RFC 7541 §7.1.3).
+type headerField struct {
+	Name, Value string
+	Sensitive   bool
+}
+
+// entrySize is the RFC 7541 §4.1 size of a table entry: the 32 bytes
+// cover the decoder's per-entry bookkeeping.
+func entrySize(name, value string) int {
+	return len(name) + len(value) + 32
+}
+
+type nameValue struct{ name, value string }
+
+// staticTable is RFC 7541 Appendix A; index i+1 refers to staticTable[i].
+var staticTable = []nameValue{
+	{":authority", ""}, {":method", "GET"}, {":method", "POST"}, {":path", "/"},
+	{":path", "/index.html"}, {":scheme", "http"}, {":scheme", "https"}, {":status", "200"},
+	{":status", "204"}, {":status", "206"}, {":status", "304"}, {":status", "400"},
+	{":status", "404"}, {":status", "500"}, {"accept-charset", ""}, {"accept-encoding", "gzip, deflate"},
+	{"accept-language", ""}, {"accept-ranges", ""}, {"accept", ""}, {"access-control-allow-origin", ""},
+	{"age", ""}, {"allow", ""}, {"authorization", ""}, {"cache-control", ""},
+	{"content-disposition", ""}, {"content-encoding", ""}, {"content-language", ""}, {"content-length", ""},
+	{"content-location", ""}, {"content-range", ""}, {"content-type", ""}, {"cookie", ""},
+	{"date", ""}, {"etag", ""}, {"expect", ""}, {"expires", ""},
+	{"from", ""}, {"host", ""}, {"if-match", ""}, {"if-modified-since", ""},
+	{"if-none-match", ""}, {"if-range", ""}, {"if-unmodified-since", ""}, {"last-modified", ""},
+	{"link", ""}, {"location", ""}, {"max-forwards", ""}, {"proxy-authenticate", ""},
+	{"proxy-authorization", ""}, {"range", ""}, {"referer", ""}, {"refresh", ""},
+	{"retry-after", ""}, {"server", ""}, {"set-cookie", ""}, {"strict-transport-security", ""},
+	{"transfer-encoding", ""}, {"user-agent", ""}, {"vary", ""}, {"via", ""},
+	{"www-authenticate", ""},
+}
+
+var (
+	staticByPair = make(map[nameValue]int, len(staticTable))
+	staticByName = make(map[string]int, len(staticTable))
+)
+
+func init() {
+	for i, nv := range staticTable {
+		if _, ok := staticByPair[nv]; !ok {
+			staticByPair[nv] = i + 1
+		}
+		if _, ok := staticByName[nv.name]; !ok {
+			staticByName[nv.name] = i + 1
+		}
+	}
+}
+
+// dynamicTable is the encoder's copy of the FIFO table the peer keeps.
+// Entries are identified by an ever-increasing insertion id so the lookup
+// maps survive evictions without being rebuilt.
+type dynamicTable struct {
+	entries []nameValue // Oldest first
+	evicted uint64      // Insertion id of entries[0]
+	size    int
+	maxSize int
+	byPair  map[nameValue]uint64
+	byName  map[string]uint64
+}
+
+func newDynamicTable(maxSize int) *dynamicTable {
+	return &dynamicTable{
+		maxSize: maxSize,
+		byPair:  make(map[nameValue]uint64),
+		byName:  make(map[string]uint64),
+	}
+}
+
+// index converts an insertion id to an HPACK index (62 = newest), or 0 if
+// the entry has been evicted.
+func (t *dynamicTable) index(id uint64) int {
+	if id < t.evicted {
+		return 0
+	}
+	newest := t.evicted + uint64(len(t.entries)) - 1
+	return len(staticTable) + 1 + int(newest-id)
+}
+
+func (t *dynamicTable) lookup(nv nameValue) (pairIdx, nameIdx int) {
+	if id, ok := t.byPair[nv]; ok {
+		pairIdx = t.index(id)
+	}
+	if id, ok := t.byName[nv.name]; ok {
+		nameIdx = t.index(id)
+	}
+	return pairIdx, nameIdx
+}
+
+// add inserts nv, evicting the oldest entries to make room. An entry
+// larger than the whole table empties it (§4.4).
+func (t *dynamicTable) add(nv nameValue) {
+	sz := entrySize(nv.name, nv.value)
+	for t.size+sz > t.maxSize && len(t.entries) > 0 {
+		old := t.entries[0]
+		t.size -= entrySize(old.name, old.value)
+		t.entries = t.entries[1:]
+		t.evicted++
+	}
+	if sz > t.maxSize {
+		return
+	}
+	id := t.evicted + uint64(len(t.entries))
+	t.entries = append(t.entries, nv)
+	t.size += sz
+	t.byPair[nv] = id
+	t.byName[nv.name] = id
+}
+
+// Encoder is a minimal HPACK encoder with a configurable dynamic table. It
+// indexes everything that fits except sensitive fields, and Huffman-codes
+// string literals when that is shorter.
+type Encoder struct {
+	table       *dynamicTable
+	sendMaxSize bool
+}
+
+// NewEncoder returns an encoder whose dynamic table holds tableSize bytes.
+// The peer's decoder must allow at least that much (its
+// SETTINGS_HEADER_TABLE_SIZE); the first block announces the size.
+func NewEncoder(tableSize int) *Encoder {
+	return &Encoder{table: newDynamicTable(tableSize), sendMaxSize: true}
+}
+
+// AppendHeaderBlock appends the encoding of fields to dst.
+func (e *Encoder) AppendHeaderBlock(dst []byte, fields []headerField) []byte {
+	if e.sendMaxSize {
+		dst = appendInt(dst, 5, 0x20, uint64(e.table.maxSize)) // Dynamic table size update
+		e.sendMaxSize = false
+	}
+	for _, f := range fields {
+		dst = e.appendField(dst, f)
+	}
+	return dst
+}
+
+func (e *Encoder) appendField(dst []byte, f headerField) []byte {
+	nv := nameValue{f.Name, f.Value}
+	dynPair, dynName := e.table.lookup(nv)
+
+	if !f.Sensitive {
+		if idx := staticByPair[nv]; idx > 0 {
+			return appendInt(dst, 7, 0x80, uint64(idx))
+		}
+		if dynPair > 0 {
+			return appendInt(dst, 7, 0x80, uint64(dynPair))
+		}
+	}
+
+	nameIdx := staticByName[f.Name]
+	if nameIdx == 0 {
+		nameIdx = dynName
+	}
+
+	if f.Sensitive {
+		dst = appendInt(dst, 4, 0x10, uint64(nameIdx)) // Literal never indexed
+	} else {
+		dst = appendInt(dst, 6, 0x40, uint64(nameIdx)) // Literal with incremental indexing
+		e.table.add(nv)
+	}
+	if nameIdx == 0 {
+		dst = appendString(dst, f.Name)
+	}
+	return appendString(dst, f.Value)
+}
+
+// appendInt writes v with an n-bit prefix (§5.1), OR-ing flags into the
+// first byte.
+func appendInt(dst []byte, n uint, flags byte, v uint64) []byte {
+	limit := uint64(1)<<n - 1
+	if v < limit {
+		return append(dst, flags|byte(v))
+	}
+	dst = append(dst, flags|byte(limit))
+	v -= limit
+	for v >= 128 {
+		dst = append(dst, byte(v&0x7f|0x80))
+		v >>= 7
+	}
+	return append(dst, byte(v))
+}
+
+// appendString writes a string literal (§5.2), Huffman-coded if shorter.
+func appendString(dst []byte, s string) []byte {
+	if hl := hpack.HuffmanEncodeLength(s); hl < uint64(len(s)) {
+		dst = appendInt(dst, 7, 0x80, hl)
+		return hpack.AppendHuffmanString(dst, s)
+	}
+	dst = appendInt(dst, 7, 0, uint64(len(s)))
+	return append(dst, s...)
+}
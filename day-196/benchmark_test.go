@@ -0,0 +1,155 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Global variable to prevent compiler optimizations
+var globalFloat float64
+
+// ========== HIT RATE BENCHMARKS ==========
+
+func Benchmark_LFUHitRate(b *testing.B) {
+	keys := zipfKeys(rand.New(rand.NewSource(1)), 1<<20, 100_000, 1.1)
+	c := NewLFUCache[int, int](10_000)
+	b.ReportAllocs()
+	hits := 0
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(1<<20-1)]
+		if _, ok := c.Get(k); ok {
+			hits++
+		} else {
+			c.Put(k, k)
+		}
+	}
+	globalFloat = float64(hits) / float64(b.N)
+	b.ReportMetric(globalFloat*100, "%hit")
+}
+
+func Benchmark_LRUHitRate(b *testing.B) {
+	keys := zipfKeys(rand.New(rand.NewSource(1)), 1<<20, 100_000, 1.1)
+	c := NewLRUCache[int, int](10_000)
+	b.ReportAllocs()
+	hits := 0
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(1<<20-1)]
+		if _, ok := c.Get(k); ok {
+			hits++
+		} else {
+			c.Put(k, k)
+		}
+	}
+	globalFloat = float64(hits) / float64(b.N)
+	b.ReportMetric(globalFloat*100, "%hit")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_LFUEvictsLowestFrequency(t *testing.T) {
+	c := NewLFUCache[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("c")
+	// Frequencies: a=3, b=1, c=2
+
+	c.Put("d", 4) // Evicts b
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b (frequency 1) to be evicted")
+	}
+	for _, k := range []string{"a", "c", "d"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("expected %s to survive", k)
+		}
+	}
+	// Frequencies now: a=4, c=3, d=2
+	c.Put("e", 5) // Evicts d
+	if _, ok := c.Get("d"); ok {
+		t.Error("expected d (frequency 2) to be evicted")
+	}
+	if got := c.Frequency("a"); got != 4 {
+		t.Errorf("expected a to have frequency 4, got %d", got)
+	}
+}
+
+func Test_LFUTiesBrokenByLRU(t *testing.T) {
+	c := NewLFUCache[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	// All at frequency 1; a is least recently used
+	c.Put("d", 4)
+	if _, ok := c.items["a"]; ok {
+		t.Error("expected a (oldest of the tied keys) to be evicted")
+	}
+
+	// Raise b, c and d to frequency 2 in that order: b is now the least
+	// recently used of the tied keys
+	c.Get("b")
+	c.Get("c")
+	c.Get("d")
+	c.Put("e", 5)
+	if _, ok := c.items["b"]; ok {
+		t.Error("expected b, least recently used at frequency 2, to be evicted")
+	}
+	for _, k := range []string{"c", "d", "e"} {
+		if _, ok := c.items[k]; !ok {
+			t.Errorf("expected %s to survive", k)
+		}
+	}
+}
+
+func Test_LFUUpdateKeepsCapacity(t *testing.T) {
+	c := NewLFUCache[int, string](2)
+	c.Put(1, "a")
+	c.Put(1, "b")
+	c.Put(2, "c")
+	if c.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d", c.Len())
+	}
+	if v, _ := c.Get(1); v != "b" {
+		t.Errorf("expected updated value b, got %q", v)
+	}
+	if got := c.Frequency(1); got != 3 {
+		t.Errorf("expected Put on an existing key to count as an access (freq 3), got %d", got)
+	}
+	if c.buckets.Len() > c.Len() {
+		t.Errorf("empty buckets leaked: %d buckets for %d entries", c.buckets.Len(), c.Len())
+	}
+}
+
+func Test_LFUConstantTimeOperations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	// O(1) means per-op cost doesn't grow with the number of entries or
+	// distinct frequencies. Allow a wide margin for cache misses.
+	perOp := func(capacity int) time.Duration {
+		c := NewLFUCache[int, int](capacity)
+		keys := zipfKeys(rand.New(rand.NewSource(2)), 200_000, capacity*10, 1.1)
+		replay(c, keys) // Warm up: fill the cache and build many buckets
+		start := time.Now()
+		replay(c, keys)
+		return time.Since(start) / time.Duration(len(keys))
+	}
+
+	small, large := perOp(1_000), perOp(100_000)
+	t.Logf("per op: %v at 1k entries, %v at 100k entries", small, large)
+	if large > 8*small {
+		t.Errorf("expected O(1) operations, per-op time grew %.1fx for 100x entries",
+			float64(large)/float64(small))
+	}
+}
+
+func Test_LFUBeatsLRUOnZipf(t *testing.T) {
+	keys := zipfKeys(rand.New(rand.NewSource(3)), 500_000, 100_000, 1.05)
+	lru := replay(NewLRUCache[int, int](10_000), keys)
+	lfu := replay(NewLFUCache[int, int](10_000), keys)
+	if lfu <= lru {
+		t.Errorf("expected LFU to beat LRU on Zipf, got %.3f vs %.3f", lfu, lru)
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Cache is the interface both eviction policies implement.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Put(key K, value V)
+	Len() int
+}
+
+// ========== LFU ==========
+
+// freqBucket holds every entry accessed exactly freq times, most recently
+// used at the front, so ties between equal frequencies evict LRU-first.
+type freqBucket[K comparable, V any] struct {
+	freq    int
+	entries *list.List // of *lfuEntry
+}
+
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	bucket *list.Element // In LFUCache.buckets
+	elem   *list.Element // In bucket.entries
+}
+
+// LFUCache evicts the least frequently used entry in O(1). Buckets are
+// kept in a list sorted by ascending frequency and created on demand, so
+// the victim is always at the back of the first bucket, and a hit moves
+// an entry into the next bucket (creating it if missing) without any
+// search.
+type LFUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*lfuEntry[K, V]
+	buckets  *list.List // of *freqBucket, ascending freq
+}
+
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+		buckets:  list.New(),
+	}
+}
+
+func (c *LFUCache[K, V]) Len() int { return len(c.items) }
+
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+
+	// New entries start at frequency 1, which is always the first bucket
+	first := c.buckets.Front()
+	if first == nil || first.Value.(*freqBucket[K, V]).freq != 1 {
+		first = c.buckets.PushFront(&freqBucket[K, V]{freq: 1, entries: list.New()})
+	}
+	e := &lfuEntry[K, V]{key: key, value: value, bucket: first}
+	e.elem = first.Value.(*freqBucket[K, V]).entries.PushFront(e)
+	c.items[key] = e
+}
+
+// Frequency returns how many times key has been accessed, or 0.
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	if e, ok := c.items[key]; ok {
+		return e.bucket.Value.(*freqBucket[K, V]).freq
+	}
+	return 0
+}
+
+// touch moves e from its bucket to the freq+1 bucket.
+func (c *LFUCache[K, V]) touch(e *lfuEntry[K, V]) {
+	cur := e.bucket
+	b := cur.Value.(*freqBucket[K, V])
+
+	next := cur.Next()
+	if next == nil || next.Value.(*freqBucket[K, V]).freq != b.freq+1 {
+		next = c.buckets.InsertAfter(&freqBucket[K, V]{freq: b.freq + 1, entries: list.New()}, cur)
+	}
+	b.entries.Remove(e.elem)
+	e.bucket = next
+	e.elem = next.Value.(*freqBucket[K, V]).entries.PushFront(e)
+
+	if b.entries.Len() == 0 {
+		c.buckets.Remove(cur)
+	}
+}
+
+// evict removes the least recently used entry of the lowest frequency.
+func (c *LFUCache[K, V]) evict() {
+	front := c.buckets.Front()
+	if front == nil {
+		return
+	}
+	b := front.Value.(*freqBucket[K, V])
+	victim := b.entries.Remove(b.entries.Back()).(*lfuEntry[K, V])
+	delete(c.items, victim.key)
+	if b.entries.Len() == 0 {
+		c.buckets.Remove(front)
+	}
+}
+
+// ========== LRU ==========
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRUCache is the classic map + doubly-linked list.
+type LRUCache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // Most recent at the front
+}
+
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *LRUCache[K, V]) Len() int { return len(c.items) }
+
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	el, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry[K, V]).value, true
+}
+
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	if c.capacity <= 0 {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// ========== WORKLOADS ==========
+
+// zipfKeys draws n keys from a Zipf distribution over keySpace keys with
+// exponent s (> 1): key 0 is the most popular.
+func zipfKeys(rng *rand.Rand, n, keySpace int, s float64) []int {
+	z := rand.NewZipf(rng, s, 1, uint64(keySpace-1))
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = int(z.Uint64())
+	}
+	return keys
+}
+
+// withScans replaces scanShare of the requests with sequential one-off
+// keys outside the Zipf key space, like a crawler or batch export.
+func withScans(keys []int, keySpace int, scanShare float64, rng *rand.Rand) []int {
+	out := make([]int, len(keys))
+	next := keySpace
+	for i, k := range keys {
+		if rng.Float64() < scanShare {
+			out[i] = next
+			next++
+			continue
+		}
+		out[i] = k
+	}
+	return out
+}
+
+// replay runs a read-through workload and returns the hit rate.
+func replay(c Cache[int, int], keys []int) float64 {
+	hits := 0
+	for _, k := range keys {
+		if _, ok := c.Get(k); ok {
+			hits++
+			continue
+		}
+		c.Put(k, k)
+	}
+	return float64(hits) / float64(len(keys))
+}
+
+func main() {
+	fmt.Println("🔬 DAY 196: LFU Cache with Frequency Buckets")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		capacity = 10_000
+		keySpace = 10 * capacity
+		requests = 2_000_000
+	)
+	rng := rand.New(rand.NewSource(196))
+	keys := zipfKeys(rng, requests, keySpace, 1.1)
+
+	fmt.Printf("📊 BENCHMARK: %d requests, Zipf s=1.1, %d keys, capacity %d\n", requests, keySpace, capacity)
+	fmt.Println(strings.Repeat("-", 40))
+
+	lfuRate, lfuTime := benchmarkLFUHitRate(keys, capacity)
+	fmt.Printf("1. LFU: %.2f%% hit rate, %v (%.0f ns/op)\n", lfuRate*100, lfuTime, nsPerOp(lfuTime, requests))
+
+	lruRate, lruTime := benchmarkLRUHitRate(keys, capacity)
+	fmt.Printf("2. LRU: %.2f%% hit rate, %v (%.0f ns/op)\n", lruRate*100, lruTime, nsPerOp(lruTime, requests))
+
+	fmt.Println("\n🔧 HIT RATE BY WORKLOAD")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeEvictionPolicyHitRate(rng, capacity, keySpace, requests/4)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateLFUCostImpact(lruRate, lfuRate, nsPerOp(lruTime, requests), nsPerOp(lfuTime, requests))
+
+	fmt.Println("\n✅ DAY 196 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 197 - mmap Ring Buffer")
+}
+
+func nsPerOp(d time.Duration, n int) float64 {
+	return float64(d.Nanoseconds()) / float64(n)
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+func benchmarkLFUHitRate(keys []int, capacity int) (float64, time.Duration) {
+	start := time.Now()
+	rate := replay(NewLFUCache[int, int](capacity), keys)
+	return rate, time.Since(start)
+}
+
+func benchmarkLRUHitRate(keys []int, capacity int) (float64, time.Duration) {
+	start := time.Now()
+	rate := replay(NewLRUCache[int, int](capacity), keys)
+	return rate, time.Since(start)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeEvictionPolicyHitRate(rng *rand.Rand, capacity, keySpace, requests int) {
+	fmt.Println("  Workload                    |    LRU |    LFU | LFU gain")
+	fmt.Println("  ----------------------------|--------|--------|---------")
+	for _, s := range []float64{1.01, 1.1, 1.3} {
+		keys := zipfKeys(rng, requests, keySpace, s)
+		printHitRates(fmt.Sprintf("Zipf s=%.2f", s), keys, capacity)
+	}
+	scanned := withScans(zipfKeys(rng, requests, keySpace, 1.1), keySpace, 0.3, rng)
+	printHitRates("Zipf s=1.10 + 30% scans", scanned, capacity)
+
+	fmt.Println()
+	fmt.Println("💡 Under a skewed distribution the popular keys are popular all the")
+	fmt.Println("   time. LRU forgets that after `capacity` distinct misses; LFU")
+	fmt.Println("   keeps them because one-off keys never out-count them. Scans make")
+	fmt.Println("   the gap wider. The catch: pure LFU never ages, so yesterday's hot")
+	fmt.Println("   keys linger. Production caches add decay (TinyLFU, W-TinyLFU).")
+}
+
+func printHitRates(name string, keys []int, capacity int) {
+	lru := replay(NewLRUCache[int, int](capacity), keys)
+	lfu := replay(NewLFUCache[int, int](capacity), keys)
+	fmt.Printf("  %-27s | %5.1f%% | %5.1f%% | %+6.1f pp\n", name, lru*100, lfu*100, (lfu-lru)*100)
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateLFUCostImpact(lruRate, lfuRate, lruNs, lfuNs float64) {
+	// Content delivery cache in front of an object-storage origin
+	requestsPerSecond := 20_000.0
+	objectKB := 100.0
+	originEgressPerGB := 0.09 // Origin → cache transfer
+	awsCostPerVCPUHour := 0.0416
+	secondsPerMonth := 3600.0 * 24 * 30
+
+	missGB := func(hitRate float64) float64 {
+		return (1 - hitRate) * requestsPerSecond * secondsPerMonth * objectKB / 1e6
+	}
+	lruCost := missGB(lruRate) * originEgressPerGB
+	lfuCost := missGB(lfuRate) * originEgressPerGB
+	extraCPUCost := (lfuNs - lruNs) * requestsPerSecond / 1e9 * awsCostPerVCPUHour * 24 * 30
+	monthlySavings := lruCost - lfuCost - extraCPUCost
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • CDN edge: %.0f requests/second, %.0f KB objects\n", requestsPerSecond, objectKB)
+	fmt.Printf("  • Origin egress: $%.2f/GB per miss\n", originEgressPerGB)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  LRU misses: %.1f%% → %.0f GB/month → $%.2f\n", (1-lruRate)*100, missGB(lruRate), lruCost)
+	fmt.Printf("  LFU misses: %.1f%% → %.0f GB/month → $%.2f\n", (1-lfuRate)*100, missGB(lfuRate), lfuCost)
+	fmt.Printf("  LFU extra CPU: %.0f ns/op → $%.2f/month\n", lfuNs-lruNs, extraCPUCost)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  For long-lived skewed popularity (static assets, product images),")
+	fmt.Println("  LFU's extra hits go straight to the origin bill. Add frequency")
+	fmt.Println("  decay if popularity shifts over days.")
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalInt int
+
+// ========== THROUGHPUT BENCHMARKS ==========
+
+// Producer and consumer are goroutines here; main.go runs them as
+// separate processes.
+
+func Benchmark_MmapRingBuffer(b *testing.B) {
+	ring, err := NewMmapRingBuffer(filepath.Join(b.TempDir(), "ring_buf"), ringCapacity)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ring.Close()
+
+	done := make(chan int)
+	go func() {
+		buf := make([]byte, messageSize)
+		n := 0
+		for {
+			if _, err := ring.Read(buf); err != nil {
+				break
+			}
+			n++
+		}
+		done <- n
+	}()
+
+	msg := make([]byte, messageSize)
+	b.SetBytes(messageSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ring.Write(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+	ring.CloseWrite()
+	globalInt = <-done
+}
+
+func benchmarkStream(b *testing.B, w io.WriteCloser, r io.Reader) {
+	done := make(chan int)
+	go func() {
+		buf := make([]byte, messageSize)
+		n := 0
+		for {
+			if _, err := io.ReadFull(r, buf); err != nil {
+				break
+			}
+			n++
+		}
+		done <- n
+	}()
+
+	msg := make([]byte, messageSize)
+	b.SetBytes(messageSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := w.Write(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+	w.Close()
+	globalInt = <-done
+}
+
+func Benchmark_OsPipe(b *testing.B) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer r.Close()
+	benchmarkStream(b, w, r)
+}
+
+func Benchmark_UnixSocket(b *testing.B) {
+	ln, err := net.Listen("unix", filepath.Join(b.TempDir(), "ipc.sock"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ln.Close()
+	client, err := net.Dial("unix", ln.Addr().String())
+	if err != nil {
+		b.Fatal(err)
+	}
+	server, err := ln.Accept()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer server.Close()
+	benchmarkStream(b, client, server)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_MmapRingBufferOrderingAndIntegrity(t *testing.T) {
+	// A small ring forces constant wrap-around and full/empty transitions
+	ring, err := NewMmapRingBuffer(filepath.Join(t.TempDir(), "ring_buf"), 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ring.Close()
+
+	const messages = 100_000
+	go func() {
+		defer ring.CloseWrite()
+		// Varying sizes put the length prefix and payload across the wrap
+		msg := make([]byte, 1000)
+		for i := 0; i < messages; i++ {
+			m := msg[:8+i%(len(msg)-8)]
+			fillMessage(m, uint64(i))
+			if err := ring.Write(m); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, 1000)
+	count := 0
+	for {
+		n, err := ring.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := 8 + count%(len(buf)-8); n != want {
+			t.Fatalf("message %d: expected %d bytes, got %d", count, want, n)
+		}
+		if !verifyMessage(buf[:n], uint64(count)) {
+			t.Fatalf("message %d corrupted or out of order", count)
+		}
+		count++
+	}
+	if count != messages {
+		t.Errorf("expected %d messages, got %d", messages, count)
+	}
+}
+
+func Test_MmapRingBufferSharedMapping(t *testing.T) {
+	// Two independent mappings of one file behave like two processes
+	path := filepath.Join(t.TempDir(), "ring_buf")
+	producer, err := NewMmapRingBuffer(path, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer producer.Close()
+	consumer, err := OpenMmapRingBuffer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer consumer.Close()
+
+	if consumer.Capacity() != 1024 {
+		t.Fatalf("expected capacity 1024, got %d", consumer.Capacity())
+	}
+	if err := producer.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	producer.CloseWrite()
+
+	buf := make([]byte, 16)
+	n, err := consumer.Read(buf)
+	if err != nil || !bytes.Equal(buf[:n], []byte("hello")) {
+		t.Fatalf("expected \"hello\", got %q, %v", buf[:n], err)
+	}
+	if _, err := consumer.Read(buf); err != io.EOF {
+		t.Errorf("expected io.EOF after close, got %v", err)
+	}
+}
+
+func Test_MmapRingBufferLimits(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewMmapRingBuffer(filepath.Join(dir, "odd"), 1000); err == nil {
+		t.Error("expected error for non-power-of-two capacity")
+	}
+
+	ring, err := NewMmapRingBuffer(filepath.Join(dir, "ring_buf"), 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ring.Close()
+
+	if _, err := ring.TryWrite(make([]byte, 61)); err != ErrMessageTooLarge {
+		t.Errorf("expected ErrMessageTooLarge, got %v", err)
+	}
+	if ok, err := ring.TryWrite(make([]byte, 60)); !ok || err != nil {
+		t.Fatalf("expected a 60-byte message to fill the ring, got %v, %v", ok, err)
+	}
+	if ok, _ := ring.TryWrite(nil); ok {
+		t.Error("expected write to a full ring to fail")
+	}
+	if _, _, err := ring.TryRead(make([]byte, 10)); err != io.ErrShortBuffer {
+		t.Errorf("expected io.ErrShortBuffer, got %v", err)
+	}
+	if n, ok, err := ring.TryRead(make([]byte, 60)); !ok || n != 60 || err != nil {
+		t.Errorf("expected to read the message after a short buffer, got %d, %v, %v", n, ok, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "junk"), make([]byte, 8192), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenMmapRingBuffer(filepath.Join(dir, "junk")); err != ErrBadRing {
+		t.Errorf("expected ErrBadRing, got %v", err)
+	}
+}
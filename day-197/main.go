@@ -0,0 +1,533 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	messageSize  = 256
+	messageCount = 1_000_000
+	ringCapacity = 1 << 20 // 1 MB, ~4000 messages in flight
+
+	// Environment variables that switch a re-executed copy of this binary
+	// into the consumer role
+	envRole      = "DAY197_ROLE"
+	envTransport = "DAY197_TRANSPORT"
+	envPath      = "DAY197_PATH"
+)
+
+// ipcResult is one transport's run: wall time for the transfer and the CPU
+// time both processes spent on it.
+type ipcResult struct {
+	Transport string
+	Messages  int
+	Elapsed   time.Duration
+	CPU       time.Duration
+}
+
+func (r ipcResult) MBPerSec() float64 {
+	return float64(r.Messages*messageSize) / (1 << 20) / r.Elapsed.Seconds()
+}
+
+func (r ipcResult) CPUNsPerMessage() float64 {
+	return float64(r.CPU.Nanoseconds()) / float64(r.Messages)
+}
+
+// ringPath prefers /dev/shm so the file is backed by tmpfs and never
+// written back to disk.
+func ringPath(name string) string {
+	if fi, err := os.Stat("/dev/shm"); err == nil && fi.IsDir() {
+		return filepath.Join("/dev/shm", name)
+	}
+	return filepath.Join(os.TempDir(), name)
+}
+
+// fillMessage writes sequence number seq and a seq-derived pattern, so the
+// consumer can check both ordering and integrity.
+func fillMessage(buf []byte, seq uint64) {
+	binary.LittleEndian.PutUint64(buf, seq)
+	for i := 8; i < len(buf); i++ {
+		buf[i] = byte(seq) + byte(i)
+	}
+}
+
+func verifyMessage(buf []byte, seq uint64) bool {
+	if len(buf) < 8 || binary.LittleEndian.Uint64(buf) != seq {
+		return false
+	}
+	for i := 8; i < len(buf); i++ {
+		if buf[i] != byte(seq)+byte(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	if os.Getenv(envRole) == "consumer" {
+		if err := runConsumer(os.Getenv(envTransport), os.Getenv(envPath)); err != nil {
+			fmt.Fprintln(os.Stderr, "consumer:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Println("🔬 DAY 197: Memory-Mapped Ring Buffer for IPC")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	fmt.Printf("📊 BENCHMARK: %d × %d-byte messages, producer → consumer process\n", messageCount, messageSize)
+	fmt.Println(strings.Repeat("-", 40))
+
+	var results []ipcResult
+	for i, run := range []func(int) (ipcResult, error){
+		benchmarkMmapRing,
+		benchmarkOsPipe,
+		benchmarkUnixSocket,
+	} {
+		r, err := run(messageCount)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		results = append(results, r)
+		fmt.Printf("%d. %s\n", i+1, r.Transport)
+		fmt.Printf("   %v, %.0f MB/s, %.0f ns CPU per message\n",
+			r.Elapsed.Round(time.Millisecond), r.MBPerSec(), r.CPUNsPerMessage())
+	}
+
+	fmt.Println("\n🔧 LATENCY COMPARISON")
+	fmt.Println(strings.Repeat("-", 40))
+	if err := analyzeIPCLatencyComparison(10_000); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateMmapIPCCostImpact(results)
+
+	fmt.Println("\n✅ DAY 197 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 198 - ShardedValueMap")
+}
+
+// ========== CONSUMER PROCESS ==========
+
+// runConsumer is the child side of every benchmark: it connects to the
+// transport, prints "ready", verifies every message, and prints the count.
+func runConsumer(transport, path string) error {
+	var read func(buf []byte) error
+	switch transport {
+	case "mmap":
+		ring, err := OpenMmapRingBuffer(path)
+		if err != nil {
+			return err
+		}
+		defer ring.Close()
+		read = func(buf []byte) error {
+			n, err := ring.Read(buf)
+			if err == nil && n != len(buf) {
+				err = fmt.Errorf("short message: %d bytes", n)
+			}
+			return err
+		}
+	case "pipe", "unix":
+		var r io.Reader
+		if transport == "pipe" {
+			r = os.NewFile(3, "pipe") // First of cmd.ExtraFiles
+		} else {
+			conn, err := net.Dial("unix", path)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+			r = conn
+		}
+		// Stream transports have no message boundaries: read in bulk and
+		// split into fixed-size frames
+		br := bufio.NewReaderSize(r, 64<<10)
+		read = func(buf []byte) error {
+			_, err := io.ReadFull(br, buf)
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown transport %q", transport)
+	}
+
+	fmt.Println("ready")
+	buf := make([]byte, messageSize)
+	count := uint64(0)
+	for {
+		err := read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !verifyMessage(buf, count) {
+			return fmt.Errorf("message %d corrupted or out of order", count)
+		}
+		count++
+	}
+	fmt.Println(count)
+	return nil
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// runTransfer starts the consumer, waits for it to connect, then times
+// produce. CPU time is the parent's rusage delta plus the child's total.
+func runTransfer(name, transport, path string, extra []*os.File, connect func() error,
+	produce func(msg []byte) error, finish func() error, messages int) (ipcResult, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return ipcResult{}, err
+	}
+	cmd := exec.Command(self)
+	cmd.Env = append(os.Environ(), envRole+"=consumer", envTransport+"="+transport, envPath+"="+path)
+	cmd.ExtraFiles = extra
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return ipcResult{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		return ipcResult{}, err
+	}
+	defer func() {
+		// On an early return the consumer may be blocked forever
+		if cmd.ProcessState == nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}
+	}()
+
+	lines := bufio.NewScanner(stdout)
+	if connect != nil {
+		if err := connect(); err != nil {
+			return ipcResult{}, err
+		}
+	}
+	if !lines.Scan() || lines.Text() != "ready" {
+		return ipcResult{}, fmt.Errorf("%s: consumer failed to start", name)
+	}
+
+	before := selfCPU()
+	start := time.Now()
+	msg := make([]byte, messageSize)
+	for i := 0; i < messages; i++ {
+		fillMessage(msg, uint64(i))
+		if err := produce(msg); err != nil {
+			return ipcResult{}, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if err := finish(); err != nil {
+		return ipcResult{}, err
+	}
+	if !lines.Scan() || lines.Text() != fmt.Sprint(messages) {
+		return ipcResult{}, fmt.Errorf("%s: consumer reported %q", name, lines.Text())
+	}
+	if err := cmd.Wait(); err != nil {
+		return ipcResult{}, fmt.Errorf("%s: consumer: %w", name, err)
+	}
+	elapsed := time.Since(start)
+
+	child := cmd.ProcessState.UserTime() + cmd.ProcessState.SystemTime()
+	return ipcResult{
+		Transport: name,
+		Messages:  messages,
+		Elapsed:   elapsed,
+		CPU:       selfCPU() - before + child,
+	}, nil
+}
+
+func selfCPU() time.Duration {
+	var ru unix.Rusage
+	if err := unix.Getrusage(unix.RUSAGE_SELF, &ru); err != nil {
+		return 0
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+}
+
+func benchmarkMmapRing(messages int) (ipcResult, error) {
+	path := ringPath("ring_buf")
+	ring, err := NewMmapRingBuffer(path, ringCapacity)
+	if err != nil {
+		return ipcResult{}, err
+	}
+	defer os.Remove(path)
+	defer ring.Close()
+
+	return runTransfer("mmap ring buffer", "mmap", path, nil, nil,
+		ring.Write,
+		func() error { ring.CloseWrite(); return nil },
+		messages)
+}
+
+func benchmarkOsPipe(messages int) (ipcResult, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return ipcResult{}, err
+	}
+	defer w.Close()
+
+	// The parent must drop its read end, or the child never sees EOF
+	connect := func() error { return r.Close() }
+	return runTransfer("os.Pipe", "pipe", "", []*os.File{r}, connect,
+		func(msg []byte) error { _, err := w.Write(msg); return err },
+		w.Close,
+		messages)
+}
+
+func benchmarkUnixSocket(messages int) (ipcResult, error) {
+	dir, err := os.MkdirTemp("", "day197")
+	if err != nil {
+		return ipcResult{}, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ipc.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return ipcResult{}, err
+	}
+	defer ln.Close()
+
+	var conn net.Conn
+	connect := func() error {
+		conn, err = ln.Accept()
+		return err
+	}
+	return runTransfer("Unix domain socket", "unix", path, nil, connect,
+		func(msg []byte) error { _, err := conn.Write(msg); return err },
+		func() error { return conn.Close() },
+		messages)
+}
+
+// ========== ANALYSIS ==========
+
+// pingPong measures the round trip of one message over a pair of
+// one-directional channels between two goroutines.
+type pingPong struct {
+	name       string
+	send, recv func([]byte) error // Client side
+	echo       func([]byte) error // Server side: read then write back
+	close      func()
+}
+
+func analyzeIPCLatencyComparison(roundTrips int) error {
+	transports := []func() (pingPong, error){newRingPingPong, newPipePingPong, newUnixPingPong}
+
+	fmt.Printf("  %d round trips of one %d-byte message, goroutine ↔ goroutine\n\n", roundTrips, messageSize)
+	fmt.Println("  Transport           | One-way latency | Syscalls/msg   | Copies/msg")
+	fmt.Println("  --------------------|-----------------|----------------|-------------")
+	for _, newPP := range transports {
+		pp, err := newPP()
+		if err != nil {
+			return err
+		}
+		latency, err := measurePingPong(pp, roundTrips)
+		pp.close()
+		if err != nil {
+			return err
+		}
+		syscalls, copies := "2 (write+read)", "2 via kernel"
+		if pp.name == "mmap ring buffer" {
+			syscalls, copies = "0 (idle only)", "2 user space"
+		}
+		fmt.Printf("  %-19s | %15v | %-14s | %s\n", pp.name, latency, syscalls, copies)
+	}
+	fmt.Println()
+	fmt.Println("💡 The ring's fast path is a memcpy and an atomic store. A pipe or")
+	fmt.Println("   socket pays a write and a read syscall plus a wakeup per message.")
+	fmt.Println("   The ring's cost is idle polling: a consumer that spins burns a")
+	fmt.Println("   core, so it sleeps after 64 empty polls and trades latency for CPU.")
+	return nil
+}
+
+func measurePingPong(pp pingPong, roundTrips int) (time.Duration, error) {
+	errc := make(chan error, 1)
+	go func() {
+		buf := make([]byte, messageSize)
+		for i := 0; i < roundTrips; i++ {
+			if err := pp.echo(buf); err != nil {
+				errc <- err
+				return
+			}
+		}
+		errc <- nil
+	}()
+
+	msg := make([]byte, messageSize)
+	start := time.Now()
+	for i := 0; i < roundTrips; i++ {
+		fillMessage(msg, uint64(i))
+		if err := pp.send(msg); err != nil {
+			return 0, err
+		}
+		if err := pp.recv(msg); err != nil {
+			return 0, err
+		}
+		if !verifyMessage(msg, uint64(i)) {
+			return 0, fmt.Errorf("%s: echo %d corrupted", pp.name, i)
+		}
+	}
+	elapsed := time.Since(start)
+	if err := <-errc; err != nil {
+		return 0, err
+	}
+	return elapsed / time.Duration(2*roundTrips), nil
+}
+
+func newRingPingPong() (pingPong, error) {
+	reqPath, respPath := ringPath("ring_buf_req"), ringPath("ring_buf_resp")
+	req, err := NewMmapRingBuffer(reqPath, 64<<10)
+	if err != nil {
+		return pingPong{}, err
+	}
+	resp, err := NewMmapRingBuffer(respPath, 64<<10)
+	if err != nil {
+		req.Close()
+		return pingPong{}, err
+	}
+	read := func(ring *MmapRingBuffer) func([]byte) error {
+		return func(buf []byte) error { _, err := ring.Read(buf); return err }
+	}
+	return pingPong{
+		name: "mmap ring buffer",
+		send: req.Write,
+		recv: read(resp),
+		echo: func(buf []byte) error {
+			if err := read(req)(buf); err != nil {
+				return err
+			}
+			return resp.Write(buf)
+		},
+		close: func() {
+			req.Close()
+			resp.Close()
+			os.Remove(reqPath)
+			os.Remove(respPath)
+		},
+	}, nil
+}
+
+// streamPingPong frames fixed-size messages over a client→server and a
+// server→client stream.
+func streamPingPong(name string, toServer, fromServer io.Writer, atServer, atClient io.Reader, close func()) pingPong {
+	return pingPong{
+		name: name,
+		send: func(msg []byte) error { _, err := toServer.Write(msg); return err },
+		recv: func(buf []byte) error { _, err := io.ReadFull(atClient, buf); return err },
+		echo: func(buf []byte) error {
+			if _, err := io.ReadFull(atServer, buf); err != nil {
+				return err
+			}
+			_, err := fromServer.Write(buf)
+			return err
+		},
+		close: close,
+	}
+}
+
+func newPipePingPong() (pingPong, error) {
+	reqR, reqW, err := os.Pipe()
+	if err != nil {
+		return pingPong{}, err
+	}
+	respR, respW, err := os.Pipe()
+	if err != nil {
+		reqR.Close()
+		reqW.Close()
+		return pingPong{}, err
+	}
+	return streamPingPong("os.Pipe", reqW, respW, reqR, respR, func() {
+		for _, f := range []*os.File{reqR, reqW, respR, respW} {
+			f.Close()
+		}
+	}), nil
+}
+
+func newUnixPingPong() (pingPong, error) {
+	dir, err := os.MkdirTemp("", "day197")
+	if err != nil {
+		return pingPong{}, err
+	}
+	ln, err := net.Listen("unix", filepath.Join(dir, "pp.sock"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return pingPong{}, err
+	}
+	defer ln.Close()
+
+	client, err := net.Dial("unix", ln.Addr().String())
+	if err != nil {
+		os.RemoveAll(dir)
+		return pingPong{}, err
+	}
+	server, err := ln.Accept()
+	if err != nil {
+		client.Close()
+		os.RemoveAll(dir)
+		return pingPong{}, err
+	}
+	// A socket is bidirectional, so one connection carries both directions
+	return streamPingPong("Unix domain socket", client, server, server, client, func() {
+		client.Close()
+		server.Close()
+		os.RemoveAll(dir)
+	}), nil
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateMmapIPCCostImpact(results []ipcResult) {
+	// Service mesh: each pod's sidecar proxy hands 1 Gbps to the app over
+	// local IPC
+	pods := 50.0
+	bytesPerSec := 1e9 / 8
+	msgsPerSec := bytesPerSec / messageSize
+	awsCostPerVCPUHour := 0.0416
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f pods, each sidecar ↔ app link at 1 Gbps\n", pods)
+	fmt.Printf("  • %d-byte messages → %.0f messages/second per pod\n", messageSize, msgsPerSec)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	monthly := make([]float64, len(results))
+	for i, r := range results {
+		vCPUs := msgsPerSec * r.CPUNsPerMessage() / 1e9 * pods
+		monthly[i] = vCPUs * awsCostPerVCPUHour * 24 * 30
+		fmt.Printf("  %-19s %6.0f ns/msg → %5.1f vCPUs → $%.2f/month\n",
+			r.Transport+":", r.CPUNsPerMessage(), vCPUs, monthly[i])
+	}
+
+	// Compare against the cheaper kernel transport so the saving is not
+	// inflated by a bad baseline
+	baseline := min(monthly[1], monthly[2])
+	monthlySavings := baseline - monthly[0]
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	if monthlySavings > 0 {
+		fmt.Println("  For high-volume local IPC a shared-memory ring removes the per-message")
+		fmt.Println("  syscalls. Keep sockets where you need many peers, kernel-managed")
+		fmt.Println("  lifetimes, or a path to remote hosts, and bound the idle polling.")
+	} else {
+		fmt.Println("  The ring's polling cost outweighs the syscalls it saves at this load.")
+		fmt.Println("  Stick with Unix domain sockets unless messages are small and constant.")
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Shared file layout. The header takes one page so the data region stays
+// page aligned; head and tail sit on separate cache lines so the producer
+// and consumer never write to the same line.
+//
+//	[0:8)     magic
+//	[8:16)    data capacity in bytes (power of two)
+//	[64:72)   tail: bytes ever written (producer only)
+//	[128:136) head: bytes ever read (consumer only)
+//	[192:196) closed flag (producer only)
+//	[4096:)   data
+const (
+	ringMagic      = 0x3146554252474e52 // "RNGRBUF1"
+	offCapacity    = 8
+	offTail        = 64
+	offHead        = 128
+	offClosed      = 192
+	ringHeaderSize = 4096
+	lenPrefixSize  = 4
+
+	spinsBeforeSleep = 64
+	idleSleep        = 20 * time.Microsecond
+)
+
+var (
+	ErrMessageTooLarge = errors.New("ring: message larger than buffer capacity")
+	ErrBadRing         = errors.New("ring: file is not a ring buffer")
+)
+
+// MmapRingBuffer is a single-producer single-consumer byte ring in a shared
+// memory file. Both processes map the same pages, so a message is copied
+// once into the ring and once out of it, with no syscalls on the fast path.
+// Messages are length-prefixed and may wrap around the end of the buffer.
+type MmapRingBuffer struct {
+	file *os.File
+	mem  []byte
+	data []byte
+	mask uint64
+
+	tail   *atomic.Uint64
+	head   *atomic.Uint64
+	closed *atomic.Uint32
+}
+
+// NewMmapRingBuffer creates (or truncates) path and initialises a ring with
+// capacity bytes of data space. capacity must be a power of two.
+func NewMmapRingBuffer(path string, capacity int) (*MmapRingBuffer, error) {
+	if capacity <= lenPrefixSize || capacity&(capacity-1) != 0 {
+		return nil, fmt.Errorf("ring: capacity %d is not a power of two", capacity)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(int64(ringHeaderSize + capacity)); err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := mapRing(f, ringHeaderSize+capacity)
+	if err != nil {
+		return nil, err
+	}
+	// Capacity before magic: a consumer that sees the magic can trust it
+	binary.LittleEndian.PutUint64(r.mem[offCapacity:], uint64(capacity))
+	(*atomic.Uint64)(unsafe.Pointer(&r.mem[0])).Store(ringMagic)
+	r.init(capacity)
+	return r, nil
+}
+
+// OpenMmapRingBuffer maps a ring created by NewMmapRingBuffer in another
+// process.
+func OpenMmapRingBuffer(path string) (*MmapRingBuffer, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() <= ringHeaderSize {
+		f.Close()
+		return nil, ErrBadRing
+	}
+	r, err := mapRing(f, int(fi.Size()))
+	if err != nil {
+		return nil, err
+	}
+	capacity := binary.LittleEndian.Uint64(r.mem[offCapacity:])
+	if (*atomic.Uint64)(unsafe.Pointer(&r.mem[0])).Load() != ringMagic ||
+		capacity != uint64(fi.Size()-ringHeaderSize) {
+		r.Close()
+		return nil, ErrBadRing
+	}
+	r.init(int(capacity))
+	return r, nil
+}
+
+func mapRing(f *os.File, size int) (*MmapRingBuffer, error) {
+	mem, err := unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("ring: mmap: %w", err)
+	}
+	return &MmapRingBuffer{file: f, mem: mem}, nil
+}
+
+func (r *MmapRingBuffer) init(capacity int) {
+	r.data = r.mem[ringHeaderSize : ringHeaderSize+capacity]
+	r.mask = uint64(capacity - 1)
+	// mmap returns page-aligned memory, so these are 8-byte aligned
+	r.tail = (*atomic.Uint64)(unsafe.Pointer(&r.mem[offTail]))
+	r.head = (*atomic.Uint64)(unsafe.Pointer(&r.mem[offHead]))
+	r.closed = (*atomic.Uint32)(unsafe.Pointer(&r.mem[offClosed]))
+}
+
+// Capacity is the size of the data region in bytes.
+func (r *MmapRingBuffer) Capacity() int { return len(r.data) }
+
+// TryWrite appends msg if there is room and reports whether it did.
+func (r *MmapRingBuffer) TryWrite(msg []byte) (bool, error) {
+	need := uint64(lenPrefixSize + len(msg))
+	if need > uint64(len(r.data)) {
+		return false, ErrMessageTooLarge
+	}
+	tail := r.tail.Load() // Only this side writes tail; the load is for symmetry
+	if tail-r.head.Load()+need > uint64(len(r.data)) {
+		return false, nil
+	}
+
+	var prefix [lenPrefixSize]byte
+	binary.LittleEndian.PutUint32(prefix[:], uint32(len(msg)))
+	r.copyIn(tail, prefix[:])
+	r.copyIn(tail+lenPrefixSize, msg)
+	// Publishing tail after the copies makes the bytes visible to the consumer
+	r.tail.Store(tail + need)
+	return true, nil
+}
+
+// Write blocks until msg fits in the ring.
+func (r *MmapRingBuffer) Write(msg []byte) error {
+	for spins := 0; ; spins++ {
+		ok, err := r.TryWrite(msg)
+		if ok || err != nil {
+			return err
+		}
+		backoff(spins)
+	}
+}
+
+// CloseWrite marks the stream finished. Read returns io.EOF once the
+// consumer has drained everything written before it.
+func (r *MmapRingBuffer) CloseWrite() {
+	r.closed.Store(1)
+}
+
+// TryRead copies the next message into buf. It returns ok=false if the
+// ring is empty, and io.ErrShortBuffer (leaving the message in place) if
+// buf is too small.
+func (r *MmapRingBuffer) TryRead(buf []byte) (n int, ok bool, err error) {
+	head := r.head.Load()
+	if head == r.tail.Load() {
+		return 0, false, nil
+	}
+
+	var prefix [lenPrefixSize]byte
+	r.copyOut(head, prefix[:])
+	n = int(binary.LittleEndian.Uint32(prefix[:]))
+	if n > len(buf) {
+		return 0, false, io.ErrShortBuffer
+	}
+	r.copyOut(head+lenPrefixSize, buf[:n])
+	// Freeing the space only after copying out keeps the producer off it
+	r.head.Store(head + lenPrefixSize + uint64(n))
+	return n, true, nil
+}
+
+// Read blocks until a message is available and copies it into buf.
+func (r *MmapRingBuffer) Read(buf []byte) (int, error) {
+	for spins := 0; ; spins++ {
+		// Check closed before emptiness: a message written just before
+		// CloseWrite is then still seen by TryRead
+		closed := r.closed.Load() != 0
+		n, ok, err := r.TryRead(buf)
+		if ok || err != nil {
+			return n, err
+		}
+		if closed {
+			return 0, io.EOF
+		}
+		backoff(spins)
+	}
+}
+
+// Close unmaps the ring. The file is left in place for the other side.
+func (r *MmapRingBuffer) Close() error {
+	err := unix.Munmap(r.mem)
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.mem, r.data = nil, nil
+	return err
+}
+
+// copyIn writes p at logical offset off, wrapping around the end.
+func (r *MmapRingBuffer) copyIn(off uint64, p []byte) {
+	i := int(off & r.mask)
+	n := copy(r.data[i:], p)
+	copy(r.data, p[n:])
+}
+
+// copyOut reads len(p) bytes at logical offset off, wrapping around the end.
+func (r *MmapRingBuffer) copyOut(off uint64, p []byte) {
+	i := int(off & r.mask)
+	n := copy(p, r.data[i:])
+	copy(p[n:], r.data)
+}
+
+// backoff yields to other goroutines for a few rounds, then sleeps so a
+// waiting process gives its core to the peer instead of burning it.
+func backoff(spins int) {
+	if spins < spinsBeforeSleep {
+		runtime.Gosched()
+		return
+	}
+	time.Sleep(idleSleep)
+}
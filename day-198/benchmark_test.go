@@ -0,0 +1,173 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+)
+
+// Global variable to prevent compiler optimizations
+var globalUser User
+
+// ========== LOOKUP BENCHMARKS ==========
+
+const benchEntries = 100_000
+
+func Benchmark_PointerMapGet(b *testing.B) {
+	m := make(map[int]*User, benchEntries)
+	for i := 0; i < benchEntries; i++ {
+		u := newUser(i)
+		m[i] = &u
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalUser = *m[i%benchEntries]
+	}
+}
+
+func Benchmark_ShardedValueMapGet(b *testing.B) {
+	m := NewShardedValueMap[int, User](defaultShardSize)
+	for i := 0; i < benchEntries; i++ {
+		m.Put(i, newUser(i))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalUser, _ = m.Get(i % benchEntries)
+	}
+}
+
+// ========== GC BENCHMARKS ==========
+
+// Each op is one forced GC cycle with the populated store live.
+
+func Benchmark_PointerMapGC(b *testing.B) {
+	m := make(map[int]*User, benchEntries)
+	for i := 0; i < benchEntries; i++ {
+		u := newUser(i)
+		m[i] = &u
+	}
+	runtime.GC()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+	runtime.KeepAlive(m)
+}
+
+func Benchmark_ShardedValueMapGC(b *testing.B) {
+	m := NewShardedValueMap[int, User](defaultShardSize)
+	for i := 0; i < benchEntries; i++ {
+		m.Put(i, newUser(i))
+	}
+	runtime.GC()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+	runtime.KeepAlive(m)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ShardedValueMapAllEntriesRetrievable(t *testing.T) {
+	const n = 10_000
+	m := NewShardedValueMap[int, User](128)
+	for i := 0; i < n; i++ {
+		m.Put(i, newUser(i))
+	}
+	if m.Len() != n {
+		t.Fatalf("expected %d entries, got %d", n, m.Len())
+	}
+	if want := (n + 127) / 128; m.Shards() != want {
+		t.Errorf("expected %d shards, got %d", want, m.Shards())
+	}
+	for i := 0; i < n; i++ {
+		u, ok := m.Get(i)
+		if !ok || u != newUser(i) {
+			t.Fatalf("entry %d: got %+v, %v", i, u, ok)
+		}
+	}
+	if _, ok := m.Get(n); ok {
+		t.Error("expected missing key to be absent")
+	}
+
+	// Put on an existing key overwrites in place
+	m.Put(5, newUser(500))
+	if u, _ := m.Get(5); u.ID != 500 || m.Len() != n {
+		t.Errorf("expected overwrite without growth, got ID %d, len %d", u.ID, m.Len())
+	}
+	m.Ref(6).Score = 99
+	if u, _ := m.Get(6); u.Score != 99 {
+		t.Errorf("expected Ref update to stick, got %v", u.Score)
+	}
+}
+
+func Test_ShardedValueMapDelete(t *testing.T) {
+	const n = 1000
+	m := NewShardedValueMap[int, User](64)
+	for i := 0; i < n; i++ {
+		m.Put(i, newUser(i))
+	}
+
+	// Delete every even key: holes in every shard and across the last one
+	for i := 0; i < n; i += 2 {
+		if !m.Delete(i) {
+			t.Fatalf("expected Delete(%d) to succeed", i)
+		}
+	}
+	if m.Delete(0) {
+		t.Error("expected second Delete(0) to fail")
+	}
+
+	if m.Len() != n/2 || len(m.index) != n/2 {
+		t.Fatalf("expected %d entries in index, got Len %d, index %d", n/2, m.Len(), len(m.index))
+	}
+	stored := 0
+	for s := range m.shards {
+		stored += len(m.shards[s])
+		if len(m.keys[s]) != len(m.shards[s]) {
+			t.Fatalf("shard %d: %d keys for %d values", s, len(m.keys[s]), len(m.shards[s]))
+		}
+	}
+	if stored != n/2 {
+		t.Errorf("expected %d values in shards, got %d", n/2, stored)
+	}
+	if want := (n/2 + 63) / 64; m.Shards() != want {
+		t.Errorf("expected emptied shards to be released: want %d, got %d", want, m.Shards())
+	}
+
+	for i := 0; i < n; i++ {
+		u, ok := m.Get(i)
+		if i%2 == 0 && ok {
+			t.Errorf("deleted key %d still present", i)
+		}
+		if i%2 == 1 && (!ok || u != newUser(i)) {
+			t.Errorf("key %d: expected its user after moves, got %+v, %v", i, u, ok)
+		}
+	}
+
+	seen := 0
+	m.Range(func(k int, u *User) bool {
+		if u.ID != int64(k) {
+			t.Errorf("Range: key %d paired with user %d", k, u.ID)
+		}
+		seen++
+		return true
+	})
+	if seen != n/2 {
+		t.Errorf("Range visited %d entries, expected %d", seen, n/2)
+	}
+
+	// Drain completely, then reuse
+	for i := 1; i < n; i += 2 {
+		m.Delete(i)
+	}
+	if m.Len() != 0 || m.Shards() != 0 || len(m.index) != 0 {
+		t.Errorf("expected empty map, got Len %d, shards %d", m.Len(), m.Shards())
+	}
+	m.Put(42, newUser(42))
+	if u, ok := m.Get(42); !ok || u.ID != 42 {
+		t.Error("expected Put after draining to work")
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// User is pointer-free on purpose: fixed-size arrays instead of strings,
+// so a []User is a single block the GC never has to look inside.
+type User struct {
+	ID       int64
+	Name     [32]byte
+	Email    [48]byte
+	LastSeen int64
+	Score    float64
+}
+
+func newUser(id int) User {
+	u := User{ID: int64(id), LastSeen: int64(id) * 1000, Score: float64(id%100) / 10}
+	copy(u.Name[:], fmt.Sprintf("user-%d", id))
+	copy(u.Email[:], fmt.Sprintf("user-%d@example.com", id))
+	return u
+}
+
+// ========== SHARDED VALUE MAP ==========
+
+const defaultShardSize = 4096
+
+// ShardedValueMap stores values inline in fixed-size []V shards and keeps
+// only a packed (shard, offset) location per key in the index map. With
+// pointer-free K and V, neither the index nor the shards contain pointers,
+// so the GC skips them entirely: the only pointers it traces are the
+// shard slice headers.
+//
+// Deletion moves the last value into the freed slot, so values stay dense
+// and locations change; do not hold on to pointers from Ref across a
+// Delete.
+type ShardedValueMap[K comparable, V any] struct {
+	index     map[K]uint64
+	shards    [][]V
+	keys      [][]K // keys[s][o] owns shards[s][o]; needed to fix up moved values
+	shardSize int
+	n         int
+}
+
+func NewShardedValueMap[K comparable, V any](shardSize int) *ShardedValueMap[K, V] {
+	if shardSize <= 0 {
+		shardSize = defaultShardSize
+	}
+	return &ShardedValueMap[K, V]{index: make(map[K]uint64), shardSize: shardSize}
+}
+
+func packLocation(shard, offset int) uint64 { return uint64(shard)<<32 | uint64(uint32(offset)) }
+
+func unpackLocation(loc uint64) (shard, offset int) { return int(loc >> 32), int(uint32(loc)) }
+
+func (m *ShardedValueMap[K, V]) Len() int { return m.n }
+
+// Shards is the number of allocated shards. The GC traces two slice
+// headers (values and keys) per shard.
+func (m *ShardedValueMap[K, V]) Shards() int { return len(m.shards) }
+
+func (m *ShardedValueMap[K, V]) Get(key K) (V, bool) {
+	loc, ok := m.index[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	s, o := unpackLocation(loc)
+	return m.shards[s][o], true
+}
+
+// Ref returns a pointer to the stored value for in-place updates, or nil.
+func (m *ShardedValueMap[K, V]) Ref(key K) *V {
+	loc, ok := m.index[key]
+	if !ok {
+		return nil
+	}
+	s, o := unpackLocation(loc)
+	return &m.shards[s][o]
+}
+
+func (m *ShardedValueMap[K, V]) Put(key K, value V) {
+	if loc, ok := m.index[key]; ok {
+		s, o := unpackLocation(loc)
+		m.shards[s][o] = value
+		return
+	}
+
+	last := len(m.shards) - 1
+	if last < 0 || len(m.shards[last]) == m.shardSize {
+		m.shards = append(m.shards, make([]V, 0, m.shardSize))
+		m.keys = append(m.keys, make([]K, 0, m.shardSize))
+		last++
+	}
+	m.index[key] = packLocation(last, len(m.shards[last]))
+	m.shards[last] = append(m.shards[last], value)
+	m.keys[last] = append(m.keys[last], key)
+	m.n++
+}
+
+// Delete removes key from the index and its value from the shards by
+// moving the very last value into the hole.
+func (m *ShardedValueMap[K, V]) Delete(key K) bool {
+	loc, ok := m.index[key]
+	if !ok {
+		return false
+	}
+	delete(m.index, key)
+	s, o := unpackLocation(loc)
+
+	last := len(m.shards) - 1
+	lastOff := len(m.shards[last]) - 1
+	if s != last || o != lastOff {
+		movedKey := m.keys[last][lastOff]
+		m.shards[s][o] = m.shards[last][lastOff]
+		m.keys[s][o] = movedKey
+		m.index[movedKey] = loc
+	}
+
+	// Zero the vacated slot so a V or K with pointers does not pin memory
+	var zeroV V
+	var zeroK K
+	m.shards[last][lastOff] = zeroV
+	m.keys[last][lastOff] = zeroK
+	m.shards[last] = m.shards[last][:lastOff]
+	m.keys[last] = m.keys[last][:lastOff]
+	if lastOff == 0 {
+		m.shards[last], m.keys[last] = nil, nil
+		m.shards, m.keys = m.shards[:last], m.keys[:last]
+	}
+	m.n--
+	return true
+}
+
+// Range calls fn for every entry in storage order until fn returns false.
+func (m *ShardedValueMap[K, V]) Range(fn func(K, *V) bool) {
+	for s := range m.shards {
+		for o := range m.shards[s] {
+			if !fn(m.keys[s][o], &m.shards[s][o]) {
+				return
+			}
+		}
+	}
+}
+
+func main() {
+	fmt.Println("🔬 DAY 198: Two-Level Value Map vs map[int]*User")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const entries = 1_000_000
+
+	fmt.Printf("📊 BENCHMARK: %d users (%d B each), forced GC cycles\n", entries, unsafe.Sizeof(User{}))
+	fmt.Println(strings.Repeat("-", 40))
+
+	pointerMap := benchmarkPointerMap(entries)
+	fmt.Println("1. map[int]*User")
+	printGCResult(pointerMap)
+
+	sharded := benchmarkShardedValueMap(entries)
+	fmt.Println("2. ShardedValueMap[int, User]")
+	printGCResult(sharded)
+
+	fmt.Println("\n🔧 GC ROOT ANALYSIS")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeShardedValueMapGCReduction(entries, pointerMap, sharded)
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateShardedValueMapCostImpact(pointerMap, sharded)
+
+	fmt.Println("\n✅ DAY 198 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 199 - Closure Capture Costs")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// gcResult is what one populated store costs the GC.
+type gcResult struct {
+	HeapBytes   uint64
+	HeapObjects uint64
+	GCTime      time.Duration // Average wall time of a forced runtime.GC()
+	PauseTotal  time.Duration // Average stop-the-world time per cycle
+	LookupNs    float64
+}
+
+const gcRounds = 10
+
+// measureGC forces gcRounds collections with build's result live and
+// reports heap size and average GC cost. build returns the store and a
+// lookup function for the latency check.
+func measureGC(build func() (any, func(int) bool), entries int) gcResult {
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	store, lookup := build()
+	runtime.GC()
+	var built runtime.MemStats
+	runtime.ReadMemStats(&built)
+
+	start := time.Now()
+	for i := 0; i < gcRounds; i++ {
+		runtime.GC()
+	}
+	gcTime := time.Since(start) / gcRounds
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	found := 0
+	lookupStart := time.Now()
+	for i := 0; i < entries; i++ {
+		if lookup(i * 7919 % entries) {
+			found++
+		}
+	}
+	lookupNs := float64(time.Since(lookupStart).Nanoseconds()) / float64(entries)
+	if found != entries {
+		panic(fmt.Sprintf("lookup found %d of %d entries", found, entries))
+	}
+	runtime.KeepAlive(store)
+
+	return gcResult{
+		HeapBytes:   built.HeapAlloc - before.HeapAlloc,
+		HeapObjects: built.HeapObjects - before.HeapObjects,
+		GCTime:      gcTime,
+		PauseTotal:  time.Duration(after.PauseTotalNs-built.PauseTotalNs) / gcRounds,
+		LookupNs:    lookupNs,
+	}
+}
+
+func benchmarkPointerMap(entries int) gcResult {
+	return measureGC(func() (any, func(int) bool) {
+		m := make(map[int]*User, entries)
+		for i := 0; i < entries; i++ {
+			u := newUser(i)
+			m[i] = &u
+		}
+		return m, func(k int) bool { u, ok := m[k]; return ok && u.ID == int64(k) }
+	}, entries)
+}
+
+func benchmarkShardedValueMap(entries int) gcResult {
+	return measureGC(func() (any, func(int) bool) {
+		m := NewShardedValueMap[int, User](defaultShardSize)
+		for i := 0; i < entries; i++ {
+			m.Put(i, newUser(i))
+		}
+		return m, func(k int) bool { u, ok := m.Get(k); return ok && u.ID == int64(k) }
+	}, entries)
+}
+
+func printGCResult(r gcResult) {
+	fmt.Printf("   Heap: %.1f MB in %d objects\n", float64(r.HeapBytes)/(1<<20), r.HeapObjects)
+	fmt.Printf("   GC cycle: %v (STW %v), lookup %.1f ns\n",
+		r.GCTime.Round(time.Microsecond), r.PauseTotal.Round(time.Microsecond), r.LookupNs)
+}
+
+// ========== ANALYSIS ==========
+
+func analyzeShardedValueMapGCReduction(entries int, pointerMap, sharded gcResult) {
+	shards := (entries + defaultShardSize - 1) / defaultShardSize
+
+	// Pointer slots are counted from the layout; objects are measured. The
+	// sharded count is mostly the index map's noscan tables.
+	fmt.Println("  Layout            | Pointers traced | Heap objects | GC cycle")
+	fmt.Println("  ------------------|-----------------|--------------|-----------")
+	fmt.Printf("  map[int]*User     | %15d | %12d | %9v\n",
+		entries, pointerMap.HeapObjects, pointerMap.GCTime.Round(time.Microsecond))
+	// One values and one keys slice header per shard
+	fmt.Printf("  ShardedValueMap   | %15d | %12d | %9v\n",
+		2*shards, sharded.HeapObjects, sharded.GCTime.Round(time.Microsecond))
+	fmt.Println()
+	fmt.Printf("  %.0fx fewer heap objects, %.0fx shorter GC cycle at the same heap size\n",
+		float64(pointerMap.HeapObjects)/float64(max(1, sharded.HeapObjects)),
+		float64(pointerMap.GCTime)/float64(max(1, sharded.GCTime)))
+	fmt.Println()
+	fmt.Println("💡 A map whose key and value types contain no pointers is allocated")
+	fmt.Println("   as noscan memory: the GC marks it live without reading it. The")
+	fmt.Println("   same goes for []User shards. What is left to trace is two slice")
+	fmt.Println("   headers per shard. A string or slice field in User undoes all of it.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateShardedValueMapCostImpact(pointerMap, sharded gcResult) {
+	// Session store: 1M sessions per instance. With GOGC=100 a cycle starts
+	// every time request handling allocates as much as the live heap.
+	instances := 10.0
+	allocMBPerSec := 200.0
+	gcCyclesPerSec := allocMBPerSec / (float64(pointerMap.HeapBytes) / (1 << 20))
+	awsCostPerVCPUHour := 0.0416
+
+	// runtime.GC() wall time ≈ mark CPU on one core
+	before := pointerMap.GCTime.Seconds() * gcCyclesPerSec * instances
+	after := sharded.GCTime.Seconds() * gcCyclesPerSec * instances
+	monthlySavings := (before - after) * awsCostPerVCPUHour * 24 * 30
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f session-store instances, 1M users each\n", instances)
+	fmt.Printf("  • %.0f MB/s allocated per instance, GOGC=100 → %.1f GC cycles/second\n", allocMBPerSec, gcCyclesPerSec)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  GC mark CPU: %.2f → %.3f vCPUs fleet-wide\n", before, after)
+	fmt.Printf("  Heap: %.1f MB → %.1f MB per instance (no memory saving)\n",
+		float64(pointerMap.HeapBytes)/(1<<20), float64(sharded.HeapBytes)/(1<<20))
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Large long-lived maps of pointers make every GC cycle walk millions")
+	fmt.Println("  of objects, and that mark work also shows up as assist latency in")
+	fmt.Println("  requests. Store pointer-free values inline and index them by location.")
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ========== FAN-OUT VARIANTS ==========
+
+// Each variant starts n goroutines and calls handle with 0..n-1, once per
+// goroutine. They differ only in how i reaches the goroutine.
+
+// fanOutByRef shares one i between the loop and every goroutine. This is
+// how every for loop behaved before Go 1.22; the variable is declared
+// outside the loop here to keep the old semantics. Goroutines read i while
+// the loop increments it: a data race, and most of them see a later value.
+func fanOutByRef(n int, handle func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	var i int
+	for i = 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			handle(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// fanOutByVal is the pre-1.22 fix: a fresh copy per iteration. i is never
+// reassigned after the copy, so the compiler stores it in the closure
+// itself instead of allocating it separately.
+func fanOutByVal(n int, handle func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			handle(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// fanOutLoopVar relies on Go 1.22 per-iteration loop variables, which
+// make the i := i copy redundant.
+func fanOutLoopVar(n int, handle func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			handle(i)
+		}()
+	}
+	wg.Wait()
+}
+
+// fanOutFuncArg passes i as an argument to a named function. The go
+// statement evaluates arguments immediately, so there is nothing to share.
+func fanOutFuncArg(n int, handle func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go handleRequest(i, &wg, handle)
+	}
+	wg.Wait()
+}
+
+func handleRequest(i int, wg *sync.WaitGroup, handle func(int)) {
+	defer wg.Done()
+	handle(i)
+}
+
+// ========== CAPTURED INTS VS PARAMETERS ==========
+
+// A backend call is described by five ints. Each variant gets them to
+// backendScore, synchronously and without a goroutine, and differs only
+// in how.
+
+// pendingCall is the callback callCaptured leaves behind, as a retry
+// queue would. Keeping it is what puts the closure on the heap.
+var pendingCall func() int
+
+//go:noinline
+func backendScore(user, shard, attempt, timeoutMs, priority int) int {
+	return (user*31+shard)*31 + attempt*timeoutMs + priority
+}
+
+//go:noinline
+func invoke(f func() int) int {
+	return f()
+}
+
+// callCaptured keeps a closure over its five parameters. None of them is
+// reassigned, so the closure holds copies: nothing is moved to the heap,
+// but the closure itself is, one 48-byte allocation per call.
+func callCaptured(user, shard, attempt, timeoutMs, priority int) {
+	pendingCall = func() int {
+		return backendScore(user, shard, attempt, timeoutMs, priority)
+	}
+}
+
+// callCapturedNow captures the same five ints in a closure that is only
+// called. invoke doesn't keep it, so it stays on the stack.
+func callCapturedNow(user, shard, attempt, timeoutMs, priority int) int {
+	return invoke(func() int {
+		return backendScore(user, shard, attempt, timeoutMs, priority)
+	})
+}
+
+// callParams passes the five ints as arguments.
+func callParams(user, shard, attempt, timeoutMs, priority int) int {
+	return backendScore(user, shard, attempt, timeoutMs, priority)
+}
+
+// Global variable to prevent compiler optimizations
+var lastScore int
+
+type fanOutVariant struct {
+	Name         string
+	Benchmark    func(requests, fanOut int) (time.Duration, float64, int)
+	Allocs       float64
+	NsPerRequest float64
+}
+
+func main() {
+	fmt.Println("🔬 DAY 199: Closure Capture by Value vs by Reference")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	const (
+		fanOut   = 10 // Goroutines per request
+		requests = 100_000
+	)
+
+	fmt.Printf("📊 BENCHMARK: %d requests, fan-out of %d goroutines each\n", requests, fanOut)
+	fmt.Println(strings.Repeat("-", 40))
+
+	variants := []fanOutVariant{
+		{Name: "by reference (shared i)", Benchmark: benchmarkClosureByRef},
+		{Name: "by value (i := i)", Benchmark: benchmarkClosureByVal},
+		{Name: "loop var (Go 1.22+)", Benchmark: benchmarkClosureLoopVar},
+		{Name: "function argument", Benchmark: benchmarkFuncArgPass},
+	}
+	for i := range variants {
+		v := &variants[i]
+		elapsed, allocs, wrong := v.Benchmark(requests, fanOut)
+		v.Allocs = allocs
+		v.NsPerRequest = float64(elapsed.Nanoseconds()) / requests
+		fmt.Printf("%d. %s\n", i+1, v.Name)
+		fmt.Printf("   %.0f ns/request, %.0f allocs/request, %d/%d goroutines saw the wrong i\n",
+			v.NsPerRequest, v.Allocs, wrong, requests*fanOut)
+	}
+
+	fmt.Println("\n📊 FIVE INTS: CAPTURED VS PASSED AS PARAMETERS")
+	fmt.Println(strings.Repeat("-", 40))
+	compareCapturedParams()
+
+	fmt.Println("\n🔧 ESCAPE ANALYSIS (go build -gcflags=-m)")
+	fmt.Println(strings.Repeat("-", 40))
+	analyzeClosureCapturePattern("main.go")
+
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateClosureCaptureCostImpact(variants, fanOut)
+
+	fmt.Println("\n✅ DAY 199 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 200 - perfcheck: Automated Performance Checklist")
+}
+
+// ========== BENCHMARK FUNCTIONS ==========
+
+// runFanOut times requests fan-outs and counts goroutines that received a
+// value other than their own index. Allocations are measured separately
+// with testing.AllocsPerRun so the check itself does not skew them.
+func runFanOut(run func(int, func(int)), requests, fanOut int) (time.Duration, float64, int) {
+	seen := make([]atomic.Int32, fanOut+1) // +1: by-ref goroutines can see i == fanOut
+	record := func(i int) { seen[i].Add(1) }
+
+	wrong := 0
+	start := time.Now()
+	for r := 0; r < requests; r++ {
+		run(fanOut, record)
+		// Each index seen at least once accounts for one correct goroutine
+		wrong += fanOut
+		for i := range seen {
+			if seen[i].Swap(0) > 0 && i < fanOut {
+				wrong--
+			}
+		}
+	}
+	elapsed := time.Since(start)
+
+	noop := func(int) {}
+	allocs := testing.AllocsPerRun(1000, func() { run(fanOut, noop) })
+	return elapsed, allocs, wrong
+}
+
+func benchmarkClosureByRef(requests, fanOut int) (time.Duration, float64, int) {
+	return runFanOut(fanOutByRef, requests, fanOut)
+}
+
+func benchmarkClosureByVal(requests, fanOut int) (time.Duration, float64, int) {
+	return runFanOut(fanOutByVal, requests, fanOut)
+}
+
+func benchmarkClosureLoopVar(requests, fanOut int) (time.Duration, float64, int) {
+	return runFanOut(fanOutLoopVar, requests, fanOut)
+}
+
+func benchmarkFuncArgPass(requests, fanOut int) (time.Duration, float64, int) {
+	return runFanOut(fanOutFuncArg, requests, fanOut)
+}
+
+// compareCapturedParams prints the allocations of one call of each
+// call* variant. The ints come from a counter so the compiler can't turn
+// a closure over constants into a static one.
+func compareCapturedParams() {
+	n := 0
+	for _, v := range []struct {
+		name string
+		call func()
+	}{
+		{"closure, kept (callCaptured)", func() { n++; callCaptured(n, n, n, n, n) }},
+		{"closure, called (callCapturedNow)", func() { n++; lastScore = callCapturedNow(n, n, n, n, n) }},
+		{"parameters (callParams)", func() { n++; lastScore = callParams(n, n, n, n, n) }},
+	} {
+		fmt.Printf("  %-36s %.0f allocs/call\n", v.name+":", testing.AllocsPerRun(1000, v.call))
+	}
+	fmt.Println()
+	fmt.Println("💡 Capturing doesn't allocate: keeping the closure does. Five")
+	fmt.Println("   never-reassigned ints are copied into it, and it is the closure")
+	fmt.Println("   that moves to the heap once something outlives the call.")
+}
+
+// ========== ANALYSIS ==========
+
+// escapeDecision is one "moved to heap" or "func literal" message from
+// -gcflags=-m, inside one of the functions escapeDecisions looks at.
+type escapeDecision struct {
+	Func   string
+	Msg    string
+	Source string // The line it refers to, trimmed
+	Line   int
+}
+
+// escapeDecisions compiles the package with -gcflags=-m and returns the
+// escape decisions inside the fanOut* and call* functions of file, in the
+// compiler's order.
+func escapeDecisions(file string) ([]escapeDecision, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	funcAt := map[int]string{}
+	for _, d := range f.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && (strings.HasPrefix(fd.Name.Name, "fanOut") || strings.HasPrefix(fd.Name.Name, "call")) {
+			for l := fset.Position(fd.Pos()).Line; l <= fset.Position(fd.End()).Line; l++ {
+				funcAt[l] = fd.Name.Name
+			}
+		}
+	}
+
+	out, err := exec.Command("go", "build", "-gcflags=-m", "-o", os.DevNull, ".").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("go build failed: %v\n%s", err, out)
+	}
+
+	lines := strings.Split(string(src), "\n")
+	decisions := make([]escapeDecision, 0, len(funcAt))
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		// ./main.go:LINE:COL: message
+		parts := strings.SplitN(sc.Text(), ":", 4)
+		if len(parts) != 4 || !strings.HasSuffix(parts[0], file) {
+			continue
+		}
+		var line int
+		fmt.Sscan(parts[1], &line)
+		msg := strings.TrimSpace(parts[3])
+		fn, ok := funcAt[line]
+		if !ok || !(strings.HasPrefix(msg, "moved to heap") || strings.HasPrefix(msg, "func literal")) {
+			continue
+		}
+		decisions = append(decisions, escapeDecision{Func: fn, Msg: msg, Source: strings.TrimSpace(lines[line-1]), Line: line})
+	}
+	return decisions, nil
+}
+
+// analyzeClosureCapturePattern prints the escapeDecisions of file next to
+// the source line they refer to.
+func analyzeClosureCapturePattern(file string) {
+	decisions, err := escapeDecisions(file)
+	if err != nil {
+		fmt.Printf("  ⚠️  %v (run from the day-199 directory)\n", err)
+		return
+	}
+	current := ""
+	for _, d := range decisions {
+		if d.Func != current {
+			fmt.Printf("  %s\n", d.Func)
+			current = d.Func
+		}
+		fmt.Printf("    %-30s │ %s\n", d.Msg, d.Source)
+	}
+	fmt.Println()
+	fmt.Println("💡 Every variant allocates one closure per goroutine (the go statement")
+	fmt.Println("   needs its arguments on the heap); capture mode does not change that.")
+	fmt.Println("   By-reference adds a single heap i shared by all goroutines: one")
+	fmt.Println("   allocation, but a data race that delivers the wrong value.")
+	fmt.Println("   Without a goroutine, a closure over five ints only escapes if it is")
+	fmt.Println("   kept; the ints are copied into it and never moved to the heap.")
+}
+
+// ========== COST ANALYSIS ==========
+
+func calculateClosureCaptureCostImpact(variants []fanOutVariant, fanOut int) {
+	// API gateway fanning each request out to backend calls
+	rps := 100_000.0
+	awsCostPerVCPUHour := 0.0416
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %d goroutines per request\n", rps, fanOut)
+	fmt.Printf("  • AWS t3.medium: $%.4f/hour per vCPU\n", awsCostPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	costs := make([]float64, len(variants))
+	for i, v := range variants {
+		vCPUs := rps * v.NsPerRequest / 1e9
+		costs[i] = vCPUs * awsCostPerVCPUHour * 24 * 30
+		fmt.Printf("  %-24s %6.0f ns, %2.0f allocs → %5.2f vCPUs → $%.2f/month\n",
+			v.Name+":", v.NsPerRequest, v.Allocs, vCPUs, costs[i])
+	}
+
+	// Timing differences between variants are scheduler noise; the only
+	// structural difference is by-ref's extra heap i, priced here
+	allocNs := 25.0
+	extraAllocs := variants[0].Allocs - variants[1].Allocs
+	monthlySavings := rps * extraAllocs * allocNs / 1e9 * awsCostPerVCPUHour * 24 * 30
+	fmt.Printf("  By-ref extra allocation: %.0f/request × %.0f ns\n", extraAllocs, allocNs)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthlySavings)
+	fmt.Printf("  Annual savings:  $%.2f\n", monthlySavings*12)
+
+	fmt.Println("\n🎯 VERDICT:")
+	fmt.Println("  Capture mode is a correctness choice, not a performance one: all")
+	fmt.Println("  variants cost the same goroutine + closure. On Go 1.22+ the loop")
+	fmt.Println("  variable is already per-iteration; watch for variables declared")
+	fmt.Println("  outside the loop, and run tests with -race to catch shared captures.")
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+)
+
+// ========== FILE SERVING BENCHMARKS ==========
+
+// Each op serves the whole file to a local client that discards it.
+
+func Benchmark_ServeFile(b *testing.B) {
+	path := testFile(b)
+	for _, a := range newApproaches() {
+		b.Run(a.ID, func(b *testing.B) {
+			benchmarkServeFile(b, path, a.Serve)
+		})
+	}
+}
+
+func benchmarkServeFile(b *testing.B, path string, serve serveFunc) {
+	o, err := newOrigin(path, serve)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer o.Close()
+
+	b.SetBytes(fileSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := o.fetch(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// testFile writes the asset once per test or benchmark and removes it
+// afterwards.
+func testFile(tb testing.TB) string {
+	tb.Helper()
+	path, err := writeTestFile()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { os.Remove(path) })
+	return path
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_AllApproachesSendTheFileIntact(t *testing.T) {
+	path := testFile(t)
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, a := range newApproaches() {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		received := make(chan []byte, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				received <- nil
+				return
+			}
+			defer conn.Close()
+			data, _ := io.ReadAll(conn)
+			received <- data
+		}()
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := a.Serve(conn, f)
+		f.Close()
+		conn.Close()
+		got := <-received
+		ln.Close()
+
+		if err != nil || n != fileSize {
+			t.Errorf("%s: sent %d bytes, err %v; expected %d", a.Name, n, err, fileSize)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: client received %d bytes that differ from the file", a.Name, len(got))
+		}
+	}
+}
+
+func Test_WrapperHidesReaderFrom(t *testing.T) {
+	var conn net.Conn = &net.TCPConn{}
+	if _, ok := conn.(io.ReaderFrom); !ok {
+		t.Error("expected *net.TCPConn to implement io.ReaderFrom")
+	}
+	if _, ok := io.Writer(&countingWriter{Conn: conn}).(io.ReaderFrom); ok {
+		t.Error("expected a Write-only wrapper to hide ReadFrom from io.Copy")
+	}
+}
+
+func Test_SendfileSkipsTheWriteLoop(t *testing.T) {
+	if _, err := readProcIO(); errors.Is(err, os.ErrNotExist) {
+		t.Skip("/proc/self/io is not available")
+	}
+	path := testFile(t)
+	approaches := newApproaches()
+
+	loop, err := measureSyscalls(path, approaches[0].Serve, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendfile, err := measureSyscalls(path, approaches[2].Serve, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("write syscalls per file: %.0f with a 4 KB loop, %.0f with io.Copy", loop.syscw, sendfile.syscw)
+
+	// The loop needs at least one write per 4 KB; sendfile moves up to
+	// 4 MB per call, plus retries while the socket buffer is full
+	if loop.syscw < fileSize/(4<<10) {
+		t.Errorf("4 KB loop: expected at least %d writes, got %.0f", fileSize/(4<<10), loop.syscw)
+	}
+	if sendfile.syscw > 100 {
+		t.Errorf("io.Copy: expected sendfile's handful of calls, got %.0f writes", sendfile.syscw)
+	}
+}
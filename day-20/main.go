@@ -0,0 +1,516 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// fileSize is the static asset every approach serves: 10 MiB.
+const fileSize = 10 << 20
+
+// ========== FILE SERVING APPROACHES ==========
+
+// A serveFunc writes the whole of f to conn and reports how many bytes
+// it sent.
+type serveFunc func(conn net.Conn, f *os.File) (int64, error)
+
+// serveIOCopy hands both ends to io.Copy. conn's dynamic type is
+// *net.TCPConn, whose ReadFrom sends an *os.File with sendfile.
+func serveIOCopy(conn net.Conn, f *os.File) (int64, error) {
+	return io.Copy(conn, f)
+}
+
+// newReadLoop returns a handler that copies through a buffer of size
+// bytes with plain Read and Write calls, the loop io.Copy replaces.
+func newReadLoop(size int) serveFunc {
+	buf := make([]byte, size)
+	return func(conn net.Conn, f *os.File) (int64, error) {
+		var total int64
+		for {
+			n, err := f.Read(buf)
+			if n > 0 {
+				if _, werr := conn.Write(buf[:n]); werr != nil {
+					return total, werr
+				}
+				total += int64(n)
+			}
+			if err == io.EOF {
+				return total, nil
+			}
+			if err != nil {
+				return total, err
+			}
+		}
+	}
+}
+
+type approach struct {
+	Name  string
+	ID    string // Sub-benchmark name
+	Serve serveFunc
+}
+
+// newApproaches builds the handlers fresh, so each gets its own buffer.
+func newApproaches() []approach {
+	return []approach{
+		{"read loop, 4 KB buffer", "ReadLoop4KB", newReadLoop(4 << 10)},
+		{"read loop, 64 KB buffer", "ReadLoop64KB", newReadLoop(64 << 10)},
+		{"io.Copy (sendfile)", "IOCopy", serveIOCopy},
+	}
+}
+
+// ========== LOCAL ORIGIN ==========
+
+// origin serves the file at path to every connection it accepts, one
+// connection at a time, with serve.
+type origin struct {
+	ln    net.Listener
+	path  string
+	serve serveFunc
+	errs  chan error
+}
+
+func newOrigin(path string, serve serveFunc) (*origin, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	o := &origin{ln: ln, path: path, serve: serve, errs: make(chan error, 1)}
+	go o.acceptLoop()
+	return o, nil
+}
+
+func (o *origin) acceptLoop() {
+	for {
+		conn, err := o.ln.Accept()
+		if err != nil {
+			return // Closed
+		}
+		o.errs <- o.serveConn(conn)
+	}
+}
+
+func (o *origin) serveConn(conn net.Conn) error {
+	defer conn.Close()
+	f, err := os.Open(o.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	n, err := o.serve(conn, f)
+	if err == nil && n != fileSize {
+		err = fmt.Errorf("sent %d of %d bytes", n, fileSize)
+	}
+	return err
+}
+
+// fetch downloads the file once, as a client would, and discards it.
+func (o *origin) fetch() error {
+	conn, err := net.Dial("tcp", o.ln.Addr().String())
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(io.Discard, conn)
+	conn.Close()
+	if err != nil {
+		return err
+	}
+	if err := <-o.errs; err != nil {
+		return err
+	}
+	if n != fileSize {
+		return fmt.Errorf("received %d of %d bytes", n, fileSize)
+	}
+	return nil
+}
+
+func (o *origin) Close() error { return o.ln.Close() }
+
+// writeTestFile creates the fileSize asset in a temporary file.
+func writeTestFile() (string, error) {
+	f, err := os.CreateTemp("", "day20-*.bin")
+	if err != nil {
+		return "", err
+	}
+	chunk := make([]byte, 64<<10)
+	for i := range chunk {
+		chunk[i] = byte(i * 31)
+	}
+	for written := 0; written < fileSize; written += len(chunk) {
+		if _, err := f.Write(chunk); err != nil {
+			f.Close()
+			return "", err
+		}
+	}
+	return f.Name(), f.Close()
+}
+
+func main() {
+	fmt.Println("🔬 DAY 20: io.Copy vs Read Loop vs sendfile")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	path, err := writeTestFile()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	defer os.Remove(path)
+
+	// The shocking truth about copy loops
+	fmt.Println("🎯 SHOCKING DISCOVERY: The file never enters your process with io.Copy!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealSendfileInternals(path)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: serving a %d MB file over local TCP\n", fileSize>>20)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(path)
+	usage := measureAllSyscalls(path)
+
+	// Copy path internals
+	fmt.Println("\n🔧 COPY PATH DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainCopyPaths()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateServingCostImpact(results, usage, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 20, Topic: "io.Copy vs Read Loop vs sendfile", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 20 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 21 - Goroutine Stack Growth")
+}
+
+// ========== SYSCALL ACCOUNTING ==========
+
+// procIO is the process's read and write system call counts from
+// /proc/self/io. sendfile counts as one of each.
+type procIO struct {
+	syscr, syscw uint64
+}
+
+func readProcIO() (procIO, error) {
+	f, err := os.Open("/proc/self/io")
+	if err != nil {
+		return procIO{}, err
+	}
+	defer f.Close()
+
+	var p procIO
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		name, value, ok := strings.Cut(s.Text(), ": ")
+		if !ok {
+			continue
+		}
+		switch name {
+		case "syscr":
+			p.syscr, err = strconv.ParseUint(value, 10, 64)
+		case "syscw":
+			p.syscw, err = strconv.ParseUint(value, 10, 64)
+		}
+		if err != nil {
+			return procIO{}, err
+		}
+	}
+	return p, s.Err()
+}
+
+// serveUsage is what serving the file once cost the process, averaged
+// over several fetches. The client's reads are included, the same for
+// every approach.
+type serveUsage struct {
+	user, sys    time.Duration
+	syscr, syscw float64
+}
+
+func (u serveUsage) cpu() time.Duration { return u.user + u.sys }
+
+// measureSyscalls serves the file runs times with serve and averages the
+// CPU time from getrusage and the system calls from /proc/self/io.
+func measureSyscalls(path string, serve serveFunc, runs int) (serveUsage, error) {
+	o, err := newOrigin(path, serve)
+	if err != nil {
+		return serveUsage{}, err
+	}
+	defer o.Close()
+	if err := o.fetch(); err != nil { // warm up
+		return serveUsage{}, err
+	}
+
+	var ruBefore, ruAfter syscall.Rusage
+	ioBefore, err := readProcIO()
+	if err != nil {
+		return serveUsage{}, err
+	}
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ruBefore); err != nil {
+		return serveUsage{}, err
+	}
+	for i := 0; i < runs; i++ {
+		if err := o.fetch(); err != nil {
+			return serveUsage{}, err
+		}
+	}
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ruAfter); err != nil {
+		return serveUsage{}, err
+	}
+	ioAfter, err := readProcIO()
+	if err != nil {
+		return serveUsage{}, err
+	}
+
+	elapsed := func(before, after syscall.Timeval) time.Duration {
+		return time.Duration(after.Nano()-before.Nano()) / time.Duration(runs)
+	}
+	return serveUsage{
+		user:  elapsed(ruBefore.Utime, ruAfter.Utime),
+		sys:   elapsed(ruBefore.Stime, ruAfter.Stime),
+		syscr: float64(ioAfter.syscr-ioBefore.syscr) / float64(runs),
+		syscw: float64(ioAfter.syscw-ioBefore.syscw) / float64(runs),
+	}, nil
+}
+
+// countingWriter counts the bytes written through it, as metrics
+// middleware wrapped around a connection does.
+type countingWriter struct {
+	net.Conn
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Conn.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+func revealSendfileInternals(path string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	defer ln.Close()
+	go func() {
+		if c, err := ln.Accept(); err == nil {
+			c.Close()
+		}
+	}()
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	defer conn.Close()
+
+	_, direct := conn.(io.ReaderFrom)
+	_, wrapped := io.Writer(&countingWriter{Conn: conn}).(io.ReaderFrom)
+	fmt.Printf("  %-22s implements io.ReaderFrom: %v\n", fmt.Sprintf("%T", conn), direct)
+	fmt.Printf("  %-22s implements io.ReaderFrom: %v\n", fmt.Sprintf("%T", &countingWriter{}), wrapped)
+	fmt.Println()
+
+	fmt.Printf("  Serving %d MB once:\n", fileSize>>20)
+	fmt.Printf("  %-26s %10s %10s\n", "", "syscr", "syscw")
+	for _, a := range newApproaches() {
+		u, err := measureSyscalls(path, a.Serve, 1)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Println("  /proc/self/io is not available on this system")
+			break
+		}
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+		fmt.Printf("  %-26s %10.0f %10.0f\n", a.Name+":", u.syscr, u.syscw)
+	}
+
+	fmt.Println("\n💡 io.Copy(conn, file) asks the file for io.WriterTo and conn for")
+	fmt.Println("   io.ReaderFrom before it loops. *os.File.WriteTo and *net.TCPConn's")
+	fmt.Println("   ReadFrom both hand the descriptors to sendfile(2), and the kernel")
+	fmt.Println("   moves page-cache pages to the socket itself: no buffer, no copy into")
+	fmt.Println("   user space, one syscall each time the socket buffer has room. The")
+	fmt.Println("   read loop pays a read and a write per buffer and copies every byte")
+	fmt.Println("   in and out of the process. Wrap conn in anything that only has a")
+	fmt.Println("   Write method, and io.Copy quietly falls back to a 32 KB loop.")
+}
+
+func runComparisonBenchmarks(path string) []bench.Result {
+	suite := bench.NewBenchmarkSuite("Serving a 10 MB file")
+	suite.Iterations = 3
+	for _, a := range newApproaches() {
+		o, err := newOrigin(path, a.Serve)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return nil
+		}
+		defer o.Close()
+		suite.Register(a.Name, func() {
+			if err := o.fetch(); err != nil {
+				fmt.Printf("❌ %v\n", err)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	fmt.Printf("  %-26s %10s %10s\n", "", "bytes/ns", "MB/s")
+	for _, r := range results {
+		bytesPerNs := fileSize / r.NsPerOp
+		fmt.Printf("  %-26s %10.2f %10.0f\n", r.Name+":", bytesPerNs, bytesPerNs*1e9/(1<<20))
+	}
+	return results
+}
+
+// measureAllSyscalls prints CPU time and syscalls per file for every
+// approach and returns them in newApproaches order.
+func measureAllSyscalls(path string) []serveUsage {
+	const runs = 10
+	fmt.Printf("\n📏 Per file, average of %d serves (getrusage, /proc/self/io):\n", runs)
+	fmt.Printf("  %-26s %9s %9s %9s %9s\n", "", "user", "sys", "syscr", "syscw")
+	approaches := newApproaches()
+	usage := make([]serveUsage, len(approaches))
+	for i, a := range approaches {
+		u, err := measureSyscalls(path, a.Serve, runs)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return nil
+		}
+		usage[i] = u
+		fmt.Printf("  %-26s %9v %9v %9.0f %9.0f\n", a.Name+":",
+			u.user.Round(time.Microsecond), u.sys.Round(time.Microsecond), u.syscr, u.syscw)
+	}
+	fmt.Println("\n  syscr includes the client's reads, the same for every approach.")
+	return usage
+}
+
+func explainCopyPaths() {
+	fmt.Println("Read loop:")
+	fmt.Println()
+	fmt.Println("┌────────────┐ read(2) ┌────────────┐ write(2) ┌────────────┐")
+	fmt.Println("│ page cache │ ──────► │ user buf   │ ───────► │ socket buf │ ──► NIC")
+	fmt.Println("└────────────┘  copy   └────────────┘   copy   └────────────┘")
+	fmt.Println("   2 syscalls and 2 copies per buffer, 2,560 rounds for 10 MB at 4 KB")
+	fmt.Println()
+	fmt.Println("io.Copy(tcpConn, file) → File.WriteTo or TCPConn.ReadFrom → sendfile(2):")
+	fmt.Println()
+	fmt.Println("┌────────────┐      sendfile(2)      ┌────────────┐")
+	fmt.Println("│ page cache │ ────────────────────► │ socket buf │ ──► NIC")
+	fmt.Println("└────────────┘  in the kernel only   └────────────┘")
+	fmt.Println("   1 syscall per socket buffer's worth, no user-space buffer at all")
+	fmt.Println()
+
+	fmt.Println("📈 WHY SENDFILE WINS:")
+	fmt.Println("  • No bytes cross into user space and back")
+	fmt.Println("  • Far fewer syscalls: one per socket buffer instead of two per 4 KB")
+	fmt.Println("  • No buffer to allocate, pool or size")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHEN IT DOESN'T APPLY:")
+	fmt.Println("  • TLS: the bytes must be encrypted in user space (unless kTLS)")
+	fmt.Println("  • Compression or any transform of the body on the way out")
+	fmt.Println("  • A wrapper around the conn or file that hides ReadFrom")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📤 LET io.Copy SEE THE REAL TYPES")
+	fmt.Println("   ✅ io.Copy(conn, file) with *net.TCPConn and *os.File")
+	fmt.Println("   Benefit: sendfile, zero copies through user space")
+	fmt.Println()
+
+	fmt.Println("2. 🗂️  USE http.ServeContent / http.FileServer")
+	fmt.Println("   ✅ http.ServeContent(w, r, name, modTime, file)")
+	fmt.Println("   Benefit: Reaches sendfile through the ResponseWriter's ReadFrom")
+	fmt.Println()
+
+	fmt.Println("3. 🧩 FORWARD ReadFrom IN CONN WRAPPERS")
+	fmt.Println("   ✅ func (w *countingConn) ReadFrom(r io.Reader) (int64, error)")
+	fmt.Println("   Benefit: Metrics middleware stops disabling sendfile")
+	fmt.Println()
+
+	fmt.Println("4. 📏 IF YOU MUST LOOP, USE A BIG BUFFER")
+	fmt.Println("   ✅ io.CopyBuffer(dst, src, make([]byte, 64<<10))")
+	fmt.Println("   Benefit: 16x fewer syscalls than 4 KB for TLS or transformed bodies")
+}
+
+func calculateServingCostImpact(results []bench.Result, usage []serveUsage, pricing cost.PricingModel) float64 {
+	if len(results) == 0 || len(usage) != len(results) {
+		fmt.Println("❌ no measurements to price")
+		return 0
+	}
+	// A CDN origin: every cache miss at the edge pulls a whole file
+	bytesPerDay := 1e12
+	filesPerDay := bytesPerDay / fileSize
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	// Compare the 4 KB loop to whichever used the least CPU
+	baseline, best := 0, 0
+	for i := range usage {
+		if usage[i].cpu() < usage[best].cpu() {
+			best = i
+		}
+	}
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • 1 TB/day served from origin: %.0f files of %d MB\n", filesPerDay, fileSize>>20)
+	fmt.Println("  • CPU per file is user + system time from getrusage")
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for i, r := range results {
+		cpuHoursPerDay := usage[i].cpu().Hours() * filesPerDay
+		fmt.Printf("  %-26s %9v CPU/file → %5.2f vCPU-hours/day\n", r.Name+":",
+			usage[i].cpu().Round(time.Microsecond), cpuHoursPerDay)
+	}
+	saved := usage[baseline].cpu() - usage[best].cpu()
+	if saved <= 0 {
+		fmt.Printf("  Difference %v/file is within noise; counting it as 0\n", saved)
+		saved = 0
+	}
+	monthly := cost.CPUSavingsMonthly(saved, filesPerDay, costPerVCPUHour)
+	fmt.Printf("\n  %s → %s saves %v of CPU per file\n", results[baseline].Name, results[best].Name, saved.Round(time.Microsecond))
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: filesPerDay, Unit: "files/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • No per-connection copy buffers: less memory per open download")
+	fmt.Println("  • User-space CPU stays free for TLS and request handling")
+	fmt.Println("  • Fewer syscalls means less time in the scheduler under load")
+	return monthly
+}
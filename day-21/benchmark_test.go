@@ -0,0 +1,86 @@
+package main
+
+import "testing"
+
+// ========== STACK DEPTH BENCHMARKS ==========
+
+// Each op runs one callDepth-node traversal on a new goroutine, so a deep
+// op pays for growing that goroutine's stack from 2 KB.
+
+// Global variable to prevent compiler optimizations
+var visited uint64
+
+func Benchmark_FlatStack(b *testing.B) {
+	benchmarkOnNewGoroutine(b, flatCalls)
+}
+
+func Benchmark_DeepStack(b *testing.B) {
+	benchmarkOnNewGoroutine(b, deepCalls)
+}
+
+// Benchmark_DeepStackOneGoroutine runs the recursion on the benchmark's
+// own goroutine, whose stack has already grown.
+func Benchmark_DeepStackOneGoroutine(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		visited += deepCalls(callDepth, nil)
+	}
+}
+
+func benchmarkOnNewGoroutine(b *testing.B, calls func(int, func()) uint64) {
+	done := make(chan uint64)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		go func() {
+			done <- calls(callDepth, nil)
+		}()
+		visited += <-done
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ShapesVisitTheSameNodes(t *testing.T) {
+	for _, depth := range depthSweep {
+		flat, deep := flatCalls(depth, nil), deepCalls(depth, nil)
+		if flat != deep {
+			t.Errorf("depth %d: flat visited %d, deep visited %d", depth, flat, deep)
+		}
+	}
+}
+
+func Test_BottomRunsOnceAtFullDepth(t *testing.T) {
+	for _, s := range shapes {
+		calls := 0
+		s.Calls(callDepth, func() { calls++ })
+		if calls != 1 {
+			t.Errorf("%s: bottom ran %d times, expected 1", s.Name, calls)
+		}
+	}
+}
+
+func Test_DeepStackOutgrowsFlatStack(t *testing.T) {
+	const goroutines = 1000
+	flat := stackPerGoroutine(goroutines, callDepth, flatCalls)
+	deep := stackPerGoroutine(goroutines, callDepth, deepCalls)
+	// 100 frames of ~200 B need more than 16 KB, so the recursion must
+	// have doubled past it; the loop fits in a few KB at any depth
+	if deep < 16<<10 {
+		t.Errorf("deep: %d B of stack per goroutine, expected more than 16 KB", deep)
+	}
+	if flat >= deep/4 {
+		t.Errorf("flat: %d B of stack per goroutine, expected under a quarter of deep's %d B", flat, deep)
+	}
+}
+
+func Test_FlatStackDoesNotGrowWithDepth(t *testing.T) {
+	const goroutines = 1000
+	shallow := stackPerGoroutine(goroutines, 1, flatCalls)
+	deep := stackPerGoroutine(goroutines, 10*callDepth, flatCalls)
+	if deep > 2*shallow {
+		t.Errorf("flat at depth %d: %d B per goroutine, expected about the %d B at depth 1",
+			10*callDepth, deep, shallow)
+	}
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	goroutineCount = 10_000
+	callDepth      = 100
+	// initialStackSize is the runtime's stackMin: every goroutine starts
+	// with this much stack, since Go 1.4
+	initialStackSize = 2 << 10
+)
+
+// depthSweep are the recursion depths revealStackGrowthCost measures
+// stacks at, to show each doubling.
+var depthSweep = []int{0, 5, 10, 25, 50, 100, 200}
+
+// ========== CALL CHAINS ==========
+
+// Both shapes do the same work, callDepth visits of a node-sized scratch
+// frame; only the stack depth they do it at differs. bottom, if not nil,
+// runs at the deepest point, with the whole chain still on the stack.
+
+// visit stands in for the per-node work of a traversal.
+//
+//go:noinline
+func visit(scratch *[16]uint64, n int) uint64 {
+	for i := range scratch {
+		scratch[i] = uint64(n*31 + i)
+	}
+	var sum uint64
+	for _, v := range scratch {
+		sum += v
+	}
+	return sum
+}
+
+// flatCalls visits depth nodes in a loop: one frame, reused.
+//
+//go:noinline
+func flatCalls(depth int, bottom func()) uint64 {
+	var scratch [16]uint64
+	var sum uint64
+	for d := depth; d > 0; d-- {
+		sum += visit(&scratch, d)
+	}
+	if bottom != nil {
+		bottom()
+	}
+	return sum
+}
+
+// deepCalls visits depth nodes by recursing once per node, the way a
+// recursive tree walk descends: depth frames on the stack at once.
+//
+//go:noinline
+func deepCalls(depth int, bottom func()) uint64 {
+	if depth == 0 {
+		if bottom != nil {
+			bottom()
+		}
+		return 0
+	}
+	var scratch [16]uint64
+	return visit(&scratch, depth) + deepCalls(depth-1, bottom)
+}
+
+type shape struct {
+	Name  string
+	Calls func(depth int, bottom func()) uint64
+}
+
+var shapes = []shape{
+	{"flat (loop)", flatCalls},
+	{"deep (recursion)", deepCalls},
+}
+
+// Global variable to prevent compiler optimizations
+var sink atomic.Uint64
+
+// spawnAndWait runs calls on a new goroutine per task, goroutines at a
+// time, the way a server starts one goroutine per request.
+func spawnAndWait(goroutines, depth int, calls func(int, func()) uint64) {
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			sink.Add(calls(depth, nil))
+		}()
+	}
+	wg.Wait()
+}
+
+// ========== STACK MEASUREMENT ==========
+
+// stackInuse is runtime.MemStats.StackInuse: bytes of stack spans in use
+// by goroutines (and the few the runtime keeps cached).
+func stackInuse() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.StackInuse
+}
+
+// stackPerGoroutine parks goroutines goroutines at the bottom of calls at
+// depth and returns the extra stack in use per goroutine while they wait.
+func stackPerGoroutine(goroutines, depth int, calls func(int, func()) uint64) uint64 {
+	runtime.GC()
+	before := stackInuse()
+
+	release := make(chan struct{})
+	var parked, done sync.WaitGroup
+	parked.Add(goroutines)
+	done.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer done.Done()
+			sink.Add(calls(depth, func() {
+				parked.Done()
+				<-release
+			}))
+		}()
+	}
+	parked.Wait()
+	during := stackInuse()
+	close(release)
+	done.Wait()
+
+	if during < before {
+		return 0
+	}
+	return (during - before) / uint64(goroutines)
+}
+
+func main() {
+	fmt.Println("🔬 DAY 21: Goroutine Stack Growth")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about goroutine stacks
+	fmt.Println("🎯 SHOCKING DISCOVERY: 100 nested calls copy a goroutine's stack 4 times!")
+	fmt.Println(strings.Repeat("-", 40))
+	stacks := revealStackGrowthCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d goroutines × %d node visits each\n", goroutineCount, callDepth)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Stack internals
+	fmt.Println("\n🔧 STACK GROWTH DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainStackGrowth()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateStackCostImpact(results, stacks, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 21, Topic: "Goroutine Stack Growth", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 21 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 22 - Zero-copy String/Byte Conversions")
+}
+
+// revealStackGrowthCost prints the stack each goroutine holds at every
+// depth in depthSweep and returns it per shape at callDepth.
+func revealStackGrowthCost() []uint64 {
+	fmt.Printf("  Initial goroutine stack: %d bytes (runtime stackMin, Go 1.4+)\n\n", initialStackSize)
+
+	// The first batch of goroutines also pays for the runtime's own
+	// bookkeeping; warm up so the sweep measures stacks only
+	stackPerGoroutine(goroutineCount, 0, flatCalls)
+
+	fmt.Printf("  Stack per goroutine, %d goroutines parked at the bottom:\n", goroutineCount)
+	fmt.Printf("  %-8s %12s %18s\n", "depth", "flat (loop)", "deep (recursion)")
+	for _, depth := range depthSweep {
+		flat := stackPerGoroutine(goroutineCount, depth, flatCalls)
+		deep := stackPerGoroutine(goroutineCount, depth, deepCalls)
+		fmt.Printf("  %-8d %10d B %16d B\n", depth, flat, deep)
+	}
+
+	atDepth := make([]uint64, len(shapes))
+	for i, s := range shapes {
+		atDepth[i] = stackPerGoroutine(goroutineCount, callDepth, s.Calls)
+	}
+	fmt.Printf("\n  %d goroutines at depth %d: %.1f MB flat, %.1f MB deep\n", goroutineCount, callDepth,
+		mb(atDepth[0]*goroutineCount), mb(atDepth[1]*goroutineCount))
+
+	fmt.Println("\n💡 A goroutine starts with 2 KB. When a call would run past the end,")
+	fmt.Println("   the function prologue calls morestack: the runtime allocates a")
+	fmt.Println("   stack twice the size, copies every frame across, fixes up pointers")
+	fmt.Println("   into the old stack and frees it. The loop grows once, to 4 KB, and")
+	fmt.Println("   stops; the recursion doubles its stack at each power of two.")
+	return atDepth
+}
+
+func mb(b uint64) float64 {
+	return float64(b) / (1024 * 1024)
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("Flat vs deep call chains")
+	suite.Iterations = 3
+	for _, s := range shapes {
+		suite.Register(s.Name+", new goroutine", func() {
+			spawnAndWait(goroutineCount, callDepth, s.Calls)
+		})
+	}
+	// The same recursion on one long-lived goroutine: its stack grows once
+	// and is reused until a GC shrinks it
+	suite.Register("deep (recursion), one goroutine", func() {
+		for i := 0; i < goroutineCount; i++ {
+			sink.Add(deepCalls(callDepth, nil))
+		}
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		fmt.Printf("  %-34s %8.0f ns/traversal\n", r.Name+":", r.NsPerOp/goroutineCount)
+	}
+	return results
+}
+
+func explainStackGrowth() {
+	fmt.Println("A call that doesn't fit on the current stack:")
+	fmt.Println()
+	fmt.Println("┌──────────────┬──────────────┬──────────────┬──────────────┬──────────────┐")
+	fmt.Println("│ prologue:    │ morestack:   │ allocate 2×  │ copy frames, │ free old,    │")
+	fmt.Println("│ SP < guard?  │ save state   │ (4K, 8K...)  │ fix pointers │ retry call   │")
+	fmt.Println("└──────────────┴──────────────┴──────────────┴──────────────┴──────────────┘")
+	fmt.Println()
+	fmt.Println("Depth 100 with ~200 B frames needs ~20 KB: 2K → 4K → 8K → 16K → 32K,")
+	fmt.Println("four copies, each one copying everything on the stack so far.")
+	fmt.Println()
+
+	fmt.Println("📈 WHY FLAT CODE WINS:")
+	fmt.Println("  • One growth at most: the stack stops at 4 KB whatever the depth")
+	fmt.Println("  • Nothing to copy: the stack holds a single frame when it grows")
+	fmt.Println("  • Stack memory per goroutine stays at the minimum")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHAT ELSE TO KNOW:")
+	fmt.Println("  • GC shrinks a stack by half when it uses under a quarter of it")
+	fmt.Println("  • A long-lived goroutine keeps its grown stack between GCs")
+	fmt.Println("  • Stack pointers into a moved stack are why Go forbids some unsafe tricks")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🔁 TRAVERSE WITH AN EXPLICIT STACK")
+	fmt.Println("   ✅ for len(stack) > 0 { n := stack[len(stack)-1]; ... }")
+	fmt.Println("   Benefit: One frame, no growth, no depth limit")
+	fmt.Println()
+
+	fmt.Println("2. 👷 RUN DEEP WORK ON LONG-LIVED WORKERS")
+	fmt.Println("   ✅ jobs <- tree // a worker whose stack already grew")
+	fmt.Println("   Benefit: Growth paid once per worker, not per request")
+	fmt.Println()
+
+	fmt.Println("3. 🪶 KEEP FRAMES SMALL ON RECURSIVE PATHS")
+	fmt.Println("   ✅ Move big local arrays to the heap or a reused buffer")
+	fmt.Println("   Benefit: More levels fit before the next doubling")
+	fmt.Println()
+
+	fmt.Println("4. 📏 BOUND RECURSION DEPTH")
+	fmt.Println("   ✅ if depth > maxDepth { return errTooDeep }")
+	fmt.Println("   Benefit: Hostile input can't grow stacks to 1 GB")
+}
+
+func calculateStackCostImpact(results []bench.Result, stacks []uint64, pricing cost.PricingModel) float64 {
+	// A tree-traversal service: every request walks a tree 100 levels
+	// deep on its own goroutine
+	requestsPerSecond := 5_000.0
+	requestsPerDay := requestsPerSecond * 24 * 3600
+	concurrentRequests := float64(goroutineCount)
+	costPerVCPUHour := pricing.CPUHourCost()
+	costPerGBMonth := pricing.RAMGBMonthCost()
+
+	flat, deep := results[0], results[1]
+	perRequest := func(r bench.Result) float64 { return r.NsPerOp / goroutineCount }
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, each a %d-level traversal on a new goroutine\n", requestsPerSecond, callDepth)
+	fmt.Printf("  • %.0f requests in flight at peak\n", concurrentRequests)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU, $%.2f/GB-month\n", pricing, costPerVCPUHour, costPerGBMonth)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Recursive traversal: %6.0f ns, %6d B of stack per request\n", perRequest(deep), stacks[1])
+	fmt.Printf("  Iterative traversal: %6.0f ns, %6d B of stack per request\n", perRequest(flat), stacks[0])
+
+	savedNs := perRequest(deep) - perRequest(flat)
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.0f ns/request is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	cpuMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), requestsPerDay, costPerVCPUHour)
+	var savedStack uint64
+	if stacks[1] > stacks[0] {
+		savedStack = uint64(float64(stacks[1]-stacks[0]) * concurrentRequests)
+	}
+	memMonthly := cost.MemorySavingsMonthly(savedStack, costPerGBMonth)
+	monthly := cpuMonthly + memMonthly
+
+	fmt.Printf("\n  CPU:    %.0f ns/request saved → $%.2f/month\n", savedNs, cpuMonthly)
+	fmt.Printf("  Memory: %.1f MB of peak stack saved → $%.2f/month\n", mb(savedStack), memMonthly)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Peak memory no longer scales with tree depth × concurrency")
+	fmt.Println("  • No stack overflow on pathological, very deep inputs")
+	fmt.Println("  • Less stack for the GC to scan on every cycle")
+	return monthly
+}
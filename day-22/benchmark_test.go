@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"hash/crc32"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== STRING TO []BYTE BENCHMARKS ==========
+
+// Each op converts one 64-byte key and checksums it.
+
+func Benchmark_CopyBytes(b *testing.B) {
+	benchmarkConversion(b, copyBytes)
+}
+
+func Benchmark_ZeroCopyBytes(b *testing.B) {
+	benchmarkConversion(b, zeroCopyBytes)
+}
+
+func benchmarkConversion(b *testing.B, convert func(string) []byte) {
+	keys := makeKeys()
+	b.ReportAllocs()
+	b.ResetTimer()
+	checksum = shardKeys(keys, b.N, convert)
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+// Test_ZeroCopyReadOnly only reads the zero-copy slice. Writing to it is
+// undefined behavior: the bytes belong to the string, and for a literal
+// like the ones below they are in read-only memory, so a write crashes.
+func Test_ZeroCopyReadOnly(t *testing.T) {
+	for _, s := range append(makeKeys()[:3], "a", "héllo, 世界", strings.Repeat("z", 4096)) {
+		b := zeroCopyBytes(s)
+		if !bytes.Equal(b, []byte(s)) {
+			t.Errorf("zero-copy bytes %q, expected %q", b, s)
+		}
+		if len(b) != len(s) || cap(b) != len(s) {
+			t.Errorf("len %d, cap %d, expected both %d", len(b), cap(b), len(s))
+		}
+		if &b[0] != unsafe.StringData(s) {
+			t.Errorf("zero-copy slice of %q doesn't share the string's bytes", s)
+		}
+	}
+}
+
+func Test_ZeroCopyEmptyString(t *testing.T) {
+	if b := zeroCopyBytes(""); len(b) != 0 {
+		t.Errorf("zero-copy of \"\" has %d bytes", len(b))
+	}
+}
+
+func Test_CopyIsIndependent(t *testing.T) {
+	s := makeKeys()[0]
+	b := copyBytes(s)
+	if &b[0] == unsafe.StringData(s) {
+		t.Fatal("[]byte(s) shares the string's bytes")
+	}
+	b[0] = '#'
+	if s[0] == '#' {
+		t.Error("writing to the copy changed the string")
+	}
+}
+
+func Test_ConversionsChecksumTheSame(t *testing.T) {
+	keys := makeKeys()
+	want := shardKeys(keys, len(keys), copyBytes)
+	if got := shardKeys(keys, len(keys), zeroCopyBytes); got != want {
+		t.Errorf("zero-copy checksum %d, expected %d", got, want)
+	}
+}
+
+func Test_ZeroCopyDoesNotAllocate(t *testing.T) {
+	key := makeKeys()[0]
+	testutil.AssertMaxAllocs(t, "unsafe.Slice", 0, func() {
+		checksum += crc32.ChecksumIEEE(zeroCopyBytes(key))
+	})
+}
+
+func Test_StringHeaderIsTwoWords(t *testing.T) {
+	if got, want := unsafe.Sizeof(""), 2*unsafe.Sizeof(uintptr(0)); got != want {
+		t.Errorf("unsafe.Sizeof(string) = %d, expected pointer + length = %d", got, want)
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	conversionsPerRun = 1_000_000
+	keyCount          = 1024
+	// keyLen is a typical cache key: long enough that []byte(s) can't use
+	// the compiler's 32-byte stack buffer
+	keyLen = 64
+	// spotDiscount is a typical spot price cut for general-purpose instances
+	spotDiscount = 0.7
+)
+
+// ========== STRING TO []BYTE CONVERSIONS ==========
+
+// Each conversion feeds a cache key to crc32, an API that takes []byte but
+// only reads it, the way a proxy picks a shard for a key.
+
+// copyBytes is the ordinary conversion: a new array with the string's
+// bytes copied into it.
+func copyBytes(s string) []byte {
+	return []byte(s)
+}
+
+// zeroCopyBytes returns a slice over the string's own bytes.
+//
+// WARNING: writing to the returned slice is undefined behavior. Strings
+// are immutable, so the compiler and runtime share their bytes freely:
+// a literal's bytes live in read-only memory and writing them crashes the
+// program; a string built at run time may be a map key, interned, or
+// shared with another string, all of which silently change under the
+// write. Only hand the slice to code that reads it and doesn't keep it.
+func zeroCopyBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+type conversion struct {
+	Name    string
+	Convert func(string) []byte
+}
+
+var conversions = []conversion{
+	{"[]byte(s)", copyBytes},
+	{"unsafe.Slice(unsafe.StringData(s))", zeroCopyBytes},
+}
+
+// makeKeys returns keyCount distinct keyLen-byte cache keys.
+func makeKeys() []string {
+	keys := make([]string, keyCount)
+	for i := range keys {
+		key := fmt.Sprintf("tenant:%04d:user:%08d:session:", i%97, i*7919)
+		keys[i] = key + strings.Repeat("x", keyLen-len(key))
+	}
+	return keys
+}
+
+// shardKeys converts n keys, cycling through keys, and checksums each.
+func shardKeys(keys []string, n int, convert func(string) []byte) uint32 {
+	var sum uint32
+	for i := 0; i < n; i++ {
+		sum += crc32.ChecksumIEEE(convert(keys[i%len(keys)]))
+	}
+	return sum
+}
+
+// Global variable to prevent compiler optimizations
+var checksum uint32
+
+func main() {
+	fmt.Println("🔬 DAY 22: Zero-copy String/Byte Conversions with unsafe")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	keys := makeKeys()
+
+	// The shocking truth about []byte(s)
+	fmt.Println("🎯 SHOCKING DISCOVERY: []byte(s) copies the whole string, every time!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealStringInternals(keys[0])
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d string → []byte conversions of %d-byte keys\n", conversionsPerRun, keyLen)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(keys)
+
+	// When it is safe
+	fmt.Println("\n🔧 ZERO-COPY DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainZeroCopySafety()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateConversionCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 22, Topic: "Zero-copy String/Byte Conversions with unsafe", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 22 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 23 - String vs Integer Map Keys")
+}
+
+func revealStringInternals(key string) {
+	// reflect.StringHeader is deprecated for use, but it still documents
+	// the layout every string value has
+	var sh reflect.StringHeader
+	fmt.Printf("  A string value is a %d-byte header (reflect.StringHeader):\n", unsafe.Sizeof(key))
+	fmt.Printf("    Data uintptr  offset %d, %d bytes → the bytes, never written\n",
+		unsafe.Offsetof(sh.Data), unsafe.Sizeof(sh.Data))
+	fmt.Printf("    Len  int      offset %d, %d bytes\n", unsafe.Offsetof(sh.Len), unsafe.Sizeof(sh.Len))
+	fmt.Printf("  A []byte adds Cap: %d bytes (reflect.SliceHeader)\n\n", unsafe.Sizeof([]byte(nil)))
+
+	data := unsafe.StringData(key)
+	fmt.Printf("  %-36s %-14s %8s %8s\n", "Conversion", "shares bytes?", "allocs", "bytes")
+	for _, c := range conversions {
+		b := c.Convert(key)
+		shared := len(b) > 0 && &b[0] == data
+		allocs, bytes := bench.AllocsPerCall(10_000, func() {
+			checksum += crc32.ChecksumIEEE(c.Convert(key))
+		})
+		fmt.Printf("  %-36s %-14t %8.0f %8.0f\n", c.Name, shared, allocs, bytes)
+	}
+
+	fmt.Println("\n💡 []byte(s) must copy: the caller may write to a []byte, and the")
+	fmt.Println("   string must never change. The unsafe slice points at the string's")
+	fmt.Println("   own bytes, which is only sound while nobody writes through it.")
+}
+
+func runComparisonBenchmarks(keys []string) []bench.Result {
+	suite := bench.NewBenchmarkSuite("string → []byte")
+	suite.Iterations = 3
+	for _, c := range conversions {
+		suite.Register(c.Name, func() {
+			checksum += shardKeys(keys, conversionsPerRun, c.Convert)
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		fmt.Printf("  %-38s %6.1f ns/conversion %6.0f B/conversion\n",
+			r.Name+":", r.NsPerOp/conversionsPerRun, r.BytesPerOp/conversionsPerRun)
+	}
+	return results
+}
+
+func explainZeroCopySafety() {
+	fmt.Println("Both conversions start from the same string header:")
+	fmt.Println()
+	fmt.Println("┌──────────────────┐          ┌────────────────────────────────┐")
+	fmt.Println("│ string  Data,Len │ ───────► │ tenant:0001:user:0000... (ro)  │ ◄── unsafe.Slice")
+	fmt.Println("└──────────────────┘          └────────────────────────────────┘")
+	fmt.Println("┌──────────────────┐          ┌────────────────────────────────┐")
+	fmt.Println("│ []byte  D,Len,Cap│ ───────► │ copy, new heap array           │ ◄── []byte(s)")
+	fmt.Println("└──────────────────┘          └────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("✅ SAFE: the slice is only read, and not kept past the string's use")
+	fmt.Println("  • Hashing, checksums, bytes.Equal, bytes.Index on the key")
+	fmt.Println("  • Writing to an io.Writer that copies before Write returns")
+	fmt.Println("  • Passing to APIs documented not to modify or retain the slice")
+	fmt.Println()
+
+	fmt.Println("❌ UNSAFE: undefined behavior, silent corruption or a crash")
+	fmt.Println("  • Writing through the slice: literals are in read-only memory")
+	fmt.Println("  • append after b = b[:0]: it writes straight into the string")
+	fmt.Println("  • Callees that keep the slice: they may write to it later")
+	fmt.Println("  • The reverse, unsafe.String(&b[0], len(b)), then writing to b:")
+	fmt.Println("    any map key or cached string made from b changes with it")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🔍 CHECK WHETHER THE COMPILER ALREADY AVOIDS THE COPY")
+	fmt.Println("   ✅ m[string(b)], string(b) == \"x\", for range []byte(s)")
+	fmt.Println("   Benefit: Zero-copy for free, no unsafe needed")
+	fmt.Println()
+
+	fmt.Println("2. 📝 USE STRING APIS INSTEAD OF CONVERTING")
+	fmt.Println("   ✅ io.WriteString(w, s), strings.Index, hash/maphash.String")
+	fmt.Println("   Benefit: No conversion at all")
+	fmt.Println()
+
+	fmt.Println("3. 🔒 WRAP unsafe.Slice IN ONE READ-ONLY HELPER")
+	fmt.Println("   ✅ func readOnlyBytes(s string) []byte // documented, tested, one place")
+	fmt.Println("   Benefit: Zero allocations where a []byte-only API can't be avoided")
+	fmt.Println()
+
+	fmt.Println("4. ♻️ KEEP THE DATA AS []byte END TO END")
+	fmt.Println("   ✅ Decode into []byte fields, convert to string once at the edge")
+	fmt.Println("   Benefit: One conversion per request instead of one per call")
+}
+
+func calculateConversionCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// A cache proxy that picks a shard for every key with crc32
+	lookupsPerSecond := 200_000.0
+	lookupsPerDay := lookupsPerSecond * 24 * 3600
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	copied, zeroCopy := results[0], results[1]
+	perLookup := func(r bench.Result) float64 { return r.NsPerOp / conversionsPerRun }
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f cache lookups/second, each converting a %d-byte key to []byte\n", lookupsPerSecond, keyLen)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  []byte(s):         %6.1f ns, %3.0f B allocated per lookup\n", perLookup(copied), copied.BytesPerOp/conversionsPerRun)
+	fmt.Printf("  unsafe.Slice:      %6.1f ns, %3.0f B allocated per lookup\n", perLookup(zeroCopy), zeroCopy.BytesPerOp/conversionsPerRun)
+
+	savedNs := perLookup(copied) - perLookup(zeroCopy)
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/lookup is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), lookupsPerDay, costPerVCPUHour)
+
+	// The GC share is already inside the measured time on this machine; it
+	// is broken out to show what the allocations cost on their own
+	allocPerSecond := uint64((copied.BytesPerOp - zeroCopy.BytesPerOp) / conversionsPerRun * lookupsPerSecond)
+	gcMonthly := cost.CalculateGCPressureImpact(allocPerSecond, cost.TypicalGCCPUFraction, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.1f ns/lookup\n", savedNs)
+	fmt.Printf("  Garbage avoided: %.1f MB/s, about $%.2f/month of GC CPU\n", float64(allocPerSecond)/1e6, gcMonthly)
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: lookupsPerDay, Unit: "lookups/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	// Caches often run on spot capacity, where the same CPU is worth less
+	fmt.Println()
+	vCPUsSaved := time.Duration(savedNs).Seconds() * lookupsPerSecond
+	if _, err := cost.CompareOnDemandVsSpot(vCPUsSaved, costPerVCPUHour, spotDiscount).WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Less garbage, so fewer GC cycles and shorter tail latency")
+	fmt.Println("  • Lower peak heap between cycles")
+	fmt.Println("  • Cost no longer grows with key length")
+	return monthly
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ========== MAP KEY BENCHMARKS ==========
+
+// Lookup ops are one lookup; insert ops are one insert into a map built
+// from scratch with its size preallocated.
+
+func Benchmark_Lookup(b *testing.B) {
+	for _, size := range mapSizes {
+		for _, n := range keyLens {
+			ks := makeKeySet(size, n)
+			m := buildStringMap(ks)
+			b.Run(fmt.Sprintf("string_%dB_n%d", n, size), func(b *testing.B) {
+				resetAndReport(b)
+				found = lookupStrings(m, ks.strs, b.N)
+			})
+		}
+		ks := makeKeySet(size, 32)
+		m := buildIntMap(ks)
+		b.Run(fmt.Sprintf("int_n%d", size), func(b *testing.B) {
+			resetAndReport(b)
+			found = lookupInts(m, ks.ids, b.N)
+		})
+	}
+}
+
+func Benchmark_Insert(b *testing.B) {
+	for _, size := range mapSizes {
+		for _, n := range keyLens {
+			ks := makeKeySet(size, n)
+			b.Run(fmt.Sprintf("string_%dB_n%d", n, size), func(b *testing.B) {
+				resetAndReport(b)
+				for i := 0; i < b.N; i += size {
+					found = len(buildStringMap(ks))
+				}
+			})
+		}
+		ks := makeKeySet(size, 32)
+		b.Run(fmt.Sprintf("int_n%d", size), func(b *testing.B) {
+			resetAndReport(b)
+			for i := 0; i < b.N; i += size {
+				found = len(buildIntMap(ks))
+			}
+		})
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_KeySetsAreDistinctAndSized(t *testing.T) {
+	for _, size := range mapSizes {
+		for _, n := range keyLens {
+			ks := makeKeySet(size, n)
+			if len(ks.strs) != size || len(ks.ids) != size {
+				t.Fatalf("size %d, %d B: got %d keys and %d IDs", size, n, len(ks.strs), len(ks.ids))
+			}
+			for _, s := range ks.strs {
+				if len(s) != n {
+					t.Fatalf("size %d: key %q is %d bytes, expected %d", size, s, len(s), n)
+				}
+			}
+			// Distinct strings and no ID collisions: both maps hold every entry
+			if got := len(buildStringMap(ks)); got != size {
+				t.Errorf("size %d, %d B: %d distinct keys", size, n, got)
+			}
+			if got := len(buildIntMap(ks)); got != size {
+				t.Errorf("size %d, %d B: %d distinct IDs", size, n, got)
+			}
+		}
+	}
+}
+
+func Test_BothKeyTypesFindEveryEntry(t *testing.T) {
+	ks := makeKeySet(1000, 32)
+	sm, im := buildStringMap(ks), buildIntMap(ks)
+	if got := lookupStrings(sm, ks.strs, 3000); got != 3000 {
+		t.Errorf("string map found %d of 3000", got)
+	}
+	if got := lookupInts(im, ks.ids, 3000); got != 3000 {
+		t.Errorf("int map found %d of 3000", got)
+	}
+	for i, id := range ks.ids {
+		if im[id] != ks.strs[i] {
+			t.Fatalf("ID %d maps to %q, expected %q", id, im[id], ks.strs[i])
+		}
+	}
+}
+
+func Test_KeyIDIsStable(t *testing.T) {
+	// FNV-1a's published test vector for "a"
+	if got := uint64(keyID("a")); got != 0xaf63dc4c8601ec8c {
+		t.Errorf("keyID(\"a\") = %#x, expected FNV-1a 0xaf63dc4c8601ec8c", got)
+	}
+	if keyID("session-1") == keyID("session-2") {
+		t.Error("different keys produced the same ID")
+	}
+}
+
+func Test_KeySetIsReproducible(t *testing.T) {
+	a, b := makeKeySet(100, 32), makeKeySet(100, 32)
+	for i := range a.strs {
+		if a.strs[i] != b.strs[i] {
+			t.Fatalf("key %d differs between runs: %q vs %q", i, a.strs[i], b.strs[i])
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// ========== JSON DECODING BENCHMARKS ==========
+
+// Each op is one GET against an httptest origin on loopback, decoded and
+// summed by the approach under test.
+
+func Benchmark_Unmarshal(b *testing.B) {
+	benchmarkApproach(b, decodeUnmarshal)
+}
+
+func Benchmark_Decoder(b *testing.B) {
+	benchmarkApproach(b, decodeDecoder)
+}
+
+func Benchmark_DecoderTokens(b *testing.B) {
+	benchmarkApproach(b, decodeTokens)
+}
+
+func benchmarkApproach(b *testing.B, decode func(r io.Reader) (result, error)) {
+	payloads := makePayloads()
+	origin := newOrigin(payloads)
+	defer origin.Close()
+	client := origin.Client()
+
+	for _, size := range payloadSizes {
+		url := fmt.Sprintf("%s/%d", origin.URL, size)
+		b.Run(strings.ReplaceAll(sizeLabel(size), " ", ""), func(b *testing.B) {
+			b.SetBytes(int64(len(payloads[size])))
+			resetAndReport(b)
+			for i := 0; i < b.N; i++ {
+				res, err := fetch(client, url, decode)
+				if err != nil {
+					b.Fatal(err)
+				}
+				sink = res
+			}
+		})
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_PayloadSizes(t *testing.T) {
+	one, _ := json.Marshal(makeOrder(0))
+	for _, size := range payloadSizes {
+		body := makePayload(size)
+		if len(body) < size || len(body) > size+2*len(one) {
+			t.Errorf("%s payload is %d bytes", sizeLabel(size), len(body))
+		}
+	}
+}
+
+func Test_ApproachesAgree(t *testing.T) {
+	payloads := makePayloads()
+	origin := newOrigin(payloads)
+	defer origin.Close()
+
+	for _, size := range payloadSizes {
+		var orders []Order
+		if err := json.Unmarshal(payloads[size], &orders); err != nil {
+			t.Fatal(err)
+		}
+		want := sumOrders(orders)
+		url := fmt.Sprintf("%s/%d", origin.URL, size)
+		for _, a := range approaches {
+			got, err := fetch(origin.Client(), url, a.Decode)
+			if err != nil {
+				t.Fatalf("%s, %s: %v", a.ID, sizeLabel(size), err)
+			}
+			if got != want {
+				t.Errorf("%s, %s: got %+v, expected %+v", a.ID, sizeLabel(size), got, want)
+			}
+		}
+	}
+}
+
+func Test_FetchReusesConnection(t *testing.T) {
+	origin := newOrigin(makePayloads())
+	defer origin.Close()
+
+	// Count dials: a body left unread closes its connection instead of
+	// returning it to the pool.
+	var dials atomic.Int32
+	var dialer net.Dialer
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dials.Add(1)
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}}
+	defer client.CloseIdleConnections()
+
+	url := fmt.Sprintf("%s/%d", origin.URL, 100<<10)
+	for _, a := range approaches {
+		for range 3 {
+			if _, err := fetch(client, url, a.Decode); err != nil {
+				t.Fatalf("%s: %v", a.ID, err)
+			}
+		}
+	}
+	if n := dials.Load(); n != 1 {
+		t.Errorf("%d requests took %d connections, expected 1", 3*len(approaches), n)
+	}
+}
+
+func Test_DecoderIgnoresTrailingGarbage(t *testing.T) {
+	body := `[{"id":1,"total":2.5}] not json`
+	if _, err := decodeUnmarshal(strings.NewReader(body)); err == nil {
+		t.Error("Unmarshal accepted trailing garbage")
+	}
+	got, err := decodeDecoder(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Decoder stopped at the value's end, but returned %v", err)
+	}
+	if got != (result{orders: 1, total: 2.5}) {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func Test_ApproachesRejectTruncatedBody(t *testing.T) {
+	body := string(makePayload(1 << 10))
+	truncated := body[:len(body)/2]
+	for _, a := range approaches {
+		if _, err := a.Decode(strings.NewReader(truncated)); err == nil {
+			t.Errorf("%s accepted a truncated body", a.ID)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// payloadSizes are the response bodies compared: a small API reply, the
+// rumored break-even point, a large page and a bulk export.
+var payloadSizes = []int{1 << 10, 50 << 10, 100 << 10, 10 << 20}
+
+// bytesPerCase is how much JSON each demo case fetches per run, so every
+// size gets a comparable amount of work.
+const bytesPerCase = 20 << 20
+
+// Order is one element of the JSON array the origin serves.
+type Order struct {
+	ID       int      `json:"id"`
+	Customer string   `json:"customer"`
+	Items    []string `json:"items"`
+	Total    float64  `json:"total"`
+	Status   string   `json:"status"`
+}
+
+func makeOrder(i int) Order {
+	return Order{
+		ID:       i,
+		Customer: fmt.Sprintf("customer-%06d", i),
+		Items:    []string{"sku-1042", "sku-77", "sku-3"},
+		Total:    float64(i%1000) + 0.99,
+		Status:   "shipped",
+	}
+}
+
+// makePayload returns a JSON array of orders at least size bytes long,
+// and less than one order longer.
+func makePayload(size int) []byte {
+	count := 0
+	for n := len("[]") - 1; n < size; count++ { // -1: no comma before the first
+		one, _ := json.Marshal(makeOrder(count))
+		n += len(one) + 1
+	}
+	orders := make([]Order, count)
+	for i := range orders {
+		orders[i] = makeOrder(i)
+	}
+	body, _ := json.Marshal(orders)
+	return body
+}
+
+// ========== DECODING APPROACHES ==========
+
+// Each approach decodes one response body and returns the sum of the
+// orders' totals and how many it saw, the work a client does with them.
+
+type result struct {
+	orders int
+	total  float64
+}
+
+// decodeUnmarshal reads the whole body, then unmarshals it.
+func decodeUnmarshal(r io.Reader) (result, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return result{}, err
+	}
+	var orders []Order
+	if err := json.Unmarshal(body, &orders); err != nil {
+		return result{}, err
+	}
+	return sumOrders(orders), nil
+}
+
+// decodeDecoder hands the body to json.Decoder and decodes the whole
+// array in one Decode call.
+func decodeDecoder(r io.Reader) (result, error) {
+	var orders []Order
+	if err := json.NewDecoder(r).Decode(&orders); err != nil {
+		return result{}, err
+	}
+	return sumOrders(orders), nil
+}
+
+// decodeTokens walks the array with Token and More, decoding one order at
+// a time into the same variable: nothing holds the whole array.
+func decodeTokens(r io.Reader) (result, error) {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil { // [
+		return result{}, err
+	}
+	var res result
+	var o Order
+	for dec.More() {
+		o = Order{}
+		if err := dec.Decode(&o); err != nil {
+			return result{}, err
+		}
+		res.orders++
+		res.total += o.Total
+	}
+	_, err := dec.Token() // ]
+	return res, err
+}
+
+func sumOrders(orders []Order) result {
+	res := result{orders: len(orders)}
+	for i := range orders {
+		res.total += orders[i].Total
+	}
+	return res
+}
+
+type approach struct {
+	Name   string
+	ID     string // Benchmark name
+	Decode func(io.Reader) (result, error)
+}
+
+var approaches = []approach{
+	{"io.ReadAll + json.Unmarshal", "Unmarshal", decodeUnmarshal},
+	{"json.NewDecoder(body).Decode", "Decoder", decodeDecoder},
+	{"json.Decoder, one order at a time", "DecoderTokens", decodeTokens},
+}
+
+// ========== ORIGIN ==========
+
+// newOrigin serves each payload at /<size>, as a downstream JSON API.
+func newOrigin(payloads map[int][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+		body, ok := payloads[size]
+		if err != nil || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+}
+
+func makePayloads() map[int][]byte {
+	payloads := make(map[int][]byte, len(payloadSizes))
+	for _, size := range payloadSizes {
+		payloads[size] = makePayload(size)
+	}
+	return payloads
+}
+
+// fetch GETs url and decodes the response with decode. It drains what the
+// decoder left unread, so the connection goes back to the pool.
+func fetch(client *http.Client, url string, decode func(io.Reader) (result, error)) (result, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return result{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return result{}, errors.New(resp.Status)
+	}
+	res, err := decode(resp.Body)
+	if err != nil {
+		return result{}, err
+	}
+	_, err = io.Copy(io.Discard, resp.Body)
+	return res, err
+}
+
+func sizeLabel(size int) string {
+	if size >= 1<<20 {
+		return fmt.Sprintf("%d MB", size>>20)
+	}
+	return fmt.Sprintf("%d KB", size>>10)
+}
+
+// Global variable to prevent compiler optimizations
+var sink result
+
+func main() {
+	fmt.Println("🔬 DAY 24: JSON Streaming vs Buffered Decoding")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	payloads := makePayloads()
+	origin := newOrigin(payloads)
+	defer origin.Close()
+
+	// The shocking truth about json.Decoder
+	fmt.Println("🎯 SHOCKING DISCOVERY: json.Decoder buffers the whole array anyway!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealDecoderInternals(payloads)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d MB of responses per case over httptest\n", bytesPerCase>>20)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(origin, payloads)
+
+	// Decoder internals
+	fmt.Println("\n🔧 JSON DECODING DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainDecodingPaths()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateDecodingCostImpact(results, cost.DefaultPricing())
+
+	// One run at each response size, named by size so they can be told apart
+	saved := make([]bench.Result, 0, len(approaches)*len(payloadSizes))
+	for _, size := range payloadSizes {
+		saved = append(saved, bench.WithPrefix(sizeLabel(size), results[size])...)
+	}
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 24, Topic: "JSON Streaming vs Buffered Decoding", Date: time.Now(), Benchmarks: saved, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 24 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 25 - Error Allocation Cost")
+}
+
+// readLog records the size of every Read a decoder makes.
+type readLog struct {
+	r     io.Reader
+	reads []int
+}
+
+func (l *readLog) Read(p []byte) (int, error) {
+	l.reads = append(l.reads, len(p))
+	return l.r.Read(p)
+}
+
+// allocatedBy returns the bytes decode allocates to decode body.
+func allocatedBy(body []byte, decode func(io.Reader) (result, error)) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	sink, _ = decode(bytes.NewReader(body))
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func revealDecoderInternals(payloads map[int][]byte) {
+	// How json.Decoder reads: watch the buffer sizes it asks for
+	body := payloads[100<<10]
+	log := &readLog{r: bytes.NewReader(body)}
+	var orders []Order
+	if err := json.NewDecoder(log).Decode(&orders); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+	fmt.Printf("  json.NewDecoder(r).Decode(&orders) on a %s body made %d reads:\n",
+		sizeLabel(len(body)), len(log.reads))
+	fmt.Printf("    the first asks for %d bytes, the largest for %d\n\n", log.reads[0], slices.Max(log.reads))
+
+	fmt.Println("  Heap allocated per response (runtime.MemStats TotalAlloc):")
+	fmt.Printf("  %-8s", "Body")
+	for _, a := range approaches {
+		fmt.Printf(" %22s", a.ID)
+	}
+	fmt.Println()
+	for _, size := range payloadSizes {
+		body := payloads[size]
+		fmt.Printf("  %-8s", sizeLabel(size))
+		for _, a := range approaches {
+			allocated := allocatedBy(body, a.Decode)
+			fmt.Printf(" %13.0f KB %4.1fx", float64(allocated)/1024, float64(allocated)/float64(len(body)))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\n💡 The classic json.Decoder reads at least 512 bytes at a time; the")
+	fmt.Println("   json/v2-backed one in Go 1.27 starts at 64 and doubles. Either way")
+	fmt.Println("   Decode won't start until its buffer holds one complete value. For a")
+	fmt.Println("   top-level array that value is the whole body, so it buffers")
+	fmt.Println("   everything, just like io.ReadAll, then decodes from that buffer.")
+}
+
+func runComparisonBenchmarks(origin *httptest.Server, payloads map[int][]byte) map[int][]bench.Result {
+	client := origin.Client()
+	results := make(map[int][]bench.Result, len(payloadSizes))
+	for _, size := range payloadSizes {
+		if len(results) > 0 {
+			fmt.Println()
+		}
+		url := fmt.Sprintf("%s/%d", origin.URL, size)
+		requests := max(1, bytesPerCase/len(payloads[size]))
+
+		suite := bench.NewBenchmarkSuite(fmt.Sprintf("%s responses × %d", sizeLabel(size), requests))
+		suite.Iterations = 3
+		for _, a := range approaches {
+			suite.Register(a.Name, func() {
+				for i := 0; i < requests; i++ {
+					res, err := fetch(client, url, a.Decode)
+					if err != nil {
+						fmt.Printf("❌ %v\n", err)
+						return
+					}
+					sink = res
+				}
+			})
+		}
+		fmt.Printf("%s:\n", suite.Title)
+		if err := suite.Report(os.Stdout); err != nil {
+			fmt.Printf("❌ %v\n", err)
+		}
+
+		// Per response, so every size reads the same way
+		perResponse := slices.Clone(suite.Results())
+		for i := range perResponse {
+			perResponse[i].NsPerOp /= float64(requests)
+			perResponse[i].BytesPerOp /= float64(requests)
+			perResponse[i].AllocsPerOp /= float64(requests)
+		}
+		results[size] = perResponse
+	}
+
+	fmt.Println("\n  Per response:")
+	fmt.Printf("  %-8s", "Body")
+	for _, a := range approaches {
+		fmt.Printf(" %16s", a.ID)
+	}
+	fmt.Printf(" %16s\n", "Decoder/Unmarshal")
+	for _, size := range payloadSizes {
+		r := results[size]
+		fmt.Printf("  %-8s", sizeLabel(size))
+		for _, res := range r {
+			fmt.Printf(" %16v", res.Duration().Round(time.Microsecond))
+		}
+		fmt.Printf(" %16.2fx\n", r[1].NsPerOp/r[0].NsPerOp)
+	}
+	return results
+}
+
+func explainDecodingPaths() {
+	fmt.Println("Three ways to decode one JSON array response:")
+	fmt.Println()
+	fmt.Println("┌──────────────────────┬───────────────────────────┬─────────────────────┐")
+	fmt.Println("│ ReadAll + Unmarshal  │ body → []byte (grows)     │ scan, then decode   │")
+	fmt.Println("├──────────────────────┼───────────────────────────┼─────────────────────┤")
+	fmt.Println("│ Decoder.Decode(&all) │ body → dec.buf (doubles)  │ scan, then decode   │")
+	fmt.Println("├──────────────────────┼───────────────────────────┼─────────────────────┤")
+	fmt.Println("│ Token + More + Decode│ body → dec.buf, one order │ decode, drop, repeat│")
+	fmt.Println("└──────────────────────┴───────────────────────────┴─────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY Decode(&all) ISN'T STREAMING:")
+	fmt.Println("  • It must find the end of the value before decoding any of it")
+	fmt.Println("  • Its buffer doubles as it grows, overshooting more than ReadAll's")
+	fmt.Println("  • The result is the same []Order, in memory all at once")
+	fmt.Println()
+
+	fmt.Println("⚠️  DECODER GOTCHAS:")
+	fmt.Println("  • It stops after the value: drain the body or the connection is lost")
+	fmt.Println("  • Trailing garbage after the value is silently ignored")
+	fmt.Println("  • It reads ahead, so the body can't be reused for anything else")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📦 UNMARSHAL WHOLE DOCUMENTS")
+	fmt.Println("   ✅ body, _ := io.ReadAll(resp.Body); json.Unmarshal(body, &v)")
+	fmt.Println("   Benefit: As fast or faster than Decoder at every size measured")
+	fmt.Println()
+
+	fmt.Println("2. 🌊 STREAM ELEMENTS WHEN YOU DON'T NEED THEM ALL AT ONCE")
+	fmt.Println("   ✅ dec.Token(); for dec.More() { dec.Decode(&o); process(o) }")
+	fmt.Println("   Benefit: Memory stays flat however big the array grows")
+	fmt.Println()
+
+	fmt.Println("3. 📏 READ INTO A BUFFER SIZED FROM Content-Length")
+	fmt.Println("   ✅ buf.Grow(int(resp.ContentLength)); buf.ReadFrom(resp.Body)")
+	fmt.Println("   Benefit: One allocation for the body instead of a growing series")
+	fmt.Println()
+
+	fmt.Println("4. 🚰 ALWAYS DRAIN AFTER A DECODER")
+	fmt.Println("   ✅ io.Copy(io.Discard, resp.Body) before Close")
+	fmt.Println("   Benefit: Keep-alive connections survive to the next request")
+}
+
+func calculateDecodingCostImpact(results map[int][]bench.Result, pricing cost.PricingModel) float64 {
+	// A service calling a downstream JSON API that returns 100 KB pages
+	const size = 100 << 10
+	responsesPerSecond := 200.0
+	responsesPerDay := responsesPerSecond * 24 * 3600
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	r := results[size]
+	unmarshal, decoder := r[0], r[1]
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f responses/second of %s JSON from a downstream API\n", responsesPerSecond, sizeLabel(size))
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, res := range r {
+		fmt.Printf("  %-34s %8v, %7.0f KB per response\n", res.Name+":",
+			res.Duration().Round(time.Microsecond), res.BytesPerOp/1024)
+	}
+
+	saved := time.Duration(decoder.NsPerOp - unmarshal.NsPerOp)
+	if saved <= 0 {
+		fmt.Printf("  Decoder → Unmarshal: %v is within noise; counting it as 0\n", saved)
+		saved = 0
+	}
+	monthly := cost.CPUSavingsMonthly(saved, responsesPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Decoder → Unmarshal saves %v per response\n", saved.Round(time.Microsecond))
+	fmt.Printf("  Monthly savings: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: responsesPerDay, Unit: "responses/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Less heap per response: no doubling buffer overshoot")
+	fmt.Println("  • The raw body is kept for logging or retries if needed")
+	fmt.Println("  • Trailing garbage is an error instead of being ignored")
+	return monthly
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== ERROR CREATION BENCHMARKS ==========
+
+// Each op creates one error; b.N runs to a million or more creations.
+
+func Benchmark_ErrorsNew(b *testing.B) {
+	benchmarkPattern(b, patterns[0])
+}
+
+func Benchmark_FmtErrorfWrap(b *testing.B) {
+	benchmarkPattern(b, patterns[1])
+}
+
+func Benchmark_Sentinel(b *testing.B) {
+	benchmarkPattern(b, patterns[2])
+}
+
+func Benchmark_ErrorStruct(b *testing.B) {
+	benchmarkPattern(b, patterns[3])
+}
+
+func benchmarkPattern(b *testing.B, p pattern) {
+	resetAndReport(b)
+	lastErr = makeErrors(b.N, p.New)
+}
+
+// ========== VALIDATION HOT PATH BENCHMARKS ==========
+
+// Each op validates one request; one in failEvery fails.
+
+func Benchmark_ValidateHotPath(b *testing.B) {
+	reqs := makeRequests(requestsPerRun)
+	for _, p := range patterns {
+		b.Run(p.ID, func(b *testing.B) {
+			resetAndReport(b)
+			for i := 0; i < b.N; i += len(reqs) {
+				failed = validateAll(reqs[:min(len(reqs), b.N-i)], p.New)
+			}
+		})
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_FmtErrorfWrapsTheSentinel(t *testing.T) {
+	err := patterns[1].New(0)
+	if got := fmt.Sprintf("%T", err); got != "*fmt.wrapError" {
+		t.Errorf("fmt.Errorf with %%w returned %s, expected *fmt.wrapError", got)
+	}
+	if !errors.Is(err, ErrInvalidAge) {
+		t.Error("errors.Is doesn't see the sentinel through %w")
+	}
+	if got := err.Error(); got != "validating request: invalid age" {
+		t.Errorf("got message %q", got)
+	}
+}
+
+func Test_ErrorsNewIsNotTheSentinel(t *testing.T) {
+	// Same message, different value: errors.Is compares identity
+	err := patterns[0].New(0)
+	if err.Error() != ErrInvalidAge.Error() {
+		t.Fatalf("messages differ: %q, %q", err, ErrInvalidAge)
+	}
+	if errors.Is(err, ErrInvalidAge) {
+		t.Error("a fresh errors.New value matched the sentinel")
+	}
+	if errors.Is(err, patterns[0].New(0)) {
+		t.Error("two errors.New values matched each other")
+	}
+}
+
+func Test_ValidationErrorCarriesCode(t *testing.T) {
+	err := fmt.Errorf("handler: %w", patterns[3].New(-1))
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.Code != -1 {
+		t.Fatalf("errors.As: got %v, %+v", errors.As(err, &ve), ve)
+	}
+	if got := err.Error(); got != "handler: validation failed: code -1" {
+		t.Errorf("got message %q", got)
+	}
+}
+
+func Test_ErrorAllocations(t *testing.T) {
+	maxAllocs := []float64{1, 2, 0, 1}
+	for i, p := range patterns {
+		testutil.AssertMaxAllocs(t, p.Name, maxAllocs[i], func() { lastErr = p.New(i) })
+	}
+}
+
+func Test_OneInTenRequestsFail(t *testing.T) {
+	reqs := makeRequests(1000)
+	for _, p := range patterns {
+		if got := validateAll(reqs, p.New); got != len(reqs)/failEvery {
+			t.Errorf("%s: %d of %d requests failed, expected %d", p.Name, got, len(reqs), len(reqs)/failEvery)
+		}
+	}
+}
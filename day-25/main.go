@@ -0,0 +1,313 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	errorsPerRun   = 1_000_000
+	requestsPerRun = 1_000_000
+	// failEvery is how often a request fails validation: one in ten
+	failEvery = 10
+)
+
+// ========== ERROR CREATION PATTERNS ==========
+
+// ErrInvalidAge is the sentinel: created once, returned by every failure.
+var ErrInvalidAge = errors.New("invalid age")
+
+// ValidationError carries which check failed, for callers that use
+// errors.As to read it.
+type ValidationError struct {
+	Code int
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed: code " + strconv.Itoa(e.Code)
+}
+
+type pattern struct {
+	Name string
+	ID   string // Benchmark name
+	New  func(code int) error
+}
+
+// patterns each return a fresh error for a failed check, the way a
+// validator would. Calls go through the func value, so none of them is
+// inlined into the caller and every returned error escapes.
+var patterns = []pattern{
+	{`errors.New("invalid age")`, "ErrorsNew", func(int) error {
+		return errors.New("invalid age")
+	}},
+	{`fmt.Errorf("...: %w", err)`, "FmtErrorfWrap", func(int) error {
+		return fmt.Errorf("validating request: %w", ErrInvalidAge)
+	}},
+	{"sentinel ErrInvalidAge", "Sentinel", func(int) error {
+		return ErrInvalidAge
+	}},
+	{"&ValidationError{Code}", "ErrorStruct", func(code int) error {
+		return &ValidationError{Code: code}
+	}},
+}
+
+// makeErrors creates n errors with newErr and returns the last, so the
+// loop can't be optimized away.
+func makeErrors(n int, newErr func(int) error) error {
+	var err error
+	for i := 0; i < n; i++ {
+		err = newErr(i)
+	}
+	return err
+}
+
+// ========== VALIDATION HOT PATH ==========
+
+type request struct {
+	Age int
+}
+
+// makeRequests returns n requests, every failEvery-th one with an age
+// that fails validation.
+func makeRequests(n int) []request {
+	reqs := make([]request, n)
+	for i := range reqs {
+		reqs[i].Age = 20 + i%60
+		if i%failEvery == 0 {
+			reqs[i].Age = -1
+		}
+	}
+	return reqs
+}
+
+// validate returns nil for a valid request and an error from fail for an
+// invalid one.
+func validate(r request, fail func(int) error) error {
+	if r.Age < 0 || r.Age > 150 {
+		return fail(r.Age)
+	}
+	return nil
+}
+
+// validateAll validates every request and returns how many failed.
+func validateAll(reqs []request, fail func(int) error) int {
+	failed := 0
+	for _, r := range reqs {
+		if err := validate(r, fail); err != nil {
+			lastErr = err
+			failed++
+		}
+	}
+	return failed
+}
+
+// Global variables to prevent compiler optimizations
+var (
+	lastErr error
+	failed  int
+)
+
+func main() {
+	fmt.Println("🔬 DAY 25: Error Allocation Cost")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about fmt.Errorf
+	fmt.Println("🎯 SHOCKING DISCOVERY: fmt.Errorf allocates a new wrapper every call!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealErrorAllocationCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d error creations\n", errorsPerRun)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Hot path
+	fmt.Println("\n🔧 ERROR VALUES DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainErrorValues()
+	runHotPathBenchmarks()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateErrorCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 25, Topic: "Error Allocation Cost", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 25 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 26 - GOGC Tuning")
+}
+
+func revealErrorAllocationCost() {
+	fmt.Printf("  %-28s %-24s %8s %8s\n", "Pattern", "dynamic type", "allocs", "bytes")
+	for i, p := range patterns {
+		allocs, bytes := bench.AllocsPerCall(10_000, func() { lastErr = p.New(i) })
+		fmt.Printf("  %-28s %-24T %8.0f %8.0f\n", p.Name, p.New(i), allocs, bytes)
+	}
+
+	// What fmt.Errorf returns depends on its verbs
+	fmt.Println("\n  fmt.Errorf's result type by verb:")
+	fmt.Printf("    %-44s → %T\n", `fmt.Errorf("age %d", age)`, fmt.Errorf("age %d", 42))
+	fmt.Printf("    %-44s → %T\n", `fmt.Errorf("...: %w", err)`, fmt.Errorf("validating request: %w", ErrInvalidAge))
+	fmt.Printf("    %-44s → %T\n", `fmt.Errorf("%w; %w", err1, err2)`, fmt.Errorf("%w; %w", ErrInvalidAge, os.ErrNotExist))
+
+	fmt.Println("\n💡 fmt.Errorf formats its message into a new string, then wraps it")
+	fmt.Println("   with the original error in a new *fmt.wrapError, two allocations")
+	fmt.Println("   on every call. errors.New allocates one *errors.errorString. A")
+	fmt.Println("   sentinel is allocated once, at init, and never again.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite("error creation")
+	suite.Iterations = 3
+	for _, p := range patterns {
+		suite.Register(p.Name, func() {
+			lastErr = makeErrors(errorsPerRun, p.New)
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		fmt.Printf("  %-30s %6.1f ns/error %4.0f B/error %4.1f allocs/error\n", r.Name+":",
+			r.NsPerOp/errorsPerRun, r.BytesPerOp/errorsPerRun, r.AllocsPerOp/errorsPerRun)
+	}
+	return results
+}
+
+func explainErrorValues() {
+	fmt.Println("What each pattern puts in the error interface:")
+	fmt.Println()
+	fmt.Println("┌──────────────────────────┬──────────────────────────────────────────┐")
+	fmt.Println("│ errors.New(msg)          │ *errorString{s}: 16 B, per call          │")
+	fmt.Println("├──────────────────────────┼──────────────────────────────────────────┤")
+	fmt.Println("│ fmt.Errorf(\"..%w\", err)  │ message string + *wrapError{msg, err}:   │")
+	fmt.Println("│                          │ 32 B + 32 B, two allocations per call    │")
+	fmt.Println("├──────────────────────────┼──────────────────────────────────────────┤")
+	fmt.Println("│ ErrInvalidAge (sentinel) │ the same pointer every time: 0 B         │")
+	fmt.Println("├──────────────────────────┼──────────────────────────────────────────┤")
+	fmt.Println("│ &ValidationError{Code}   │ 8 B struct, per call; Error() formats    │")
+	fmt.Println("│                          │ only if someone calls it                 │")
+	fmt.Println("└──────────────────────────┴──────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY SENTINELS ARE FREE:")
+	fmt.Println("  • An error is an interface: a type pointer and a data pointer")
+	fmt.Println("  • Returning a package-level pointer copies two words, no allocation")
+	fmt.Println("  • errors.Is compares it with ==, even through %w wrapping")
+	fmt.Println()
+
+	fmt.Println("⚠️  TRADE-OFFS:")
+	fmt.Println("  • A sentinel can't say which field or value failed")
+	fmt.Println("  • errors.New per call breaks errors.Is: each value is distinct")
+	fmt.Println("  • A struct error formats lazily, but still allocates to escape")
+	fmt.Println()
+}
+
+func runHotPathBenchmarks() {
+	reqs := makeRequests(requestsPerRun)
+	suite := bench.NewBenchmarkSuite("validation, 1 in 10 requests failing")
+	suite.Iterations = 3
+	for _, p := range patterns {
+		suite.Register(p.Name, func() {
+			failed = validateAll(reqs, p.New)
+		})
+	}
+	fmt.Printf("%d requests, 1 in %d failing validation:\n", requestsPerRun, failEvery)
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println()
+	for _, r := range suite.Results() {
+		fmt.Printf("  %-30s %6.2f ns/request\n", r.Name+":", r.NsPerOp/requestsPerRun)
+	}
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🏷️ RETURN SENTINELS FOR EXPECTED FAILURES")
+	fmt.Println("   ✅ var ErrInvalidAge = errors.New(\"invalid age\"); return ErrInvalidAge")
+	fmt.Println("   Benefit: Zero allocations, and errors.Is still works")
+	fmt.Println()
+
+	fmt.Println("2. 🧱 USE A STRUCT WHEN CALLERS NEED DETAILS")
+	fmt.Println("   ✅ return &ValidationError{Code: code} // format in Error(), if asked")
+	fmt.Println("   Benefit: One small allocation instead of formatting a message")
+	fmt.Println()
+
+	fmt.Println("3. 🎁 WRAP ONCE, AT THE BOUNDARY")
+	fmt.Println("   ✅ return fmt.Errorf(\"loading user: %w\", err) // in the handler only")
+	fmt.Println("   Benefit: Context for logs without paying at every layer")
+	fmt.Println()
+
+	fmt.Println("4. 🚫 DON'T USE errors.New INSIDE HOT FUNCTIONS")
+	fmt.Println("   ✅ Hoist it to a package-level var")
+	fmt.Println("   Benefit: No allocation, and callers can match it with errors.Is")
+}
+
+func calculateErrorCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// A validation service rejecting a tenth of its requests
+	requestsPerDay := 1_000_000.0
+	errorsPerDay := requestsPerDay / failEvery
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	wrapped, sentinel := results[1], results[2]
+	perError := func(r bench.Result) float64 { return r.NsPerOp / errorsPerRun }
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/day, 1 in %d failing validation: %.0f errors/day\n", requestsPerDay, failEvery, errorsPerDay)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  fmt.Errorf with %%w: %6.1f ns, %3.0f B allocated per error\n", perError(wrapped), wrapped.BytesPerOp/errorsPerRun)
+	fmt.Printf("  Sentinel:           %6.1f ns, %3.0f B allocated per error\n", perError(sentinel), sentinel.BytesPerOp/errorsPerRun)
+
+	savedNs := perError(wrapped) - perError(sentinel)
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/error is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), errorsPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.1f ns/error, %.1f ms/day\n", savedNs, savedNs*errorsPerDay/1e6)
+	fmt.Printf("  Monthly savings: $%.6f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.6f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: errorsPerDay, Unit: "errors/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Less garbage when a dependency fails and errors spike")
+	fmt.Println("  • Sentinels make errors.Is checks exact and cheap")
+	fmt.Println("  • Struct errors give callers fields instead of strings to parse")
+	return monthly
+}
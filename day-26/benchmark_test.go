@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+// ========== GOGC BENCHMARKS ==========
+
+// Each op is one round: a million short-lived events over a 32 MiB live
+// heap.
+
+func Benchmark_GOGC25(b *testing.B) {
+	benchmarkSetting(b, settings[0])
+}
+
+func Benchmark_GOGC100(b *testing.B) {
+	benchmarkSetting(b, settings[1])
+}
+
+func Benchmark_GOGC400(b *testing.B) {
+	benchmarkSetting(b, settings[2])
+}
+
+func Benchmark_GOGC400MemoryLimit(b *testing.B) {
+	benchmarkSetting(b, settings[3])
+}
+
+func benchmarkSetting(b *testing.B, s gcSetting) {
+	restore := applySetting(s)
+	defer restore()
+	live := makeLive()
+	runtime.GC()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		churn(live, structsPerRound, nil)
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.NumGC-before.NumGC)/float64(b.N), "GCs/op")
+	runtime.KeepAlive(live)
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ApplySettingRestores(t *testing.T) {
+	before := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(before)
+
+	restore := applySetting(gcSetting{GCPercent: 25, MemoryLimit: 64 * MiB})
+	if got := debug.SetGCPercent(-1); got != 25 {
+		t.Errorf("GOGC: got %d, expected 25", got)
+	}
+	debug.SetGCPercent(25)
+	restore()
+
+	if got := debug.SetGCPercent(100); got != 100 {
+		t.Errorf("GOGC after restore: got %d, expected 100", got)
+	}
+	if got := debug.SetMemoryLimit(-1); got != math.MaxInt64 {
+		t.Errorf("memory limit after restore: got %d, expected none", got)
+	}
+}
+
+func Test_HeapGoalFollowsFormula(t *testing.T) {
+	live := makeLive()
+	runtime.GC()
+	samples := newSamples()
+	_, heap, _ := readMetrics(samples)
+
+	for _, percent := range []int{25, 100, 400} {
+		restore := applySetting(gcSetting{GCPercent: percent, MemoryLimit: math.MaxInt64})
+		goal, _, _ := readMetrics(samples)
+		restore()
+
+		// Roots (stacks, globals) add a little on top of the live heap
+		want := float64(heap) * (1 + float64(percent)/100)
+		if ratio := float64(goal) / want; ratio < 0.95 || ratio > 1.10 {
+			t.Errorf("GOGC=%d: goal %.1f MiB, formula %.1f MiB", percent, mib(goal), want/MiB)
+		}
+	}
+	runtime.KeepAlive(live)
+}
+
+func Test_LowerGOGCRunsMoreCycles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the full workload")
+	}
+	aggressive := measureSetting(settings[0])
+	lazy := measureSetting(settings[2])
+	t.Logf("GOGC=25: %.0f GCs, peak %.0f MiB; GOGC=400: %.0f GCs, peak %.0f MiB",
+		aggressive.Cycles, mib(aggressive.PeakHeap), lazy.Cycles, mib(lazy.PeakHeap))
+
+	if aggressive.Cycles < 4*lazy.Cycles {
+		t.Errorf("expected GOGC=25 to run at least 4x the cycles of GOGC=400, got %.0f vs %.0f",
+			aggressive.Cycles, lazy.Cycles)
+	}
+	if lazy.PeakHeap < 2*aggressive.PeakHeap {
+		t.Errorf("expected GOGC=400 to use at least 2x the heap, got %.0f vs %.0f MiB",
+			mib(lazy.PeakHeap), mib(aggressive.PeakHeap))
+	}
+}
+
+func Test_MemoryLimitCapsLazyGC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the full workload")
+	}
+	limited := measureSetting(settings[3])
+	t.Logf("GOGC=400 + 96 MiB: peak heap %.0f MiB, goal %.0f MiB, %.0f GCs",
+		mib(limited.PeakHeap), mib(limited.PeakGoal), limited.Cycles)
+
+	if limited.PeakGoal > uint64(limited.Setting.MemoryLimit) {
+		t.Errorf("heap goal %.0f MiB is over the %d MiB limit",
+			mib(limited.PeakGoal), limited.Setting.MemoryLimit/MiB)
+	}
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	MiB = 1 << 20
+	// structsPerRound is one round of the workload: a million short-lived
+	// events, allocated and dropped
+	structsPerRound = 1_000_000
+	rounds          = 10
+	// liveEvents stay reachable the whole run, so every GC cycle has a
+	// real heap to mark: 512Ki × 64 B = 32 MiB
+	liveEvents = 512 << 10
+	runs       = 3
+)
+
+// event is a small, pointer-carrying struct: 64 bytes with its padding
+// to the size class.
+type event struct {
+	ID      int64
+	Payload [40]byte
+	Next    *event
+}
+
+// ========== GC SETTINGS ==========
+
+// gcSetting is one GOGC value, optionally with a memory limit. Both can be
+// set with environment variables (GOGC=25 GOMEMLIMIT=96MiB); here they
+// are set in-process with runtime/debug so all of them run in one binary.
+type gcSetting struct {
+	Name        string
+	GCPercent   int
+	MemoryLimit int64 // math.MaxInt64 means no limit
+}
+
+var settings = []gcSetting{
+	{"GOGC=25 (aggressive)", 25, math.MaxInt64},
+	{"GOGC=100 (default)", 100, math.MaxInt64},
+	{"GOGC=400 (lazy)", 400, math.MaxInt64},
+	{"GOGC=400 + GOMEMLIMIT=96MiB", 400, 96 * MiB},
+}
+
+// applySetting installs s and returns a func that restores the previous
+// settings, so runs never leak tuning into each other.
+func applySetting(s gcSetting) (restore func()) {
+	oldPercent := debug.SetGCPercent(s.GCPercent)
+	oldLimit := debug.SetMemoryLimit(s.MemoryLimit)
+	return func() {
+		debug.SetGCPercent(oldPercent)
+		debug.SetMemoryLimit(oldLimit)
+	}
+}
+
+// ========== WORKLOAD ==========
+
+// Global variable to prevent compiler optimizations
+var last *event
+
+// makeLive returns the events that stay reachable for the whole run.
+func makeLive() []*event {
+	live := make([]*event, liveEvents)
+	for i := range live {
+		live[i] = &event{ID: int64(i)}
+	}
+	return live
+}
+
+// churn allocates n events that are dropped right away, each pointing
+// into live. sample, if set, runs every 4096 allocations.
+func churn(live []*event, n int, sample func()) {
+	for i := 0; i < n; i++ {
+		e := &event{ID: int64(i), Next: live[i%len(live)]}
+		last = e
+		if sample != nil && i%4096 == 0 {
+			sample()
+		}
+	}
+}
+
+// ========== MEASUREMENT ==========
+
+// gcResult is what the GC did while the workload ran, averaged over runs.
+type gcResult struct {
+	Setting    gcSetting
+	Elapsed    time.Duration
+	Cycles     float64
+	PauseTotal time.Duration
+	PauseMax   time.Duration
+	GCCPU      time.Duration // GC CPU time, STW and concurrent, from runtime/metrics
+	PeakHeap   uint64        // Most heap in objects seen while sampling
+	PeakGoal   uint64        // Highest heap goal the pacer set
+	Allocated  uint64
+}
+
+// GCCPUPerGiB is the collector's CPU time per GiB allocated, the figure
+// that scales with a service's allocation rate.
+func (r gcResult) GCCPUPerGiB() time.Duration {
+	return time.Duration(float64(r.GCCPU) / (float64(r.Allocated) / (1 << 30)))
+}
+
+var metricNames = []string{
+	"/gc/heap/goal:bytes",
+	"/memory/classes/heap/objects:bytes",
+	"/cpu/classes/gc/total:cpu-seconds",
+}
+
+func readMetrics(samples []metrics.Sample) (goal, heap uint64, gcCPU float64) {
+	metrics.Read(samples)
+	return samples[0].Value.Uint64(), samples[1].Value.Uint64(), samples[2].Value.Float64()
+}
+
+func newSamples() []metrics.Sample {
+	samples := make([]metrics.Sample, len(metricNames))
+	for i, name := range metricNames {
+		samples[i].Name = name
+	}
+	return samples
+}
+
+// measureSetting runs the workload runs times under s and averages.
+func measureSetting(s gcSetting) gcResult {
+	restore := applySetting(s)
+	defer restore()
+
+	live := makeLive()
+	samples := newSamples()
+	res := gcResult{Setting: s}
+	for range runs {
+		runtime.GC()
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, _, cpuBefore := readMetrics(samples)
+
+		sample := func() {
+			goal, heap, _ := readMetrics(samples)
+			res.PeakGoal = max(res.PeakGoal, goal)
+			res.PeakHeap = max(res.PeakHeap, heap)
+		}
+		start := time.Now()
+		churn(live, structsPerRound*rounds, sample)
+		res.Elapsed += time.Since(start)
+
+		// The GC CPU metric is only updated at the end of a cycle; one
+		// more flushes the last partial one into the total
+		runtime.GC()
+		_, _, cpuAfter := readMetrics(samples)
+		runtime.ReadMemStats(&after)
+
+		cycles := after.NumGC - before.NumGC - 1 // minus the flushing GC
+		res.Cycles += float64(cycles)
+		res.PauseTotal += time.Duration(after.PauseTotalNs - before.PauseTotalNs)
+		for _, p := range gcPauses(&after, before.NumGC) {
+			res.PauseMax = max(res.PauseMax, p)
+		}
+		res.GCCPU += time.Duration((cpuAfter - cpuBefore) * float64(time.Second))
+		res.Allocated += after.TotalAlloc - before.TotalAlloc
+	}
+	runtime.KeepAlive(live)
+
+	res.Elapsed /= runs
+	res.Cycles /= runs
+	res.PauseTotal /= runs
+	res.GCCPU /= runs
+	res.Allocated /= runs
+	return res
+}
+
+// gcPauses returns the STW pauses for cycles after sinceGC. MemStats keeps
+// only the last 256.
+func gcPauses(m *runtime.MemStats, sinceGC uint32) []time.Duration {
+	n := min(m.NumGC-sinceGC, uint32(len(m.PauseNs)))
+	pauses := make([]time.Duration, 0, n)
+	for i := uint32(0); i < n; i++ {
+		idx := (m.NumGC - 1 - i) % uint32(len(m.PauseNs))
+		pauses = append(pauses, time.Duration(m.PauseNs[idx]))
+	}
+	return pauses
+}
+
+func mib(b uint64) float64 { return float64(b) / MiB }
+
+func main() {
+	fmt.Println("🔬 DAY 26: GOGC Tuning")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about GOGC
+	fmt.Println("🎯 SHOCKING DISCOVERY: GOGC trades RAM for CPU, one for one!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealGCTuningCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d rounds of %d short-lived structs, %d MiB live heap\n",
+		rounds, structsPerRound, liveEvents*64/MiB)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// GC pacing and GOMEMLIMIT
+	fmt.Println("\n🔧 GC PACING DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainGCPacing(results)
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	calculateGCTuningCostImpact(results, cost.DefaultPricing())
+
+	fmt.Println("\n✅ DAY 26 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 27 - Batch vs Individual Database Inserts")
+}
+
+func revealGCTuningCost() {
+	live := makeLive()
+	runtime.GC()
+	samples := newSamples()
+	_, heap, _ := readMetrics(samples)
+
+	fmt.Println("  The pacer starts the next cycle when the heap reaches its goal:")
+	fmt.Println()
+	fmt.Println("    goal = live heap + (live heap + GC roots) × GOGC/100")
+	fmt.Println()
+	fmt.Printf("  With %.1f MiB live after a full GC (roots are stacks and globals,\n", mib(heap))
+	fmt.Println("  small here), each setting's goal:")
+	for _, percent := range []int{25, 100, 400} {
+		old := debug.SetGCPercent(percent)
+		goal, _, _ := readMetrics(samples)
+		debug.SetGCPercent(old)
+		formula := float64(heap) * (1 + float64(percent)/100)
+		fmt.Printf("    GOGC=%-3d  formula %6.1f MiB, runtime's goal %6.1f MiB, %4.1f MiB of garbage per cycle\n",
+			percent, mib(uint64(formula)), mib(goal), mib(goal-heap))
+	}
+	runtime.KeepAlive(live)
+
+	fmt.Println("\n💡 Each cycle marks the whole live heap, whatever GOGC is. GOGC only")
+	fmt.Println("   sets how much garbage may pile up between cycles: 4x the headroom")
+	fmt.Println("   means a quarter of the cycles, so a quarter of the marking CPU,")
+	fmt.Println("   paid for with that much more RAM.")
+}
+
+func runComparisonBenchmarks() []gcResult {
+	results := make([]gcResult, 0, len(settings))
+	for i, s := range settings {
+		r := measureSetting(s)
+		results = append(results, r)
+		fmt.Printf("%d. %s\n", i+1, s.Name)
+		fmt.Printf("   %v, %.0f GCs, GC CPU %v, pauses %v total / %v max, peak heap %.0f MiB (goal %.0f MiB)\n",
+			r.Elapsed.Round(time.Millisecond), r.Cycles, r.GCCPU.Round(time.Millisecond),
+			r.PauseTotal.Round(time.Microsecond), r.PauseMax.Round(time.Microsecond),
+			mib(r.PeakHeap), mib(r.PeakGoal))
+	}
+
+	base := results[1]
+	fmt.Println()
+	fmt.Printf("  %-30s %10s %12s %10s\n", "vs GOGC=100", "time", "GC CPU", "peak heap")
+	for _, r := range results {
+		fmt.Printf("  %-30s %9.2fx %11.2fx %9.2fx\n", r.Setting.Name+":",
+			float64(r.Elapsed)/float64(base.Elapsed),
+			float64(r.GCCPU)/float64(base.GCCPU),
+			float64(r.PeakHeap)/float64(base.PeakHeap))
+	}
+	return results
+}
+
+func explainGCPacing(results []gcResult) {
+	fmt.Println("Heap over time for each setting (live heap L, goal G):")
+	fmt.Println()
+	fmt.Println("  GOGC=25   ┌─┐┌─┐┌─┐┌─┐┌─┐┌─┐┌─┐┌─┐  G = 1.25 L: many short climbs")
+	fmt.Println("  GOGC=100  ┌───┐┌───┐┌───┐┌───┐       G = 2 L")
+	fmt.Println("  GOGC=400  ┌───────────┐┌──────────  G = 5 L: few, tall climbs")
+	fmt.Println("  +LIMIT    ┌─────┐┌─────┐┌─────┐     G = min(5 L, limit - other memory)")
+	fmt.Println()
+
+	lazy, limited := results[2], results[3]
+	fmt.Println("📈 GOMEMLIMIT WITH A HIGH GOGC:")
+	fmt.Printf("  • Alone, GOGC=400 let the heap reach %.0f MiB\n", mib(lazy.PeakHeap))
+	fmt.Printf("  • With a %d MiB limit it peaked at %.0f MiB, over %.0f GCs instead of %.0f\n",
+		limited.Setting.MemoryLimit/MiB, mib(limited.PeakHeap), limited.Cycles, lazy.Cycles)
+	fmt.Println("  • The limit counts all Go memory, so the heap gets less than the limit")
+	fmt.Println("  • Below the limit GOGC rules; near it the limit does")
+	fmt.Println()
+
+	fmt.Println("⚠️  RISKS:")
+	fmt.Println("  • GOGC=25 with a big live heap: the GC can eat most of a core")
+	fmt.Println("  • GOGC=400 without a limit: a live-heap spike needs 5x the RAM")
+	fmt.Println("  • A limit below the live heap: the GC runs constantly (death spiral),")
+	fmt.Println("    capped at about 50% of CPU, instead of the process OOMing")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📏 SIZE GOGC FROM THE INSTANCE, NOT THE DEFAULT")
+	fmt.Println("   ✅ GOGC=400 when RAM sits idle and CPU is the bill")
+	fmt.Println("   Benefit: A quarter of the GC cycles for 4x the headroom")
+	fmt.Println()
+
+	fmt.Println("2. 🧱 PAIR A HIGH GOGC WITH GOMEMLIMIT")
+	fmt.Println("   ✅ GOGC=400 GOMEMLIMIT=<container limit - 10-20%>")
+	fmt.Println("   Benefit: Lazy GC while memory is plentiful, a ceiling when it isn't")
+	fmt.Println()
+
+	fmt.Println("3. 🔬 MEASURE GC CPU BEFORE TUNING")
+	fmt.Println("   ✅ runtime/metrics /cpu/classes/gc/total:cpu-seconds, GODEBUG=gctrace=1")
+	fmt.Println("   Benefit: Tune only when the GC is a real share of CPU")
+	fmt.Println()
+
+	fmt.Println("4. ♻️ ALLOCATE LESS FIRST")
+	fmt.Println("   ✅ Pools, preallocation and value types (days 2, 9 and 7)")
+	fmt.Println("   Benefit: Fewer cycles at any GOGC, without spending RAM")
+}
+
+func calculateGCTuningCostImpact(results []gcResult, pricing cost.PricingModel) {
+	// A fleet where each instance runs this workload's heap and allocates
+	// steadily, as an API server decoding and discarding requests does
+	instances := 10.0
+	allocGiBPerSecond := 0.5
+	costPerVCPUHour := pricing.CPUHourCost()
+	ramPerGBMonth := pricing.RAMGBMonthCost()
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f instances, each allocating %.1f GiB/s with a %d MiB live heap\n",
+		instances, allocGiBPerSecond, liveEvents*64/MiB)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU, $%.2f/month per GB of RAM\n", pricing, costPerVCPUHour, ramPerGBMonth)
+	fmt.Println("  • RAM is billed at each setting's peak heap; GC CPU at its measured rate")
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  %-30s %12s %10s %10s %10s\n", "Setting", "GC CPU/GiB", "CPU $/mo", "RAM $/mo", "total")
+	totals := make([]float64, len(results))
+	for i, r := range results {
+		vCPUs := r.GCCPUPerGiB().Seconds() * allocGiBPerSecond * instances
+		cpuMonthly := vCPUs * costPerVCPUHour * cost.HoursPerMonth
+		ramMonthly := cost.MemorySavingsMonthly(r.PeakHeap, ramPerGBMonth) * instances
+		totals[i] = cpuMonthly + ramMonthly
+		fmt.Printf("  %-30s %12v %9.2f$ %9.2f$ %9.2f$\n", r.Setting.Name,
+			r.GCCPUPerGiB().Round(time.Microsecond), cpuMonthly, ramMonthly, totals[i])
+	}
+
+	best := 0
+	for i := range totals {
+		if totals[i] < totals[best] {
+			best = i
+		}
+	}
+	monthly := totals[1] - totals[best]
+	if monthly <= 0 {
+		fmt.Println("  The default is already the cheapest setting here")
+		monthly = 0
+	}
+	fmt.Printf("\n  Cheapest: %s\n", results[best].Setting.Name)
+	fmt.Printf("  Monthly savings vs GOGC=100: $%.2f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.2f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly/instances); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Fewer cycles mean fewer write-barrier and assist slowdowns")
+	fmt.Println("  • GOMEMLIMIT turns an OOM kill into a slower, still-running process")
+	fmt.Println("  • Tuning is an env var: no code change, easy to roll back")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// ========== INSERT BENCHMARKS ==========
+
+// Each op inserts usersPerRun users into an emptied in-memory table.
+
+func Benchmark_IndividualInserts(b *testing.B) {
+	benchmarkStrategy(b, strategies[0])
+}
+
+func Benchmark_TransactionInserts(b *testing.B) {
+	benchmarkStrategy(b, strategies[1])
+}
+
+func Benchmark_BatchInsert(b *testing.B) {
+	benchmarkStrategy(b, strategies[2])
+}
+
+func benchmarkStrategy(b *testing.B, s strategy) {
+	db := openTestDB(b)
+	users := makeUsers(usersPerRun)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		if err := resetUsers(db); err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+		if err := s.Insert(db, users); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	db, err := openDB(":memory:")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_StrategiesInsertEveryUser(t *testing.T) {
+	users := makeUsers(usersPerRun)
+	for _, s := range strategies {
+		t.Run(s.ID, func(t *testing.T) {
+			db := openTestDB(t)
+			if err := s.Insert(db, users); err != nil {
+				t.Fatal(err)
+			}
+			if n, err := countUsers(db); err != nil || n != len(users) {
+				t.Fatalf("got %d rows (err %v), expected %d", n, err, len(users))
+			}
+
+			last := users[len(users)-1]
+			var got User
+			err := db.QueryRow("SELECT id, name, email, age FROM users WHERE id = ?", last.ID).
+				Scan(&got.ID, &got.Name, &got.Email, &got.Age)
+			if err != nil || got != last {
+				t.Errorf("got %+v (err %v), expected %+v", got, err, last)
+			}
+		})
+	}
+}
+
+func Test_BatchQuery(t *testing.T) {
+	q := batchQuery(3)
+	want := insertPrefix + "(?, ?, ?, ?), (?, ?, ?, ?), (?, ?, ?, ?)"
+	if q != want {
+		t.Errorf("got %q, expected %q", q, want)
+	}
+	if n := strings.Count(batchQuery(usersPerRun), "?"); n != usersPerRun*columnsPerUser {
+		t.Errorf("got %d placeholders, expected %d", n, usersPerRun*columnsPerUser)
+	}
+}
+
+func Test_FailedInsertsLeaveNoPartialBatch(t *testing.T) {
+	users := makeUsers(10)
+	users[5].ID = users[4].ID // Duplicate primary key
+
+	// The batch and the transaction are all or nothing; autocommit keeps
+	// the rows before the failure
+	for i, want := range []int{5, 0, 0} {
+		s := strategies[i]
+		db := openTestDB(t)
+		if err := s.Insert(db, users); err == nil {
+			t.Errorf("%s: expected a primary key error", s.ID)
+		}
+		if n, _ := countUsers(db); n != want {
+			t.Errorf("%s: %d rows left after the failure, expected %d", s.ID, n, want)
+		}
+	}
+}
+
+func Test_IndividualInsertsWriteMore(t *testing.T) {
+	if _, _, ok := ioCounters(); !ok {
+		t.Skip("needs /proc/self/io")
+	}
+	dir := t.TempDir()
+	users := makeUsers(100)
+	individual, err := measureOnDisk(dir, strategies[0], users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch, err := measureOnDisk(dir, strategies[2], users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("100 users: %d B in %d writes individually, %d B in %d writes batched",
+		individual.BytesWritten, individual.WriteCalls, batch.BytesWritten, batch.WriteCalls)
+
+	if individual.WriteCalls < 10*batch.WriteCalls {
+		t.Errorf("expected at least 10x the write calls, got %d vs %d", individual.WriteCalls, batch.WriteCalls)
+	}
+	if individual.FileSize != batch.FileSize {
+		t.Errorf("expected the same file either way, got %d vs %d bytes", individual.FileSize, batch.FileSize)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== PROPAGATION BENCHMARKS ==========
+
+// Each op handles one request: 5 values down a 5-level call chain.
+
+func Benchmark_ContextWithValue(b *testing.B) {
+	benchmarkApproach(b, approaches[0])
+}
+
+func Benchmark_StructPointer(b *testing.B) {
+	benchmarkApproach(b, approaches[1])
+}
+
+func Benchmark_GoroutineLocal(b *testing.B) {
+	benchmarkApproach(b, approaches[2])
+}
+
+func benchmarkApproach(b *testing.B, a approach) {
+	reqs := makeRequests(1024)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		checksum = a.Handle(&reqs[i%len(reqs)])
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ApproachesAgree(t *testing.T) {
+	reqs := makeRequests(100)
+	want := handleAll(reqs, approaches[1].Handle)
+	if want == 0 {
+		t.Fatal("expected a non-zero checksum")
+	}
+	for _, a := range approaches {
+		if got := handleAll(reqs, a.Handle); got != want {
+			t.Errorf("%s: got checksum %d, expected %d", a.ID, got, want)
+		}
+	}
+}
+
+func Test_StructPointerDoesNotAllocate(t *testing.T) {
+	r := makeRequests(1)[0]
+	testutil.AssertMaxAllocs(t, "handleWithStruct", 0, func() {
+		checksum = handleWithStruct(&r)
+	})
+}
+
+func Test_WithValueAllocatesPerValue(t *testing.T) {
+	r := makeRequests(1)[0]
+	allocs := testing.AllocsPerRun(100, func() {
+		lastCtx = context.WithValue(context.Background(), traceIDKey, r.TraceID)
+	})
+	// The valueCtx node and the boxed string
+	if allocs != 2 {
+		t.Errorf("got %.0f allocs per WithValue, expected 2", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { checksum = handleWithContext(&r) }); allocs < 5 {
+		t.Errorf("got %.0f allocs per request, expected at least one per value", allocs)
+	}
+}
+
+func Test_GoroutineIDIsPerGoroutine(t *testing.T) {
+	id := goroutineID()
+	if id == 0 || goroutineID() != id {
+		t.Fatalf("expected a stable non-zero ID, got %d", id)
+	}
+
+	var wg sync.WaitGroup
+	ids := make([]uint64, 4)
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i] = goroutineID()
+		}()
+	}
+	wg.Wait()
+	seen := map[uint64]bool{id: true}
+	for _, other := range ids {
+		if seen[other] {
+			t.Errorf("goroutine ID %d seen twice in %v (test goroutine %d)", other, ids, id)
+		}
+		seen[other] = true
+	}
+}
+
+func Test_GoroutineLocalCleansUp(t *testing.T) {
+	r := makeRequests(1)[0]
+	checksum = handleWithGoroutineLocal(&r)
+	if _, ok := requestsByGoroutine.Load(goroutineID()); ok {
+		t.Error("expected the request to be deleted after the handler returns")
+	}
+}
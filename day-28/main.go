@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	requestsPerRun = 100_000
+	// callDepth is the handler → service → repository → cache → client
+	// chain every request walks
+	callDepth = 5
+)
+
+// ========== REQUEST-SCOPED DATA ==========
+
+// incoming is what a request arrives with: headers a tracing middleware
+// and an auth middleware would pull out.
+type incoming struct {
+	TraceID   string
+	SpanID    string
+	UserID    string
+	TenantID  string
+	RequestID string
+}
+
+// makeRequests returns n requests with distinct IDs, so no value is a
+// constant the compiler could box for free.
+func makeRequests(n int) []incoming {
+	reqs := make([]incoming, n)
+	for i := range reqs {
+		id := strconv.Itoa(i)
+		reqs[i] = incoming{
+			TraceID:   "trace-" + id,
+			SpanID:    "span-" + id,
+			UserID:    "user-" + strconv.Itoa(i%1000),
+			TenantID:  "tenant-" + strconv.Itoa(i%10),
+			RequestID: "req-" + id,
+		}
+	}
+	return reqs
+}
+
+// RequestContext carries the same five values as one struct.
+type RequestContext struct {
+	TraceID   string
+	SpanID    string
+	UserID    string
+	TenantID  string
+	RequestID string
+}
+
+func newRequestContext(r *incoming) RequestContext {
+	return RequestContext(*r)
+}
+
+// field returns the value each level of the chain reads besides the
+// trace ID: level 0 the span, level 1 the user, and so on.
+func (rc *RequestContext) field(depth int) string {
+	switch depth % 4 {
+	case 0:
+		return rc.SpanID
+	case 1:
+		return rc.UserID
+	case 2:
+		return rc.TenantID
+	}
+	return rc.RequestID
+}
+
+// ========== APPROACHES ==========
+
+type approach struct {
+	Name   string
+	ID     string // Benchmark name
+	Handle func(r *incoming) int
+}
+
+var approaches = []approach{
+	{"context.WithValue × 5", "ContextWithValue", handleWithContext},
+	{"*RequestContext parameter", "StructPointer", handleWithStruct},
+	{"sync.Map[goroutine ID]", "GoroutineLocal", handleWithGoroutineLocal},
+}
+
+// ctxKey is unexported so no other package can collide with these keys.
+// Small integer keys box into an interface without allocating.
+type ctxKey int
+
+const (
+	traceIDKey ctxKey = iota
+	spanIDKey
+	userIDKey
+	tenantIDKey
+	requestIDKey
+)
+
+// levelKeys mirrors RequestContext.field for the context approach.
+var levelKeys = [...]ctxKey{spanIDKey, userIDKey, tenantIDKey, requestIDKey}
+
+// handleWithContext is what tracing and auth middleware usually do: one
+// WithValue per value, then ctx goes down the chain.
+func handleWithContext(r *incoming) int {
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, traceIDKey, r.TraceID)
+	ctx = context.WithValue(ctx, spanIDKey, r.SpanID)
+	ctx = context.WithValue(ctx, userIDKey, r.UserID)
+	ctx = context.WithValue(ctx, tenantIDKey, r.TenantID)
+	ctx = context.WithValue(ctx, requestIDKey, r.RequestID)
+	return contextLevel(ctx, 0)
+}
+
+// contextLevel is one function of the call chain. Each reads the trace
+// ID, as a logger or span would, and one other value.
+//
+//go:noinline
+func contextLevel(ctx context.Context, depth int) int {
+	n := len(ctx.Value(traceIDKey).(string)) + len(ctx.Value(levelKeys[depth%len(levelKeys)]).(string))
+	if depth+1 < callDepth {
+		n += contextLevel(ctx, depth+1)
+	}
+	return n
+}
+
+// handleWithStruct passes the values explicitly. The pointer never
+// outlives the request, so rc stays on the handler's stack.
+func handleWithStruct(r *incoming) int {
+	rc := newRequestContext(r)
+	return structLevel(&rc, 0)
+}
+
+//go:noinline
+func structLevel(rc *RequestContext, depth int) int {
+	n := len(rc.TraceID) + len(rc.field(depth))
+	if depth+1 < callDepth {
+		n += structLevel(rc, depth+1)
+	}
+	return n
+}
+
+// requestsByGoroutine simulates goroutine-local storage, which Go leaves
+// out on purpose: each function finds its request by asking which
+// goroutine it is running on.
+var requestsByGoroutine sync.Map // goroutine ID -> *RequestContext
+
+// goroutineID parses the ID from the "goroutine 42 [running]:" header of
+// runtime.Stack. There is no supported API for it.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header, _, _ := bytes.Cut(buf[:runtime.Stack(buf[:], false)], []byte(" ["))
+	id, _ := strconv.ParseUint(string(bytes.TrimPrefix(header, []byte("goroutine "))), 10, 64)
+	return id
+}
+
+func currentRequest() *RequestContext {
+	v, _ := requestsByGoroutine.Load(goroutineID())
+	return v.(*RequestContext)
+}
+
+func handleWithGoroutineLocal(r *incoming) int {
+	rc := newRequestContext(r)
+	id := goroutineID()
+	requestsByGoroutine.Store(id, &rc)
+	defer requestsByGoroutine.Delete(id)
+	return goroutineLocalLevel(0)
+}
+
+//go:noinline
+func goroutineLocalLevel(depth int) int {
+	rc := currentRequest()
+	n := len(rc.TraceID) + len(rc.field(depth))
+	if depth+1 < callDepth {
+		n += goroutineLocalLevel(depth + 1)
+	}
+	return n
+}
+
+// handleAll runs every request through handle and returns the checksum.
+func handleAll(reqs []incoming, handle func(r *incoming) int) int {
+	total := 0
+	for i := range reqs {
+		total += handle(&reqs[i])
+	}
+	return total
+}
+
+// Global variable to prevent compiler optimizations
+var checksum int
+
+func main() {
+	fmt.Println("🔬 DAY 28: context.WithValue Cost")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about context.WithValue
+	fmt.Println("🎯 SHOCKING DISCOVERY: every context.WithValue is a heap-allocated list node!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealContextAllocationCost()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d requests, 5 values, %d-level call chain\n", requestsPerRun, callDepth)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Context internals
+	fmt.Println("\n🔧 CONTEXT VALUES DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainContextChain()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateContextCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 28, Topic: "context.WithValue Cost", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 28 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 29 - Middleware Chain Allocation Cost")
+}
+
+// Global variable to prevent compiler optimizations
+var lastCtx context.Context
+
+func revealContextAllocationCost() {
+	reqs := makeRequests(1000)
+	parent := context.Background()
+	i := 0
+	next := func() *incoming {
+		i++
+		return &reqs[i%len(reqs)]
+	}
+
+	fmt.Printf("  %-44s %8s %8s\n", "Call", "allocs", "bytes")
+	allocs, bytes := bench.AllocsPerCall(10_000, func() {
+		lastCtx = context.WithValue(parent, traceIDKey, "constant")
+	})
+	fmt.Printf("  %-44s %8.0f %8.0f\n", `WithValue(ctx, key, "constant")`, allocs, bytes)
+	allocs, bytes = bench.AllocsPerCall(10_000, func() {
+		lastCtx = context.WithValue(parent, traceIDKey, next().TraceID)
+	})
+	fmt.Printf("  %-44s %8.0f %8.0f\n", "WithValue(ctx, key, r.TraceID)", allocs, bytes)
+	allocs, bytes = bench.AllocsPerCall(10_000, func() { checksum = handleWithContext(next()) })
+	fmt.Printf("  %-44s %8.0f %8.0f\n", "5 × WithValue + 5-level chain", allocs, bytes)
+	allocs, bytes = bench.AllocsPerCall(10_000, func() { checksum = handleWithStruct(next()) })
+	fmt.Printf("  %-44s %8.0f %8.0f\n", "RequestContext + 5-level chain", allocs, bytes)
+
+	ctx := context.WithValue(context.WithValue(parent, traceIDKey, "t"), spanIDKey, "s")
+	fmt.Printf("\n  Two WithValue calls: %v\n", ctx)
+	fmt.Println("\n💡 WithValue can't change its parent, so it returns a new valueCtx")
+	fmt.Println("   node pointing at it: a linked list, newest value first. The node")
+	fmt.Println("   outlives the call, so it goes on the heap, and a non-constant")
+	fmt.Println("   value is boxed into an interface: a second allocation.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	reqs := makeRequests(requestsPerRun)
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d requests", requestsPerRun))
+	suite.Iterations = 3
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			checksum = handleAll(reqs, a.Handle)
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, r := range results {
+		fmt.Printf("  %-30s %8.1f ns/request %5.0f B/request %4.1f allocs/request\n", r.Name+":",
+			r.NsPerOp/requestsPerRun, r.BytesPerOp/requestsPerRun, r.AllocsPerOp/requestsPerRun)
+	}
+	return results
+}
+
+func explainContextChain() {
+	fmt.Println("What 5 WithValue calls build, and how Value finds a key:")
+	fmt.Println()
+	fmt.Println("  requestID ─▶ tenantID ─▶ userID ─▶ spanID ─▶ traceID ─▶ Background")
+	fmt.Println("  (newest)                                      (oldest)")
+	fmt.Println()
+	fmt.Println("  ctx.Value(traceIDKey): compare 5 keys, newest first")
+	fmt.Println("  ctx.Value(unknownKey): compare all 5, then ask Background")
+	fmt.Println()
+
+	fmt.Println("📈 WHY IT ADDS UP:")
+	fmt.Println("  • 2 allocations per value: the 48-byte valueCtx and the boxed string")
+	fmt.Println("  • Value is O(depth): the oldest value, often the trace ID, is the slowest")
+	fmt.Println("  • Every WithCancel, WithTimeout and WithValue deepens the list")
+	fmt.Println()
+
+	fmt.Println("⚠️  THE GOROUTINE-LOCAL TRAP:")
+	fmt.Println("  • Go has no goroutine IDs on purpose; parsing runtime.Stack is a hack")
+	fmt.Println("  • Values vanish when work moves to another goroutine")
+	fmt.Println("  • A missed Delete leaks the request until the ID is reused")
+	fmt.Println()
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📦 ONE WithValue WITH A STRUCT")
+	fmt.Println("   ✅ ctx = context.WithValue(ctx, requestKey{}, &RequestContext{...})")
+	fmt.Println("   Benefit: 5 values for one node, and one lookup per read")
+	fmt.Println()
+
+	fmt.Println("2. ➡️ PASS WHAT THE CALLEE NEEDS EXPLICITLY")
+	fmt.Println("   ✅ func (s *Service) Get(ctx context.Context, rc *RequestContext, id string)")
+	fmt.Println("   Benefit: No allocation, and the dependency is in the signature")
+	fmt.Println()
+
+	fmt.Println("3. 🔍 READ ONCE PER FUNCTION")
+	fmt.Println("   ✅ traceID := TraceID(ctx) at the top, not in every log call")
+	fmt.Println("   Benefit: One list walk instead of one per use")
+	fmt.Println()
+
+	fmt.Println("4. 🚫 DON'T SIMULATE GOROUTINE-LOCAL STORAGE")
+	fmt.Println("   ✅ Keep request data in ctx or parameters")
+	fmt.Println("   Benefit: Works across goroutines, nothing to clean up")
+}
+
+func calculateContextCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// A tracing-heavy microservice: middleware adds trace, span, user,
+	// tenant and request IDs to every request's context
+	rps := 5000.0
+	requestsPerDay := rps * 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	withValue, explicit := results[0], results[1]
+	perRequest := func(r bench.Result) (ns, bytes float64) {
+		return r.NsPerOp / requestsPerRun, r.BytesPerOp / requestsPerRun
+	}
+	withValueNs, withValueBytes := perRequest(withValue)
+	explicitNs, explicitBytes := perRequest(explicit)
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %.0f requests/second, %d context values each, read across %d call levels\n", rps, 5, callDepth)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • GC cost: %.2f of a vCPU per GiB/s allocated\n", cost.TypicalGCCPUFraction)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  context.WithValue:  %6.1f ns, %4.0f B allocated per request\n", withValueNs, withValueBytes)
+	fmt.Printf("  *RequestContext:    %6.1f ns, %4.0f B allocated per request\n", explicitNs, explicitBytes)
+
+	savedNs := withValueNs - explicitNs
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/request is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	cpuMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), requestsPerDay, costPerVCPUHour)
+
+	savedBytesPerSecond := max(withValueBytes-explicitBytes, 0) * rps
+	gcMonthly := cost.CalculateGCPressureImpact(uint64(savedBytesPerSecond), cost.TypicalGCCPUFraction, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.1f ns/request, %.1f CPU-seconds/day\n", savedNs, savedNs*requestsPerDay/1e9)
+	fmt.Printf("  Garbage avoided: %.2f MB/s\n", savedBytesPerSecond/1e6)
+	fmt.Printf("  CPU savings:     $%.4f/month\n", cpuMonthly)
+	fmt.Printf("  GC savings:      $%.4f/month\n", gcMonthly)
+
+	monthly := cpuMonthly + gcMonthly
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Explicit parameters show each function's dependencies")
+	fmt.Println("  • Shallower contexts make every ctx.Value and ctx.Done faster")
+	fmt.Println("  • Typed struct fields instead of any and type assertions")
+	return monthly
+}
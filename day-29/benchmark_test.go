@@ -0,0 +1,120 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== MIDDLEWARE BENCHMARKS ==========
+
+// Each op is one authenticated request through logging, auth, rate
+// limiting and tracing, into a new httptest.ResponseRecorder.
+
+func Benchmark_ClosureChain(b *testing.B) {
+	benchmarkApproach(b, approaches[0])
+}
+
+func Benchmark_StructChain(b *testing.B) {
+	benchmarkApproach(b, approaches[1])
+}
+
+func Benchmark_SingleHandler(b *testing.B) {
+	benchmarkApproach(b, approaches[2])
+}
+
+// Benchmark_ChainPerRequest is the anti-pattern: the closure chain built
+// inside the request path.
+func Benchmark_ChainPerRequest(b *testing.B) {
+	s, l, r := &stats{}, newLimiter(), newRequest()
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		served = serveAll(closureChain(s, l), r, 1)
+	}
+}
+
+func benchmarkApproach(b *testing.B, a approach) {
+	h, r := a.Build(&stats{}, newLimiter()), newRequest()
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		served = serveAll(h, r, 1)
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ApproachesBehaveTheSame(t *testing.T) {
+	for _, a := range approaches {
+		t.Run(a.ID, func(t *testing.T) {
+			s := &stats{}
+			h := a.Build(s, newLimiter())
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, newRequest())
+			if w.Code != http.StatusOK || w.Body.String() != string(helloBody) {
+				t.Errorf("authenticated: got %d %q, expected 200 %q", w.Code, w.Body.String(), helloBody)
+			}
+			if got := w.Header().Get(traceHeader); got != newRequest().Header.Get(traceHeader) {
+				t.Errorf("expected the trace ID echoed back, got %q", got)
+			}
+
+			anonymous := newRequest()
+			anonymous.Header.Del("Authorization")
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, anonymous)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("anonymous: got %d, expected 401", w.Code)
+			}
+
+			if s.requests.Load() != 2 || s.errors.Load() != 1 {
+				t.Errorf("logged %d requests and %d errors, expected 2 and 1", s.requests.Load(), s.errors.Load())
+			}
+		})
+	}
+}
+
+func Test_RateLimiterRejectsOverBurst(t *testing.T) {
+	h := approaches[0].Build(&stats{}, newRateLimiter(0, 2))
+	codes := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRequest())
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("got %v, expected two 200s then a 429", codes)
+	}
+}
+
+func Test_SingleHandlerAllocatesLess(t *testing.T) {
+	r := newRequest()
+	chain := approaches[0].Build(&stats{}, newLimiter())
+	single := approaches[2].Build(&stats{}, newLimiter())
+
+	chainAllocs := testing.AllocsPerRun(100, func() { served = serveAll(chain, r, 1) })
+	// The statusRecorder, the request copy, the context node and the
+	// boxed user are gone
+	testutil.AssertMaxAllocs(t, "single handler", chainAllocs-4, func() { served = serveAll(single, r, 1) })
+}
+
+func Test_ClosuresAllocateOnlyWhenBuilt(t *testing.T) {
+	s, l, r := &stats{}, newLimiter(), newRequest()
+	built := testing.AllocsPerRun(100, func() { lastHandler = closureChain(s, l) })
+	if built != 4 {
+		t.Errorf("got %.0f allocs to build the chain, expected one closure per layer", built)
+	}
+
+	chain := closureChain(s, l)
+	perRequest := testing.AllocsPerRun(100, func() { served = serveAll(chain, r, 1) })
+	rebuilt := testing.AllocsPerRun(100, func() { served = serveAll(closureChain(s, l), r, 1) })
+	if rebuilt != perRequest+built {
+		t.Errorf("rebuilding per request: got %.0f allocs, expected %.0f + %.0f", rebuilt, perRequest, built)
+	}
+}
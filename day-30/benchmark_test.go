@@ -0,0 +1,149 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// ========== REPORT BENCHMARKS ==========
+
+func Benchmark_CumulativeReport(b *testing.B) {
+	pricing := cost.DefaultPricing()
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		report = NewCumulativeSavingsReport(series, pricing)
+	}
+}
+
+var report CumulativeSavingsReport
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+// fixture has one day per category, with numbers that price to round
+// dollars at $0.0416/vCPU-hour and $3.75/GB-month.
+var fixture = []dayInputs{
+	// 1 vCPU all month: 720 h × $0.0416
+	{Day: 1, Topic: "cpu", CPUSavedPerOp: time.Second, OpsPerDay: 86400},
+	// 2 GiB
+	{Day: 2, Topic: "memory", RAMSavedBytes: 2 << 30},
+	// 1 GiB/s at half a vCPU per GiB/s
+	{Day: 3, Topic: "gc", AllocAvoidedPerSec: 1 << 30, GCCPUFraction: 0.5},
+	// 1 GiB given back to GC headroom, and egress
+	{Day: 4, Topic: "trade-off", RAMSavedBytes: -1 << 30, OtherMonthly: 10},
+}
+
+func Test_CumulativeCalculation(t *testing.T) {
+	r := NewCumulativeSavingsReport(fixture, cost.DefaultPricing())
+
+	want := []DaySavings{
+		{Day: 1, Topic: "cpu", CPU: 29.952},
+		{Day: 2, Topic: "memory", Memory: 7.5},
+		{Day: 3, Topic: "gc", GC: 14.976},
+		{Day: 4, Topic: "trade-off", Memory: -3.75, Other: 10},
+	}
+	wantRunning := []float64{29.952, 37.452, 52.428, 58.678}
+
+	if len(r.Days) != len(want) {
+		t.Fatalf("%d days, want %d", len(r.Days), len(want))
+	}
+	for i, w := range want {
+		got := r.Days[i]
+		if got.Day != w.Day || got.Topic != w.Topic ||
+			!near(got.CPU, w.CPU) || !near(got.Memory, w.Memory) || !near(got.GC, w.GC) || !near(got.Other, w.Other) {
+			t.Errorf("day %d: got %+v, want %+v", w.Day, got, w)
+		}
+		if !near(r.Running[i], wantRunning[i]) {
+			t.Errorf("running total after day %d = %.4f, want %.4f", w.Day, r.Running[i], wantRunning[i])
+		}
+	}
+
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"CPU", r.CPU, 29.952},
+		{"Memory", r.Memory, 3.75},
+		{"GC", r.GC, 14.976},
+		{"Other", r.Other, 10},
+		{"Total", r.Total(), 58.678},
+	} {
+		if !near(c.got, c.want) {
+			t.Errorf("%s = %.4f, want %.4f", c.name, c.got, c.want)
+		}
+	}
+}
+
+func Test_DaysAreSortedBeforeRunningTotals(t *testing.T) {
+	reversed := []dayInputs{fixture[3], fixture[2], fixture[1], fixture[0]}
+	r := NewCumulativeSavingsReport(reversed, cost.DefaultPricing())
+	for i, d := range r.Days {
+		if d.Day != i+1 {
+			t.Fatalf("Days[%d].Day = %d, want %d", i, d.Day, i+1)
+		}
+	}
+	if !near(r.Running[0], 29.952) {
+		t.Errorf("Running[0] = %.4f, want day 1's 29.952", r.Running[0])
+	}
+}
+
+func Test_SeriesCoversEveryDay(t *testing.T) {
+	r := NewCumulativeSavingsReport(series, cost.DefaultPricing())
+	if len(r.Days) != 29 {
+		t.Fatalf("%d days, want 29", len(r.Days))
+	}
+	for i, d := range r.Days {
+		if d.Day != i+1 {
+			t.Errorf("Days[%d].Day = %d, want %d", i, d.Day, i+1)
+		}
+	}
+	if last := r.Running[len(r.Running)-1]; !near(last, r.Total()) {
+		t.Errorf("final running total %.4f != Total() %.4f", last, r.Total())
+	}
+}
+
+func Test_BarScale(t *testing.T) {
+	tests := []struct {
+		monthly float64
+		want    string
+	}{
+		{0, " "},
+		{-1, " "},
+		{0.01, "▁"},
+		{0.001, "▁"}, // below the scale clamps to the lowest bar
+		{1, "▅"},     // log scale: halfway between $0.01 and $100
+		{100, "█"},
+		{1000, "█"},
+	}
+	for _, tt := range tests {
+		if got := bar(tt.monthly, 0.01, 100); got != tt.want {
+			t.Errorf("bar(%v) = %q, want %q", tt.monthly, got, tt.want)
+		}
+	}
+}
+
+func Test_ReportPrintsEveryDay(t *testing.T) {
+	var sb strings.Builder
+	r := NewCumulativeSavingsReport(fixture, cost.DefaultPricing())
+	if _, err := r.WriteTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{"cpu", "memory", "gc", "trade-off", "Total ($/month)", "58.68"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
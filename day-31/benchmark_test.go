@@ -0,0 +1,142 @@
+package main
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== CONTENTION BENCHMARKS ==========
+
+// Each op is incrementsPerRun increments split across the goroutines, at
+// GOMAXPROCS of at least contentionProcs like the demo.
+
+func Benchmark_Mutex_1Goroutine(b *testing.B) {
+	benchmarkCounter(b, func() Counter { return &mutexCounter{} }, 1)
+}
+
+func Benchmark_Mutex_2Goroutines(b *testing.B) {
+	benchmarkCounter(b, func() Counter { return &mutexCounter{} }, 2)
+}
+
+func Benchmark_Mutex_8Goroutines(b *testing.B) {
+	benchmarkCounter(b, func() Counter { return &mutexCounter{} }, 8)
+}
+
+func Benchmark_Mutex_64Goroutines(b *testing.B) {
+	benchmarkCounter(b, func() Counter { return &mutexCounter{} }, 64)
+}
+
+func Benchmark_SpinLock_64Goroutines(b *testing.B) {
+	benchmarkCounter(b, func() Counter { return &spinCounter{} }, 64)
+}
+
+func benchmarkCounter(b *testing.B, newCounter func() Counter, goroutines int) {
+	withContentionProcs(b)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		c := newCounter()
+		runContended(c, goroutines, incrementsPerRun)
+		lastCount = c.Load()
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/incrementsPerRun, "ns/increment")
+}
+
+// ========== POOL BENCHMARKS ==========
+
+func Benchmark_MutexPool_64Goroutines(b *testing.B) {
+	benchmarkPool(b, func() ConnPool { return newMutexPool(poolSize) })
+}
+
+func Benchmark_ShardedPool_64Goroutines(b *testing.B) {
+	benchmarkPool(b, func() ConnPool { return newShardedPool(poolSize) })
+}
+
+func benchmarkPool(b *testing.B, newPool func() ConnPool) {
+	withContentionProcs(b)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		runPool(newPool(), 64, incrementsPerRun)
+	}
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/incrementsPerRun, "ns/checkout")
+}
+
+// withContentionProcs raises GOMAXPROCS as main does, until the test ends.
+func withContentionProcs(tb testing.TB) {
+	prev := runtime.GOMAXPROCS(max(runtime.NumCPU(), contentionProcs))
+	tb.Cleanup(func() { runtime.GOMAXPROCS(prev) })
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_CountersCountEveryIncrement(t *testing.T) {
+	withContentionProcs(t)
+	for _, newCounter := range map[string]func() Counter{
+		"mutex": func() Counter { return &mutexCounter{} },
+		"spin":  func() Counter { return &spinCounter{} },
+	} {
+		for _, l := range levels {
+			c := newCounter()
+			// 10,007 doesn't divide evenly by any level's goroutine count
+			runContended(c, l.Goroutines, 10_007)
+			if got := c.Load(); got != 10_007 {
+				t.Errorf("%T with %d goroutines: counted %d, want 10007", c, l.Goroutines, got)
+			}
+		}
+	}
+}
+
+func Test_UncontendedMutexDoesNotAllocate(t *testing.T) {
+	c := &mutexCounter{}
+	testutil.AssertMaxAllocs(t, "mutexCounter.Inc", 0, c.Inc)
+	testutil.AssertMaxAllocs(t, "spinCounter.Inc", 0, (&spinCounter{}).Inc)
+}
+
+func Test_SpinLockCountsNoRetriesAlone(t *testing.T) {
+	c := &spinCounter{}
+	runContended(c, 1, 1000)
+	if failed := c.mu.failed.Load(); failed != 0 {
+		t.Errorf("one goroutine retried %d times, want 0", failed)
+	}
+}
+
+func Test_PoolsReturnEveryConnection(t *testing.T) {
+	withContentionProcs(t)
+
+	single := newMutexPool(poolSize)
+	runPool(single, 64, 10_000)
+	if n := len(single.idle); n < poolSize {
+		t.Errorf("mutexPool: %d idle connections after the run, want at least %d", n, poolSize)
+	}
+
+	sharded := newShardedPool(poolSize)
+	runPool(sharded, 64, 10_000)
+	idle := 0
+	for i := range sharded.shards {
+		s := &sharded.shards[i].mutexPool
+		for _, c := range s.idle {
+			if c.shard != i {
+				t.Fatalf("shard %d holds a connection from shard %d", i, c.shard)
+			}
+		}
+		idle += len(s.idle)
+	}
+	if idle < poolSize {
+		t.Errorf("shardedPool: %d idle connections after the run, want at least %d", idle, poolSize)
+	}
+}
+
+func Test_MutexWaitTotalIsMonotonic(t *testing.T) {
+	withContentionProcs(t)
+	before := mutexWaitTotal()
+	runContended(&mutexCounter{}, 64, 100_000)
+	if after := mutexWaitTotal(); after < before {
+		t.Errorf("mutex wait went backwards: %v → %v", before, after)
+	}
+}
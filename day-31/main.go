@@ -0,0 +1,484 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const incrementsPerRun = 1_000_000
+
+// contentionProcs is the GOMAXPROCS the demo runs with. With one P only
+// one goroutine runs at a time, and it's almost never preempted inside a
+// one-line critical section, so a 1 vCPU machine would show no contention
+// at all. With 4 Ps the OS deschedules lock holders while others wait, as
+// it does on a busy multi-core server.
+const contentionProcs = 4
+
+// ========== COUNTERS ==========
+
+type Counter interface {
+	Inc()
+	Load() int64
+}
+
+// mutexCounter is an int64 behind a sync.Mutex.
+type mutexCounter struct {
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *mutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *mutexCounter) Load() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// spinLock never parks: a waiter retries its CompareAndSwap, yielding its
+// P between tries, until the lock is free. failed counts the retries.
+type spinLock struct {
+	state  atomic.Int64
+	failed atomic.Int64
+}
+
+func (l *spinLock) Lock() {
+	for !l.state.CompareAndSwap(0, 1) {
+		l.failed.Add(1)
+		runtime.Gosched()
+	}
+}
+
+func (l *spinLock) Unlock() {
+	l.state.Store(0)
+}
+
+// spinCounter is an int64 behind a spinLock.
+type spinCounter struct {
+	mu spinLock
+	n  int64
+}
+
+func (c *spinCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *spinCounter) Load() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// ========== CONTENTION LEVELS ==========
+
+type level struct {
+	Name       string
+	Goroutines int
+}
+
+var levels = []level{
+	{"zero", 1},
+	{"low", 2},
+	{"medium", 8},
+	{"high", 64},
+}
+
+// runContended does total increments on c, split as evenly as possible
+// across goroutines goroutines that all start at once.
+func runContended(c Counter, goroutines, total int) {
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		n := total / goroutines
+		if g < total%goroutines {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				c.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// mutexWaitTotal returns the time goroutines have spent parked on a
+// sync.Mutex or sync.RWMutex since the program started, summed over
+// goroutines.
+func mutexWaitTotal() time.Duration {
+	sample := []metrics.Sample{{Name: "/sync/mutex/wait/total:seconds"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	return time.Duration(sample[0].Value.Float64() * float64(time.Second))
+}
+
+// contentionRun is one run of incrementsPerRun increments at one level.
+type contentionRun struct {
+	Level   level
+	Elapsed time.Duration
+	Parked  time.Duration // summed over goroutines, so it can exceed Elapsed
+}
+
+func measureMutexLevels() []contentionRun {
+	runs := make([]contentionRun, 0, len(levels))
+	for _, l := range levels {
+		c := &mutexCounter{}
+		parkedBefore := mutexWaitTotal()
+		start := time.Now()
+		runContended(c, l.Goroutines, incrementsPerRun)
+		runs = append(runs, contentionRun{
+			Level:   l,
+			Elapsed: time.Since(start),
+			Parked:  mutexWaitTotal() - parkedBefore,
+		})
+		lastCount = c.Load()
+	}
+	return runs
+}
+
+// ========== CONNECTION POOLS ==========
+
+// conn stands in for a database or HTTP connection. shard is the pool
+// shard it belongs to, so Put can return it there.
+type conn struct {
+	uses  int
+	shard int
+}
+
+type ConnPool interface {
+	Get() *conn
+	Put(*conn)
+}
+
+// mutexPool is the usual pool: a slice of idle connections behind one
+// lock, dialing a new one when none is idle.
+type mutexPool struct {
+	mu    sync.Mutex
+	idle  []*conn
+	shard int
+}
+
+func newMutexPool(size int) *mutexPool {
+	p := &mutexPool{idle: make([]*conn, 0, size)}
+	for i := 0; i < size; i++ {
+		p.idle = append(p.idle, &conn{})
+	}
+	return p
+}
+
+func (p *mutexPool) Get() *conn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n := len(p.idle); n > 0 {
+		c := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		return c
+	}
+	return &conn{shard: p.shard} // dial
+}
+
+func (p *mutexPool) Put(c *conn) {
+	p.mu.Lock()
+	p.idle = append(p.idle, c)
+	p.mu.Unlock()
+}
+
+// poolShards is how many independent mutexPools a shardedPool spreads
+// connections over: twice contentionProcs, so two Ps rarely pick the same
+// one at once.
+const poolShards = 2 * contentionProcs
+
+// paddedPool keeps each shard's lock on its own cache line (Day 11).
+type paddedPool struct {
+	mutexPool
+	_ [64]byte
+}
+
+// shardedPool picks a shard round-robin for Get, and Put returns each
+// connection to the shard it came from.
+type shardedPool struct {
+	shards [poolShards]paddedPool
+	next   atomic.Uint64
+}
+
+func newShardedPool(size int) *shardedPool {
+	p := &shardedPool{}
+	for i := range p.shards {
+		s := &p.shards[i].mutexPool
+		s.shard = i
+		s.idle = make([]*conn, 0, size/poolShards+1)
+		for j := 0; j < size/poolShards; j++ {
+			s.idle = append(s.idle, &conn{shard: i})
+		}
+	}
+	return p
+}
+
+func (p *shardedPool) Get() *conn {
+	return p.shards[p.next.Add(1)%poolShards].Get()
+}
+
+func (p *shardedPool) Put(c *conn) {
+	p.shards[c.shard].Put(c)
+}
+
+// poolSize is each pool's idle connections at the start: enough that no
+// benchmark dials.
+const poolSize = 128
+
+// runPool does total checkouts, each a Get, one use and a Put, split
+// across goroutines goroutines.
+func runPool(p ConnPool, goroutines, total int) {
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		n := total / goroutines
+		if g < total%goroutines {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				c := p.Get()
+				c.uses++
+				p.Put(c)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Global variables to prevent compiler optimizations
+var lastCount int64
+
+func main() {
+	fmt.Println("🔬 DAY 31: sync.Mutex Internals: Spin vs Park")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	prevProcs := runtime.GOMAXPROCS(max(runtime.NumCPU(), contentionProcs))
+	defer runtime.GOMAXPROCS(prevProcs)
+
+	// The shocking truth about contended locks
+	fmt.Println("🎯 SHOCKING DISCOVERY: contended goroutines don't spin, they sleep!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealMutexInternals()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d increments of a mutex-protected int64\n", incrementsPerRun)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// Contention internals
+	fmt.Println("\n🔧 CONTENTION DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainContention(results)
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateMutexCostImpact(cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 31, Topic: "sync.Mutex Internals: Spin vs Park", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 31 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 32 - Regexp Compilation Caching")
+}
+
+// canSpin reports whether sync.Mutex may spin at all on this machine. The
+// runtime also requires another P to be running and the local run queue
+// to be empty, which it checks on every attempt.
+func canSpin() bool {
+	return runtime.NumCPU() > 1 && runtime.GOMAXPROCS(0) > 1
+}
+
+func revealMutexInternals() {
+	fmt.Println("  What sync.Mutex.Lock does when the lock is taken:")
+	fmt.Println("    1. fast path: one CompareAndSwap from unlocked to locked")
+	fmt.Println("    2. spin: up to 4 times, 30 PAUSE instructions each, only if")
+	fmt.Println("       the machine is multi-core and another P is running")
+	fmt.Println("    3. park: runtime_SemacquireMutex puts the goroutine to sleep on")
+	fmt.Println("       a semaphore keyed by the mutex's address")
+	fmt.Println("    4. starve: a waiter parked over 1 ms switches the mutex to")
+	fmt.Println("       starvation mode, where Unlock hands it over directly")
+	fmt.Println()
+
+	fmt.Printf("  This machine: NumCPU=%d, GOMAXPROCS=%d → spinning %s\n",
+		runtime.NumCPU(), runtime.GOMAXPROCS(0), map[bool]string{true: "possible", false: "never happens"}[canSpin()])
+	fmt.Println()
+
+	runs := measureMutexLevels()
+	fmt.Printf("  %-8s %10s %10s %14s\n", "Level", "goroutines", "elapsed", "parked (sum)")
+	for _, r := range runs {
+		fmt.Printf("  %-8s %10d %10v %14v\n", r.Level.Name, r.Level.Goroutines,
+			r.Elapsed.Round(time.Microsecond), r.Parked.Round(time.Microsecond))
+	}
+
+	fmt.Println("\n💡 sync.Mutex gives up on spinning after 4 tries, which is about as")
+	fmt.Println("   long as a short critical section. Past that, waiters park, and a")
+	fmt.Println("   parked goroutine uses no CPU. The time shows up as latency: the")
+	fmt.Println("   parked column, summed over goroutines, can exceed the wall clock.")
+}
+
+func runComparisonBenchmarks() []bench.Result {
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d increments, GOMAXPROCS=%d", incrementsPerRun, runtime.GOMAXPROCS(0)))
+	suite.Iterations = 3
+	for _, l := range levels {
+		suite.Register(fmt.Sprintf("sync.Mutex ×%d", l.Goroutines), func() {
+			c := &mutexCounter{}
+			runContended(c, l.Goroutines, incrementsPerRun)
+			lastCount = c.Load()
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	base := results[0].NsPerOp
+	for i, res := range results {
+		fmt.Printf("  %-7s %5.1f ns/increment, %5.1f M increments/s, %5.1f%% of uncontended throughput\n",
+			levels[i].Name+":", res.NsPerOp/incrementsPerRun, incrementsPerRun/res.NsPerOp*1e3, base/res.NsPerOp*100)
+	}
+	return results
+}
+
+func explainContention(results []bench.Result) {
+	fmt.Println("A lock that never parks, at the same levels:")
+	fmt.Println()
+	fmt.Printf("  %-8s %10s %12s %12s %16s\n", "Level", "goroutines", "sync.Mutex", "spinLock", "failed tries/inc")
+	for i, l := range levels {
+		c := &spinCounter{}
+		start := time.Now()
+		runContended(c, l.Goroutines, incrementsPerRun)
+		elapsed := time.Since(start)
+		lastCount = c.Load()
+		fmt.Printf("  %-8s %10d %12v %12v %16.3f\n", l.Name, l.Goroutines,
+			results[i].Duration().Round(time.Microsecond), elapsed.Round(time.Microsecond),
+			float64(c.mu.failed.Load())/incrementsPerRun)
+	}
+	fmt.Println()
+
+	fmt.Println("📈 WHERE CONTENTION COSTS CPU:")
+	fmt.Println("  • Failed CompareAndSwaps: each bounces the cache line between cores")
+	fmt.Println("  • Spinning waiters: a pure spin lock burns a core until the holder runs")
+	fmt.Println("  • Park and wake: a futex call and two scheduler trips per handoff")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHERE IT DOESN'T:")
+	fmt.Println("  • Parked goroutines: they wait off-CPU, costing latency, not vCPUs")
+	fmt.Println("  • sync.Mutex's spin is bounded at 4 tries, so it never burns a core")
+	fmt.Println("  • One P: goroutines take turns and rarely meet inside the lock")
+	fmt.Println()
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🔪 SHARD THE LOCK")
+	fmt.Println("   ✅ N independent pools or maps, each with its own mutex (Day 18)")
+	fmt.Println("   Benefit: Contention drops roughly N times")
+	fmt.Println()
+
+	fmt.Println("2. ⚛️ USE ATOMICS FOR COUNTERS")
+	fmt.Println("   ✅ atomic.Int64.Add instead of Lock, n++, Unlock (Day 13)")
+	fmt.Println("   Benefit: No parking, no starvation mode")
+	fmt.Println()
+
+	fmt.Println("3. ✂️ SHORTEN THE CRITICAL SECTION")
+	fmt.Println("   ✅ Build the value outside, swap it in under the lock")
+	fmt.Println("   Benefit: Waiters spin and succeed instead of parking")
+	fmt.Println()
+
+	fmt.Println("4. 🚫 DON'T WRITE YOUR OWN SPIN LOCK")
+	fmt.Println("   ✅ sync.Mutex already spins when spinning can win")
+	fmt.Println("   Benefit: No core burned while the holder is descheduled")
+}
+
+func calculateMutexCostImpact(pricing cost.PricingModel) float64 {
+	// An API service whose handlers each check out one connection from a
+	// shared pool, with 50k requests in flight across the fleet
+	const goroutines = 64
+	rps := 50000.0
+	requestsPerDay := rps * 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d checkouts from %d goroutines", incrementsPerRun, goroutines))
+	suite.Iterations = 3
+	suite.Register("1 pool, 1 mutex", func() {
+		runPool(newMutexPool(poolSize), goroutines, incrementsPerRun)
+	})
+	suite.Register(fmt.Sprintf("%d shards, %d mutexes", poolShards, poolShards), func() {
+		runPool(newShardedPool(poolSize), goroutines, incrementsPerRun)
+	})
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	results := suite.Results()
+	single, sharded := results[0].NsPerOp/incrementsPerRun, results[1].NsPerOp/incrementsPerRun
+
+	fmt.Println("\n☁️  ASSUMPTIONS:")
+	fmt.Printf("  • 50,000 concurrent requests taking 1 s each: %.0f checkouts/second\n", rps)
+	fmt.Printf("  • %d goroutines contending in each process, GOMAXPROCS=%d\n", goroutines, runtime.GOMAXPROCS(0))
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  1 pool, 1 mutex:       %6.1f ns per Get + Put\n", single)
+	fmt.Printf("  %d shards, %d mutexes:   %6.1f ns per Get + Put\n", poolShards, poolShards, sharded)
+
+	savedNs := single - sharded
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/checkout is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), requestsPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.1f ns/checkout, %.1f CPU-seconds/day\n", savedNs, savedNs*requestsPerDay/1e9)
+	fmt.Printf("  vCPUs freed:     %.4f\n", savedNs*rps/1e9)
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Lower tail latency: fewer requests park behind one lock")
+	fmt.Println("  • No starvation-mode handoffs, which serialize every waiter")
+	fmt.Println("  • The pool scales with cores instead of flattening at one")
+	return monthly
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// benchLines is the 1M-line input every benchmark cycles through, built on
+// first use so the tests don't pay for it.
+var benchLines = sync.OnceValue(func() []string { return newLogLines(1_000_000) })
+
+// ========== REGEXP BENCHMARKS ==========
+
+// Each op parses one line; b.N ops walk through the same 1M lines.
+
+func Benchmark_CompileEachCall(b *testing.B) {
+	benchmarkParse(b, parseCompileEachCall)
+}
+
+func Benchmark_MustCompileAtInit(b *testing.B) {
+	benchmarkParse(b, parsePrecompiled)
+}
+
+func Benchmark_SyncMapCache(b *testing.B) {
+	benchmarkParse(b, parseCached)
+}
+
+func benchmarkParse(b *testing.B, parse func(string) (string, bool)) {
+	lines := benchLines()
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		status, ok := parse(lines[i%len(lines)])
+		if !ok {
+			b.Fatal("line didn't parse")
+		}
+		if status[0] == '5' {
+			serverErrors++
+		}
+	}
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_ApproachesAgree(t *testing.T) {
+	lines := newLogLines(1000)
+	want := countServerErrors(parsePrecompiled, lines)
+	// statuses has 2 of 8 in the 5xx range
+	if want != 250 {
+		t.Fatalf("precompiled counted %d server errors, want 250", want)
+	}
+	for _, a := range approaches {
+		if got := countServerErrors(a.Parse, lines); got != want {
+			t.Errorf("%s counted %d server errors, want %d", a.Name, got, want)
+		}
+	}
+}
+
+func Test_LogPatternCapturesFields(t *testing.T) {
+	line := newLogLines(2)[1]
+	m := logRegexp.FindStringSubmatch(line)
+	if m == nil {
+		t.Fatalf("no match for %q", line)
+	}
+	want := []string{"10.0.0.1", "16/Oct/2026:10:00:01 +0000", "GET", "/api/users/1", "200", "201"}
+	for i, w := range want {
+		if m[i+1] != w {
+			t.Errorf("group %d = %q, want %q", i+1, m[i+1], w)
+		}
+	}
+	if _, ok := parsePrecompiled(`10.0.0.1 - - [x] "get / HTTP/1.1" 200 1`); ok {
+		t.Error("lowercase method parsed, want no match")
+	}
+}
+
+func Test_CacheCompilesOnce(t *testing.T) {
+	const pattern = `^cache-test-(\d+)$`
+	first, err := cachedRegexp(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if re, err := cachedRegexp(pattern); err != nil || re != first {
+				t.Errorf("cachedRegexp = %p, %v; want the first *Regexp %p", re, err, first)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := cachedRegexp(`(`); err == nil {
+		t.Error("invalid pattern compiled, want an error")
+	}
+	if _, ok := regexpCache.Load(`(`); ok {
+		t.Error("invalid pattern was cached")
+	}
+}
+
+func Test_CachedAndPrecompiledDoNotCompile(t *testing.T) {
+	line := newLogLines(1)[0]
+	parseCached(line) // fill the cache
+
+	// FindStringSubmatch allocates the result: its slice and its backing
+	// array of indexes. Compiling would add some 200 more.
+	testutil.AssertMaxAllocs(t, "parsePrecompiled", 2, func() { parsePrecompiled(line) })
+	testutil.AssertMaxAllocs(t, "parseCached", 2, func() { parseCached(line) })
+
+	allocs := testing.AllocsPerRun(100, func() { parseCompileEachCall(line) })
+	if allocs < 100 {
+		t.Errorf("parseCompileEachCall: %.0f allocs, want compile's 100+", allocs)
+	}
+}
+
+func Test_MustCompilePanicsOnBadPattern(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustCompile of an invalid pattern didn't panic")
+		}
+	}()
+	regexp.MustCompile(`(`)
+}
+
+func Test_ProgSize(t *testing.T) {
+	n, err := progSize(logPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n < 10 {
+		t.Errorf("logPattern compiled to %d instructions, want at least 10", n)
+	}
+	if _, err := progSize(`(`); err == nil {
+		t.Error("progSize of an invalid pattern: want an error")
+	}
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const linesPerRun = 100_000
+
+// ========== LOG LINES ==========
+
+// logPattern matches a Common Log Format line and captures the client,
+// time, method, path, status and size.
+const logPattern = `^(\S+) \S+ \S+ \[([^\]]+)\] "([A-Z]+) ([^ "]+) HTTP/[\d.]+" (\d{3}) (\d+)$`
+
+// statusGroup is the index of the status code in a FindStringSubmatch
+// result.
+const statusGroup = 5
+
+var (
+	methods  = []string{"GET", "GET", "GET", "POST", "PUT", "DELETE"}
+	statuses = []string{"200", "200", "200", "201", "304", "404", "500", "503"}
+)
+
+// newLogLines returns n distinct access log lines, the same n every time.
+func newLogLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf(`10.%d.%d.%d - - [16/Oct/2026:10:%02d:%02d +0000] "%s /api/users/%d HTTP/1.1" %s %d`,
+			i>>16&0xff, i>>8&0xff, i&0xff, i/60%60, i%60,
+			methods[i%len(methods)], i%10_000, statuses[i%len(statuses)], 200+i%5000)
+	}
+	return lines
+}
+
+// ========== THREE WAYS TO GET A REGEXP ==========
+
+// logRegexp is compiled once, when the package is initialized. A bad
+// pattern panics at startup instead of failing every request.
+var logRegexp = regexp.MustCompile(logPattern)
+
+// regexpCache holds patterns that are only known at runtime, compiled on
+// first use. A sync.Map suits it: written once per pattern, then only read.
+var regexpCache sync.Map // pattern string → *regexp.Regexp
+
+// cachedRegexp returns the compiled pattern, compiling it on first use. If
+// two goroutines race to compile the same pattern, both get the first one
+// stored.
+func cachedRegexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexpCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := regexpCache.LoadOrStore(pattern, re)
+	return actual.(*regexp.Regexp), nil
+}
+
+// statusOf returns line's status code, or false if line doesn't match.
+func statusOf(re *regexp.Regexp, line string) (string, bool) {
+	m := re.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	return m[statusGroup], true
+}
+
+// parseCompileEachCall compiles the pattern on every line: the worst
+// practice, usually hidden inside a helper.
+func parseCompileEachCall(line string) (string, bool) {
+	re, err := regexp.Compile(logPattern)
+	if err != nil {
+		return "", false
+	}
+	return statusOf(re, line)
+}
+
+func parsePrecompiled(line string) (string, bool) {
+	return statusOf(logRegexp, line)
+}
+
+func parseCached(line string) (string, bool) {
+	re, err := cachedRegexp(logPattern)
+	if err != nil {
+		return "", false
+	}
+	return statusOf(re, line)
+}
+
+type approach struct {
+	Name  string
+	ID    string // Benchmark name
+	Parse func(line string) (string, bool)
+}
+
+var approaches = []approach{
+	{"regexp.Compile per call", "CompileEachCall", parseCompileEachCall},
+	{"regexp.MustCompile at init", "MustCompileAtInit", parsePrecompiled},
+	{"sync.Map cache by pattern", "SyncMapCache", parseCached},
+}
+
+// countServerErrors parses every line and returns how many had a 5xx
+// status. It panics on a line that doesn't parse: the inputs all should.
+func countServerErrors(parse func(string) (string, bool), lines []string) int {
+	errors := 0
+	for _, line := range lines {
+		status, ok := parse(line)
+		if !ok {
+			panic("unparsed log line: " + line)
+		}
+		if status[0] == '5' {
+			errors++
+		}
+	}
+	return errors
+}
+
+// Global variables to prevent compiler optimizations
+var (
+	serverErrors int
+	lastRegexp   *regexp.Regexp
+	lastMatch    []string
+)
+
+func main() {
+	fmt.Println("🔬 DAY 32: Regexp Compilation Caching")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about regexp.Compile
+	fmt.Println("🎯 SHOCKING DISCOVERY: compiling a regexp costs more than running it 10 times!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealRegexpCompileCost()
+
+	lines := newLogLines(linesPerRun)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: parsing %d access log lines\n", linesPerRun)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(lines)
+
+	// Regexp internals
+	fmt.Println("\n🔧 REGEXP DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainRegexpEngine()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateRegexpCostImpact(results, lines, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 32, Topic: "Regexp Compilation Caching", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 32 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 33 - encoding/binary vs Manual Bit Packing")
+}
+
+// progSize returns how many instructions pattern compiles to: the NFA
+// program regexp runs, before any matcher-specific setup.
+func progSize(pattern string) (int, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return 0, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0, err
+	}
+	return len(prog.Inst), nil
+}
+
+func revealRegexpCompileCost() {
+	line := newLogLines(1)[0]
+	fmt.Printf("  Pattern: %s\n", logPattern)
+	fmt.Printf("  Line:    %s\n\n", line)
+
+	fmt.Printf("  unsafe.Sizeof(regexp.Regexp{}): %d bytes (the header only)\n", unsafe.Sizeof(regexp.Regexp{}))
+	if n, err := progSize(logPattern); err == nil {
+		fmt.Printf("  NFA program:                    %d instructions\n", n)
+	}
+	fmt.Println()
+
+	const calls = 10_000
+	start := time.Now()
+	compileAllocs, compileBytes := bench.AllocsPerCall(calls, func() { lastRegexp = regexp.MustCompile(logPattern) })
+	compileNs := float64(time.Since(start).Nanoseconds()) / calls
+	start = time.Now()
+	matchAllocs, matchBytes := bench.AllocsPerCall(calls, func() { lastMatch = logRegexp.FindStringSubmatch(line) })
+	matchNs := float64(time.Since(start).Nanoseconds()) / calls
+
+	fmt.Printf("  %-34s %9s %8s %8s\n", "Call", "time", "allocs", "bytes")
+	fmt.Printf("  %-34s %7.0f ns %8.0f %8.0f\n", "regexp.MustCompile(logPattern)", compileNs, compileAllocs, compileBytes)
+	fmt.Printf("  %-34s %7.0f ns %8.0f %8.0f\n", "logRegexp.FindStringSubmatch(line)", matchNs, matchAllocs, matchBytes)
+
+	fmt.Printf("\n💡 The 160-byte header is the small part: compiling builds the\n")
+	fmt.Printf("   parse tree, the NFA program and the matcher's setup, %.0f heap\n", compileAllocs)
+	fmt.Printf("   objects in all. One compile costs %.0fx a match on this line.\n", compileNs/matchNs)
+}
+
+func runComparisonBenchmarks(lines []string) []bench.Result {
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d lines", len(lines)))
+	suite.Iterations = 3
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			serverErrors = countServerErrors(a.Parse, lines)
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	n := float64(len(lines))
+	for _, res := range results {
+		fmt.Printf("  %-28s %8.0f ns/line %7.0f B/line %6.1f allocs/line\n", res.Name+":",
+			res.NsPerOp/n, res.BytesPerOp/n, res.AllocsPerOp/n)
+	}
+	return results
+}
+
+func explainRegexpEngine() {
+	fmt.Println("What regexp.Compile builds, and how a match runs:")
+	fmt.Println()
+	fmt.Println("  ┌─────────────────┬──────────────────────────────────────────────┐")
+	fmt.Println("  │ Step            │ Does                                         │")
+	fmt.Println("  ├─────────────────┼──────────────────────────────────────────────┤")
+	fmt.Println("  │ syntax.Parse    │ pattern → tree of concatenations, classes    │")
+	fmt.Println("  │ Simplify        │ rewrites x{2,5} and friends into basics      │")
+	fmt.Println("  │ syntax.Compile  │ tree → NFA program of Inst (the 'Prog')      │")
+	fmt.Println("  │ compileOnePass  │ checks for one path per byte: faster matcher │")
+	fmt.Println("  │ literal prefix  │ finds a prefix to skip to with strings.Index │")
+	fmt.Println("  └─────────────────┴──────────────────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 NFA, NOT DFA:")
+	fmt.Println("  • Go runs the NFA program directly: a backtracker for small inputs,")
+	fmt.Println("    a one-pass matcher when it can, or a Pike VM tracking all states")
+	fmt.Println("  • It never builds a DFA, so compiling stays linear in the pattern")
+	fmt.Println("  • RE2 in C++ builds DFA states lazily while matching: faster scans,")
+	fmt.Println("    more memory; PCRE backtracks, which can go exponential")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHY COMPILE PER CALL HIDES SO WELL:")
+	fmt.Println("  • regexp.MatchString(pattern, s) compiles on every call")
+	fmt.Println("  • A helper that takes a pattern string and compiles inside")
+	fmt.Println("  • Patterns built with fmt.Sprintf from request data")
+	fmt.Println()
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📦 COMPILE AT PACKAGE INIT")
+	fmt.Println("   ✅ var logRe = regexp.MustCompile(`...`)")
+	fmt.Println("   Benefit: Compiled once, and a typo panics at startup")
+	fmt.Println()
+
+	fmt.Println("2. 🗂️ CACHE RUNTIME PATTERNS")
+	fmt.Println("   ✅ sync.Map from pattern to *regexp.Regexp, or an LRU if unbounded")
+	fmt.Println("   Benefit: One compile per distinct pattern, not per call")
+	fmt.Println()
+
+	fmt.Println("3. 🚫 AVOID THE PACKAGE-LEVEL HELPERS")
+	fmt.Println("   ✅ logRe.MatchString(s) instead of regexp.MatchString(p, s)")
+	fmt.Println("   Benefit: regexp.MatchString compiles every time")
+	fmt.Println()
+
+	fmt.Println("4. ✂️ SKIP REGEXP FOR FIXED FORMATS")
+	fmt.Println("   ✅ strings.Cut and strings.Index for known delimiters")
+	fmt.Println("   Benefit: No NFA at all: often another 5-10x on hot parsers")
+}
+
+func calculateRegexpCostImpact(results []bench.Result, lines []string, pricing cost.PricingModel) float64 {
+	// A log pipeline parsing every access log line it ingests
+	const bytesPerDay = 100e9
+	avgLine := 0
+	for _, line := range lines {
+		avgLine += len(line) + 1 // newline
+	}
+	lineBytes := float64(avgLine) / float64(len(lines))
+	linesPerDay := bytesPerDay / lineBytes
+	linesPerSecond := linesPerDay / 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perLine := func(r bench.Result) (ns, bytes float64) {
+		n := float64(len(lines))
+		return r.NsPerOp / n, r.BytesPerOp / n
+	}
+	compileNs, compileBytes := perLine(results[0])
+	initNs, initBytes := perLine(results[1])
+	cachedNs, _ := perLine(results[2])
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • 100 GB/day of access logs, %.0f bytes per line: %.2fB lines/day\n", lineBytes, linesPerDay/1e9)
+	fmt.Printf("  • %.0f lines/second, each parsed with one regexp\n", linesPerSecond)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • GC cost: %.2f of a vCPU per GiB/s allocated\n", cost.TypicalGCCPUFraction)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  regexp.Compile per call:    %7.0f ns, %6.0f B allocated per line\n", compileNs, compileBytes)
+	fmt.Printf("  regexp.MustCompile at init: %7.0f ns, %6.0f B allocated per line\n", initNs, initBytes)
+	fmt.Printf("  sync.Map cache:             %7.0f ns per line (%+.0f ns vs init)\n", cachedNs, cachedNs-initNs)
+
+	savedNs := compileNs - initNs
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.0f ns/line is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	cpuMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), linesPerDay, costPerVCPUHour)
+
+	savedBytesPerSecond := max(compileBytes-initBytes, 0) * linesPerSecond
+	gcMonthly := cost.CalculateGCPressureImpact(uint64(savedBytesPerSecond), cost.TypicalGCCPUFraction, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.0f ns/line, %.2f vCPUs\n", savedNs, savedNs*linesPerSecond/1e9)
+	fmt.Printf("  Garbage avoided: %.2f GB/s\n", savedBytesPerSecond/1e9)
+	fmt.Printf("  CPU savings:     $%.4f/month\n", cpuMonthly)
+	fmt.Printf("  GC savings:      $%.4f/month\n", gcMonthly)
+
+	monthly := cpuMonthly + gcMonthly
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: linesPerDay, Unit: "lines/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Invalid patterns fail at startup, not on the first request")
+	fmt.Println("  • Lower and steadier latency: no 20 µs compile in the request path")
+	fmt.Println("  • Far fewer GC cycles in the ingest service")
+	return monthly
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== ENCODE BENCHMARKS ==========
+
+// Each op encodes or decodes recordsPerRun records.
+
+func Benchmark_BinaryWrite(b *testing.B) {
+	records := newRecords(recordsPerRun)
+	var buf bytes.Buffer
+	buf.Grow(recordsPerRun * recordSize)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := encodeBinaryWrite(&buf, records); err != nil {
+			b.Fatal(err)
+		}
+	}
+	encoded = buf.Bytes()
+	reportPerRecord(b)
+}
+
+func Benchmark_ManualPack(b *testing.B) {
+	records := newRecords(recordsPerRun)
+	buf := make([]byte, recordsPerRun*recordSize)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		packRecords(buf, records)
+	}
+	encoded = buf
+	reportPerRecord(b)
+}
+
+// ========== DECODE BENCHMARKS ==========
+
+func Benchmark_BinaryRead(b *testing.B) {
+	buf, out := packedRecords(), make([]UserRecord, recordsPerRun)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		if err := decodeBinaryRead(bytes.NewReader(buf), out); err != nil {
+			b.Fatal(err)
+		}
+	}
+	decoded = out
+	reportPerRecord(b)
+}
+
+func Benchmark_ManualUnpack(b *testing.B) {
+	buf, out := packedRecords(), make([]UserRecord, recordsPerRun)
+	resetAndReport(b)
+	for i := 0; i < b.N; i++ {
+		unpackRecords(buf, out)
+	}
+	decoded = out
+	reportPerRecord(b)
+}
+
+func packedRecords() []byte {
+	buf := make([]byte, recordsPerRun*recordSize)
+	packRecords(buf, newRecords(recordsPerRun))
+	return buf
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+func reportPerRecord(b *testing.B) {
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/recordsPerRun, "ns/record")
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_RecordSizes(t *testing.T) {
+	if got := unsafe.Sizeof(UserRecord{}); got != recordSize {
+		t.Errorf("UserRecord is %d bytes, want %d: no padding", got, recordSize)
+	}
+	if got := binary.Size(UserRecord{}); got != recordSize {
+		t.Errorf("binary.Size(UserRecord) = %d, want %d", got, recordSize)
+	}
+	if got := unsafe.Sizeof(looseRecord); got != 12 {
+		t.Errorf("looseRecord is %d bytes, want 12: 5 of padding", got)
+	}
+}
+
+func Test_ManualPackMatchesBinaryWrite(t *testing.T) {
+	records := newRecords(1000)
+	records[1].Reserved = 0xab // every byte of the word in use
+
+	var viaBinary bytes.Buffer
+	if err := encodeBinaryWrite(&viaBinary, records); err != nil {
+		t.Fatal(err)
+	}
+	manual := make([]byte, len(records)*recordSize)
+	packRecords(manual, records)
+	if !bytes.Equal(manual, viaBinary.Bytes()) {
+		t.Fatal("manual packing differs from binary.Write with LittleEndian")
+	}
+
+	viaRead := make([]UserRecord, len(records))
+	if err := decodeBinaryRead(bytes.NewReader(manual), viaRead); err != nil {
+		t.Fatal(err)
+	}
+	unpacked := make([]UserRecord, len(records))
+	unpackRecords(manual, unpacked)
+	for i, want := range records {
+		if viaRead[i] != want || unpacked[i] != want {
+			t.Fatalf("record %d: binary.Read %+v, unpack %+v, want %+v", i, viaRead[i], unpacked[i], want)
+		}
+	}
+}
+
+func Test_PackRecordFieldBoundaries(t *testing.T) {
+	// All ones in one field at a time: a mask or shift that's off by a
+	// bit leaks into a neighbour
+	for _, r := range []UserRecord{
+		{ID: 0xffff_ffff},
+		{Score: 0xffff},
+		{Flags: 0xff},
+		{Reserved: 0xff},
+		{ID: 0xffff_ffff, Score: 0xffff, Flags: 0xff, Reserved: 0xff},
+	} {
+		var b [recordSize]byte
+		packRecord(b[:], r)
+		if got := unpackRecord(b[:]); got != r {
+			t.Errorf("unpackRecord(packRecord(%+v)) = %+v", r, got)
+		}
+	}
+}
+
+func Test_CompactRoundTrip(t *testing.T) {
+	records := newRecords(1000)
+	packed := make([]byte, len(records)*compactSize)
+	for i, r := range records {
+		packCompact(packed[i*compactSize:], r)
+	}
+	for i, want := range records {
+		if got := unpackCompact(packed[i*compactSize:]); got != want {
+			t.Fatalf("record %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+func Test_ManualPackingDoesNotAllocate(t *testing.T) {
+	records := newRecords(100)
+	buf := make([]byte, len(records)*recordSize)
+	out := make([]UserRecord, len(records))
+	testutil.AssertMaxAllocs(t, "packRecords", 0, func() { packRecords(buf, records) })
+	testutil.AssertMaxAllocs(t, "unpackRecords", 0, func() { unpackRecords(buf, out) })
+
+	// binary.Write allocates a scratch buffer per record
+	var w bytes.Buffer
+	w.Grow(len(buf))
+	allocs := testing.AllocsPerRun(10, func() {
+		w.Reset()
+		_ = encodeBinaryWrite(&w, records)
+	})
+	if allocs < float64(len(records)) {
+		t.Errorf("binary.Write: %.0f allocs for %d records, want one or more each", allocs, len(records))
+	}
+}
+
+func Test_StorageSizes(t *testing.T) {
+	s := measureStorage(newRecords(10_000))
+	if s.Packed != s.Structs {
+		t.Errorf("8-byte records: packed %d B vs structs %d B, want equal", s.Packed, s.Structs)
+	}
+	if s.PackedLoose >= s.Loose {
+		t.Errorf("3-field records: packed %d B, want less than the padded struct's %d B", s.PackedLoose, s.Loose)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== CLOCK BENCHMARKS ==========
+
+// Each op is one clock read.
+
+func Benchmark_TimeNow(b *testing.B) {
+	resetAndReport(b)
+	readWallClock(b.N)
+}
+
+func Benchmark_TimeSince(b *testing.B) {
+	epoch := time.Now()
+	resetAndReport(b)
+	readMonotonic(epoch, b.N)
+}
+
+func Benchmark_CoarseClock(b *testing.B) {
+	clock := newCoarseClock(tickInterval)
+	defer clock.Stop()
+	resetAndReport(b)
+	readCoarse(clock, b.N)
+}
+
+func resetAndReport(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_CoarseClockAdvances(t *testing.T) {
+	clock := newCoarseClock(tickInterval)
+	time.Sleep(20 * time.Millisecond)
+
+	got, real := clock.Since(), time.Since(clock.start)
+	if got <= 0 {
+		t.Fatalf("after 20ms the clock reads %v, want it advanced", got)
+	}
+	if got > real {
+		t.Errorf("clock reads %v, ahead of time.Since's %v", got, real)
+	}
+	// A tick is 1 ms; 50 ms leaves room for a descheduled runner
+	if lag := real - got; lag > 50*time.Millisecond {
+		t.Errorf("clock lags by %v, want at most 50ms", lag)
+	}
+
+	clock.Stop()
+	frozen := clock.Since()
+	time.Sleep(5 * time.Millisecond)
+	if got := clock.Since(); got != frozen {
+		t.Errorf("stopped clock moved from %v to %v", frozen, got)
+	}
+}
+
+func Test_ClockReadsDoNotAllocate(t *testing.T) {
+	clock := newCoarseClock(tickInterval)
+	defer clock.Stop()
+	epoch := time.Now()
+
+	testutil.AssertMaxAllocs(t, "time.Now", 0, func() { readWallClock(100) })
+	testutil.AssertMaxAllocs(t, "time.Since", 0, func() { readMonotonic(epoch, 100) })
+	testutil.AssertMaxAllocs(t, "coarseClock.Since", 0, func() { readCoarse(clock, 100) })
+}
+
+func Test_TimeNowCarriesMonotonicReading(t *testing.T) {
+	// time.Since reads only the monotonic clock when its argument has a
+	// monotonic reading, which String prints as "m=±<seconds>"
+	if s := time.Now().String(); !strings.Contains(s, " m=") {
+		t.Errorf("time.Now() = %q, want a monotonic reading", s)
+	}
+	if s := time.Now().Round(0).String(); strings.Contains(s, " m=") {
+		t.Errorf("Round(0) kept the monotonic reading: %q", s)
+	}
+}
+
+func Test_ProcessCPUAdvances(t *testing.T) {
+	before := processCPU()
+	for deadline := time.Now().Add(50 * time.Millisecond); time.Now().Before(deadline); {
+	}
+	if spent := processCPU() - before; spent <= 0 {
+		t.Errorf("50ms busy loop used %v of process CPU, want more than 0", spent)
+	}
+}
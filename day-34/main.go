@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	callsPerRun   = 1_000_000
+	tickInterval  = time.Millisecond
+	clocksourceAt = "/sys/devices/system/clocksource/clocksource0/current_clocksource"
+)
+
+// ========== A COARSE CLOCK ==========
+
+// coarseClock is a monotonic clock that a background goroutine advances
+// every interval. Reading it is one atomic load, but it's up to one
+// interval behind, plus however long the goroutine waits to be scheduled.
+type coarseClock struct {
+	start time.Time
+	nanos atomic.Int64 // time.Since(start) at the last tick
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+func newCoarseClock(interval time.Duration) *coarseClock {
+	c := &coarseClock{
+		start: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go c.run(interval)
+	return c
+}
+
+func (c *coarseClock) run(interval time.Duration) {
+	defer close(c.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.nanos.Store(int64(time.Since(c.start)))
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Since returns the time since the clock started, as of its last tick.
+func (c *coarseClock) Since() time.Duration {
+	return time.Duration(c.nanos.Load())
+}
+
+// Stop ends the background goroutine. The clock stays readable, frozen.
+func (c *coarseClock) Stop() {
+	close(c.stop)
+	<-c.done
+}
+
+// ========== READING THE CLOCK ==========
+
+// readWallClock calls time.Now n times: a wall clock and a monotonic
+// clock read each time.
+func readWallClock(n int) {
+	for i := 0; i < n; i++ {
+		lastNow = time.Now()
+	}
+}
+
+// readMonotonic calls time.Since n times. epoch carries a monotonic
+// reading, so time.Since reads only the monotonic clock.
+func readMonotonic(epoch time.Time, n int) {
+	for i := 0; i < n; i++ {
+		lastElapsed = time.Since(epoch)
+	}
+}
+
+// readCoarse reads c n times.
+func readCoarse(c *coarseClock, n int) {
+	for i := 0; i < n; i++ {
+		lastElapsed = c.Since()
+	}
+}
+
+// processCPU returns the user and system CPU time the process has used.
+func processCPU() time.Duration {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+}
+
+// Global variables to prevent compiler optimizations
+var (
+	lastNow     time.Time
+	lastElapsed time.Duration
+)
+
+func main() {
+	fmt.Println("🔬 DAY 34: time.Now() Overhead")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about time.Now
+	fmt.Println("🎯 SHOCKING DISCOVERY: time.Now() reads two clocks, and time.Since reads one!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealTimeCost()
+
+	clock := newCoarseClock(tickInterval)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d clock reads\n", callsPerRun)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(clock)
+
+	// The coarse clock's price
+	fmt.Println("\n🔧 COARSE CLOCK DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	measureLag(clock)
+	clock.Stop()
+	tickCost := measureTickCost()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateClockCostImpact(results, tickCost, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 34, Topic: "time.Now() Overhead", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 34 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 35 - bytes.Buffer Growth vs Pre-sized Buffers")
+}
+
+func revealTimeCost() {
+	source := "unknown"
+	if b, err := os.ReadFile(clocksourceAt); err == nil {
+		source = strings.TrimSpace(string(b))
+	}
+	fmt.Printf("  Kernel clocksource: %s\n\n", source)
+
+	fmt.Println("  time.Now()")
+	fmt.Println("   ├─ walltime: vDSO clock_gettime(CLOCK_REALTIME)  → wall")
+	fmt.Println("   └─ nanotime: vDSO clock_gettime(CLOCK_MONOTONIC) → mono")
+	fmt.Println("  time.Since(t), t from time.Now()")
+	fmt.Println("   └─ nanotime only, then mono - t.mono")
+	fmt.Println()
+
+	const n = 1_000_000
+	epoch := time.Now()
+	start := time.Now()
+	readWallClock(n)
+	nowNs := float64(time.Since(start).Nanoseconds()) / n
+	start = time.Now()
+	readMonotonic(epoch, n)
+	sinceNs := float64(time.Since(start).Nanoseconds()) / n
+
+	fmt.Printf("  time.Now():        %6.1f ns/call\n", nowNs)
+	fmt.Printf("  time.Since(epoch): %6.1f ns/call (runtime.nanotime)\n", sinceNs)
+
+	fmt.Println("\n💡 Neither is a syscall: the vDSO is kernel code mapped into")
+	fmt.Println("   the process, reading the TSC and the kernel's scaling values.")
+	fmt.Println("   The usual figures are ~20 ns and ~5 ns on bare metal; a VM")
+	fmt.Println("   reading a virtualized TSC pays more for both. If the clocksource")
+	fmt.Println("   can't be read from user space (hpet, xen), each read becomes a")
+	fmt.Println("   real syscall costing hundreds of ns.")
+}
+
+func runComparisonBenchmarks(clock *coarseClock) []bench.Result {
+	epoch := time.Now()
+
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d reads", callsPerRun))
+	suite.Iterations = 3
+	suite.Register("time.Now()", func() { readWallClock(callsPerRun) })
+	suite.Register("time.Since(epoch)", func() { readMonotonic(epoch, callsPerRun) })
+	suite.Register("Coarse clock (1 ms)", func() { readCoarse(clock, callsPerRun) })
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, res := range results {
+		fmt.Printf("  %-22s %7.2f ns/call\n", res.Name+":", res.NsPerOp/callsPerRun)
+	}
+	return results
+}
+
+// measureLag samples how far clock lags behind time.Since.
+func measureLag(clock *coarseClock) {
+	// Read both clocks every 100 µs for 200 ms
+	var maxLag, totalLag time.Duration
+	samples := 0
+	for deadline := time.Now().Add(200 * time.Millisecond); time.Now().Before(deadline); {
+		lag := time.Since(clock.start) - clock.Since()
+		maxLag = max(maxLag, lag)
+		totalLag += lag
+		samples++
+		time.Sleep(100 * time.Microsecond)
+	}
+	fmt.Printf("Lag behind time.Since over %d samples:\n", samples)
+	fmt.Printf("  Average: %v\n", totalLag/time.Duration(samples))
+	fmt.Printf("  Max:     %v\n", maxLag)
+}
+
+// measureTickCost returns the CPU time one tick of a coarse clock costs:
+// the process's CPU while it sleeps with a clock ticking, less its CPU
+// while it sleeps with none. No other clock may be running.
+func measureTickCost() time.Duration {
+	const idle = 500 * time.Millisecond
+	idleCPU := func() time.Duration {
+		before := processCPU()
+		time.Sleep(idle)
+		return processCPU() - before
+	}
+	baseline := idleCPU()
+	clock := newCoarseClock(tickInterval)
+	ticking := idleCPU()
+	clock.Stop()
+	tickCost := max(ticking-baseline, 0) / time.Duration(idle/tickInterval)
+
+	fmt.Printf("\nCPU while the process sleeps for %v:\n", idle)
+	fmt.Printf("  No clock:       %v\n", baseline)
+	fmt.Printf("  Clock ticking:  %v\n", ticking)
+	fmt.Printf("  Per tick:       %v\n", tickCost)
+	fmt.Println()
+
+	fmt.Println("📈 WHAT THE COARSE CLOCK TRADES:")
+	fmt.Println("  • Precision: readings are up to a tick old, more under load")
+	fmt.Println("  • Idle CPU: the ticker wakes a thread 1,000 times a second")
+	fmt.Println("  • Correctness: no use for timeouts under a few ms or for latency")
+	fmt.Println("    histograms; fine for rate limits, caches and coarse TTLs")
+	fmt.Println()
+	return tickCost
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. ⏱️ USE time.Since FOR DURATIONS")
+	fmt.Println("   ✅ start := time.Now(); ...; elapsed := time.Since(start)")
+	fmt.Println("   Benefit: The second read skips the wall clock")
+	fmt.Println()
+
+	fmt.Println("2. 🔁 READ THE CLOCK ONCE PER BATCH")
+	fmt.Println("   ✅ now := time.Now() before the loop, not inside it")
+	fmt.Println("   Benefit: One read per batch instead of one per item")
+	fmt.Println()
+
+	fmt.Println("3. 🕰️ COARSE CLOCK FOR RATE LIMITS AND TTLs")
+	fmt.Println("   ✅ An atomic.Int64 a goroutine updates every millisecond")
+	fmt.Println("   Benefit: One atomic load per read, if 1 ms of error is fine")
+	fmt.Println()
+
+	fmt.Println("4. 🔍 CHECK THE CLOCKSOURCE")
+	fmt.Println("   ✅ cat /sys/devices/system/clocksource/clocksource0/current_clocksource")
+	fmt.Println("   Benefit: tsc or kvm-clock stays in the vDSO; hpet and xen syscall")
+}
+
+func calculateClockCostImpact(results []bench.Result, tickCost time.Duration, pricing cost.PricingModel) float64 {
+	// A rate limiter reading the clock once per request
+	const callsPerSecond = 100_000
+	const callsPerDay = callsPerSecond * 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	nowNs := results[0].NsPerOp / callsPerRun
+	sinceNs := results[1].NsPerOp / callsPerRun
+	coarseNs := results[2].NsPerOp / callsPerRun
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • A token-bucket rate limiter: %d clock reads/second\n", callsPerSecond)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • The coarse clock ticks every %v: %v of CPU per tick\n", tickInterval, tickCost)
+	fmt.Println("    (measured idle, the worst case: a busy process wakes less)")
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  time.Now():        %5.1f ns/read, %.4f vCPUs\n", nowNs, nowNs*callsPerSecond/1e9)
+	fmt.Printf("  time.Since(epoch): %5.1f ns/read, %.4f vCPUs\n", sinceNs, sinceNs*callsPerSecond/1e9)
+	ticksPerSecond := float64(time.Second / tickInterval)
+	tickVCPUs := tickCost.Seconds() * ticksPerSecond
+	fmt.Printf("  Coarse clock:      %5.1f ns/read, %.4f vCPUs + %.4f vCPUs ticking\n",
+		coarseNs, coarseNs*callsPerSecond/1e9, tickVCPUs)
+
+	savedNs := nowNs - coarseNs
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.1f ns/read is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	// Reads saved scale with the rate; the ticks cost the same at any rate
+	readMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), callsPerDay, costPerVCPUHour)
+	tickMonthly := tickVCPUs * costPerVCPUHour * cost.HoursPerMonth
+	monthly := readMonthly - tickMonthly
+	sinceMonthly := cost.CPUSavingsMonthly(time.Duration(max(nowNs-sinceNs, 0)), callsPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Reads saved:     $%.4f/month\n", readMonthly)
+	fmt.Printf("  Ticks added:     $%.4f/month\n", tickMonthly)
+	fmt.Printf("  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+	if savedNs > 0 {
+		fmt.Printf("  Break-even:      %.0f reads/s per process\n", tickVCPUs/savedNs*1e9)
+	}
+	fmt.Printf("\n  time.Now() → time.Since: $%.4f/month, with no ticks to pay for\n", sinceMonthly)
+
+	fmt.Println("\n📈 NET SAVINGS BY READ RATE (one process):")
+	for _, rate := range []float64{callsPerSecond, 1e6, 10e6} {
+		net := readMonthly*rate/callsPerSecond - tickMonthly
+		fmt.Printf("  • %5.0fk reads/s: $%9.4f/month\n", rate/1e3, net)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • A clock read can't show up in a CPU profile's top 10")
+	fmt.Println("  • Every reader sees the same time within a tick")
+	fmt.Println("  • Fewer vDSO reads on VMs where the clock is slow")
+	return monthly
+}
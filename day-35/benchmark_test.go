@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== RESPONSE BUFFER BENCHMARKS ==========
+
+// Each op builds one 100 KB response body and writes it to io.Discard.
+
+func Benchmark_BufferUnsized(b *testing.B) {
+	benchmarkWrite(b, writeGrowing)
+}
+
+func Benchmark_BufferPresized(b *testing.B) {
+	benchmarkWrite(b, writePresized)
+}
+
+// ========== POOLED BENCHMARKS ==========
+
+func Benchmark_BufferPooled(b *testing.B) {
+	benchmarkWrite(b, writePooled)
+}
+
+func benchmarkWrite(b *testing.B, write func(io.Writer, []string)) {
+	rows := responseRows(responseSize)
+	b.SetBytes(int64(len(rows) * len(rows[0])))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		write(io.Discard, rows)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+// bodyRecorder keeps the last body written to it.
+type bodyRecorder struct {
+	body []byte
+}
+
+func (r *bodyRecorder) Write(p []byte) (int, error) {
+	r.body = append(r.body[:0], p...)
+	return len(p), nil
+}
+
+func Test_ResponseFitsPresizedBuffer(t *testing.T) {
+	rows := responseRows(responseSize)
+	n := 0
+	for _, row := range rows {
+		n += len(row)
+	}
+	if n > responseSize || n < responseSize-len(rows[0]) {
+		t.Errorf("body is %d bytes, want the most rows that fit in %d", n, responseSize)
+	}
+}
+
+func Test_AllApproachesWriteSameBody(t *testing.T) {
+	rows := responseRows(responseSize)
+	var want bodyRecorder
+	approaches[0].Write(&want, rows)
+	if !bytes.HasSuffix(want.body, []byte("}\n")) || bytes.Count(want.body, []byte("\n")) != len(rows) {
+		t.Fatalf("%s wrote %d bytes, not %d NDJSON rows", approaches[0].Name, len(want.body), len(rows))
+	}
+
+	for _, a := range approaches[1:] {
+		// Twice, so the pooled approach is checked with a reused buffer
+		for i := 0; i < 2; i++ {
+			var got bodyRecorder
+			a.Write(&got, rows)
+			if !bytes.Equal(got.body, want.body) {
+				t.Errorf("%s (call %d): body differs from %s", a.Name, i+1, approaches[0].Name)
+			}
+		}
+	}
+}
+
+func Test_BufferAllocations(t *testing.T) {
+	rows := responseRows(responseSize)
+	unsized := testing.AllocsPerRun(10, func() { writeGrowing(io.Discard, rows) })
+	if unsized < 10 {
+		t.Errorf("unsized Buffer: %.0f allocs for a 100 KB body, want one per doubling (10+)", unsized)
+	}
+	testutil.AssertMaxAllocs(t, "bytes.NewBuffer(100 KB)", 1, func() { writePresized(io.Discard, rows) })
+
+	// sync.Pool drops items at random under -race, where AssertMaxAllocs
+	// skips instead of failing this zero-alloc ceiling
+	writePooled(io.Discard, rows) // Warm the pool
+	testutil.AssertMaxAllocs(t, "sync.Pool + Reset()", 0, func() { writePooled(io.Discard, rows) })
+}
+
+func Test_BufferGrowthDoubles(t *testing.T) {
+	rows := responseRows(responseSize)
+	steps := bufferGrowth(rows)
+	for i := 1; i < len(steps); i++ {
+		if steps[i].Cap < 2*steps[i-1].Cap {
+			t.Errorf("growth %d: cap %d → %d, want at least double", i+1, steps[i-1].Cap, steps[i].Cap)
+		}
+	}
+	last := steps[len(steps)-1]
+	if body := len(rows) * len(rows[0]); last.Cap < body {
+		t.Errorf("final cap %d can't hold the %d-byte body", last.Cap, body)
+	}
+
+	allocated, copied := growthTotals(steps, len(rows[0]))
+	t.Logf("%d growths: %d bytes allocated, %d copied, final cap %d", len(steps), allocated, copied, last.Cap)
+	if allocated < 2*responseSize {
+		t.Errorf("growing to %d bytes allocated %d, want at least twice the body", responseSize, allocated)
+	}
+}
+
+func Test_OversizedBufferNotPooled(t *testing.T) {
+	writePooled(io.Discard, responseRows(2*maxPooledBuffer))
+	buf := bufferPool.Get().(*bytes.Buffer)
+	if buf.Cap() > maxPooledBuffer {
+		t.Errorf("pool returned a %d-byte buffer, over the %d-byte limit", buf.Cap(), maxPooledBuffer)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	responseSize    = 100 * 1024
+	responsesPerRun = 1_000
+
+	// maxPooledBuffer keeps one oversized response from pinning its
+	// buffer in the pool for every request after it.
+	maxPooledBuffer = 4 * responseSize
+)
+
+// responseRows returns the lines of a newline-delimited JSON body of at
+// most size bytes: one order per line, as an export endpoint writes them.
+func responseRows(size int) []string {
+	rows := make([]string, 0, size/64) // rows are 74 bytes: room to spare
+	total := 0
+	for id := 100_000; ; id++ {
+		row := `{"id":` + strconv.Itoa(id) +
+			`,"customer":"Ada Lovelace","status":"shipped","total":129.90}` + "\n"
+		if total+len(row) > size {
+			return rows
+		}
+		rows = append(rows, row)
+		total += len(row)
+	}
+}
+
+// ========== RESPONSE BUFFER APPROACHES ==========
+
+// Each approach writes the rows into a bytes.Buffer and hands the body to
+// w in one call, as a handler does before setting Content-Length.
+
+func writeRows(buf *bytes.Buffer, rows []string) {
+	for _, row := range rows {
+		buf.WriteString(row)
+	}
+}
+
+// writeGrowing starts from an empty Buffer, which doubles its way up to
+// the body's size: an allocation and a copy at every doubling.
+func writeGrowing(w io.Writer, rows []string) {
+	var buf bytes.Buffer
+	writeRows(&buf, rows)
+	w.Write(buf.Bytes())
+}
+
+// writePresized allocates the whole body up front.
+func writePresized(w io.Writer, rows []string) {
+	buf := bytes.NewBuffer(make([]byte, 0, responseSize))
+	writeRows(buf, rows)
+	w.Write(buf.Bytes())
+}
+
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// writePooled reuses a Buffer from earlier responses. Reset keeps its
+// capacity, so once warm it never grows; w.Write copies the bytes out
+// before the Buffer goes back.
+func writePooled(w io.Writer, rows []string) {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	writeRows(buf, rows)
+	w.Write(buf.Bytes())
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+type approach struct {
+	Name  string
+	Write func(io.Writer, []string)
+}
+
+var approaches = []approach{
+	{"bytes.Buffer, unsized", writeGrowing},
+	{"bytes.NewBuffer(100 KB)", writePresized},
+	{"sync.Pool + Reset()", writePooled},
+}
+
+// ========== GROWTH ==========
+
+// growthStep is the Buffer right after a write that made it grow.
+type growthStep struct {
+	Len, Cap int
+}
+
+// bufferGrowth writes rows into an empty Buffer and records every time its
+// capacity changes. Each step is a new array; the bytes written before it
+// were copied over.
+func bufferGrowth(rows []string) []growthStep {
+	var buf bytes.Buffer
+	steps := make([]growthStep, 0, 32) // one per doubling: up to 4 GB
+	for _, row := range rows {
+		before := buf.Cap()
+		buf.WriteString(row)
+		if buf.Cap() != before {
+			steps = append(steps, growthStep{Len: buf.Len(), Cap: buf.Cap()})
+		}
+	}
+	return steps
+}
+
+// growthTotals sums what the steps allocated and copied.
+func growthTotals(steps []growthStep, rowLen int) (allocated, copied int) {
+	for _, s := range steps {
+		allocated += s.Cap
+		copied += s.Len - rowLen // written before the step's row
+	}
+	return allocated, copied
+}
+
+func main() {
+	fmt.Println("🔬 DAY 35: bytes.Buffer Growth vs Pre-sized Buffers")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	rows := responseRows(responseSize)
+
+	// The shocking truth about bytes.Buffer
+	fmt.Println("🎯 SHOCKING DISCOVERY: A 100 KB body allocates almost 4x its size!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealBufferGrowth(rows)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d responses of %d rows each\n", responsesPerRun, len(rows))
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(rows)
+
+	// Growth internals
+	fmt.Println("\n🔧 BUFFER GROWTH DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainGrowthRules(rows)
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateBufferCostImpact(results, rows, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 35, Topic: "bytes.Buffer Growth vs Pre-sized Buffers", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 35 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 36 - io.ReadAll vs Streaming Large Request Bodies")
+}
+
+func revealBufferGrowth(rows []string) {
+	body := 0
+	for _, row := range rows {
+		body += len(row)
+	}
+	rowLen := len(rows[0])
+	fmt.Printf("  Response body: %d bytes, %d rows of %d bytes\n\n", body, len(rows), rowLen)
+
+	steps := bufferGrowth(rows)
+	fmt.Println("📈 bytes.Buffer GROWTH TABLE:")
+	fmt.Println("  Growth | Length  | New Capacity | Copied  | Allocated so far")
+	fmt.Println("  -------|---------|--------------|---------|-----------------")
+	allocated := 0
+	for i, s := range steps {
+		allocated += s.Cap
+		fmt.Printf("  %6d | %7d | %12d | %7d | %16d\n", i+1, s.Len, s.Cap, s.Len-rowLen, allocated)
+	}
+	allocated, copied := growthTotals(steps, rowLen)
+	final := steps[len(steps)-1].Cap
+	fmt.Printf("\n  %d arrays, %d bytes allocated (%.1fx the body), %d bytes copied\n",
+		len(steps), allocated, float64(allocated)/float64(body), copied)
+	fmt.Printf("  Final capacity %d: %.1f%% of it unused\n\n", final, float64(final-body)/float64(final)*100)
+
+	fmt.Println("📈 BY BODY SIZE:")
+	fmt.Println("  Body      | Growths | Final Capacity | Allocated | Waste")
+	fmt.Println("  ----------|---------|----------------|-----------|------")
+	for _, size := range []int{1 << 10, 10 << 10, 100 << 10, 1 << 20} {
+		sized := responseRows(size)
+		steps := bufferGrowth(sized)
+		allocated, _ := growthTotals(steps, rowLen)
+		final := steps[len(steps)-1].Cap
+		n := len(sized) * rowLen
+		fmt.Printf("  %7d B | %7d | %14d | %8.1fx | %4.1f%%\n",
+			n, len(steps), final, float64(allocated)/float64(n), float64(final-n)/float64(final)*100)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %-26s %s\n", "Approach", "allocs/response")
+	for _, a := range approaches {
+		allocs, _ := bench.AllocsPerCall(100, func() { a.Write(io.Discard, rows) })
+		fmt.Printf("  %-26s %5.1f\n", a.Name+":", allocs)
+	}
+
+	fmt.Println("\n💡 bytes.Buffer grows like append (day 2): when a write doesn't fit,")
+	fmt.Println("   it allocates at least twice the capacity and copies everything")
+	fmt.Println("   written so far. The old arrays are garbage the moment it does.")
+}
+
+func runComparisonBenchmarks(rows []string) []bench.Result {
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d × 100 KB responses", responsesPerRun))
+	suite.Iterations = 3
+	for _, a := range approaches {
+		suite.Register(a.Name, func() {
+			for i := 0; i < responsesPerRun; i++ {
+				a.Write(io.Discard, rows)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, res := range results {
+		fmt.Printf("  %-26s %7.1f µs, %8.0f B/response\n",
+			res.Name+":", res.NsPerOp/responsesPerRun/1e3, res.BytesPerOp/responsesPerRun)
+	}
+	return results
+}
+
+func explainGrowthRules(rows []string) {
+	fmt.Println("How bytes.Buffer.grow finds room for a write of n bytes:")
+	fmt.Println("  • Empty and n ≤ 64: a 64-byte array")
+	fmt.Println("  • Enough room after sliding unread bytes down: reuse the array")
+	fmt.Println("  • Otherwise: a new array of max(2 × cap, len + n), rounded up")
+	fmt.Println("    to the allocator's size class, and a copy of the old bytes")
+	fmt.Println()
+
+	// What Reset keeps: a warm pooled buffer holds the capacity its
+	// first, unsized, response grew to
+	buf := new(bytes.Buffer)
+	writeRows(buf, rows)
+	grown := buf.Cap()
+	buf.Reset()
+	fmt.Printf("After Reset(): Len %d, Cap %d\n", buf.Len(), buf.Cap())
+	writeRows(buf, rows)
+	fmt.Printf("Second body:   Len %d, Cap %d (grew: %t)\n", buf.Len(), buf.Cap(), buf.Cap() != grown)
+	fmt.Println()
+
+	fmt.Println("⚠️  POOLING GOTCHAS:")
+	fmt.Println("  • A pooled buffer keeps its largest size: put back only up to a cap")
+	fmt.Printf("    (here %d KB), or one huge export pins memory for every request\n", maxPooledBuffer/1024)
+	fmt.Println("  • Copy the bytes out before Put: buf.Bytes() aliases the array")
+	fmt.Println("  • The pool is emptied over two GC cycles: cold buffers grow again")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 📏 PRE-SIZE WHEN YOU KNOW THE SIZE")
+	fmt.Println("   ✅ bytes.NewBuffer(make([]byte, 0, n)) or buf.Grow(n)")
+	fmt.Println("   Benefit: One allocation instead of one per doubling")
+	fmt.Println()
+
+	fmt.Println("2. ♻️  POOL PER-REQUEST BUFFERS")
+	fmt.Println("   ✅ buf := pool.Get().(*bytes.Buffer); buf.Reset(); ...; pool.Put(buf)")
+	fmt.Println("   Benefit: Zero allocations per response once warm")
+	fmt.Println()
+
+	fmt.Println("3. 🧢 CAP WHAT GOES BACK IN THE POOL")
+	fmt.Println("   ✅ if buf.Cap() <= maxPooledBuffer { pool.Put(buf) }")
+	fmt.Println("   Benefit: A rare 50 MB body doesn't become every request's buffer")
+	fmt.Println()
+
+	fmt.Println("4. 🚰 STREAM WHEN YOU DON'T NEED Content-Length")
+	fmt.Println("   ✅ bufio.NewWriterSize(w, 32<<10) instead of a full-body buffer")
+	fmt.Println("   Benefit: Memory per request stays fixed, whatever the body size")
+}
+
+func calculateBufferCostImpact(results []bench.Result, rows []string, pricing cost.PricingModel) float64 {
+	// An export endpoint returning one 100 KB body per request
+	const requestsPerSecond = 1_000
+	const requestsPerDay = requestsPerSecond * 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perResponse := func(r bench.Result) (ns, bytes float64) {
+		return r.NsPerOp / responsesPerRun, r.BytesPerOp / responsesPerRun
+	}
+	growNs, growBytes := perResponse(results[0])
+	pooledNs, pooledBytes := perResponse(results[2])
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • An export API: %d requests/second, %d rows of NDJSON each\n", requestsPerSecond, len(rows))
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Printf("  • GC cost: %.2f of a vCPU per GiB/s allocated\n", cost.TypicalGCCPUFraction)
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	for _, r := range results {
+		ns, b := perResponse(r)
+		fmt.Printf("  %-26s %6.1f µs, %7.0f B/response → %6.1f MB/s allocated\n",
+			r.Name+":", ns/1e3, b, b*requestsPerSecond/1e6)
+	}
+
+	savedNs := growNs - pooledNs
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.0f ns/response is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	cpuMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), requestsPerDay, costPerVCPUHour)
+
+	savedBytesPerSecond := max(growBytes-pooledBytes, 0) * requestsPerSecond
+	gcMonthly := cost.CalculateGCPressureImpact(uint64(savedBytesPerSecond), cost.TypicalGCCPUFraction, costPerVCPUHour)
+
+	fmt.Printf("\n  Unsized → pooled saves %.1f µs/response, %.3f vCPUs\n", savedNs/1e3, savedNs*requestsPerSecond/1e9)
+	fmt.Printf("  Garbage avoided: %.0f MB/s\n", savedBytesPerSecond/1e6)
+	fmt.Printf("  CPU savings:     $%.4f/month\n", cpuMonthly)
+	fmt.Printf("  GC savings:      $%.4f/month\n", gcMonthly)
+
+	monthly := cpuMonthly + gcMonthly
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: requestsPerDay, Unit: "requests/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Fewer GC cycles: no body-sized garbage per request")
+	fmt.Println("  • No 100 KB memmoves while the body is being written")
+	fmt.Println("  • Heap size tracks concurrent requests, not total requests")
+	return monthly
+}
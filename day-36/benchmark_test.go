@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// ========== io.ReadAll BENCHMARKS ==========
+
+// Each op is one upload.
+
+func Benchmark_ReadAll_1KB(b *testing.B)  { benchmarkUpload(b, readAllUpload, 1<<10) }
+func Benchmark_ReadAll_1MB(b *testing.B)  { benchmarkUpload(b, readAllUpload, 1<<20) }
+func Benchmark_ReadAll_50MB(b *testing.B) { benchmarkUpload(b, readAllUpload, 50<<20) }
+
+// ========== LIMITED BENCHMARKS ==========
+
+// 50 MB is over the limit: each op reads 10 MB and one byte, then rejects.
+// Its MB/s counts the whole 50 MB, so only compare it by ns/op.
+
+func Benchmark_LimitedReadAll_1KB(b *testing.B)  { benchmarkUpload(b, readLimitedUpload, 1<<10) }
+func Benchmark_LimitedReadAll_1MB(b *testing.B)  { benchmarkUpload(b, readLimitedUpload, 1<<20) }
+func Benchmark_LimitedReadAll_50MB(b *testing.B) { benchmarkUpload(b, readLimitedUpload, 50<<20) }
+
+// ========== STREAMING BENCHMARKS ==========
+
+func Benchmark_Stream_1KB(b *testing.B)  { benchmarkUpload(b, streamUpload, 1<<10) }
+func Benchmark_Stream_1MB(b *testing.B)  { benchmarkUpload(b, streamUpload, 1<<20) }
+func Benchmark_Stream_50MB(b *testing.B) { benchmarkUpload(b, streamUpload, 50<<20) }
+
+func benchmarkUpload(b *testing.B, handle func(io.Reader) (uint32, error), size int64) {
+	b.SetBytes(size)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var err error
+		checksum, err = handle(newUpload(size))
+		if err != nil && !errors.Is(err, errBodyTooLarge) {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_UploadReaderSize(t *testing.T) {
+	for _, size := range []int64{0, 1, int64(len(uploadPattern)) + 1, 1 << 20} {
+		body, err := io.ReadAll(newUpload(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(len(body)) != size {
+			t.Errorf("upload of %d bytes read as %d", size, len(body))
+		}
+		want := bytes.Repeat([]byte(uploadPattern), len(body)/len(uploadPattern)+1)[:len(body)]
+		if !bytes.Equal(body, want) {
+			t.Errorf("upload of %d bytes doesn't repeat the pattern", size)
+		}
+	}
+}
+
+func Test_StrategiesAgreeOnChecksum(t *testing.T) {
+	for _, size := range uploadSizes[:2] {
+		body, _ := io.ReadAll(newUpload(size))
+		want := crc32.ChecksumIEEE(body)
+		for _, s := range strategies {
+			// Twice, so the pooled writer is checked once reused
+			for i := 0; i < 2; i++ {
+				got, err := s.Handle(newUpload(size))
+				if err != nil || got != want {
+					t.Errorf("%s, %s (call %d): checksum %08x, %v; want %08x", s.Name, sizeLabel(size), i+1, got, err, want)
+				}
+			}
+		}
+	}
+}
+
+func Test_LimitBoundary(t *testing.T) {
+	if _, err := readLimitedUpload(newUpload(maxBodySize)); err != nil {
+		t.Errorf("a body of exactly %d bytes: %v, want it accepted", maxBodySize, err)
+	}
+	if _, err := readLimitedUpload(newUpload(maxBodySize + 1)); !errors.Is(err, errBodyTooLarge) {
+		t.Errorf("a body of %d bytes: %v, want %v", maxBodySize+1, err, errBodyTooLarge)
+	}
+}
+
+func Test_ReadAllHoldsBodyTwice(t *testing.T) {
+	const size = 50 << 20
+	readAll := bench.RunAndMeasure(func() { checksum, _ = readAllUpload(newUpload(size)) })
+	stream := bench.RunAndMeasure(func() { checksum, _ = streamUpload(newUpload(size)) })
+	t.Logf("50 MB upload: io.ReadAll allocated %.1f MB, streaming %.1f KB",
+		mb(readAll.AllocsBytes), float64(stream.AllocsBytes)/1024)
+
+	// The chunks it read into, then the right-sized copy it returns
+	if readAll.AllocsBytes < 2*size {
+		t.Errorf("io.ReadAll allocated %.1f MB, want at least twice the 50 MB body", mb(readAll.AllocsBytes))
+	}
+	// At most a fresh bufio.Writer, if a GC emptied the pool
+	if stream.AllocsBytes > 2*streamBufferSize {
+		t.Errorf("streaming allocated %d B, want at most %d", stream.AllocsBytes, 2*streamBufferSize)
+	}
+}
+
+func Test_StreamingAllocationsDoNotGrow(t *testing.T) {
+	streamUpload(newUpload(1 << 10)) // Warm the pool
+	small := testutil.AssertMaxAllocs(t, "streaming 1 KB", 3, func() { checksum, _ = streamUpload(newUpload(1 << 10)) })
+	large := testutil.AssertMaxAllocs(t, "streaming 50 MB", 3, func() { checksum, _ = streamUpload(newUpload(50 << 20)) })
+	if large > small {
+		t.Errorf("streaming 50 MB: %.1f allocs, more than the %.1f for 1 KB", large, small)
+	}
+}
+
+func Test_PeakRSSCliff(t *testing.T) {
+	if _, ok := procStatus("VmRSS"); !ok || !resetPeakRSS() {
+		t.Skip("needs /proc/self/status and /proc/self/clear_refs")
+	}
+	const size = 50 << 20
+	readAll := measureUpload(readAllUpload, size)
+	stream := measureUpload(streamUpload, size)
+	t.Logf("50 MB upload: peak RSS +%.1f MB with io.ReadAll, +%.1f MB streaming",
+		mb(readAll.PeakRSS), mb(stream.PeakRSS))
+
+	// Both copies of the body are live at once, and the pages were
+	// written, so they're resident
+	if readAll.PeakRSS < size*3/2 {
+		t.Errorf("io.ReadAll grew peak RSS by %.1f MB, want at least 1.5x the 50 MB body", mb(readAll.PeakRSS))
+	}
+	if stream.PeakRSS > 4<<20 {
+		t.Errorf("streaming grew peak RSS by %.1f MB, want at most 4 MB", mb(stream.PeakRSS))
+	}
+}
@@ -0,0 +1,453 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	// maxBodySize is the cap io.LimitReader enforces: the largest upload
+	// the limited handler accepts.
+	maxBodySize = 10 << 20
+
+	// streamBufferSize is the bufio.Writer the streaming handler copies
+	// through: all the memory an upload of any size needs.
+	streamBufferSize = 32 << 10
+
+	// bytesPerRun is how much upload data one benchmark case reads, so a
+	// 1 KB case runs many uploads and a 50 MB case runs one.
+	bytesPerRun = 64 << 20
+)
+
+// uploadSizes are the synthetic uploads: a small JSON payload, a photo,
+// and a video clip five times over the limit.
+var uploadSizes = []int64{1 << 10, 1 << 20, 50 << 20}
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// ========== SYNTHETIC UPLOADS ==========
+
+// upload is a request body of a given size, generated as it is read, so
+// the client's copy of a 50 MB file doesn't sit in the server's heap.
+type upload struct {
+	remaining int64
+	offset    int // position in uploadPattern
+}
+
+const uploadPattern = `{"event":"page_view","user_id":48213,"ts":1700000000}` + "\n"
+
+func newUpload(size int64) *upload {
+	return &upload{remaining: size}
+}
+
+func (u *upload) Read(p []byte) (int, error) {
+	if u.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > u.remaining {
+		p = p[:u.remaining]
+	}
+	n := 0
+	for n < len(p) {
+		c := copy(p[n:], uploadPattern[u.offset:])
+		n += c
+		u.offset = (u.offset + c) % len(uploadPattern)
+	}
+	u.remaining -= int64(n)
+	return n, nil
+}
+
+// ========== UPLOAD HANDLERS ==========
+
+// Each handler reads an upload and returns its CRC-32, standing in for the
+// real work: hashing, scanning or writing it to object storage.
+
+// readAllUpload buffers the whole body, then processes it.
+func readAllUpload(r io.Reader) (uint32, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(body), nil
+}
+
+// readLimitedUpload buffers at most maxBodySize bytes. It reads one byte
+// past the limit to tell a body of exactly maxBodySize from a larger one.
+func readLimitedUpload(r io.Reader) (uint32, error) {
+	body, err := io.ReadAll(io.LimitReader(r, maxBodySize+1))
+	if err != nil {
+		return 0, err
+	}
+	if len(body) > maxBodySize {
+		return 0, errBodyTooLarge
+	}
+	return crc32.ChecksumIEEE(body), nil
+}
+
+var writerPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, streamBufferSize) },
+}
+
+// streamUpload processes the body as it arrives, one buffer at a time.
+// bufio.Writer implements io.ReaderFrom, so io.Copy reads straight into
+// its buffer and allocates none of its own. The writer is pooled: a new
+// 32 KB buffer per request would cost a 1 KB upload 32 times its size.
+func streamUpload(r io.Reader) (uint32, error) {
+	h := crc32.NewIEEE()
+	w := writerPool.Get().(*bufio.Writer)
+	w.Reset(h)
+	defer func() {
+		w.Reset(nil) // drop h
+		writerPool.Put(w)
+	}()
+
+	if _, err := io.Copy(w, r); err != nil {
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	return h.Sum32(), nil
+}
+
+type strategy struct {
+	Name   string
+	Handle func(io.Reader) (uint32, error)
+}
+
+var strategies = []strategy{
+	{"io.ReadAll", readAllUpload},
+	{"io.LimitReader + ReadAll", readLimitedUpload},
+	{"streaming, pooled bufio", streamUpload},
+}
+
+// ========== MEMORY ==========
+
+// memoryUse is what one upload cost a handler.
+type memoryUse struct {
+	Allocated uint64 // runtime.MemStats TotalAlloc
+	PeakRSS   int64  // Growth of the process's peak resident set, or -1
+	Err       error
+}
+
+// HasRSS reports whether PeakRSS was measured. It isn't where
+// /proc/self/status can't report it.
+func (u memoryUse) HasRSS() bool {
+	return u.PeakRSS >= 0
+}
+
+// procStatus returns a size field of /proc/self/status, such as VmRSS or
+// VmHWM, in bytes. ok is false where the file doesn't exist, such as
+// outside Linux.
+func procStatus(field string) (uint64, bool) {
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return 0, false
+	}
+	for line := range bytes.Lines(data) {
+		name, value, found := bytes.Cut(line, []byte(":"))
+		if !found || string(name) != field {
+			continue
+		}
+		kb, err := strconv.ParseUint(string(bytes.TrimSuffix(bytes.TrimSpace(value), []byte(" kB"))), 10, 64)
+		return kb << 10, err == nil
+	}
+	return 0, false
+}
+
+// resetPeakRSS sets VmHWM back to the current VmRSS (Linux 4.0+).
+func resetPeakRSS() bool {
+	return os.WriteFile("/proc/self/clear_refs", []byte("5"), 0) == nil
+}
+
+// measureUpload runs one upload of size bytes through h. The heap is
+// collected and returned to the OS first, so the peak RSS growth is the
+// memory the upload itself made the process take.
+func measureUpload(h func(io.Reader) (uint32, error), size int64) memoryUse {
+	runtime.GC()
+	debug.FreeOSMemory()
+	use := memoryUse{PeakRSS: -1}
+	before, ok := procStatus("VmRSS")
+	ok = ok && resetPeakRSS()
+
+	m := bench.RunAndMeasure(func() { _, use.Err = h(newUpload(size)) })
+	use.Allocated = m.AllocsBytes
+
+	if peak, peakOK := procStatus("VmHWM"); ok && peakOK {
+		use.PeakRSS = int64(peak - min(peak, before))
+	}
+	return use
+}
+
+func sizeLabel(size int64) string {
+	if size >= 1<<20 {
+		return fmt.Sprintf("%d MB", size>>20)
+	}
+	return fmt.Sprintf("%d KB", size>>10)
+}
+
+func mb[T int64 | uint64](n T) float64 {
+	return float64(n) / (1 << 20)
+}
+
+func main() {
+	fmt.Println("🔬 DAY 36: io.ReadAll vs Streaming Large Request Bodies")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	// The shocking truth about io.ReadAll
+	fmt.Println("🎯 SHOCKING DISCOVERY: A 50 MB upload takes over 100 MB of RAM to read!")
+	fmt.Println(strings.Repeat("-", 40))
+	cliff := revealMemoryCliff()
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d MB of uploads per run\n", bytesPerRun>>20)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks()
+
+	// io.ReadAll internals
+	fmt.Println("\n🔧 BODY BUFFERING DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainReadAllGrowth(cliff)
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateLargeBodyCostImpact(cliff, results, cost.DefaultPricing())
+
+	// One run at each upload size, named by size so they can be told apart
+	saved := make([]bench.Result, 0, len(strategies)*len(uploadSizes))
+	for _, size := range uploadSizes {
+		saved = append(saved, bench.WithPrefix(sizeLabel(size), results[size])...)
+	}
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 36, Topic: "io.ReadAll vs Streaming Large Request Bodies", Date: time.Now(), Benchmarks: saved, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 36 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 37 - Column-oriented vs Row-oriented Data")
+}
+
+// revealMemoryCliff measures one upload of each size with each strategy.
+// The result is indexed by size, then strategy.
+func revealMemoryCliff() map[int64][]memoryUse {
+	cliff := make(map[int64][]memoryUse, len(uploadSizes))
+	fmt.Println("  Peak RSS growth for one upload (VmHWM, /proc/self/status):")
+	fmt.Printf("  %-7s", "Body")
+	for _, s := range strategies {
+		fmt.Printf(" %26s", s.Name)
+	}
+	fmt.Println()
+
+	for _, size := range uploadSizes {
+		fmt.Printf("  %-7s", sizeLabel(size))
+		for _, s := range strategies {
+			use := measureUpload(s.Handle, size)
+			cliff[size] = append(cliff[size], use)
+			cell := "n/a"
+			switch {
+			case errors.Is(use.Err, errBodyTooLarge) && use.HasRSS():
+				cell = fmt.Sprintf("+%.1f MB, rejected", mb(use.PeakRSS))
+			case use.Err != nil:
+				cell = use.Err.Error()
+			case use.HasRSS():
+				cell = fmt.Sprintf("+%.1f MB", mb(use.PeakRSS))
+			}
+			fmt.Printf(" %26s", cell)
+		}
+		fmt.Println()
+	}
+	if !cliff[uploadSizes[0]][0].HasRSS() {
+		fmt.Println("  /proc/self/status isn't available here; see allocations below")
+	}
+
+	fmt.Println("\n  Heap allocated for one upload (runtime.MemStats TotalAlloc):")
+	for _, size := range uploadSizes {
+		fmt.Printf("  %-7s", sizeLabel(size))
+		for _, use := range cliff[size] {
+			fmt.Printf(" %23.1f MB", mb(use.Allocated))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\n💡 io.ReadAll ends up holding the body twice: once in the chunks it")
+	fmt.Println("   read into, once in the slice it returns. The limit caps that at")
+	fmt.Println("   10 MB by refusing bigger uploads. Streaming holds one 32 KB")
+	fmt.Println("   buffer, whatever the size.")
+	return cliff
+}
+
+func runComparisonBenchmarks() map[int64][]bench.Result {
+	results := make(map[int64][]bench.Result, len(uploadSizes))
+	for _, size := range uploadSizes {
+		if len(results) > 0 {
+			fmt.Println()
+		}
+		uploads := max(1, bytesPerRun/size)
+		suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d × %s uploads", uploads, sizeLabel(size)))
+		suite.Iterations = 3
+		for _, s := range strategies {
+			suite.Register(s.Name, func() {
+				for i := int64(0); i < uploads; i++ {
+					checksum, _ = s.Handle(newUpload(size))
+				}
+			})
+		}
+		fmt.Printf("%s:\n", suite.Title)
+		if err := suite.Report(os.Stdout); err != nil {
+			fmt.Printf("❌ %v\n", err)
+		}
+		results[size] = suite.Results()
+	}
+	return results
+}
+
+// Global variable to prevent compiler optimizations
+var checksum uint32
+
+func explainReadAllGrowth(cliff map[int64][]memoryUse) {
+	big := uploadSizes[len(uploadSizes)-1]
+	readAll := cliff[big][0]
+	fmt.Printf("io.ReadAll on a %s body (%s):\n", sizeLabel(big), runtime.Version())
+	fmt.Println()
+	fmt.Println("┌──────┬──────┬──────┬─────┬─────────┐     ┌────────────────┐")
+	fmt.Println("│ 512B │ 256B │ 384B │ ... │ 1.5x... │ ──► │ final, 50 MB   │")
+	fmt.Println("└──────┴──────┴──────┴─────┴─────────┘     └────────────────┘")
+	fmt.Println("  chunks, all live until the copy           one right-sized copy")
+	fmt.Println()
+	fmt.Println("  While the chunks are copied into the final slice, the body is in")
+	fmt.Printf("  memory twice: %.1f MB allocated to return %s (%.1fx the body)\n",
+		mb(readAll.Allocated), sizeLabel(big), float64(readAll.Allocated)/float64(big))
+	fmt.Println()
+	fmt.Println("streaming, any size:")
+	fmt.Println()
+	fmt.Println("┌──────────────────────────────────┐")
+	fmt.Println("│ one 32 KB bufio.Writer buffer    │  refilled until EOF")
+	fmt.Println("└──────────────────────────────────┘")
+	fmt.Println()
+
+	fmt.Println("📈 WHY BUFFERING HURTS AT SCALE:")
+	fmt.Println("  • Memory is per concurrent upload: 1,000 at once is 1,000 bodies")
+	fmt.Println("  • A request nobody limited can be as big as the client wants")
+	fmt.Println("  • The heap target doubles the live heap (GOGC=100): more RSS again")
+	fmt.Println()
+
+	fmt.Println("⚠️  LIMIT PITFALLS:")
+	fmt.Println("  • io.LimitReader stops at the limit silently: read limit+1 to detect it")
+	fmt.Println("  • http.MaxBytesReader also closes the connection and returns an error")
+	fmt.Println("  • A limit makes memory bounded, not small: 1,000 × 10 MB is 10 GB")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🌊 STREAM BODIES YOU PROCESS ONCE")
+	fmt.Println("   ✅ io.Copy(dst, r.Body) into a hash, a file or an S3 multipart upload")
+	fmt.Println("   Benefit: Memory per upload is one buffer, whatever the size")
+	fmt.Println()
+
+	fmt.Println("2. 🚧 LIMIT EVERY BODY YOU BUFFER")
+	fmt.Println("   ✅ r.Body = http.MaxBytesReader(w, r.Body, 10<<20)")
+	fmt.Println("   Benefit: One client can't make the server allocate gigabytes")
+	fmt.Println()
+
+	fmt.Println("3. 📏 SIZE FROM Content-Length WHEN YOU MUST BUFFER")
+	fmt.Println("   ✅ body := make([]byte, r.ContentLength); io.ReadFull(r.Body, body)")
+	fmt.Println("   Benefit: One allocation instead of a growth chain (see day 14)")
+	fmt.Println()
+
+	fmt.Println("4. 🎟️  BOUND CONCURRENT UPLOADS")
+	fmt.Println("   ✅ A semaphore sized to memory / per-upload buffer")
+	fmt.Println("   Benefit: Peak memory is a number you chose, not one clients chose")
+}
+
+func calculateLargeBodyCostImpact(cliff map[int64][]memoryUse, results map[int64][]bench.Result, pricing cost.PricingModel) float64 {
+	// A file upload API with 1,000 uploads of 50 MB in flight at once
+	const concurrentUploads = 1_000
+	const uploadDuration = 40 * time.Second // 50 MB at 10 Mbit/s
+	big := uploadSizes[len(uploadSizes)-1]
+	uploadsPerDay := concurrentUploads / uploadDuration.Seconds() * 86400
+	costPerVCPUHour := pricing.CPUHourCost()
+	costPerGBMonth := pricing.RAMGBMonthCost()
+
+	// Peak RSS where it was measured, heap allocated otherwise. A
+	// streaming upload needs at least its buffer
+	perUpload := func(use memoryUse) uint64 {
+		if use.HasRSS() {
+			return uint64(use.PeakRSS)
+		}
+		return use.Allocated
+	}
+	readAllBytes := perUpload(cliff[big][0])
+	streamBytes := max(perUpload(cliff[big][2]), streamBufferSize)
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • %d concurrent %s uploads, each taking %v (10 Mbit/s clients)\n",
+		concurrentUploads, sizeLabel(big), uploadDuration)
+	fmt.Printf("  • %.0f uploads/day\n", uploadsPerDay)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU, $%.2f/GB-month\n", pricing, costPerVCPUHour, costPerGBMonth)
+	fmt.Println("  • Memory is provisioned for the peak, upload by upload")
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	readAllTotal := uint64(concurrentUploads) * readAllBytes
+	streamTotal := uint64(concurrentUploads) * streamBytes
+	fmt.Printf("  io.ReadAll:  %6.1f MB per upload × %d = %7.1f GB\n",
+		mb(readAllBytes), concurrentUploads, float64(readAllTotal)/(1<<30))
+	fmt.Printf("  Streaming:   %6.3f MB per upload × %d = %7.3f GB\n",
+		mb(streamBytes), concurrentUploads, float64(streamTotal)/(1<<30))
+	fmt.Printf("  LimitReader: rejects every %s upload, so it isn't an option here\n", sizeLabel(big))
+	memoryMonthly := cost.MemorySavingsMonthly(readAllTotal-min(streamTotal, readAllTotal), costPerGBMonth)
+
+	uploads := float64(max(1, bytesPerRun/big))
+	savedNs := (results[big][0].NsPerOp - results[big][2].NsPerOp) / uploads
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.0f ns/upload is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	cpuMonthly := cost.CPUSavingsMonthly(time.Duration(savedNs), uploadsPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Memory savings:  $%.4f/month\n", memoryMonthly)
+	fmt.Printf("  CPU savings:     $%.4f/month (%.1f ms/upload)\n", cpuMonthly, savedNs/1e6)
+
+	monthly := memoryMonthly + cpuMonthly
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: uploadsPerDay, Unit: "uploads/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • No OOM kill when uploads bunch up")
+	fmt.Println("  • Processing overlaps the network: work starts at the first byte")
+	fmt.Println("  • Upload size stops being a capacity-planning input")
+	return monthly
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/layout"
+)
+
+// trades generates the 1M trades once for all benchmarks and tests.
+var trades = sync.OnceValues(func() ([]TradeRecord, *TradeDatabase) {
+	rows := generateTrades(recordCount)
+	return rows, toColumns(rows)
+})
+
+// ========== AVERAGE PRICE BENCHMARKS ==========
+
+// Each op is one AVG(price) over 1M trades. SetBytes counts only the
+// prices, so MB/s is the useful bytes read.
+
+func Benchmark_RowAveragePrice(b *testing.B) {
+	benchmarkQuery(b, queries[0])
+}
+
+func Benchmark_ColumnAveragePrice(b *testing.B) {
+	benchmarkQuery(b, queries[1])
+}
+
+// ========== FOUR RUNNING SUMS BENCHMARKS ==========
+
+func Benchmark_RowAveragePriceUnrolled(b *testing.B) {
+	benchmarkQuery(b, queries[2])
+}
+
+func Benchmark_ColumnAveragePriceUnrolled(b *testing.B) {
+	benchmarkQuery(b, queries[3])
+}
+
+func benchmarkQuery(b *testing.B, q query) {
+	rows, db := trades()
+	b.SetBytes(recordCount * int64(unsafe.Sizeof(TradeRecord{}.Price)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		averagePrice = q.Run(rows, db)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_TradeRecordLayout(t *testing.T) {
+	if size := layout.AssertStructOptimized(t, TradeRecord{}, "TradeRecord"); size != 24 {
+		t.Errorf("TradeRecord is %d bytes, expected 24", size)
+	}
+	layout.AssertStructOptimized(t, TradeDatabase{}, "TradeDatabase")
+}
+
+func Test_ColumnsMatchRows(t *testing.T) {
+	rows := generateTrades(1000)
+	db := toColumns(rows)
+	for i, r := range rows {
+		got := TradeRecord{db.Timestamps[i], db.Prices[i], db.Volumes[i], db.Symbols[i]}
+		if got != r {
+			t.Fatalf("trade %d: columns hold %+v, rows %+v", i, got, r)
+		}
+	}
+}
+
+func Test_QueriesAgree(t *testing.T) {
+	// 1003 trades: the unrolled queries' leftover loop runs too
+	rows := generateTrades(1003)
+	db := toColumns(rows)
+	want := averagePriceRows(rows)
+	if want < 100 || want >= 500 {
+		t.Fatalf("average price $%.2f, outside the generated $100-$500", want)
+	}
+	for _, q := range queries[1:] {
+		// Summing in another order can change the last bits
+		if got := q.Run(rows, db); math.Abs(got-want) > 1e-9*want {
+			t.Errorf("%s: $%.10f, expected $%.10f", q.Name, got, want)
+		}
+	}
+}
+
+func Test_QueriesDoNotAllocate(t *testing.T) {
+	rows, db := trades()
+	for _, q := range queries {
+		if allocs := testing.AllocsPerRun(5, func() { averagePrice = q.Run(rows, db) }); allocs != 0 {
+			t.Errorf("%s: %.0f allocs, expected 0", q.Name, allocs)
+		}
+	}
+}
+
+// medianSpeedup times slow and fast alternately, runs times each, and
+// returns the median of slow's time over fast's. Pairing the runs keeps a
+// busy moment on a shared host from landing on one side only.
+func medianSpeedup(runs int, slow, fast func()) (speedup, slowNs, fastNs float64) {
+	ratios := make([]float64, runs)
+	for i := range ratios {
+		start := time.Now()
+		slow()
+		s := float64(time.Since(start).Nanoseconds())
+		start = time.Now()
+		fast()
+		f := float64(time.Since(start).Nanoseconds())
+		ratios[i] = s / f
+		slowNs += s / float64(runs)
+		fastNs += f / float64(runs)
+	}
+	sort.Float64s(ratios)
+	return ratios[runs/2], slowNs, fastNs
+}
+
+// Test_ColumnQuerySpeedup compares the layouts with four running sums.
+// With one, the column scan is held to about 1 ns a price by its chain of
+// additions, and measured anywhere from 1.1x to 3.1x faster than rows on
+// a 1 vCPU host, so that pair is logged rather than asserted.
+func Test_ColumnQuerySpeedup(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	rows, db := trades()
+	const runs = 15
+
+	compare := func(slow, fast query) (float64, float64, float64) {
+		return medianSpeedup(runs,
+			func() { averagePrice = slow.Run(rows, db) },
+			func() { averagePrice = fast.Run(rows, db) })
+	}
+	speedup, rowNs, colNs := compare(queries[0], queries[1])
+	t.Logf("1 running sum:  rows %.2f ms, columns %.2f ms, median %.1fx", rowNs/1e6, colNs/1e6, speedup)
+
+	speedup, rowNs, colNs = compare(queries[2], queries[3])
+	t.Logf("4 running sums: rows %.2f ms, columns %.2f ms, median %.1fx", rowNs/1e6, colNs/1e6, speedup)
+	if speedup < 2 {
+		t.Errorf("columns only %.1fx faster than rows with four running sums, expected at least 2x", speedup)
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const (
+	recordCount = 1_000_000
+	scansPerRun = 10
+
+	// cacheLineSize is what the CPU loads from memory at a time, on amd64
+	// and most arm64 parts.
+	cacheLineSize = 64
+)
+
+// ========== LAYOUTS ==========
+
+// TradeRecord is one executed trade: 24 bytes, no padding.
+type TradeRecord struct {
+	Timestamp int64 // Unix nanoseconds
+	Price     float32
+	Volume    int32
+	Symbol    [8]byte
+}
+
+// TradeDatabase holds the same trades one column per field: the i-th
+// trade is Timestamps[i], Prices[i], Volumes[i] and Symbols[i].
+type TradeDatabase struct {
+	Timestamps []int64
+	Prices     []float32
+	Volumes    []int32
+	Symbols    [][8]byte
+}
+
+var symbols = []string{"AAPL", "MSFT", "GOOGL", "AMZN", "NVDA", "TSLA", "META", "JPM"}
+
+// generateTrades returns n trades a millisecond apart, the same ones on
+// every run.
+func generateTrades(n int) []TradeRecord {
+	rng := rand.New(rand.NewPCG(37, 2026))
+	start := time.Date(2026, 1, 5, 14, 30, 0, 0, time.UTC).UnixNano()
+	trades := make([]TradeRecord, n)
+	for i := range trades {
+		t := &trades[i]
+		t.Timestamp = start + int64(i)*int64(time.Millisecond)
+		t.Price = float32(10_000+rng.IntN(40_000)) / 100 // $100.00-$499.99
+		t.Volume = int32(1 + rng.IntN(1000))
+		copy(t.Symbol[:], symbols[rng.IntN(len(symbols))])
+	}
+	return trades
+}
+
+// toColumns copies rows into a TradeDatabase.
+func toColumns(rows []TradeRecord) *TradeDatabase {
+	db := &TradeDatabase{
+		Timestamps: make([]int64, len(rows)),
+		Prices:     make([]float32, len(rows)),
+		Volumes:    make([]int32, len(rows)),
+		Symbols:    make([][8]byte, len(rows)),
+	}
+	for i, r := range rows {
+		db.Timestamps[i] = r.Timestamp
+		db.Prices[i] = r.Price
+		db.Volumes[i] = r.Volume
+		db.Symbols[i] = r.Symbol
+	}
+	return db
+}
+
+// ========== AVERAGE PRICE QUERIES ==========
+
+// Each query is SELECT AVG(price) FROM trades. The sum is a float64: a
+// float32 one would lose cents long before a million trades.
+
+// averagePriceRows walks the records and reads one field of each. Every
+// cache line it loads holds 64 bytes of trades, 10.7 of them prices.
+func averagePriceRows(rows []TradeRecord) float64 {
+	var sum float64
+	for i := range rows {
+		sum += float64(rows[i].Price)
+	}
+	return sum / float64(len(rows))
+}
+
+// averagePriceColumns reads only the Prices column: 16 prices per cache
+// line, every byte of it used.
+func averagePriceColumns(db *TradeDatabase) float64 {
+	var sum float64
+	for _, p := range db.Prices {
+		sum += float64(p)
+	}
+	return sum / float64(len(db.Prices))
+}
+
+// averagePriceRowsUnrolled is averagePriceRows with four running sums, so
+// each addition doesn't wait for the one before it. The row scan is
+// mostly waiting on memory, so it gains much less than the column scan.
+func averagePriceRowsUnrolled(rows []TradeRecord) float64 {
+	var s0, s1, s2, s3 float64
+	r := rows
+	for ; len(r) >= 4; r = r[4:] {
+		s0 += float64(r[0].Price)
+		s1 += float64(r[1].Price)
+		s2 += float64(r[2].Price)
+		s3 += float64(r[3].Price)
+	}
+	for i := range r {
+		s0 += float64(r[i].Price)
+	}
+	return (s0 + s1 + s2 + s3) / float64(len(rows))
+}
+
+// averagePriceColumnsUnrolled is averagePriceColumns with four running
+// sums. One sum holds the column scan to one addition's latency per
+// price, about 1 ns; with four it runs closer to memory speed.
+func averagePriceColumnsUnrolled(db *TradeDatabase) float64 {
+	var s0, s1, s2, s3 float64
+	p := db.Prices
+	for ; len(p) >= 4; p = p[4:] {
+		s0 += float64(p[0])
+		s1 += float64(p[1])
+		s2 += float64(p[2])
+		s3 += float64(p[3])
+	}
+	for _, v := range p {
+		s0 += float64(v)
+	}
+	return (s0 + s1 + s2 + s3) / float64(len(db.Prices))
+}
+
+type query struct {
+	Name string
+	Run  func(rows []TradeRecord, db *TradeDatabase) float64
+}
+
+var queries = []query{
+	{"rows, 1 running sum", func(rows []TradeRecord, _ *TradeDatabase) float64 { return averagePriceRows(rows) }},
+	{"columns, 1 running sum", func(_ []TradeRecord, db *TradeDatabase) float64 { return averagePriceColumns(db) }},
+	{"rows, 4 running sums", func(rows []TradeRecord, _ *TradeDatabase) float64 { return averagePriceRowsUnrolled(rows) }},
+	{"columns, 4 running sums", func(_ []TradeRecord, db *TradeDatabase) float64 { return averagePriceColumnsUnrolled(db) }},
+}
+
+// Global variable to prevent compiler optimizations
+var averagePrice float64
+
+func main() {
+	fmt.Println("🔬 DAY 37: Column-oriented vs Row-oriented Data")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("📅 Date: %s\n\n", time.Now().Format("2006-01-02"))
+
+	rows := generateTrades(recordCount)
+	db := toColumns(rows)
+
+	// The shocking truth about row layouts
+	fmt.Println("🎯 SHOCKING DISCOVERY: An average over one field loads every field!")
+	fmt.Println(strings.Repeat("-", 40))
+	revealCacheLineWaste(rows, db)
+
+	// Benchmark
+	fmt.Printf("\n📊 BENCHMARK: %d scans of %d trades\n", scansPerRun, recordCount)
+	fmt.Println(strings.Repeat("-", 40))
+	results := runComparisonBenchmarks(rows, db)
+
+	// Layout internals
+	fmt.Println("\n🔧 MEMORY LAYOUT DEEP DIVE")
+	fmt.Println(strings.Repeat("-", 40))
+	explainColumnLayout()
+
+	// Optimization strategies
+	fmt.Println("\n⚡ OPTIMIZATION STRATEGIES")
+	fmt.Println(strings.Repeat("-", 40))
+	shareOptimizationStrategies()
+
+	// Cost analysis
+	fmt.Println("\n💰 COST IMPACT ANALYSIS")
+	fmt.Println(strings.Repeat("=", 60))
+	monthly := calculateColumnarCostImpact(results, cost.DefaultPricing())
+
+	// Compare with the last run
+	fmt.Println("\n🗂️  SINCE LAST RUN")
+	fmt.Println(strings.Repeat("-", 40))
+	run := bench.DayResult{Day: 37, Topic: "Column-oriented vs Row-oriented Data", Date: time.Now(), Benchmarks: results, CostSavingsMonthly: monthly}
+	if err := bench.CompareWithLast(bench.LastRunPath(run.Day), run, os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n✅ DAY 37 COMPLETED! 🎉")
+	fmt.Println("\n🔜 Next: Day 38 - Profiling & Benchmarking")
+}
+
+// scanFootprint is what one average-price scan makes the CPU load.
+func scanFootprint(recordBytes, usefulBytes, n int) (loaded, lines int, usedPerLine float64) {
+	loaded = recordBytes * n
+	lines = (loaded + cacheLineSize - 1) / cacheLineSize
+	return loaded, lines, float64(usefulBytes*cacheLineSize) / float64(recordBytes)
+}
+
+func revealCacheLineWaste(rows []TradeRecord, db *TradeDatabase) {
+	recordBytes := int(unsafe.Sizeof(TradeRecord{}))
+	priceBytes := int(unsafe.Sizeof(TradeRecord{}.Price))
+	fmt.Printf("  %d trades, %d bytes each; the query needs the %d-byte Price\n\n", len(rows), recordBytes, priceBytes)
+
+	fmt.Println("  Layout  | Loaded per scan | Cache lines | Price bytes per line")
+	fmt.Println("  --------|-----------------|-------------|---------------------")
+	rowLoaded, rowLines, rowUsed := scanFootprint(recordBytes, priceBytes, len(rows))
+	colLoaded, colLines, colUsed := scanFootprint(priceBytes, priceBytes, len(db.Prices))
+	fmt.Printf("  rows    | %12.1f MB | %11d | %13.1f of %d\n", float64(rowLoaded)/1e6, rowLines, rowUsed, cacheLineSize)
+	fmt.Printf("  columns | %12.1f MB | %11d | %13.1f of %d\n", float64(colLoaded)/1e6, colLines, colUsed, cacheLineSize)
+
+	fmt.Printf("\n  Average price: $%.4f (rows), $%.4f (columns)\n", averagePriceRows(rows), averagePriceColumns(db))
+
+	fmt.Printf("\n💡 A CPU loads memory a %d-byte cache line at a time. Row by row,\n", cacheLineSize)
+	fmt.Println("   each line carries timestamps, volumes and symbols the query never")
+	fmt.Printf("   reads: %.0fx the bytes of the column scan for the same answer.\n", float64(rowLoaded)/float64(colLoaded))
+}
+
+func runComparisonBenchmarks(rows []TradeRecord, db *TradeDatabase) []bench.Result {
+	suite := bench.NewBenchmarkSuite(fmt.Sprintf("%d × AVG(price) over %d trades", scansPerRun, recordCount))
+	suite.Iterations = 3
+	for _, q := range queries {
+		suite.Register(q.Name, func() {
+			for i := 0; i < scansPerRun; i++ {
+				averagePrice = q.Run(rows, db)
+			}
+		})
+	}
+	if err := suite.Report(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	results := suite.Results()
+	fmt.Println()
+	for _, res := range results {
+		ns := res.NsPerOp / scansPerRun
+		fmt.Printf("  %-25s %6.2f ms/scan, %5.2f ns/trade\n", res.Name+":", ns/1e6, ns/recordCount)
+	}
+	fmt.Printf("\n  Columns vs rows: %.1fx with one running sum, %.1fx with four\n",
+		results[0].NsPerOp/results[1].NsPerOp, results[2].NsPerOp/results[3].NsPerOp)
+	return results
+}
+
+func explainColumnLayout() {
+	fmt.Println("One 64-byte cache line, row-oriented ([]TradeRecord):")
+	fmt.Println()
+	fmt.Println("  ┌────┬─────┬───┬─────┬────┬─────┬───┬─────┬────┬─────┐")
+	fmt.Println("  │ ts │PRICE│vol│ sym │ ts │PRICE│vol│ sym │ ts │PRICE│ …")
+	fmt.Println("  └────┴─────┴───┴─────┴────┴─────┴───┴─────┴────┴─────┘")
+	fmt.Println("    2.7 trades per line: 10.7 of 64 bytes are prices")
+	fmt.Println()
+	fmt.Println("The same line, column-oriented (TradeDatabase.Prices):")
+	fmt.Println()
+	fmt.Println("  ┌─────┬─────┬─────┬─────┬─────┬─── … ───┬─────┐")
+	fmt.Println("  │PRICE│PRICE│PRICE│PRICE│PRICE│         │PRICE│")
+	fmt.Println("  └─────┴─────┴─────┴─────┴─────┴─── … ───┴─────┘")
+	fmt.Println("    16 prices per line: all 64 bytes are used")
+	fmt.Println()
+
+	fmt.Println("📈 WHY FOUR RUNNING SUMS WIDEN THE GAP:")
+	fmt.Println("  • sum += p can't start until the previous addition finishes:")
+	fmt.Println("    about 4 cycles, so one running sum manages ~1 ns per price")
+	fmt.Println("  • The row scan is slower than that anyway: it waits on 6x the memory")
+	fmt.Println("  • Four independent sums lift the limit, and only the column scan")
+	fmt.Println("    was held back by it")
+	fmt.Println()
+
+	fmt.Println("⚠️  WHEN ROWS WIN:")
+	fmt.Println("  • Reading or writing whole trades: one line instead of four columns")
+	fmt.Println("  • Appending one trade at a time: one write instead of four")
+	fmt.Println("  • Queries that touch most fields of few records (OLTP lookups)")
+}
+
+func shareOptimizationStrategies() {
+	fmt.Println("1. 🏛️  STORE ANALYTICAL DATA BY COLUMN")
+	fmt.Println("   ✅ A struct of slices: Prices []float32, Volumes []int32, ...")
+	fmt.Println("   Benefit: A scan loads only the fields it reads")
+	fmt.Println()
+
+	fmt.Println("2. ➕ GIVE THE CPU INDEPENDENT WORK")
+	fmt.Println("   ✅ Four running sums, combined at the end")
+	fmt.Println("   Benefit: Additions overlap; the column layout's bandwidth is used")
+	fmt.Println()
+
+	fmt.Println("3. 📦 USE A COLUMNAR FORMAT AT REST")
+	fmt.Println("   ✅ Parquet or Arrow files, or a columnar store like ClickHouse")
+	fmt.Println("   Benefit: Same-typed columns also compress far better than rows")
+	fmt.Println()
+
+	fmt.Println("4. 🔀 KEEP ROWS FOR TRANSACTIONAL ACCESS")
+	fmt.Println("   ✅ Write trades as rows; batch them into columns for analytics")
+	fmt.Println("   Benefit: Each workload gets the layout it reads fastest")
+}
+
+func calculateColumnarCostImpact(results []bench.Result, pricing cost.PricingModel) float64 {
+	// A financial analytics service scanning its trade history
+	const recordsPerDay = 1e9
+	const scansPerDay = recordsPerDay / recordCount
+	costPerVCPUHour := pricing.CPUHourCost()
+
+	perScan := func(r bench.Result) float64 { return r.NsPerOp / scansPerRun }
+	rowNs, colNs := perScan(results[0]), perScan(results[3])
+
+	fmt.Println("☁️  ASSUMPTIONS:")
+	fmt.Printf("  • A financial analytics service: %.0f trades scanned/day, AVG(price)-style\n", recordsPerDay)
+	fmt.Printf("  • %v: $%.4f/hour per vCPU\n", pricing, costPerVCPUHour)
+	fmt.Println("  • Before: rows, one running sum; after: columns, four running sums")
+
+	fmt.Println("\n🧮 CALCULATIONS:")
+	fmt.Printf("  Rows:    %6.2f ns/trade → %7.1f CPU-seconds/day\n", rowNs/recordCount, rowNs*scansPerDay/1e9)
+	fmt.Printf("  Columns: %6.2f ns/trade → %7.1f CPU-seconds/day\n", colNs/recordCount, colNs*scansPerDay/1e9)
+
+	savedNs := rowNs - colNs
+	if savedNs <= 0 {
+		fmt.Printf("  Difference %.0f ns/scan is within noise; counting it as 0\n", savedNs)
+		savedNs = 0
+	}
+	monthly := cost.CPUSavingsMonthly(time.Duration(savedNs), scansPerDay, costPerVCPUHour)
+
+	fmt.Printf("\n  Time saved:      %.2f ms per million trades\n", savedNs/1e6)
+	fmt.Printf("  One query over all %.0fB trades: %.1f s → %.1f s\n", recordsPerDay/1e9, rowNs*scansPerDay/1e9, colNs*scansPerDay/1e9)
+	fmt.Printf("  CPU savings:     $%.4f/month\n", monthly)
+	fmt.Printf("\n  Monthly savings: $%.4f\n", monthly)
+	fmt.Printf("  Annual savings:  $%.4f\n", cost.AnnualFromMonthly(monthly))
+
+	fmt.Println()
+	projections := cost.ScalingProjections{MonthlySavings: monthly, BaseUnits: recordsPerDay, Unit: "trades/day"}
+	if _, err := projections.WriteTo(os.Stdout); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+	fmt.Println()
+	if err := cost.WriteInstanceScaling(os.Stdout, monthly); err != nil {
+		fmt.Printf("❌ %v\n", err)
+	}
+
+	fmt.Println("\n🎯 ADDITIONAL BENEFITS:")
+	fmt.Println("  • Interactive queries: a dashboard scan is 3-4x quicker to return")
+	fmt.Println("  • Less memory bandwidth taken from everything else on the host")
+	fmt.Println("  • Columns compress well: prices next to prices, symbols next to symbols")
+	return monthly
+}
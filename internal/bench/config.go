@@ -0,0 +1,80 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// BenchmarkConfig controls how RunWithConfig measures a function. CPU
+// frequency scaling makes the first calls of a run slower than the rest
+// and short runs noisy, which is what warm-up and a minimum duration
+// smooth out.
+type BenchmarkConfig struct {
+	// WarmupRounds is how many untimed calls run before measuring.
+	WarmupRounds int
+	// MinDurationSeconds keeps calling the function until at least this
+	// long has passed; 0 means a single timed call.
+	MinDurationSeconds float64
+	// ForceGC collects garbage before measuring, as RunAndMeasure does.
+	ForceGC bool
+	// LockOSThread wires the measuring goroutine to its OS thread so the
+	// scheduler can't migrate it mid-run. It does not pin the thread to a
+	// CPU; use taskset for that.
+	LockOSThread bool
+}
+
+// DefaultConfig returns one warm-up call, at least 100ms of timed calls
+// and a GC before measuring.
+func DefaultConfig() BenchmarkConfig {
+	return BenchmarkConfig{
+		WarmupRounds:       1,
+		MinDurationSeconds: 0.1,
+		ForceGC:            true,
+	}
+}
+
+// RunWithConfig measures fn according to config. Duration, AllocsBytes and
+// AllocsCount are averages per timed call; HeapObjects, NumGC and GCPause
+// cover the whole timed run, so NumGC says whether a GC cycle landed in
+// the measurement at all.
+func RunWithConfig(config BenchmarkConfig, fn func()) MeasurementResult {
+	if config.LockOSThread {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+	}
+	for i := 0; i < config.WarmupRounds; i++ {
+		fn()
+	}
+
+	minDuration := time.Duration(config.MinDurationSeconds * float64(time.Second))
+	calls := 0
+	m := measure(config.ForceGC, func() {
+		start := time.Now()
+		for calls == 0 || time.Since(start) < minDuration {
+			fn()
+			calls++
+		}
+	})
+
+	n := uint64(calls)
+	m.Duration /= time.Duration(calls)
+	m.AllocsBytes /= n
+	m.AllocsCount /= n
+	return m
+}
+
+// WriteHeader prints config and the machine settings that make runs
+// comparable, ahead of a day's measurements.
+func (c BenchmarkConfig) WriteHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "⚙️  Benchmark config: %d warm-up rounds, ≥%.2fs per case, ForceGC=%t, LockOSThread=%t\n"+
+		"   GOMAXPROCS=%d on %d CPUs\n"+
+		"💡 For reproducible single-core comparisons:\n"+
+		"   GOMAXPROCS=1 go run .\n"+
+		"   sudo cpupower frequency-set -g performance  # stop frequency scaling\n"+
+		"   taskset -c 2 go run .                       # keep to one core\n",
+		c.WarmupRounds, c.MinDurationSeconds, c.ForceGC, c.LockOSThread,
+		runtime.GOMAXPROCS(0), runtime.NumCPU())
+	return err
+}
@@ -0,0 +1,211 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RegressionThreshold is how much slower, in percent, a benchmark must get
+// between two runs before CompareDays calls it a regression. In-process
+// timings move a few percent from run to run on their own.
+const RegressionThreshold = 10.0
+
+// LastRunFile is the name of the file each day's demo keeps its DayResult
+// in, inside the day's own directory, for CompareWithLast.
+const LastRunFile = "last_run.json"
+
+// ANSI escape codes for the comparison table.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// BenchmarkRecord is one suite Result as saved in a DayResult.
+type BenchmarkRecord = Result
+
+// DayResult is what one run of a day's demo measured, saved as JSON so the
+// next run can be compared against it.
+type DayResult struct {
+	Day                int               `json:"day"`
+	Topic              string            `json:"topic,omitempty"`
+	Date               time.Time         `json:"date"`
+	Benchmarks         []BenchmarkRecord `json:"benchmarks"`
+	CostSavingsMonthly float64           `json:"cost_savings_monthly"`
+}
+
+// WithPrefix returns copies of results named "prefix/Name", so one suite
+// run at several sizes can go in a DayResult without its names colliding.
+func WithPrefix(prefix string, results []Result) []Result {
+	out := make([]Result, len(results))
+	for i, r := range results {
+		r.Name = prefix + "/" + r.Name
+		out[i] = r
+	}
+	return out
+}
+
+// LastRunPath returns where day's demo keeps its last run: day-NN/last_run.json
+// relative to the repo root, or just last_run.json when the demo runs from
+// inside day-NN. Either way each day gets its own file.
+func LastRunPath(day int) string {
+	dir := fmt.Sprintf("day-%02d", day)
+	if wd, err := os.Getwd(); err == nil && filepath.Base(wd) == dir {
+		return LastRunFile
+	}
+	return filepath.Join(dir, LastRunFile)
+}
+
+// SaveResult writes r to path as indented JSON, replacing any earlier file.
+func SaveResult(path string, r DayResult) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding day %d result: %w", r.Day, err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadResult reads a DayResult written by SaveResult.
+func LoadResult(path string) (DayResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DayResult{}, err
+	}
+	var r DayResult
+	if err := json.Unmarshal(data, &r); err != nil {
+		return DayResult{}, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Comparison is one benchmark's change between two runs. Changes are
+// percentages of the earlier run; a positive TimeChange is slower.
+type Comparison struct {
+	Name         string
+	Before       BenchmarkRecord
+	After        BenchmarkRecord
+	TimeChange   float64
+	BytesChange  float64
+	Regression   bool
+	MissingAfter bool // The benchmark was dropped; only Before is set
+}
+
+// ComparisonReport lines up the benchmarks of two runs by name.
+type ComparisonReport struct {
+	Before, After DayResult
+	Comparisons   []Comparison
+}
+
+// CompareDays compares each benchmark in a with the one of the same name in
+// b, in a's order. Benchmarks only b has are new and have nothing to be
+// compared to, so they are left out.
+func CompareDays(a, b DayResult) ComparisonReport {
+	after := make(map[string]BenchmarkRecord, len(b.Benchmarks))
+	for _, r := range b.Benchmarks {
+		after[r.Name] = r
+	}
+
+	report := ComparisonReport{Before: a, After: b, Comparisons: make([]Comparison, 0, len(a.Benchmarks))}
+	for _, before := range a.Benchmarks {
+		c := Comparison{Name: before.Name, Before: before}
+		if r, ok := after[before.Name]; ok {
+			c.After = r
+			c.TimeChange = percentChange(before.NsPerOp, r.NsPerOp)
+			c.BytesChange = percentChange(before.BytesPerOp, r.BytesPerOp)
+			c.Regression = c.TimeChange > RegressionThreshold
+		} else {
+			c.MissingAfter = true
+		}
+		report.Comparisons = append(report.Comparisons, c)
+	}
+	return report
+}
+
+// percentChange is the change from before to after as a percentage of
+// before, or 0 when before is 0 and there is nothing to scale by.
+func percentChange(before, after float64) float64 {
+	if before == 0 {
+		return 0
+	}
+	return (after - before) / before * 100
+}
+
+// Regressions returns the comparisons that got slower by more than
+// RegressionThreshold, for a CI gate to fail on.
+func (r ComparisonReport) Regressions() []Comparison {
+	out := make([]Comparison, 0, len(r.Comparisons))
+	for _, c := range r.Comparisons {
+		if c.Regression {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// WriteTo prints the comparison table, regressions in red and
+// improvements beyond the threshold in green.
+func (r ComparisonReport) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📉 DAY %d: %s vs %s\n", r.After.Day,
+		r.Before.Date.Format("2006-01-02"), r.After.Date.Format("2006-01-02"))
+	fmt.Fprintln(&sb, strings.Repeat("-", 72))
+
+	width := len("Benchmark")
+	for _, c := range r.Comparisons {
+		width = max(width, len(c.Name))
+	}
+	fmt.Fprintf(&sb, "%-*s %12s %12s %9s %9s\n", width, "Benchmark", "before", "after", "time", "bytes")
+	for _, c := range r.Comparisons {
+		if c.MissingAfter {
+			fmt.Fprintf(&sb, "%-*s %12v %12s\n", width, c.Name, c.Before.Duration(), "removed")
+			continue
+		}
+		line := fmt.Sprintf("%-*s %12v %12v %+8.1f%% %+8.1f%%", width, c.Name,
+			c.Before.Duration(), c.After.Duration(), c.TimeChange, c.BytesChange)
+		switch {
+		case c.Regression:
+			line = ansiRed + line + " ⚠️  regression" + ansiReset
+		case c.TimeChange < -RegressionThreshold:
+			line = ansiGreen + line + ansiReset
+		}
+		fmt.Fprintln(&sb, line)
+	}
+
+	if before, after := r.Before.CostSavingsMonthly, r.After.CostSavingsMonthly; before != after {
+		fmt.Fprintf(&sb, "\n💰 Monthly savings: $%.4f → $%.4f\n", before, after)
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// CompareWithLast prints how r compares with the result saved at path by
+// the previous run, if there is one, then saves r there for the next run.
+// A saved result from a different day has nothing to compare with, so it
+// is replaced without a comparison.
+func CompareWithLast(path string, r DayResult, w io.Writer) error {
+	last, err := LoadResult(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if _, err := fmt.Fprintf(w, "📁 No previous run at %s; saving this one\n", path); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case last.Day != r.Day:
+		if _, err := fmt.Fprintf(w, "📁 %s holds a day %d run, not day %d; replacing it\n", path, last.Day, r.Day); err != nil {
+			return err
+		}
+	default:
+		if _, err := CompareDays(last, r).WriteTo(w); err != nil {
+			return err
+		}
+	}
+	return SaveResult(path, r)
+}
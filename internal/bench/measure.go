@@ -0,0 +1,88 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// MeasurementResult is what one call of a function cost, from the
+// runtime.MemStats read on either side of it:
+//   - Duration: wall time of the call
+//   - AllocsBytes, AllocsCount: TotalAlloc and Mallocs deltas, everything
+//     the call allocated whether or not it is still live
+//   - HeapObjects: live heap objects right after the call, including ones
+//     the next GC cycle will free
+//   - NumGC, GCPause: GC cycles that finished during the call and their
+//     stop-the-world pauses (the PauseTotalNs delta)
+type MeasurementResult struct {
+	Duration    time.Duration
+	AllocsBytes uint64
+	AllocsCount uint64
+	HeapObjects uint64
+	NumGC       uint32
+	GCPause     time.Duration
+}
+
+// String formats the three metrics in the columns the suite table uses, so
+// before/after lines printed one under the other stay aligned.
+func (m MeasurementResult) String() string {
+	return fmt.Sprintf("%12v %10d B %7d allocs", m.Duration, m.AllocsBytes, m.AllocsCount)
+}
+
+// WriteMemoryStats prints every field of m under label, one per line, so
+// a day can show what a call did to the GC as well as what it allocated.
+func (m MeasurementResult) WriteMemoryStats(w io.Writer, label string) error {
+	_, err := fmt.Fprintf(w, "%s:\n"+
+		"  Time:          %v\n"+
+		"  Allocated:     %d B in %d objects\n"+
+		"  Heap objects:  %d live after the call\n"+
+		"  GC cycles:     %d (%v paused)\n",
+		label, m.Duration, m.AllocsBytes, m.AllocsCount, m.HeapObjects, m.NumGC, m.GCPause)
+	return err
+}
+
+// RunAndMeasure calls fn once and returns what it cost. It collects garbage
+// first so allocations made before the call don't trigger a GC cycle
+// inside it.
+func RunAndMeasure(fn func()) MeasurementResult {
+	return measure(true, fn)
+}
+
+// AllocsPerCall calls fn once to warm up, then calls times, and returns
+// the average heap allocations and bytes allocated per call. Unlike
+// testing.AllocsPerRun it works outside tests and reports bytes too.
+func AllocsPerCall(calls int, fn func()) (allocs, bytes float64) {
+	fn() // warm up
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < calls; i++ {
+		fn()
+	}
+	runtime.ReadMemStats(&after)
+	n := float64(calls)
+	return float64(after.Mallocs-before.Mallocs) / n, float64(after.TotalAlloc-before.TotalAlloc) / n
+}
+
+// measure calls fn once between two runtime.ReadMemStats, collecting
+// garbage first if gc is set.
+func measure(gc bool, fn func()) MeasurementResult {
+	if gc {
+		runtime.GC()
+	}
+	var m1, m2 runtime.MemStats
+	runtime.ReadMemStats(&m1)
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&m2)
+	return MeasurementResult{
+		Duration:    elapsed,
+		AllocsBytes: m2.TotalAlloc - m1.TotalAlloc,
+		AllocsCount: m2.Mallocs - m1.Mallocs,
+		HeapObjects: m2.HeapObjects,
+		NumGC:       m2.NumGC - m1.NumGC,
+		GCPause:     time.Duration(m2.PauseTotalNs - m1.PauseTotalNs),
+	}
+}
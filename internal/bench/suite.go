@@ -0,0 +1,176 @@
+// Package bench runs the quick in-process comparisons the daily demos print
+// from main, as opposed to `go test -bench` runs (see pkg/bench).
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+)
+
+// Result is one measured case. Values are averages over Iterations runs;
+// Speedup is the first case's time divided by this one's.
+type Result struct {
+	Name        string  `json:"name"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	Speedup     float64 `json:"speedup_vs_first"`
+}
+
+// Duration returns NsPerOp as a time.Duration for printing.
+func (r Result) Duration() time.Duration {
+	return time.Duration(r.NsPerOp)
+}
+
+type benchCase struct {
+	name string
+	fn   func()
+}
+
+// BenchmarkSuite times a list of named cases and reports them side by side.
+// The first registered case is the baseline the others are compared to.
+type BenchmarkSuite struct {
+	Title string
+	// Iterations is how many times each case runs; 0 means once, which
+	// matches the time.Now()/time.Since() pattern of the early days.
+	Iterations int
+	// Config, if set, measures each case with RunWithConfig instead of one
+	// RunAndMeasure call, and Report prints its header above the table.
+	Config *BenchmarkConfig
+
+	cases   []benchCase
+	results []Result
+}
+
+// NewBenchmarkSuite returns an empty suite that runs each case once.
+func NewBenchmarkSuite(title string) *BenchmarkSuite {
+	return &BenchmarkSuite{Title: title}
+}
+
+// Register adds a case. Cases run and are reported in registration order.
+func (s *BenchmarkSuite) Register(name string, fn func()) {
+	s.cases = append(s.cases, benchCase{name: name, fn: fn})
+}
+
+// Run measures every registered case and returns the results, replacing
+// those of any earlier Run. Each case is one RunAndMeasure call, or
+// RunWithConfig with Config, so heap usage counts everything allocated
+// during the case, including garbage that was already collected.
+func (s *BenchmarkSuite) Run() []Result {
+	iterations := s.Iterations
+	if iterations <= 0 {
+		iterations = 1
+	}
+
+	s.results = make([]Result, 0, len(s.cases))
+	for _, c := range s.cases {
+		run := func() {
+			for i := 0; i < iterations; i++ {
+				c.fn()
+			}
+		}
+		var m MeasurementResult
+		if s.Config != nil {
+			m = RunWithConfig(*s.Config, run)
+		} else {
+			m = RunAndMeasure(run)
+		}
+
+		n := float64(iterations)
+		s.results = append(s.results, Result{
+			Name:        c.name,
+			Iterations:  iterations,
+			NsPerOp:     float64(m.Duration.Nanoseconds()) / n,
+			BytesPerOp:  float64(m.AllocsBytes) / n,
+			AllocsPerOp: float64(m.AllocsCount) / n,
+		})
+	}
+
+	if len(s.results) > 0 {
+		base := s.results[0].NsPerOp
+		for i := range s.results {
+			if s.results[i].NsPerOp > 0 {
+				s.results[i].Speedup = base / s.results[i].NsPerOp
+			}
+		}
+	}
+	return s.results
+}
+
+// Results returns the results of the last Run.
+func (s *BenchmarkSuite) Results() []Result {
+	return s.results
+}
+
+// Report writes the comparison table followed by a JSON summary, after the
+// Config header if there is one. It runs the suite first if Run has not
+// been called.
+func (s *BenchmarkSuite) Report(w io.Writer) error {
+	if s.results == nil {
+		s.Run()
+	}
+	if s.Config != nil {
+		if err := s.Config.WriteHeader(w); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	if err := s.writeTable(w); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "\n📄 JSON summary:"); err != nil {
+		return err
+	}
+	return s.WriteJSON(w)
+}
+
+// writeTable prints the numbered list used by runComparisonBenchmarks in
+// the early days, with memory columns next to the timing.
+func (s *BenchmarkSuite) writeTable(w io.Writer) error {
+	width := 0
+	for _, r := range s.results {
+		width = max(width, len(r.Name)+1) // +1 for the colon
+	}
+	for i, r := range s.results {
+		line := fmt.Sprintf("%d. %-*s %12v %10.0f B %7.0f allocs",
+			i+1, width, r.Name+":", r.Duration(), r.BytesPerOp, r.AllocsPerOp)
+		switch {
+		case i == 0:
+		case r.Speedup >= 1:
+			line += fmt.Sprintf(" (%.1fx faster)", r.Speedup)
+		case r.Speedup > 0:
+			line += fmt.Sprintf(" (%.1fx slower)", 1/r.Speedup)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Summary is the machine-readable form of a suite's results.
+type Summary struct {
+	Title     string   `json:"title"`
+	GoVersion string   `json:"go_version"`
+	GOARCH    string   `json:"goarch"`
+	Results   []Result `json:"results"`
+}
+
+// WriteJSON writes the last Run as an indented Summary, one field per line
+// so that CI can diff it across commits.
+func (s *BenchmarkSuite) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Summary{
+		Title:     s.Title,
+		GoVersion: runtime.Version(),
+		GOARCH:    runtime.GOARCH,
+		Results:   s.results,
+	})
+}
@@ -0,0 +1,355 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+var sink []byte
+
+func TestBenchmarkSuite_Run(t *testing.T) {
+	s := NewBenchmarkSuite("test")
+	s.Iterations = 10
+	s.Register("slow", func() { time.Sleep(2 * time.Millisecond) })
+	s.Register("alloc", func() { sink = make([]byte, 1<<20) })
+
+	results := s.Run()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Name != "slow" || results[1].Name != "alloc" {
+		t.Errorf("results out of registration order: %q, %q", results[0].Name, results[1].Name)
+	}
+	if results[0].NsPerOp < float64(2*time.Millisecond) {
+		t.Errorf("slow case measured %.0f ns/op, expected at least 2ms", results[0].NsPerOp)
+	}
+	if results[1].BytesPerOp < 1<<20 || results[1].AllocsPerOp < 1 {
+		t.Errorf("alloc case measured %.0f B/op, %.1f allocs/op; expected >= 1 MiB in >= 1 alloc",
+			results[1].BytesPerOp, results[1].AllocsPerOp)
+	}
+	if results[0].Speedup != 1 || results[1].Speedup <= 1 {
+		t.Errorf("unexpected speedups %.2f, %.2f", results[0].Speedup, results[1].Speedup)
+	}
+}
+
+func TestBenchmarkSuite_Report(t *testing.T) {
+	s := NewBenchmarkSuite("report")
+	s.Register("a", func() {})
+	s.Register("bb", func() {})
+
+	var buf bytes.Buffer
+	if err := s.Report(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "1. a: ") || !strings.Contains(out, "2. bb:") {
+		t.Errorf("table missing numbered cases:\n%s", out)
+	}
+
+	_, js, ok := strings.Cut(out, "📄 JSON summary:\n")
+	if !ok {
+		t.Fatalf("no JSON summary in report:\n%s", out)
+	}
+	var sum Summary
+	if err := json.Unmarshal([]byte(js), &sum); err != nil {
+		t.Fatalf("JSON summary does not parse: %v\n%s", err, js)
+	}
+	if sum.Title != "report" || len(sum.Results) != 2 || sum.Results[1].Name != "bb" {
+		t.Errorf("unexpected summary %+v", sum)
+	}
+}
+
+func TestBenchmarkSuite_Config(t *testing.T) {
+	calls := 0
+	s := NewBenchmarkSuite("config")
+	s.Iterations = 2
+	s.Config = &BenchmarkConfig{WarmupRounds: 1, MinDurationSeconds: 0.005}
+	s.Register("sleep", func() {
+		calls++
+		time.Sleep(time.Millisecond)
+	})
+
+	var buf bytes.Buffer
+	if err := s.Report(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// One warm-up run of 2 calls, then runs of 2 calls until 5ms have passed
+	if calls < 2+4 {
+		t.Errorf("case ran %d times, expected a warm-up and at least two timed runs", calls)
+	}
+	if r := s.Results()[0]; r.NsPerOp < float64(time.Millisecond) || r.NsPerOp > float64(5*time.Millisecond) {
+		t.Errorf("measured %v per call, expected about 1ms", r.Duration())
+	}
+	if !strings.HasPrefix(buf.String(), "⚙️  Benchmark config: 1 warm-up rounds") {
+		t.Errorf("expected the config header above the table:\n%s", buf.String())
+	}
+}
+
+func TestRunAndMeasure(t *testing.T) {
+	m := RunAndMeasure(func() {
+		time.Sleep(time.Millisecond)
+		sink = make([]byte, 1<<20)
+	})
+	if m.Duration < time.Millisecond {
+		t.Errorf("measured %v, expected at least 1ms", m.Duration)
+	}
+	if m.AllocsBytes < 1<<20 || m.AllocsCount < 1 {
+		t.Errorf("measured %d B in %d allocs, expected >= 1 MiB in >= 1 alloc", m.AllocsBytes, m.AllocsCount)
+	}
+
+	if empty := RunAndMeasure(func() {}); empty.AllocsCount != 0 {
+		t.Errorf("empty function measured %d allocs", empty.AllocsCount)
+	}
+}
+
+func TestRunAndMeasure_CountsGCCycles(t *testing.T) {
+	m := RunAndMeasure(func() {
+		runtime.GC()
+		runtime.GC()
+	})
+	if m.NumGC != 2 {
+		t.Errorf("measured %d GC cycles, expected 2", m.NumGC)
+	}
+	if m.GCPause <= 0 {
+		t.Errorf("measured %v of GC pauses, expected some", m.GCPause)
+	}
+	if m.HeapObjects == 0 {
+		t.Error("expected live heap objects after the call")
+	}
+}
+
+func TestAllocsPerCall(t *testing.T) {
+	allocs, bytes := AllocsPerCall(100, func() { sink = make([]byte, 1024) })
+	if allocs != 1 || bytes != 1024 {
+		t.Errorf("measured %.2f allocs, %.0f B per call, expected 1 alloc of 1024 B", allocs, bytes)
+	}
+	if allocs, _ := AllocsPerCall(100, func() {}); allocs != 0 {
+		t.Errorf("empty function measured %.2f allocs per call", allocs)
+	}
+}
+
+func TestMeasurementResult_WriteMemoryStats(t *testing.T) {
+	m := MeasurementResult{
+		Duration: 3 * time.Millisecond, AllocsBytes: 4096, AllocsCount: 2,
+		HeapObjects: 1500, NumGC: 1, GCPause: 50 * time.Microsecond,
+	}
+	var buf bytes.Buffer
+	if err := m.WriteMemoryStats(&buf, "build"); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"build:", "3ms", "4096 B in 2 objects", "1500 live", "1 (50µs paused)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestMeasurementResult_StringAligned(t *testing.T) {
+	before := MeasurementResult{Duration: 1500 * time.Millisecond, AllocsBytes: 48_000_000, AllocsCount: 1_000_000}
+	after := MeasurementResult{Duration: 2 * time.Millisecond, AllocsBytes: 0, AllocsCount: 0}
+	b, a := before.String(), after.String()
+	if len(b) != len(a) {
+		t.Errorf("columns not aligned:\n%q\n%q", b, a)
+	}
+	if !strings.Contains(b, "48000000 B") || !strings.Contains(b, "1000000 allocs") {
+		t.Errorf("missing metrics in %q", b)
+	}
+}
+
+func TestSaveResult_LoadResultRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day.json")
+	want := DayResult{
+		Day:                16,
+		Date:               time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		Benchmarks:         []BenchmarkRecord{{Name: "a", Iterations: 3, NsPerOp: 100, BytesPerOp: 16, AllocsPerOp: 2, Speedup: 1}},
+		CostSavingsMonthly: 1.25,
+	}
+	if err := SaveResult(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadResult(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Day != want.Day || !got.Date.Equal(want.Date) || got.CostSavingsMonthly != want.CostSavingsMonthly ||
+		len(got.Benchmarks) != 1 || got.Benchmarks[0] != want.Benchmarks[0] {
+		t.Errorf("round trip changed the result:\nsaved  %+v\nloaded %+v", want, got)
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	results := []Result{{Name: "io.ReadAll", NsPerOp: 100}, {Name: "pooled", NsPerOp: 50}}
+	got := WithPrefix("64 KB", results)
+	if got[0].Name != "64 KB/io.ReadAll" || got[1].Name != "64 KB/pooled" || got[1].NsPerOp != 50 {
+		t.Errorf("unexpected results: %+v", got)
+	}
+	if results[0].Name != "io.ReadAll" {
+		t.Errorf("WithPrefix renamed its input: %+v", results)
+	}
+}
+
+func TestCompareDays(t *testing.T) {
+	a := DayResult{Day: 16, Benchmarks: []BenchmarkRecord{
+		{Name: "steady", NsPerOp: 100, BytesPerOp: 10},
+		{Name: "slower", NsPerOp: 100, BytesPerOp: 10},
+		{Name: "faster", NsPerOp: 100, BytesPerOp: 10},
+		{Name: "dropped", NsPerOp: 100},
+	}}
+	b := DayResult{Day: 16, Benchmarks: []BenchmarkRecord{
+		{Name: "new", NsPerOp: 1},
+		{Name: "faster", NsPerOp: 50, BytesPerOp: 0},
+		{Name: "slower", NsPerOp: 150, BytesPerOp: 20},
+		{Name: "steady", NsPerOp: 105, BytesPerOp: 10},
+	}}
+
+	report := CompareDays(a, b)
+	if len(report.Comparisons) != 4 {
+		t.Fatalf("expected 4 comparisons in the first run's order, got %+v", report.Comparisons)
+	}
+	steady, slower, faster, dropped := report.Comparisons[0], report.Comparisons[1], report.Comparisons[2], report.Comparisons[3]
+	if steady.Regression || steady.TimeChange != 5 {
+		t.Errorf("5%% slower is within noise, got %+v", steady)
+	}
+	if !slower.Regression || slower.TimeChange != 50 || slower.BytesChange != 100 {
+		t.Errorf("expected a +50%% time, +100%% bytes regression, got %+v", slower)
+	}
+	if faster.Regression || faster.TimeChange != -50 || faster.BytesChange != -100 {
+		t.Errorf("expected a -50%% time, -100%% bytes improvement, got %+v", faster)
+	}
+	if !dropped.MissingAfter {
+		t.Errorf("expected a benchmark missing from the second run to be marked, got %+v", dropped)
+	}
+	if regs := report.Regressions(); len(regs) != 1 || regs[0].Name != "slower" {
+		t.Errorf("expected only \"slower\" to regress, got %+v", regs)
+	}
+
+	var buf bytes.Buffer
+	if _, err := report.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		red := strings.HasPrefix(line, ansiRed)
+		if red != strings.HasPrefix(line, ansiRed+"slower ") {
+			t.Errorf("only the regression should be red, got %q", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "removed") {
+		t.Errorf("dropped benchmark not shown:\n%s", buf.String())
+	}
+}
+
+func TestCompareWithLast(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day.json")
+	first := DayResult{Day: 1, Benchmarks: []BenchmarkRecord{{Name: "a", NsPerOp: 100}}}
+	second := DayResult{Day: 1, Benchmarks: []BenchmarkRecord{{Name: "a", NsPerOp: 200}}}
+
+	var buf bytes.Buffer
+	if err := CompareWithLast(path, first, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No previous run") {
+		t.Errorf("expected the first run to report no previous result, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := CompareWithLast(path, second, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "+100.0%") {
+		t.Errorf("expected the second run to be compared with the first:\n%s", buf.String())
+	}
+	if saved, err := LoadResult(path); err != nil || saved.Benchmarks[0].NsPerOp != 200 {
+		t.Errorf("expected the second run to replace the first, got %+v, %v", saved, err)
+	}
+}
+
+func TestCompareWithLast_OtherDay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "day.json")
+	day5 := DayResult{Day: 5, Benchmarks: []BenchmarkRecord{{Name: "a", NsPerOp: 100}}}
+	day6 := DayResult{Day: 6, Benchmarks: []BenchmarkRecord{{Name: "a", NsPerOp: 200}}}
+	if err := SaveResult(path, day5); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := CompareWithLast(path, day6, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "+100.0%") || !strings.Contains(buf.String(), "day 5 run") {
+		t.Errorf("expected day 6 not to be compared with day 5:\n%s", buf.String())
+	}
+	if saved, err := LoadResult(path); err != nil || saved.Day != 6 {
+		t.Errorf("expected the day 6 run to replace day 5, got %+v, %v", saved, err)
+	}
+}
+
+func TestLastRunPath(t *testing.T) {
+	if got, want := LastRunPath(5), filepath.Join("day-05", LastRunFile); got != want {
+		t.Errorf("LastRunPath(5) from the package dir = %q, want %q", got, want)
+	}
+
+	dir := filepath.Join(t.TempDir(), "day-05")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	if got := LastRunPath(5); got != LastRunFile {
+		t.Errorf("LastRunPath(5) inside day-05 = %q, want %q", got, LastRunFile)
+	}
+}
+
+func TestRunWithConfig_WarmsUpThenRunsForMinDuration(t *testing.T) {
+	calls := 0
+	config := BenchmarkConfig{WarmupRounds: 3, MinDurationSeconds: 0.01}
+	m := RunWithConfig(config, func() {
+		calls++
+		time.Sleep(time.Millisecond)
+		sink = make([]byte, 1024)
+	})
+	// 3 warm-up calls plus enough 1ms calls to fill 10ms
+	if calls < 3+5 {
+		t.Errorf("fn ran %d times, expected 3 warm-up calls and at least 5 timed ones", calls)
+	}
+	if m.Duration < time.Millisecond || m.Duration > 10*time.Millisecond {
+		t.Errorf("measured %v per call, expected about 1ms", m.Duration)
+	}
+	if m.AllocsCount != 1 || m.AllocsBytes < 1024 {
+		t.Errorf("measured %d B in %d allocs per call, expected >= 1 KiB in 1 alloc", m.AllocsBytes, m.AllocsCount)
+	}
+}
+
+func TestRunWithConfig_ForceGC(t *testing.T) {
+	for _, forceGC := range []bool{true, false} {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		m := RunWithConfig(BenchmarkConfig{ForceGC: forceGC}, func() {})
+		runtime.ReadMemStats(&after)
+
+		// Cycles that ran but weren't measured happened before the timed run
+		unmeasured := after.NumGC - before.NumGC - m.NumGC
+		if forceGC && unmeasured != 1 {
+			t.Errorf("ForceGC=true: %d GC cycles before measuring, expected 1", unmeasured)
+		}
+		if !forceGC && unmeasured != 0 {
+			t.Errorf("ForceGC=false: %d GC cycles before measuring, expected none", unmeasured)
+		}
+	}
+}
+
+func TestBenchmarkConfig_WriteHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := DefaultConfig().WriteHeader(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"1 warm-up rounds", "≥0.10s", "ForceGC=true", "GOMAXPROCS=1 go run ."} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Package cost converts measured resource usage into cloud spend, using
+// the same assumptions as the per-day cost analyses.
+package cost
+
+// HoursPerMonth is the 30-day month every cost analysis in this repo uses.
+const HoursPerMonth = 24 * 30
+
+// CostCalculator turns per-operation CPU time into a monthly bill.
+type CostCalculator interface {
+	// MonthlyCPUCost returns what it costs per month to spend nsPerOp
+	// nanoseconds of CPU on each of rps operations per second.
+	MonthlyCPUCost(nsPerOp, rps float64) float64
+}
+
+// VCPUCalculator prices CPU time at a flat per-vCPU-hour rate.
+type VCPUCalculator struct {
+	VCPUHourPrice float64
+}
+
+// DefaultCalculator returns the AWS t3.medium rate used throughout the
+// daily analyses ($0.0416/hour per vCPU).
+func DefaultCalculator() VCPUCalculator {
+	return VCPUCalculator{VCPUHourPrice: DefaultPricing().CPUHourCost()}
+}
+
+// MonthlyCPUCost implements CostCalculator.
+func (c VCPUCalculator) MonthlyCPUCost(nsPerOp, rps float64) float64 {
+	vCPUs := nsPerOp * rps / 1e9
+	return vCPUs * c.VCPUHourPrice * HoursPerMonth
+}
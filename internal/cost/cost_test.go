@@ -0,0 +1,349 @@
+package cost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVCPUCalculator_MonthlyCPUCost(t *testing.T) {
+	// 1ms per request at 1000 rps keeps exactly one vCPU busy
+	got := DefaultCalculator().MonthlyCPUCost(1e6, 1000)
+	want := 0.0416 * 24 * 30
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected $%.4f/month, got $%.4f", want, got)
+	}
+}
+
+func TestSafeDurationToFloat64(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       time.Duration
+		want    float64
+		wantErr error
+	}{
+		{"zero", 0, 0, nil},
+		{"negative", -time.Second, -1e9, nil},
+		{"max exact", MaxExactDuration, 1 << 53, nil},
+		{"min exact", -MaxExactDuration, -(1 << 53), nil},
+		{"one past exact", MaxExactDuration + 1, 1 << 53, ErrPrecisionLoss},
+		{"max duration", math.MaxInt64, math.MaxInt64, ErrPrecisionLoss},
+		{"min duration", math.MinInt64, math.MinInt64, ErrPrecisionLoss},
+	}
+	for _, tt := range tests {
+		got, err := SafeDurationToFloat64(tt.d)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected (%v, %v), got (%v, %v)", tt.name, tt.want, tt.wantErr, got, err)
+		}
+	}
+}
+
+func TestCheckedFloat64Mul(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    float64
+		wantErr error
+	}{
+		{"finite", 1.5, 2, nil},
+		{"zero", 0, math.MaxFloat64, nil},
+		{"negative", -3, 4, nil},
+		{"overflow", math.MaxFloat64, 2, ErrInfinity},
+		{"negative overflow", -math.MaxFloat64, 2, ErrInfinity},
+		{"inf input", math.Inf(1), 2, ErrInfinity},
+		{"inf times zero", math.Inf(1), 0, ErrNaN},
+		{"nan input", math.NaN(), 1, ErrNaN},
+	}
+	for _, tt := range tests {
+		got, err := CheckedFloat64Mul(tt.a, tt.b)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+		// The raw product is always returned so NaN/Inf propagate
+		if want := tt.a * tt.b; got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Errorf("%s: expected %v, got %v", tt.name, want, got)
+		}
+	}
+}
+
+func TestDefaultPricing_MatchesSeriesFigures(t *testing.T) {
+	var p PricingModel = DefaultPricing()
+	if p.CPUHourCost() != 0.0416 {
+		t.Errorf("expected $0.0416/vCPU-hour, got $%.4f", p.CPUHourCost())
+	}
+	if p.RAMGBMonthCost() != 3.75 {
+		t.Errorf("expected $3.75/GB-month, got $%.2f", p.RAMGBMonthCost())
+	}
+	if got := DefaultCalculator().VCPUHourPrice; got != p.CPUHourCost() {
+		t.Errorf("DefaultCalculator ($%.4f) and DefaultPricing ($%.4f) disagree", got, p.CPUHourCost())
+	}
+}
+
+func TestPricingModels_UnknownInstanceFallsBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		unknown PricingModel
+		def     PricingModel
+	}{
+		{"aws", AWSPricing{InstanceType: "nope"}, AWSPricing{InstanceType: "t3.medium"}},
+		{"gcp", GCPPricing{}, GCPPricing{MachineType: "e2-standard-2"}},
+		{"azure", AzurePricing{VMSize: "nope"}, AzurePricing{VMSize: "B2s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.unknown.CPUHourCost() != tt.def.CPUHourCost() ||
+				tt.unknown.RAMGBMonthCost() != tt.def.RAMGBMonthCost() {
+				t.Errorf("%v does not fall back to %v", tt.unknown, tt.def)
+			}
+			if fmt.Sprint(tt.unknown) != fmt.Sprint(tt.def) {
+				t.Errorf("expected %q, got %q", fmt.Sprint(tt.def), fmt.Sprint(tt.unknown))
+			}
+			if tt.unknown.NetworkGBCost() <= 0 {
+				t.Error("expected a positive egress price")
+			}
+		})
+	}
+}
+
+func TestCostProjection_ProjectAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     CostProjection
+		units float64
+		want  float64
+	}{
+		{"measured scale", SuperlinearProjection(2), 1, 2},
+		{"linear", LinearProjection(2), 1000, 2000},
+		{"zero exponent is linear", CostProjection{BaseSavings: 2}, 1000, 2000},
+		{"superlinear", SuperlinearProjection(2), 1000, 2 * math.Pow(1000, 1.3)},
+		{"sublinear", SublinearProjection(2), 1000, 2 * math.Pow(1000, 0.8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.ProjectAt(tt.units); math.Abs(got-tt.want) > 1e-9*tt.want {
+				t.Errorf("expected %.6f, got %.6f", tt.want, got)
+			}
+		})
+	}
+
+	// Past the measured scale the curves must stay ordered
+	base := 1.0
+	for _, units := range []float64{10, 100, 1000} {
+		sub, lin, sup := SublinearProjection(base).ProjectAt(units),
+			LinearProjection(base).ProjectAt(units), SuperlinearProjection(base).ProjectAt(units)
+		if !(sub < lin && lin < sup) {
+			t.Errorf("at %.0fx expected sublinear < linear < superlinear, got %.2f, %.2f, %.2f", units, sub, lin, sup)
+		}
+	}
+}
+
+func TestMemorySavingsMonthly(t *testing.T) {
+	// Reference case: 1 GiB at the series' $3.75/GB-month
+	if got := MemorySavingsMonthly(1<<30, 3.75); math.Abs(got-3.75) > 1e-12 {
+		t.Errorf("expected $3.75/month for 1 GiB, got $%.6f", got)
+	}
+	// 512 MiB is half of that; nothing saved costs nothing
+	if got := MemorySavingsMonthly(512<<20, 3.75); math.Abs(got-1.875) > 1e-12 {
+		t.Errorf("expected $1.875/month for 512 MiB, got $%.6f", got)
+	}
+	if got := MemorySavingsMonthly(0, 3.75); got != 0 {
+		t.Errorf("expected $0 for 0 bytes, got $%.6f", got)
+	}
+}
+
+func TestCPUSavingsMonthly(t *testing.T) {
+	// 1 ms saved on 3.6M requests/day is one vCPU-hour per day
+	got := CPUSavingsMonthly(time.Millisecond, 3_600_000, 0.0416)
+	want := 0.0416 * 30
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected $%.4f/month, got $%.4f", want, got)
+	}
+	// It must agree with the calculator for the same load
+	rps := 3_600_000.0 / 86400
+	if calc := DefaultCalculator().MonthlyCPUCost(1e6, rps); math.Abs(calc-want) > 1e-9 {
+		t.Errorf("VCPUCalculator disagrees: $%.4f vs $%.4f", calc, want)
+	}
+	if annual := AnnualFromMonthly(got); math.Abs(annual-want*12) > 1e-12 {
+		t.Errorf("expected $%.4f/year, got $%.4f", want*12, annual)
+	}
+}
+
+func TestCalculateGCPressureImpact(t *testing.T) {
+	// Tripling 1 GiB/s at 5% GC adds 2 GiB/s: a tenth of a vCPU all month
+	got := CalculateGCPressureImpact(2<<30, 0.05, 0.0416)
+	want := 0.10 * 0.0416 * HoursPerMonth
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected $%.4f/month, got $%.4f", want, got)
+	}
+	// Linear in both the extra rate and the GC fraction
+	if half := CalculateGCPressureImpact(1<<30, 0.05, 0.0416); math.Abs(half-want/2) > 1e-12 {
+		t.Errorf("expected half the cost for half the extra allocation, got $%.4f", half)
+	}
+	if double := CalculateGCPressureImpact(2<<30, 0.10, 0.0416); math.Abs(double-want*2) > 1e-12 {
+		t.Errorf("expected twice the cost at twice the GC fraction, got $%.4f", double)
+	}
+	if none := CalculateGCPressureImpact(0, TypicalGCCPUFraction, 0.0416); none != 0 {
+		t.Errorf("expected $0 with no extra allocation, got $%.4f", none)
+	}
+}
+
+func TestScaleLabel(t *testing.T) {
+	for units, want := range map[float64]string{
+		1e6: "1M", 1e7: "10M", 1e8: "100M", 1e9: "1B", 2.5e9: "2.5B", 5e3: "5K", 42: "42",
+	} {
+		if got := ScaleLabel(units); got != want {
+			t.Errorf("ScaleLabel(%g): expected %q, got %q", units, want, got)
+		}
+	}
+}
+
+func TestScalingProjections_WriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	p := ScalingProjections{MonthlySavings: 2, BaseUnits: 1e6, Unit: "users"}
+	n, err := p.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+	out := buf.String()
+	// At the measured scale both models give the measured savings; at 10x
+	// linear is 10x
+	for _, row := range []string{
+		"1M users: $      2.0000/month linear, $      2.0000/month super-linear",
+		"10M users: $     20.0000/month linear",
+		"1B users:",
+	} {
+		if !strings.Contains(out, row) {
+			t.Errorf("missing %q in:\n%s", row, out)
+		}
+	}
+}
+
+func TestWriteInstanceScaling(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteInstanceScaling(&buf, 1.5); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "16 instances: $   24.0000/month, $    288.00/year") {
+		t.Errorf("expected 16 × $1.50 = $24/month, $288/year in:\n%s", out)
+	}
+	if got := strings.Count(out, "instances:"); got != len(InstanceCounts) {
+		t.Errorf("expected %d rows, got %d", len(InstanceCounts), got)
+	}
+}
+
+func TestWriteInstanceScaling_ReturnsWriteError(t *testing.T) {
+	if err := WriteInstanceScaling(failingWriter{}, 1); err == nil {
+		t.Error("expected the write error to be returned")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestSpotInstancePricing_DiscountsInstance(t *testing.T) {
+	var p PricingModel = SpotInstancePricing{OnDemandPrice: 0.0416, SpotDiscount: 0.7}
+	if got, want := p.CPUHourCost(), 0.0416*0.3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected $%.5f/vCPU-hour, got $%.5f", want, got)
+	}
+	if got, want := p.RAMGBMonthCost(), 3.75*0.3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("expected $%.4f/GB-month, got $%.4f", want, got)
+	}
+	if p.NetworkGBCost() != DefaultPricing().NetworkGBCost() {
+		t.Errorf("expected on-demand egress, got $%.3f/GB", p.NetworkGBCost())
+	}
+	if got := fmt.Sprint(p); got != "spot at 70% off $0.0416/vCPU-hour" {
+		t.Errorf("unexpected String() %q", got)
+	}
+}
+
+func TestCompareOnDemandVsSpot(t *testing.T) {
+	// Half a vCPU freed: $14.98/month on-demand, 30% of that on spot
+	r := CompareOnDemandVsSpot(0.5, 0.0416, 0.7)
+	if want := 0.5 * 0.0416 * HoursPerMonth; math.Abs(r.OnDemandMonthly-want) > 1e-9 {
+		t.Errorf("on-demand: expected $%.4f/month, got $%.4f", want, r.OnDemandMonthly)
+	}
+	if want := 0.3 * r.OnDemandMonthly; math.Abs(r.SpotMonthly-want) > 1e-9 {
+		t.Errorf("spot: expected $%.4f/month, got $%.4f", want, r.SpotMonthly)
+	}
+
+	onDemand, spot := r.PaybackMonths(1000)
+	if math.Abs(spot/onDemand-1/0.3) > 1e-9 {
+		t.Errorf("expected spot payback %.2fx longer, got %.1f vs %.1f months", 1/0.3, spot, onDemand)
+	}
+	if _, spot := CompareOnDemandVsSpot(0, 0.0416, 0.7).PaybackMonths(1000); !math.IsInf(spot, 1) {
+		t.Errorf("expected a zero saving never to pay back, got %.1f months", spot)
+	}
+}
+
+func TestSpotComparisonReport_WriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := CompareOnDemandVsSpot(0.5, 0.0416, 0.7).WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+	out := buf.String()
+	for _, want := range []string{"On-demand:          $   14.9760/month", "Spot ( 70% off):", "3.3x as long"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestMemoryBudget_MaxEntries(t *testing.T) {
+	budget := MemoryBudget{TotalRAMBytes: 4 << 30, OverheadFraction: 0.5}
+	if got := budget.UsableBytes(); got != 2<<30 {
+		t.Errorf("expected 2 GiB usable, got %d bytes", got)
+	}
+	if got := budget.MaxEntries(32); got != (2<<30)/32 {
+		t.Errorf("expected %d 32-byte entries, got %d", (2<<30)/32, got)
+	}
+	if got := budget.MaxEntries(0); got != 0 {
+		t.Errorf("expected 0 zero-byte entries, got %d", got)
+	}
+	// Out-of-range overheads are clamped to [0, 1]
+	for overhead, want := range map[float64]uint64{-1: 1000, 2: 0} {
+		b := MemoryBudget{TotalRAMBytes: 1000, OverheadFraction: overhead}
+		if got := b.UsableBytes(); got != want {
+			t.Errorf("overhead %.0f: expected %d usable bytes, got %d", overhead, want, got)
+		}
+	}
+}
+
+func TestCompareStructCapacity(t *testing.T) {
+	// Day 1: BadUser is 32 bytes, GoodUser 24
+	c := CompareStructCapacity(MemoryBudget{TotalRAMBytes: 4 << 30, OverheadFraction: 0.5}, 32, 24)
+	if c.BeforeEntries != 67_108_864 || c.AfterEntries != 89_478_485 {
+		t.Errorf("expected 67108864 and 89478485 entries, got %d and %d", c.BeforeEntries, c.AfterEntries)
+	}
+	if math.Abs(c.CapacityGain()-1.0/3) > 1e-6 {
+		t.Errorf("expected a 33.3%% capacity gain, got %.4f", c.CapacityGain())
+	}
+	if c.CostReduction() != 0.25 {
+		t.Errorf("expected a 25%% cost reduction, got %.4f", c.CostReduction())
+	}
+	if gain := CompareStructCapacity(MemoryBudget{TotalRAMBytes: 16}, 32, 24).CapacityGain(); gain != 0 {
+		t.Errorf("expected no gain when nothing fits, got %.2f", gain)
+	}
+
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned (%d, %v), wrote %d bytes", n, err, buf.Len())
+	}
+	for _, want := range []string{"4.0 GiB, 50% overhead", "67108864 entries", "+33.3%", "25.0% per entry"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cost
+
+import (
+	"fmt"
+	"io"
+)
+
+// MemoryBudget answers "how many of X fit in N GB of RAM?". TotalRAMBytes
+// is the instance's or container's memory; OverheadFraction is the share
+// of it that can't hold entries: the runtime, other data, and GC
+// headroom. With the default GOGC=100 the heap grows to twice the live
+// data before a collection, so 0.5 is a reasonable start for a Go cache.
+type MemoryBudget struct {
+	TotalRAMBytes    uint64
+	OverheadFraction float64
+}
+
+// UsableBytes returns the part of the budget left for entries.
+// OverheadFraction is clamped to [0, 1].
+func (b MemoryBudget) UsableBytes() uint64 {
+	overhead := min(max(b.OverheadFraction, 0), 1)
+	return uint64(float64(b.TotalRAMBytes) * (1 - overhead))
+}
+
+// MaxEntries returns how many entryBytes-sized entries fit in the usable
+// part of the budget. An entry of zero bytes returns 0, not infinity.
+func (b MemoryBudget) MaxEntries(entryBytes uint64) uint64 {
+	if entryBytes == 0 {
+		return 0
+	}
+	return b.UsableBytes() / entryBytes
+}
+
+func (b MemoryBudget) String() string {
+	return fmt.Sprintf("%.1f GiB, %.0f%% overhead", float64(b.TotalRAMBytes)/(1024*1024*1024), b.OverheadFraction*100)
+}
+
+// CapacityComparison is how many entries a budget holds before and after
+// an optimization shrinks each one.
+type CapacityComparison struct {
+	Budget        MemoryBudget
+	BeforeBytes   uint64
+	AfterBytes    uint64
+	BeforeEntries uint64
+	AfterEntries  uint64
+}
+
+// CompareStructCapacity compares how many before- and after-sized entries
+// fit in budget. Shrinking a 32-byte struct to 24 bytes fits 33% more.
+func CompareStructCapacity(budget MemoryBudget, before, after uint64) CapacityComparison {
+	return CapacityComparison{
+		Budget:        budget,
+		BeforeBytes:   before,
+		AfterBytes:    after,
+		BeforeEntries: budget.MaxEntries(before),
+		AfterEntries:  budget.MaxEntries(after),
+	}
+}
+
+// CapacityGain returns the extra entries as a fraction of the original
+// count: 0.33 for 33% more. It is 0 when nothing fit before.
+func (c CapacityComparison) CapacityGain() float64 {
+	if c.BeforeEntries == 0 {
+		return 0
+	}
+	return float64(c.AfterEntries)/float64(c.BeforeEntries) - 1
+}
+
+// CostReduction returns the RAM saved per entry as a fraction: 0.25 for a
+// 32-byte entry shrunk to 24. It is the same change as CapacityGain, seen
+// as a smaller bill instead of more room.
+func (c CapacityComparison) CostReduction() float64 {
+	if c.BeforeBytes == 0 || c.AfterBytes > c.BeforeBytes {
+		return 0
+	}
+	return float64(c.BeforeBytes-c.AfterBytes) / float64(c.BeforeBytes)
+}
+
+// WriteTo prints the 📦 CAPACITY block: entries that fit before and after,
+// and the capacity gain next to the cost reduction.
+func (c CapacityComparison) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	fmt.Fprintf(cw, "📦 CAPACITY (%v):\n", c.Budget)
+	fmt.Fprintf(cw, "  • Before (%3d B each): %14d entries\n", c.BeforeBytes, c.BeforeEntries)
+	fmt.Fprintf(cw, "  • After  (%3d B each): %14d entries\n", c.AfterBytes, c.AfterEntries)
+	fmt.Fprintf(cw, "  Capacity gain: +%.1f%% in the same RAM; cost reduction: %.1f%% per entry\n",
+		c.CapacityGain()*100, c.CostReduction()*100)
+	return cw.n, cw.err
+}
@@ -0,0 +1,128 @@
+package cost
+
+import "fmt"
+
+// PricingModel is a cloud provider's list prices for the resources the
+// daily analyses turn savings into.
+type PricingModel interface {
+	// CPUHourCost is the price of one vCPU for one hour.
+	CPUHourCost() float64
+	// RAMGBMonthCost is the price of one GB of RAM for a 30-day month.
+	RAMGBMonthCost() float64
+	// NetworkGBCost is the price of one GB of internet egress.
+	NetworkGBCost() float64
+}
+
+// instanceRates are on-demand Linux prices. Unless noted, CPU is the
+// hourly instance price divided by its vCPUs and RAM is the monthly
+// instance price divided by its GB.
+type instanceRates struct {
+	cpuHour    float64
+	ramGBMonth float64
+}
+
+// DefaultPricing returns the AWS t3.medium prices used throughout the
+// daily analyses.
+func DefaultPricing() AWSPricing {
+	return AWSPricing{InstanceType: "t3.medium"}
+}
+
+// AWSPricing prices EC2 in us-east-1. Unknown or empty instance types use
+// t3.medium.
+type AWSPricing struct {
+	InstanceType string
+}
+
+var awsRates = map[string]instanceRates{
+	// The series' historical figures: the full $0.0416 hourly price per
+	// vCPU and $30/month over 8 GB, kept so earlier days stay reproducible
+	"t3.medium": {cpuHour: 0.0416, ramGBMonth: 3.75},
+	"m6i.large": {cpuHour: 0.048, ramGBMonth: 8.64},  // $0.096/h, 2 vCPU, 8 GB
+	"c6i.large": {cpuHour: 0.0425, ramGBMonth: 15.3}, // $0.085/h, 2 vCPU, 4 GB
+}
+
+func (p AWSPricing) rates() instanceRates {
+	if r, ok := awsRates[p.InstanceType]; ok {
+		return r
+	}
+	return awsRates["t3.medium"]
+}
+
+func (p AWSPricing) CPUHourCost() float64    { return p.rates().cpuHour }
+func (p AWSPricing) RAMGBMonthCost() float64 { return p.rates().ramGBMonth }
+
+// NetworkGBCost is the first-10-TB internet egress tier.
+func (p AWSPricing) NetworkGBCost() float64 { return 0.09 }
+
+func (p AWSPricing) String() string {
+	it := p.InstanceType
+	if _, ok := awsRates[it]; !ok {
+		it = "t3.medium"
+	}
+	return fmt.Sprintf("AWS us-east-1 %s", it)
+}
+
+// GCPPricing prices Compute Engine in us-central1. Unknown or empty
+// machine types use e2-standard-2.
+type GCPPricing struct {
+	MachineType string
+}
+
+var gcpRates = map[string]instanceRates{
+	"e2-standard-2": {cpuHour: 0.0335, ramGBMonth: 6.03}, // $0.067/h, 2 vCPU, 8 GB
+	"n2-standard-2": {cpuHour: 0.0486, ramGBMonth: 8.74}, // $0.0971/h, 2 vCPU, 8 GB
+}
+
+func (p GCPPricing) rates() instanceRates {
+	if r, ok := gcpRates[p.MachineType]; ok {
+		return r
+	}
+	return gcpRates["e2-standard-2"]
+}
+
+func (p GCPPricing) CPUHourCost() float64    { return p.rates().cpuHour }
+func (p GCPPricing) RAMGBMonthCost() float64 { return p.rates().ramGBMonth }
+
+// NetworkGBCost is the premium-tier first-1-TB internet egress rate.
+func (p GCPPricing) NetworkGBCost() float64 { return 0.12 }
+
+func (p GCPPricing) String() string {
+	mt := p.MachineType
+	if _, ok := gcpRates[mt]; !ok {
+		mt = "e2-standard-2"
+	}
+	return fmt.Sprintf("GCP us-central1 %s", mt)
+}
+
+// AzurePricing prices Virtual Machines in East US. Unknown or empty sizes
+// use B2s.
+type AzurePricing struct {
+	VMSize string
+}
+
+var azureRates = map[string]instanceRates{
+	"B2s":    {cpuHour: 0.0208, ramGBMonth: 7.49},  // $0.0416/h, 2 vCPU, 4 GB
+	"D2s_v5": {cpuHour: 0.048, ramGBMonth: 8.64},   // $0.096/h, 2 vCPU, 8 GB
+	"F2s_v2": {cpuHour: 0.0423, ramGBMonth: 15.23}, // $0.0846/h, 2 vCPU, 4 GB
+}
+
+func (p AzurePricing) rates() instanceRates {
+	if r, ok := azureRates[p.VMSize]; ok {
+		return r
+	}
+	return azureRates["B2s"]
+}
+
+func (p AzurePricing) CPUHourCost() float64    { return p.rates().cpuHour }
+func (p AzurePricing) RAMGBMonthCost() float64 { return p.rates().ramGBMonth }
+
+// NetworkGBCost is the first-10-TB internet egress tier.
+func (p AzurePricing) NetworkGBCost() float64 { return 0.087 }
+
+func (p AzurePricing) String() string {
+	size := p.VMSize
+	if _, ok := azureRates[size]; !ok {
+		size = "B2s"
+	}
+	return fmt.Sprintf("Azure East US %s", size)
+}
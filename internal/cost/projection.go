@@ -0,0 +1,48 @@
+package cost
+
+import "math"
+
+// Scaling exponents for the projection constructors. 1.3 models GC work
+// growing faster than the heap it scans; 0.8 models fixed costs (instance
+// overhead, connection pools) being shared as usage grows.
+const (
+	LinearExponent      = 1.0
+	SublinearExponent   = 0.8
+	SuperlinearExponent = 1.3
+)
+
+// CostProjection extrapolates savings measured at one scale to others as
+// BaseSavings × units^ScalingExponent, where units is a multiple of the
+// measured scale (1 = as measured, 10 = ten times the load).
+type CostProjection struct {
+	BaseSavings float64
+	// ScalingExponent is 1.0 when zero, so a literal with only
+	// BaseSavings set projects linearly.
+	ScalingExponent float64
+}
+
+// LinearProjection assumes savings grow in proportion to scale, the
+// assumption behind every `savings * users / 1_000_000` in the series.
+func LinearProjection(baseSavings float64) CostProjection {
+	return CostProjection{BaseSavings: baseSavings, ScalingExponent: LinearExponent}
+}
+
+// SublinearProjection assumes savings grow slower than scale.
+func SublinearProjection(baseSavings float64) CostProjection {
+	return CostProjection{BaseSavings: baseSavings, ScalingExponent: SublinearExponent}
+}
+
+// SuperlinearProjection assumes savings grow faster than scale, the
+// realistic worst case for memory: a bigger heap means longer GC cycles.
+func SuperlinearProjection(baseSavings float64) CostProjection {
+	return CostProjection{BaseSavings: baseSavings, ScalingExponent: SuperlinearExponent}
+}
+
+// ProjectAt returns the savings at units times the measured scale.
+func (p CostProjection) ProjectAt(units float64) float64 {
+	exp := p.ScalingExponent
+	if exp == 0 {
+		exp = LinearExponent
+	}
+	return p.BaseSavings * math.Pow(units, exp)
+}
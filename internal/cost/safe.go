@@ -0,0 +1,47 @@
+package cost
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	// ErrPrecisionLoss means a value has more significant bits than a
+	// float64 mantissa (53) can hold, so converting it would round.
+	ErrPrecisionLoss = errors.New("cost: value exceeds float64 integer precision")
+	// ErrInfinity means a calculation overflowed to ±Inf.
+	ErrInfinity = errors.New("cost: result is infinite")
+	// ErrNaN means a calculation produced or received NaN.
+	ErrNaN = errors.New("cost: result is NaN")
+)
+
+// MaxExactDuration is the longest duration (~104 days) whose nanosecond
+// count converts to float64 without rounding.
+const MaxExactDuration = time.Duration(1 << 53)
+
+// SafeDurationToFloat64 returns d in nanoseconds as a float64. An int64
+// can never come near math.MaxFloat64, so the hazard is the 53-bit
+// mantissa: beyond ±MaxExactDuration the conversion silently rounds, and
+// ErrPrecisionLoss is returned along with the rounded value.
+func SafeDurationToFloat64(d time.Duration) (float64, error) {
+	f := float64(d.Nanoseconds())
+	if d > MaxExactDuration || d < -MaxExactDuration {
+		return f, ErrPrecisionLoss
+	}
+	return f, nil
+}
+
+// CheckedFloat64Mul returns a*b, or ErrInfinity / ErrNaN when the product
+// is not a finite number. The product is returned either way so NaN and
+// Inf still propagate to callers that ignore the error.
+func CheckedFloat64Mul(a, b float64) (float64, error) {
+	p := a * b
+	switch {
+	case math.IsNaN(p):
+		return p, ErrNaN
+	case math.IsInf(p, 0):
+		return p, ErrInfinity
+	}
+	return p, nil
+}
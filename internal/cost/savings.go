@@ -0,0 +1,47 @@
+package cost
+
+import "time"
+
+// DaysPerMonth and MonthsPerYear match HoursPerMonth: every analysis in
+// the series bills a 30-day month and a 12-month year.
+const (
+	DaysPerMonth  = 30
+	MonthsPerYear = 12
+)
+
+// MemorySavingsMonthly returns what savedBytes of RAM cost per month at
+// pricePerGBMonth, with GB meaning 1024³ bytes as in the daily analyses.
+func MemorySavingsMonthly(savedBytes uint64, pricePerGBMonth float64) float64 {
+	return float64(savedBytes) / (1024 * 1024 * 1024) * pricePerGBMonth
+}
+
+// CPUSavingsMonthly returns what it saves per month to spend
+// timeSavedPerRequest less CPU on each of requestsPerDay requests, at
+// vCPUHourPrice per vCPU-hour.
+func CPUSavingsMonthly(timeSavedPerRequest time.Duration, requestsPerDay float64, vCPUHourPrice float64) float64 {
+	cpuHoursPerDay := timeSavedPerRequest.Seconds() * requestsPerDay / 3600
+	return cpuHoursPerDay * vCPUHourPrice * DaysPerMonth
+}
+
+// TypicalGCCPUFraction is the share of a vCPU a Go service's collector
+// commonly uses per GiB/s it allocates: runtime.MemStats.GCCPUFraction of
+// about 0.05 at that rate with the default GOGC=100.
+const TypicalGCCPUFraction = 0.05
+
+// CalculateGCPressureImpact returns the monthly cost of the extra GC CPU
+// caused by allocating extraAllocBytes more per second, at vCPUHourPrice.
+// gcCPUFraction is the collector's share of one vCPU per GiB/s allocated;
+// scale a fraction measured at another rate to 1 GiB/s first.
+//
+// GC work grows with the allocation rate, since the collector runs once
+// per GOGC% of new heap. Tripling 1 GiB/s at 0.05 therefore adds 2 GiB/s
+// and 0.10 of a vCPU.
+func CalculateGCPressureImpact(extraAllocBytes uint64, gcCPUFraction float64, vCPUHourPrice float64) float64 {
+	extraVCPUs := float64(extraAllocBytes) / (1024 * 1024 * 1024) * gcCPUFraction
+	return extraVCPUs * vCPUHourPrice * HoursPerMonth
+}
+
+// AnnualFromMonthly scales a monthly figure to a year.
+func AnnualFromMonthly(monthly float64) float64 {
+	return monthly * MonthsPerYear
+}
@@ -0,0 +1,85 @@
+package cost
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ProjectionScales are the unit counts every day projects its savings to,
+// as day-01 does for users.
+var ProjectionScales = []float64{1e6, 1e7, 1e8, 1e9}
+
+// InstanceCounts are the fleet sizes shown for horizontally scaled services.
+var InstanceCounts = []int{2, 4, 8, 16}
+
+// ScaleLabel formats a unit count the way the projection tables show it:
+// 1e6 is "1M", 1e8 is "100M" and 1e9 is "1B".
+func ScaleLabel(units float64) string {
+	switch {
+	case units >= 1e9:
+		return strconv.FormatFloat(units/1e9, 'f', -1, 64) + "B"
+	case units >= 1e6:
+		return strconv.FormatFloat(units/1e6, 'f', -1, 64) + "M"
+	case units >= 1e3:
+		return strconv.FormatFloat(units/1e3, 'f', -1, 64) + "K"
+	}
+	return strconv.FormatFloat(units, 'f', -1, 64)
+}
+
+// ScalingProjections extrapolates monthly savings measured at BaseUnits
+// (1M users, 4.32B requests/day, ...) to each of ProjectionScales.
+type ScalingProjections struct {
+	MonthlySavings float64
+	BaseUnits      float64
+	Unit           string // Plural noun for the table, e.g. "users"
+}
+
+// WriteTo prints the 📈 SCALING PROJECTIONS block: one row per scale with
+// linear and super-linear estimates.
+func (s ScalingProjections) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	fmt.Fprintln(cw, "📈 SCALING PROJECTIONS:")
+	fmt.Fprintln(cw, "  Linear assumes savings scale with load; super-linear (^1.3) models")
+	fmt.Fprintln(cw, "  costs growing faster than load, such as GC work on a bigger heap:")
+
+	linear := LinearProjection(s.MonthlySavings)
+	superlinear := SuperlinearProjection(s.MonthlySavings)
+	for _, scale := range ProjectionScales {
+		units := scale / s.BaseUnits
+		fmt.Fprintf(cw, "  • %4s %s: $%12.4f/month linear, $%12.4f/month super-linear\n",
+			ScaleLabel(scale), s.Unit, linear.ProjectAt(units), superlinear.ProjectAt(units))
+	}
+	return cw.n, cw.err
+}
+
+// WriteInstanceScaling prints what perInstanceMonthly savings add up to
+// across each of InstanceCounts identical instances.
+func WriteInstanceScaling(w io.Writer, perInstanceMonthly float64) error {
+	cw := &countingWriter{w: w}
+	fmt.Fprintln(cw, "🖥️  MULTI-INSTANCE SCALING:")
+	for _, n := range InstanceCounts {
+		monthly := perInstanceMonthly * float64(n)
+		fmt.Fprintf(cw, "  • %2d instances: $%10.4f/month, $%10.2f/year\n",
+			n, monthly, AnnualFromMonthly(monthly))
+	}
+	return cw.err
+}
+
+// countingWriter keeps the first write error so a block of Fprint calls
+// can be checked once at the end.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,82 @@
+package cost
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// SpotInstancePricing prices spot capacity as a discount on on-demand:
+// OnDemandPrice is the on-demand price of one vCPU for one hour, and
+// SpotDiscount the fraction taken off it, typically 0.6-0.7.
+//
+// The discount applies to the whole instance, so RAM is DefaultPricing's
+// figure with the same discount. Egress is billed the same either way.
+type SpotInstancePricing struct {
+	OnDemandPrice float64
+	SpotDiscount  float64
+}
+
+func (p SpotInstancePricing) spotFactor() float64 { return 1 - p.SpotDiscount }
+
+func (p SpotInstancePricing) CPUHourCost() float64 { return p.OnDemandPrice * p.spotFactor() }
+
+func (p SpotInstancePricing) RAMGBMonthCost() float64 {
+	return DefaultPricing().RAMGBMonthCost() * p.spotFactor()
+}
+
+func (p SpotInstancePricing) NetworkGBCost() float64 { return DefaultPricing().NetworkGBCost() }
+
+func (p SpotInstancePricing) String() string {
+	return fmt.Sprintf("spot at %.0f%% off $%.4f/vCPU-hour", p.SpotDiscount*100, p.OnDemandPrice)
+}
+
+// SpotComparisonReport is what the same optimization is worth on
+// on-demand and on spot capacity.
+type SpotComparisonReport struct {
+	VCPUsSaved      float64
+	SpotDiscount    float64
+	OnDemandMonthly float64
+	SpotMonthly     float64
+}
+
+// CompareOnDemandVsSpot prices savings, the vCPUs an optimization frees
+// around the clock, at hourlyRate per vCPU-hour on-demand and at
+// spotDiscount off it on spot.
+func CompareOnDemandVsSpot(savings float64, hourlyRate float64, spotDiscount float64) SpotComparisonReport {
+	spot := SpotInstancePricing{OnDemandPrice: hourlyRate, SpotDiscount: spotDiscount}
+	return SpotComparisonReport{
+		VCPUsSaved:      savings,
+		SpotDiscount:    spotDiscount,
+		OnDemandMonthly: savings * hourlyRate * HoursPerMonth,
+		SpotMonthly:     savings * spot.CPUHourCost() * HoursPerMonth,
+	}
+}
+
+// PaybackMonths returns how many months of savings it takes to recover
+// effortCost, the price of doing the optimization, on each kind of
+// capacity. A saving of zero never pays back: +Inf.
+func (r SpotComparisonReport) PaybackMonths(effortCost float64) (onDemand, spot float64) {
+	payback := func(monthly float64) float64 {
+		if monthly <= 0 {
+			return math.Inf(1)
+		}
+		return effortCost / monthly
+	}
+	return payback(r.OnDemandMonthly), payback(r.SpotMonthly)
+}
+
+// WriteTo prints the 💸 ON-DEMAND VS SPOT block: monthly and annual savings
+// on each, and how much longer the work takes to pay back on spot.
+func (r SpotComparisonReport) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	fmt.Fprintln(cw, "💸 ON-DEMAND VS SPOT:")
+	fmt.Fprintf(cw, "  • On-demand:          $%10.4f/month, $%10.2f/year\n",
+		r.OnDemandMonthly, AnnualFromMonthly(r.OnDemandMonthly))
+	fmt.Fprintf(cw, "  • Spot (%3.0f%% off):    $%10.4f/month, $%10.2f/year\n",
+		r.SpotDiscount*100, r.SpotMonthly, AnnualFromMonthly(r.SpotMonthly))
+	if r.SpotMonthly > 0 {
+		fmt.Fprintf(cw, "  On spot the same work takes %.1fx as long to pay back\n", r.OnDemandMonthly/r.SpotMonthly)
+	}
+	return cw.n, cw.err
+}
@@ -0,0 +1,73 @@
+// Package gcbench measures the GC stop-the-world pauses a function causes,
+// without parsing GODEBUG=gctrace output or an execution trace.
+package gcbench
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// pauseRing is the length of the runtime.MemStats.PauseNs circular buffer.
+const pauseRing = uint32(len(runtime.MemStats{}.PauseNs))
+
+// GCPauseStats summarizes the stop-the-world pauses of the GC cycles that
+// finished during a call. A cycle's pause is both its stop-the-world phases
+// added together, as runtime.MemStats.PauseNs records it. All fields are
+// zero if no cycle finished.
+//
+// Avg is the PauseTotalNs delta over NumGC. Min and Max come from PauseNs,
+// which only holds the last 256 cycles: if more finished, they cover the
+// last 256.
+type GCPauseStats struct {
+	Min, Max, Avg time.Duration
+	NumGC         uint32
+}
+
+// MeasureGCPause calls fn once and returns the GC pauses it caused. It
+// collects garbage first, like bench.RunAndMeasure, so allocations made
+// before the call don't trigger a cycle inside it; that cycle isn't counted.
+func MeasureGCPause(fn func()) GCPauseStats {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return pauseStats(&before, &after)
+}
+
+// pauseStats returns the pauses of the cycles between two MemStats reads.
+func pauseStats(before, after *runtime.MemStats) GCPauseStats {
+	n := after.NumGC - before.NumGC
+	if n == 0 {
+		return GCPauseStats{}
+	}
+	s := GCPauseStats{
+		Avg:   time.Duration((after.PauseTotalNs - before.PauseTotalNs) / uint64(n)),
+		NumGC: n,
+	}
+	// Cycle k (counting from 1) is at PauseNs[(k+255)%256]
+	first := after.NumGC - min(n, pauseRing) + 1
+	for k := first; k <= after.NumGC; k++ {
+		pause := time.Duration(after.PauseNs[(k+pauseRing-1)%pauseRing])
+		if k == first || pause < s.Min {
+			s.Min = pause
+		}
+		if pause > s.Max {
+			s.Max = pause
+		}
+	}
+	return s
+}
+
+// AssertMaxPause fails t if any GC cycle that finished during fn paused the
+// world for longer than maxPause. It returns the measured stats for tests
+// that also log them.
+func AssertMaxPause(t testing.TB, maxPause time.Duration, fn func()) GCPauseStats {
+	t.Helper()
+	s := MeasureGCPause(fn)
+	if s.Max > maxPause {
+		t.Errorf("GC paused for %v in one of %d cycles, expected at most %v", s.Max, s.NumGC, maxPause)
+	}
+	return s
+}
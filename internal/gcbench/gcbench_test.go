@@ -0,0 +1,101 @@
+package gcbench
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// recordingTB captures Errorf calls instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// memStatsWithPauses returns the MemStats the runtime would report after
+// the given per-cycle pauses, in nanoseconds.
+func memStatsWithPauses(pauses ...uint64) runtime.MemStats {
+	var m runtime.MemStats
+	for _, p := range pauses {
+		m.PauseNs[m.NumGC%pauseRing] = p
+		m.PauseTotalNs += p
+		m.NumGC++
+	}
+	return m
+}
+
+func TestPauseStats(t *testing.T) {
+	before := memStatsWithPauses(500, 900)
+	after := memStatsWithPauses(500, 900, 300, 100, 200)
+
+	got := pauseStats(&before, &after)
+	want := GCPauseStats{Min: 100, Max: 300, Avg: 200, NumGC: 3}
+	if got != want {
+		t.Errorf("pauseStats = %+v, want %+v", got, want)
+	}
+
+	if got := pauseStats(&after, &after); got != (GCPauseStats{}) {
+		t.Errorf("no cycles: pauseStats = %+v, want zero", got)
+	}
+}
+
+func TestPauseStatsAcrossRingWrap(t *testing.T) {
+	// 300 cycles: the first 44 have fallen out of the ring, including the
+	// only 1 ns and 10 µs pauses
+	pauses := make([]uint64, 300)
+	for i := range pauses {
+		pauses[i] = 1000
+	}
+	pauses[0], pauses[1] = 1, 10_000
+	pauses[299] = 2000
+
+	var before runtime.MemStats
+	after := memStatsWithPauses(pauses...)
+	got := pauseStats(&before, &after)
+	if got.Min != 1000 || got.Max != 2000 || got.NumGC != 300 {
+		t.Errorf("pauseStats = %+v, want Min 1µs and Max 2µs from the last 256 of 300 cycles", got)
+	}
+	if wantAvg := time.Duration(after.PauseTotalNs / 300); got.Avg != wantAvg {
+		t.Errorf("Avg = %v, want %v over all 300 cycles", got.Avg, wantAvg)
+	}
+}
+
+func TestMeasureGCPause(t *testing.T) {
+	s := MeasureGCPause(func() {
+		for i := 0; i < 3; i++ {
+			runtime.GC()
+		}
+	})
+	if s.NumGC < 3 {
+		t.Fatalf("3 runtime.GC calls: NumGC = %d, want at least 3", s.NumGC)
+	}
+	if s.Min > s.Avg || s.Avg > s.Max {
+		t.Errorf("want Min ≤ Avg ≤ Max, got %+v", s)
+	}
+
+	if s := MeasureGCPause(func() {}); s.NumGC != 0 {
+		t.Errorf("no-op: %d GC cycles, want 0", s.NumGC)
+	}
+}
+
+func TestAssertMaxPause(t *testing.T) {
+	var r recordingTB
+	if s := AssertMaxPause(&r, time.Second, runtime.GC); s.NumGC == 0 || len(r.errors) != 0 {
+		t.Errorf("one GC against a max of 1s: got %+v, errors %q", s, r.errors)
+	}
+	if AssertMaxPause(&r, 0, func() {}); len(r.errors) != 0 {
+		t.Errorf("no GC against a max of 0: errors %q", r.errors)
+	}
+
+	AssertMaxPause(&r, 0, runtime.GC)
+	if len(r.errors) != 1 {
+		t.Errorf("one GC against a max of 0: expected one error, got %q", r.errors)
+	}
+}
@@ -0,0 +1,48 @@
+// Package interfacebench measures what calling a method through an
+// interface costs over calling it on the concrete type.
+package interfacebench
+
+// GoodUser mirrors day-01's GoodUser: 24 bytes, no padding before Name.
+type GoodUser struct {
+	ID     int32
+	Age    int8
+	Active bool
+	Name   string
+}
+
+// UserID returns the user's ID. It is small enough to be inlined when
+// called on a *GoodUser, and can't be when called through a UserReader
+// whose concrete type the compiler doesn't know.
+func (u *GoodUser) UserID() int32 {
+	return u.ID
+}
+
+// UserReader is the interface a caller would take instead of *GoodUser.
+type UserReader interface {
+	UserID() int32
+}
+
+// Calls is the number of method calls each benchmark op makes.
+const Calls = 1_000_000
+
+// SumDirect calls u.UserID Calls times on the concrete type.
+func SumDirect(u *GoodUser) int64 {
+	var sum int64
+	for i := 0; i < Calls; i++ {
+		sum += int64(u.UserID())
+	}
+	return sum
+}
+
+// SumInterface calls r.UserID Calls times through the interface. It is
+// kept out of line so a caller passing a *GoodUser can't let the compiler
+// see the concrete type and devirtualize the call.
+//
+//go:noinline
+func SumInterface(r UserReader) int64 {
+	var sum int64
+	for i := 0; i < Calls; i++ {
+		sum += int64(r.UserID())
+	}
+	return sum
+}
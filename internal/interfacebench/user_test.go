@@ -0,0 +1,91 @@
+package interfacebench
+
+import (
+	"sort"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+var user = &GoodUser{ID: 42, Age: 30, Active: true, Name: "alice"}
+
+// Global variable to prevent compiler optimizations
+var sink int64
+
+// ========== DISPATCH BENCHMARKS ==========
+
+// Each op is Calls (1M) calls of UserID.
+
+func Benchmark_DirectCall(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sink = SumDirect(user)
+	}
+}
+
+func Benchmark_InterfaceCall(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sink = SumInterface(user)
+	}
+}
+
+// ========== CORRECTNESS TESTS ==========
+
+func Test_GoodUserLayout(t *testing.T) {
+	if size := unsafe.Sizeof(GoodUser{}); size != 24 {
+		t.Errorf("GoodUser is %d bytes, expected 24 like day-01's", size)
+	}
+}
+
+func Test_DirectAndInterfaceAgree(t *testing.T) {
+	want := int64(user.ID) * Calls
+	if got := SumDirect(user); got != want {
+		t.Errorf("SumDirect = %d, expected %d", got, want)
+	}
+	if got := SumInterface(user); got != want {
+		t.Errorf("SumInterface = %d, expected %d", got, want)
+	}
+}
+
+func Test_CallsDoNotAllocate(t *testing.T) {
+	if allocs := testing.AllocsPerRun(5, func() { sink = SumDirect(user) }); allocs != 0 {
+		t.Errorf("SumDirect: %.0f allocs, expected 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(5, func() { sink = SumInterface(user) }); allocs != 0 {
+		t.Errorf("SumInterface: %.0f allocs, expected 0", allocs)
+	}
+}
+
+// Test_InterfaceDispatchNSPerOp times both loops alternately and takes the
+// median extra cost per interface call. An indirect call through the itab
+// is about 1-2 ns; 5 ns leaves room for a noisy host and still catches a
+// call that allocates or converts its receiver each time.
+func Test_InterfaceDispatchNSPerOp(t *testing.T) {
+	if testing.Short() {
+		t.Skip("timing test")
+	}
+	const runs = 15
+
+	overheads := make([]float64, runs)
+	var directNs, ifaceNs float64
+	for i := range overheads {
+		start := time.Now()
+		sink = SumDirect(user)
+		d := float64(time.Since(start).Nanoseconds()) / Calls
+		start = time.Now()
+		sink = SumInterface(user)
+		f := float64(time.Since(start).Nanoseconds()) / Calls
+		overheads[i] = f - d
+		directNs += d / runs
+		ifaceNs += f / runs
+	}
+	sort.Float64s(overheads)
+	overhead := overheads[runs/2]
+
+	t.Logf("direct %.2f ns/call, interface %.2f ns/call, median overhead %.2f ns/call",
+		directNs, ifaceNs, overhead)
+	if overhead > 5 {
+		t.Errorf("interface dispatch costs %.2f ns/call over a direct call, expected at most 5 ns", overhead)
+	}
+}
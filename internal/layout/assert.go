@@ -0,0 +1,101 @@
+package layout
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// maxPermutedFields is the most fields AssertStructOptimized tries every
+// order of: 8! is 40,320 layouts, a few milliseconds. Wider structs get
+// the greedy order instead.
+const maxPermutedFields = 8
+
+// AssertStructOptimized fails t if v's struct type could be smaller with
+// its fields in another order. v may be a struct or a pointer to one. It
+// returns the smallest size it found, for tests that also log it.
+//
+// Up to maxPermutedFields fields, every order is laid out and the
+// smallest wins, so the check doesn't rely on the sorting rule it is
+// meant to back up. Past that, the struct is compared with optimalOrder,
+// and a warning is logged if the declared order differs from it even
+// though the size is the same.
+func AssertStructOptimized(t testing.TB, v interface{}, name string) uintptr {
+	t.Helper()
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		t.Errorf("%s: expected a struct, got %T", name, v)
+		return 0
+	}
+	r := AnalyzeStructLayout(v)
+	align := uintptr(typ.Align())
+
+	var best []FieldLayout
+	var bestSize uintptr
+	if len(r.Fields) <= maxPermutedFields {
+		best, bestSize = smallestPermutation(r.Fields, align)
+	} else {
+		best = optimalOrder(r.Fields)
+		bestSize = structSize(best, align)
+		if bestSize == r.Size && !sameOrder(best, r.Fields) {
+			t.Logf("%s: warning: %d fields, too many to try every order; the greedy order %s is also %d bytes",
+				name, len(r.Fields), fieldNames(best), bestSize)
+		}
+	}
+
+	if bestSize < r.Size {
+		t.Errorf("%s: %d bytes, but %s fits in %d", name, r.Size, fieldNames(best), bestSize)
+		return bestSize
+	}
+	return r.Size
+}
+
+// smallestPermutation lays fields out in every order and returns the first
+// smallest one. It uses Heap's algorithm, one swap per permutation.
+func smallestPermutation(fields []FieldLayout, align uintptr) ([]FieldLayout, uintptr) {
+	perm := append([]FieldLayout(nil), fields...)
+	best := append([]FieldLayout(nil), perm...)
+	bestSize := structSize(perm, align)
+
+	c := make([]int, len(perm))
+	for i := 1; i < len(perm); {
+		if c[i] >= i {
+			c[i] = 0
+			i++
+			continue
+		}
+		if i%2 == 0 {
+			perm[0], perm[i] = perm[i], perm[0]
+		} else {
+			perm[c[i]], perm[i] = perm[i], perm[c[i]]
+		}
+		if size := structSize(perm, align); size < bestSize {
+			bestSize = size
+			copy(best, perm)
+		}
+		c[i]++
+		i = 1
+	}
+	return best, bestSize
+}
+
+func sameOrder(a, b []FieldLayout) bool {
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldNames formats fields as "{Name, ID, Age}".
+func fieldNames(fields []FieldLayout) string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return "{" + strings.Join(names, ", ") + "}"
+}
@@ -0,0 +1,117 @@
+// Package layout reports the in-memory layout of Go structs at run time,
+// the reflection counterpart of pkg/analyze's source-level padding check.
+package layout
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldLayout is one field of a struct as the compiler laid it out.
+type FieldLayout struct {
+	Name         string
+	Type         string
+	Size         uintptr
+	Align        uintptr
+	Offset       uintptr // Same value unsafe.Offsetof returns for the field
+	PaddingAfter uintptr // Bytes between this field's end and the next field (or the struct's end)
+}
+
+// LayoutReport describes a struct type's layout and how much a field
+// reorder would save.
+type LayoutReport struct {
+	Name         string
+	Size         uintptr // unsafe.Sizeof
+	Fields       []FieldLayout
+	PaddingBytes uintptr // Sum of PaddingAfter over all fields
+	OptimalOrder []string
+	OptimalSize  uintptr // Size with fields in OptimalOrder
+}
+
+// SavingsPerStruct is how many bytes each value saves in OptimalOrder.
+func (r LayoutReport) SavingsPerStruct() uintptr {
+	return r.Size - r.OptimalSize
+}
+
+// AnalyzeStructLayout reports the layout of v's type. v may be a struct or
+// a pointer to one; for any other type the report has no fields.
+//
+// Offsets come from reflect.StructField.Offset, which is what
+// unsafe.Offsetof returns for the same field, so no instance is inspected.
+func AnalyzeStructLayout(v interface{}) LayoutReport {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return LayoutReport{}
+	}
+
+	r := LayoutReport{Name: t.Name(), Size: t.Size(), OptimalSize: t.Size()}
+	if t.Kind() != reflect.Struct {
+		return r
+	}
+
+	r.Fields = make([]FieldLayout, t.NumField())
+	for i := range r.Fields {
+		f := t.Field(i)
+		r.Fields[i] = FieldLayout{
+			Name:   f.Name,
+			Type:   f.Type.String(),
+			Size:   f.Type.Size(),
+			Align:  uintptr(f.Type.Align()),
+			Offset: f.Offset,
+		}
+	}
+	for i := range r.Fields {
+		end := r.Size
+		if i+1 < len(r.Fields) {
+			end = r.Fields[i+1].Offset
+		}
+		r.Fields[i].PaddingAfter = end - r.Fields[i].Offset - r.Fields[i].Size
+		r.PaddingBytes += r.Fields[i].PaddingAfter
+	}
+
+	optimal := optimalOrder(r.Fields)
+	r.OptimalOrder = make([]string, len(optimal))
+	for i, f := range optimal {
+		r.OptimalOrder[i] = f.Name
+	}
+	r.OptimalSize = structSize(optimal, uintptr(t.Align()))
+	return r
+}
+
+// optimalOrder sorts fields from largest to smallest alignment (ties
+// broken by size), the same rule day-01 teaches and pkg/analyze applies.
+func optimalOrder(fields []FieldLayout) []FieldLayout {
+	sorted := append([]FieldLayout(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Align != sorted[j].Align {
+			return sorted[i].Align > sorted[j].Align
+		}
+		return sorted[i].Size > sorted[j].Size
+	})
+
+	// Zero-size fields at the end force padding, so keep them first
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Size == 0 && sorted[j].Size != 0
+	})
+	return sorted
+}
+
+// structSize lays fields out in order the way the gc compiler does.
+func structSize(fields []FieldLayout, align uintptr) uintptr {
+	var off uintptr
+	for _, f := range fields {
+		off = alignUp(off, f.Align) + f.Size
+	}
+	// A trailing zero-size field gets a byte so &s.f stays inside s
+	if n := len(fields); n > 0 && fields[n-1].Size == 0 && off > 0 {
+		off++
+	}
+	return alignUp(off, align)
+}
+
+func alignUp(n, align uintptr) uintptr {
+	return (n + align - 1) &^ (align - 1)
+}
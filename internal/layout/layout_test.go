@@ -0,0 +1,298 @@
+package layout
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// Same shapes as day-01
+type badUser struct {
+	ID     int32
+	Active bool
+	Name   string
+	Age    int8
+}
+
+type goodUser struct {
+	ID     int32
+	Age    int8
+	Active bool
+	Name   string
+}
+
+func TestAnalyzeStructLayout_BadUser(t *testing.T) {
+	r := AnalyzeStructLayout(badUser{})
+
+	if r.Size != unsafe.Sizeof(badUser{}) {
+		t.Errorf("expected size %d, got %d", unsafe.Sizeof(badUser{}), r.Size)
+	}
+	var u badUser
+	wantOffsets := []uintptr{
+		unsafe.Offsetof(u.ID), unsafe.Offsetof(u.Active),
+		unsafe.Offsetof(u.Name), unsafe.Offsetof(u.Age),
+	}
+	for i, f := range r.Fields {
+		if f.Offset != wantOffsets[i] {
+			t.Errorf("%s: expected offset %d, got %d", f.Name, wantOffsets[i], f.Offset)
+		}
+	}
+
+	// On 64-bit: 3 bytes after Active, 7 after Age
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		want := []uintptr{0, 3, 0, 7}
+		for i, f := range r.Fields {
+			if f.PaddingAfter != want[i] {
+				t.Errorf("%s: expected %d padding bytes after, got %d", f.Name, want[i], f.PaddingAfter)
+			}
+		}
+		if r.PaddingBytes != 10 {
+			t.Errorf("expected 10 padding bytes, got %d", r.PaddingBytes)
+		}
+	}
+
+	if r.OptimalSize != unsafe.Sizeof(goodUser{}) {
+		t.Errorf("expected optimal size %d, got %d", unsafe.Sizeof(goodUser{}), r.OptimalSize)
+	}
+	if want := []string{"Name", "ID", "Active", "Age"}; !reflect.DeepEqual(r.OptimalOrder, want) {
+		t.Errorf("expected order %v, got %v", want, r.OptimalOrder)
+	}
+	if r.SavingsPerStruct() != unsafe.Sizeof(badUser{})-unsafe.Sizeof(goodUser{}) {
+		t.Errorf("unexpected savings %d", r.SavingsPerStruct())
+	}
+}
+
+func TestAnalyzeStructLayout_AlreadyOptimal(t *testing.T) {
+	r := AnalyzeStructLayout(&goodUser{})
+	if r.Name != "goodUser" {
+		t.Errorf("expected pointer to be dereferenced, got name %q", r.Name)
+	}
+	if r.SavingsPerStruct() != 0 {
+		t.Errorf("expected no savings, got %d", r.SavingsPerStruct())
+	}
+}
+
+func TestAnalyzeStructLayout_ZeroSizeField(t *testing.T) {
+	type trailing struct {
+		N   int64
+		Tag struct{}
+	}
+	r := AnalyzeStructLayout(trailing{})
+	if r.Size != unsafe.Sizeof(trailing{}) {
+		t.Fatalf("expected size %d, got %d", unsafe.Sizeof(trailing{}), r.Size)
+	}
+	if r.OptimalSize != unsafe.Sizeof(struct {
+		Tag struct{}
+		N   int64
+	}{}) {
+		t.Errorf("moving the zero-size field first should drop the trailing padding, got %d", r.OptimalSize)
+	}
+}
+
+func TestAnalyzeStructLayout_NonStruct(t *testing.T) {
+	r := AnalyzeStructLayout(42)
+	if len(r.Fields) != 0 || r.Size != unsafe.Sizeof(0) {
+		t.Errorf("unexpected report for int: %+v", r)
+	}
+	if r := AnalyzeStructLayout(nil); r.Size != 0 {
+		t.Errorf("unexpected report for nil: %+v", r)
+	}
+}
+
+func TestVisualizeLayout_BoxesProportionalToSize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := VisualizeLayout(badUser{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	top := lines[1]
+	if !strings.HasPrefix(top, "┌") || !strings.HasSuffix(top, "┐") {
+		t.Fatalf("expected a box top, got %q", top)
+	}
+
+	// One box per field and padding run, charsPerByte wide per byte
+	var widths []int
+	for _, box := range strings.Split(strings.Trim(top, "┌┐"), "┬") {
+		widths = append(widths, len([]rune(box)))
+	}
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		// ID, Active, padding, Name, Age, padding
+		want := []int{4, 1, 3, 16, 1, 7}
+		for i := range want {
+			want[i] *= charsPerByte
+		}
+		if !reflect.DeepEqual(widths, want) {
+			t.Errorf("expected box widths %v, got %v", want, widths)
+		}
+		if got := strings.Count(lines[2], "░"); got != 10*charsPerByte {
+			t.Errorf("expected %d padding characters, got %d", 10*charsPerByte, got)
+		}
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Name", "@ offset", "Total: " + strconv.Itoa(int(unsafe.Sizeof(badUser{}))) + " bytes"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestVisualizeLayout_ExpandsNestedStructOneLevel(t *testing.T) {
+	type inner struct {
+		Deep badUser
+		N    int64
+	}
+	type outer struct {
+		Flag  bool
+		Inner inner
+	}
+	var buf bytes.Buffer
+	if err := VisualizeLayout(&outer{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Inner.Deep ", "Inner.N "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected nested field %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Inner.Deep.") {
+		t.Errorf("expected expansion to stop one level deep:\n%s", out)
+	}
+	want := "@ offset " + strconv.Itoa(int(unsafe.Offsetof(outer{}.Inner)+unsafe.Offsetof(inner{}.N)))
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "  Inner.N ") && !strings.HasSuffix(line, want) {
+			t.Errorf("expected Inner.N %s, got %q", want, line)
+		}
+	}
+}
+
+func TestVisualizeLayout_NonStruct(t *testing.T) {
+	var buf bytes.Buffer
+	if err := VisualizeLayout(42, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "int is not a struct\n" {
+		t.Errorf("unexpected output %q", got)
+	}
+}
+
+// recordingTB captures Errorf and Logf calls instead of reporting them.
+type recordingTB struct {
+	testing.TB
+	errors, logs []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertStructOptimized_Permutations(t *testing.T) {
+	var r recordingTB
+	if got := AssertStructOptimized(&r, goodUser{}, "goodUser"); got != unsafe.Sizeof(goodUser{}) || len(r.errors) != 0 {
+		t.Errorf("goodUser: got %d bytes, errors %q", got, r.errors)
+	}
+	if AssertStructOptimized(&r, &goodUser{}, "*goodUser"); len(r.errors) != 0 {
+		t.Errorf("expected pointer to be dereferenced, got errors %q", r.errors)
+	}
+
+	got := AssertStructOptimized(&r, badUser{}, "badUser")
+	if got != unsafe.Sizeof(goodUser{}) {
+		t.Errorf("badUser: expected smallest size %d, got %d", unsafe.Sizeof(goodUser{}), got)
+	}
+	want := fmt.Sprintf("badUser: %d bytes, but ", unsafe.Sizeof(badUser{}))
+	if len(r.errors) != 1 || !strings.HasPrefix(r.errors[0], want) {
+		t.Errorf("expected one error starting %q, got %q", want, r.errors)
+	}
+	if len(r.logs) != 0 {
+		t.Errorf("expected no warnings below %d fields, got %q", maxPermutedFields, r.logs)
+	}
+}
+
+func TestAssertStructOptimized_ZeroSizeField(t *testing.T) {
+	type trailing struct {
+		N   int64
+		Tag struct{}
+	}
+	var r recordingTB
+	AssertStructOptimized(&r, trailing{}, "trailing")
+	if len(r.errors) != 1 {
+		t.Errorf("expected the trailing zero-size field to be caught, got errors %q", r.errors)
+	}
+}
+
+// Nine fields: one past what AssertStructOptimized permutes
+type wideSorted struct {
+	A, B    int64
+	C, D    int32
+	E, F    int16
+	G, H, I int8
+}
+
+type wideAscending struct {
+	G, H int8
+	E    int16
+	C    int32
+	A, B int64
+	D    int32
+	F    int16
+	I    int8
+}
+
+type wideUnsorted struct {
+	G    int8
+	A    int64
+	H    int8
+	B    int64
+	C, D int32
+	E, F int16
+	I    int8
+}
+
+func TestAssertStructOptimized_Greedy(t *testing.T) {
+	var r recordingTB
+	AssertStructOptimized(&r, wideSorted{}, "wideSorted")
+	if len(r.errors) != 0 || len(r.logs) != 0 {
+		t.Errorf("wideSorted: expected no errors or warnings, got %q, %q", r.errors, r.logs)
+	}
+
+	// Same size as the greedy order, but not that order: a warning only
+	AssertStructOptimized(&r, wideAscending{}, "wideAscending")
+	if len(r.errors) != 0 {
+		t.Errorf("wideAscending: expected no errors, got %q", r.errors)
+	}
+	if len(r.logs) != 1 || !strings.Contains(r.logs[0], "warning") {
+		t.Errorf("wideAscending: expected one warning, got %q", r.logs)
+	}
+
+	r = recordingTB{}
+	got := AssertStructOptimized(&r, wideUnsorted{}, "wideUnsorted")
+	if got != unsafe.Sizeof(wideSorted{}) {
+		t.Errorf("wideUnsorted: expected smallest size %d, got %d", unsafe.Sizeof(wideSorted{}), got)
+	}
+	want := fmt.Sprintf("wideUnsorted: %d bytes, but {A, B, C, D, E, F, G, H, I} fits in %d",
+		unsafe.Sizeof(wideUnsorted{}), unsafe.Sizeof(wideSorted{}))
+	if len(r.errors) != 1 || r.errors[0] != want {
+		t.Errorf("expected error %q, got %q", want, r.errors)
+	}
+}
+
+func TestAssertStructOptimized_NonStruct(t *testing.T) {
+	var r recordingTB
+	AssertStructOptimized(&r, 42, "int")
+	AssertStructOptimized(&r, nil, "nil")
+	want := []string{"int: expected a struct, got int", "nil: expected a struct, got <nil>"}
+	if !reflect.DeepEqual(r.errors, want) {
+		t.Errorf("expected errors %q, got %q", want, r.errors)
+	}
+}
@@ -0,0 +1,144 @@
+package layout
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// Diagrams are drawn at charsPerByte characters per byte, halved for
+// structs that would otherwise be wider than maxDiagramWidth.
+const (
+	charsPerByte    = 2
+	maxDiagramWidth = 120
+)
+
+// segment is one box in a layout diagram: a field, or a run of padding.
+type segment struct {
+	name   string // Empty for padding
+	typ    string
+	offset uintptr
+	size   uintptr
+}
+
+func (s segment) padding() bool { return s.name == "" }
+
+// VisualizeLayout draws the layout of v's type as a row of boxes, one per
+// field and one per run of padding (░), each as wide as the bytes it
+// covers, followed by a legend with every field's offset and the struct's
+// size and alignment. Fields that are themselves structs are expanded one
+// level deep. v may be a struct or a pointer to one.
+func VisualizeLayout(v interface{}, w io.Writer) error {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		_, err := fmt.Fprintf(w, "%v is not a struct\n", t)
+		return err
+	}
+
+	segs := layoutSegments(t, 0, "", 1)
+	scale := uintptr(charsPerByte)
+	if t.Size()*scale > maxDiagramWidth {
+		scale = 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d bytes):\n", t.Name(), t.Size())
+	writeDiagram(&b, segs, scale)
+
+	var padding uintptr
+	nameWidth := 0
+	for _, s := range segs {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(s.name))
+	}
+	for _, s := range segs {
+		if s.padding() {
+			padding += s.size
+			continue
+		}
+		fmt.Fprintf(&b, "  %-*s %-14s %3d bytes @ offset %d\n", nameWidth, s.name, s.typ, s.size, s.offset)
+	}
+	fmt.Fprintf(&b, "  Total: %d bytes, %d-byte aligned, %d padding bytes (░)\n", t.Size(), t.Align(), padding)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// layoutSegments lists t's fields and padding in memory order, at offsets
+// relative to base. Struct fields are expanded while depth > 0.
+func layoutSegments(t reflect.Type, base uintptr, prefix string, depth int) []segment {
+	segs := make([]segment, 0, 2*t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Kind() == reflect.Struct && f.Type.NumField() > 0 && depth > 0 {
+			segs = append(segs, layoutSegments(f.Type, base+f.Offset, prefix+f.Name+".", depth-1)...)
+		} else {
+			segs = append(segs, segment{
+				name:   prefix + f.Name,
+				typ:    f.Type.String(),
+				offset: base + f.Offset,
+				size:   f.Type.Size(),
+			})
+		}
+
+		end := t.Size()
+		if i+1 < t.NumField() {
+			end = t.Field(i + 1).Offset
+		}
+		if pad := end - f.Offset - f.Type.Size(); pad > 0 {
+			segs = append(segs, segment{
+				offset: base + f.Offset + f.Type.Size(),
+				size:   pad,
+			})
+		}
+	}
+	return segs
+}
+
+// writeDiagram draws the boxes: names on the first row, sizes on the
+// second. Zero-size fields have no bytes to draw and appear only in the
+// legend.
+func writeDiagram(b *strings.Builder, segs []segment, scale uintptr) {
+	top := make([]string, 0, len(segs))
+	names := make([]string, 0, len(segs))
+	sizes := make([]string, 0, len(segs))
+	bottom := make([]string, 0, len(segs))
+	for _, s := range segs {
+		width := int(s.size * scale)
+		if width == 0 {
+			continue
+		}
+		top = append(top, strings.Repeat("─", width))
+		bottom = append(bottom, strings.Repeat("─", width))
+		if s.padding() {
+			names = append(names, strings.Repeat("░", width))
+			sizes = append(sizes, strings.Repeat("░", width))
+			continue
+		}
+		names = append(names, fitLabel(s.name, width))
+		sizes = append(sizes, fitLabel(fmt.Sprint(s.size), width))
+	}
+	fmt.Fprintf(b, "┌%s┐\n", strings.Join(top, "┬"))
+	fmt.Fprintf(b, "│%s│\n", strings.Join(names, "│"))
+	fmt.Fprintf(b, "│%s│\n", strings.Join(sizes, "│"))
+	fmt.Fprintf(b, "└%s┘\n", strings.Join(bottom, "┴"))
+}
+
+// fitLabel centers label in width characters, truncating it with … when
+// it doesn't fit.
+func fitLabel(label string, width int) string {
+	n := utf8.RuneCountInString(label)
+	if n > width {
+		r := []rune(label)
+		if width == 1 {
+			return string(r[:1])
+		}
+		return string(r[:width-1]) + "…"
+	}
+	left := (width - n) / 2
+	return strings.Repeat(" ", left) + label + strings.Repeat(" ", width-n-left)
+}
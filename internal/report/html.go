@@ -0,0 +1,75 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+)
+
+// chartJSURL is where the page loads Chart.js from; everything else is
+// inline, so the file can be opened straight from disk.
+const chartJSURL = "https://cdn.jsdelivr.net/npm/chart.js@4"
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Cost-Aware Backend: {{len .Rows}} days compared</title>
+<script src="{{.ChartJS}}"></script>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 960px; margin: 2em auto; }
+table { border-collapse: collapse; width: 100%; margin-top: 2em; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.4em; text-align: right; }
+th:nth-child(2), td:nth-child(2) { text-align: left; }
+</style>
+</head>
+<body>
+<h1>💰 Cost-Aware Backend: multi-day comparison</h1>
+<canvas id="savings"></canvas>
+<table>
+<tr><th>Day</th><th>Topic</th><th>Best Time (ns/op)</th><th>Allocations Saved</th><th>Monthly $ Savings</th><th>Improvement</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Day}}</td><td>{{.Topic}}</td><td>{{if .BestNs}}{{printf "%.1f" .BestNs}}{{else}}-{{end}}</td><td>{{printf "%.1f" .AllocsSaved}}</td><td>${{printf "%.2f" .Monthly}}</td><td>{{.Sparkline}}</td></tr>
+{{- end}}
+<tr><th></th><th>Total</th><th></th><th></th><th>${{printf "%.2f" .Total}}</th><th></th></tr>
+</table>
+<script>
+new Chart(document.getElementById("savings"), {
+  type: "bar",
+  data: {
+    labels: {{.Labels}},
+    datasets: [{label: "Monthly $ savings", data: {{.Savings}}}]
+  },
+  options: {scales: {y: {beginAtZero: true, title: {display: true, text: "USD/month"}}}}
+});
+</script>
+</body>
+</html>
+`))
+
+// HTMLReport writes results as a standalone HTML page: a Chart.js bar
+// chart of each day's monthly savings above the same table MarkdownReport
+// writes. Only Chart.js itself is loaded from a CDN.
+func HTMLReport(results []bench.DayResult, w io.Writer) error {
+	rows := summarizeAll(results)
+	data := struct {
+		ChartJS string
+		Rows    []daySummary
+		Labels  []string
+		Savings []float64
+		Total   float64
+	}{
+		ChartJS: chartJSURL,
+		Rows:    rows,
+		Labels:  make([]string, len(rows)),
+		Savings: make([]float64, len(rows)),
+	}
+	for i, row := range rows {
+		data.Labels[i] = fmt.Sprintf("Day %d", row.Day)
+		data.Savings[i] = row.Monthly
+		data.Total += row.Monthly
+	}
+	return htmlTemplate.Execute(w, data)
+}
@@ -0,0 +1,109 @@
+// Package report lays the saved results of many days side by side, as a
+// Markdown table for the repo's READMEs or a standalone HTML page.
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+)
+
+// sparkLevels are the bar heights a sparkline is drawn with, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// daySummary is one row of a report: a day's results reduced to the
+// numbers worth comparing across days.
+type daySummary struct {
+	Day         int
+	Topic       string
+	BestNs      float64 // Fastest case's ns/op; 0 when the day has no benchmarks
+	AllocsSaved float64 // Baseline allocs/op minus the fewest any case made
+	Monthly     float64
+	Sparkline   string
+}
+
+// summarize reduces r to a row. As in a BenchmarkSuite, the first
+// benchmark is the baseline the others improved on.
+func summarize(r bench.DayResult) daySummary {
+	s := daySummary{Day: r.Day, Topic: r.Topic, Monthly: r.CostSavingsMonthly}
+	if len(r.Benchmarks) == 0 {
+		return s
+	}
+	baseline := r.Benchmarks[0]
+	s.BestNs = baseline.NsPerOp
+	fewest := baseline.AllocsPerOp
+	for _, b := range r.Benchmarks[1:] {
+		s.BestNs = min(s.BestNs, b.NsPerOp)
+		fewest = min(fewest, b.AllocsPerOp)
+	}
+	s.AllocsSaved = baseline.AllocsPerOp - fewest
+	s.Sparkline = sparkline(r.Benchmarks)
+	return s
+}
+
+// sparkline draws one bar per benchmark, its height the benchmark's
+// speedup over the baseline relative to the day's best speedup: the
+// fastest case is always █ and a 4x speedup leaves the baseline at ▃.
+func sparkline(benchmarks []bench.BenchmarkRecord) string {
+	baseline := benchmarks[0].NsPerOp
+	speedups := make([]float64, len(benchmarks))
+	for i, b := range benchmarks {
+		if b.NsPerOp > 0 {
+			speedups[i] = baseline / b.NsPerOp
+		}
+	}
+	best := slices.Max(speedups)
+
+	var sb strings.Builder
+	for _, s := range speedups {
+		level := 0
+		if best > 0 {
+			level = int(math.Round(s / best * float64(len(sparkLevels)-1)))
+		}
+		sb.WriteRune(sparkLevels[level])
+	}
+	return sb.String()
+}
+
+// summarizeAll returns a row per result, in day order.
+func summarizeAll(results []bench.DayResult) []daySummary {
+	rows := make([]daySummary, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, summarize(r))
+	}
+	slices.SortStableFunc(rows, func(a, b daySummary) int { return cmp.Compare(a.Day, b.Day) })
+	return rows
+}
+
+// MarkdownReport writes results as a GitHub-flavored Markdown table, one
+// row per day in day order, followed by the total monthly savings.
+func MarkdownReport(results []bench.DayResult, w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("| Day | Topic | Best Time (ns/op) | Allocations Saved | Monthly $ Savings | Improvement |\n")
+	sb.WriteString("| ---: | --- | ---: | ---: | ---: | --- |\n")
+
+	total := 0.0
+	for _, row := range summarizeAll(results) {
+		best := "-"
+		if row.BestNs > 0 {
+			best = fmt.Sprintf("%.1f", row.BestNs)
+		}
+		fmt.Fprintf(&sb, "| %d | %s | %s | %.1f | $%.2f | %s |\n", row.Day,
+			markdownEscape(row.Topic), best, row.AllocsSaved, row.Monthly, row.Sparkline)
+		total += row.Monthly
+	}
+	fmt.Fprintf(&sb, "| | **Total** | | | **$%.2f** | |\n", total)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownEscape keeps a topic such as "a | b" from splitting its cell.
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
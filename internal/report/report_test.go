@@ -0,0 +1,97 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/bench"
+)
+
+// fixtureResults are out of day order, and day 22 has no benchmarks.
+var fixtureResults = []bench.DayResult{
+	{Day: 23, Topic: "String vs Integer Map Keys", CostSavingsMonthly: 0.06, Benchmarks: []bench.BenchmarkRecord{
+		{Name: "map[string], 128 B keys", NsPerOp: 18.9},
+		{Name: "map[string], 32 B keys", NsPerOp: 12.4},
+		{Name: "map[int]", NsPerOp: 8.9},
+	}},
+	{Day: 16, Topic: "fmt.Sprintf vs strconv | Integers", CostSavingsMonthly: 12.5, Benchmarks: []bench.BenchmarkRecord{
+		{Name: "fmt.Sprintf", NsPerOp: 80, AllocsPerOp: 2},
+		{Name: "strconv.Itoa", NsPerOp: 20, AllocsPerOp: 1},
+		{Name: "strconv.AppendInt", NsPerOp: 25, AllocsPerOp: 0},
+	}},
+	{Day: 22, Topic: "Zero-copy <unsafe>", CostSavingsMonthly: 0.16},
+}
+
+func TestMarkdownReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := MarkdownReport(fixtureResults, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.Bytes()
+
+	for _, want := range []string{
+		"| Day | Topic | Best Time (ns/op) | Allocations Saved | Monthly $ Savings | Improvement |",
+		`| 16 | fmt.Sprintf vs strconv \| Integers | 20.0 | 2.0 | $12.50 | ▃█▇ |`,
+		"| 22 | Zero-copy <unsafe> | - | 0.0 | $0.16 |  |",
+		"| 23 | String vs Integer Map Keys | 8.9 | 0.0 | $0.06 | ▄▆█ |",
+		"| | **Total** | | | **$12.72** | |",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if i16, i22, i23 := bytes.Index(out, []byte("| 16 |")), bytes.Index(out, []byte("| 22 |")), bytes.Index(out, []byte("| 23 |")); !(i16 < i22 && i22 < i23) {
+		t.Errorf("rows not in day order:\n%s", out)
+	}
+}
+
+func TestHTMLReport(t *testing.T) {
+	var buf bytes.Buffer
+	if err := HTMLReport(fixtureResults, &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.Bytes()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		`<script src="` + chartJSURL + `"></script>`,
+		`type: "bar"`,
+		`labels: ["Day 16","Day 22","Day 23"]`,
+		`data: [12.5,0.16,0.06]`,
+		"<td>16</td><td>fmt.Sprintf vs strconv | Integers</td><td>20.0</td><td>2.0</td><td>$12.50</td><td>▃█▇</td>",
+		"<td>Zero-copy &lt;unsafe&gt;</td><td>-</td>",
+		"<th>$12.72</th>",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if bytes.Contains(out, []byte("<unsafe>")) {
+		t.Error("topic was not HTML-escaped")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	got := sparkline([]bench.BenchmarkRecord{{NsPerOp: 400}, {NsPerOp: 200}, {NsPerOp: 100}, {NsPerOp: 400}})
+	if got != "▃▅█▃" {
+		t.Errorf("got %q, expected the 4x case at █ and the baseline at ▃", got)
+	}
+	if got := sparkline([]bench.BenchmarkRecord{{NsPerOp: 50}}); got != "█" {
+		t.Errorf("a lone baseline is its own best: got %q", got)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestReports_ReturnWriteErrors(t *testing.T) {
+	if err := MarkdownReport(fixtureResults, failingWriter{}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("MarkdownReport: expected the write error, got %v", err)
+	}
+	if err := HTMLReport(fixtureResults, failingWriter{}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("HTMLReport: expected the write error, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package speedup holds helpers for the days' `go test -bench`
+// functions. Demos timed from main use internal/bench instead.
+package speedup
+
+import (
+	"testing"
+)
+
+// ThresholdCheck reports whether a measured speedup meets what the
+// optimization claims. CompareTwo fails the benchmark when it doesn't.
+type ThresholdCheck func(speedup float64) bool
+
+// AtLeast returns a ThresholdCheck that passes when the optimized version
+// is at least min times faster than the baseline.
+func AtLeast(min float64) ThresholdCheck {
+	return func(speedup float64) bool { return speedup >= min }
+}
+
+// CompareTwo runs fn1 (the baseline) and fn2 as sub-benchmarks of b named
+// name1 and name2, and reports fn2's speedup over fn1 as an "x-faster"
+// metric on fn2's line, so the comparison shows up in the -bench output:
+//
+//	Benchmark_Append/NaiveAppend    4521 ns/op
+//	Benchmark_Append/MakeAppend     1150 ns/op   3.931 x-faster
+//
+// Each check runs against the speedup, and the benchmark fails if any
+// returns false. Both sub-benchmarks must run for a speedup to exist, so a
+// -bench pattern that selects only one of them reports and checks nothing.
+func CompareTwo(b *testing.B, name1 string, fn1 func(), name2 string, fn2 func(), checks ...ThresholdCheck) {
+	b.Helper()
+	var baselineNs, speedup float64
+	b.Run(name1, func(b *testing.B) {
+		baselineNs = runTimed(b, fn1)
+	})
+	b.Run(name2, func(b *testing.B) {
+		speedup = 0
+		if ns := runTimed(b, fn2); baselineNs > 0 && ns > 0 {
+			speedup = baselineNs / ns
+			b.ReportMetric(speedup, "x-faster")
+		}
+	})
+	if speedup == 0 {
+		return
+	}
+
+	b.Logf("%s is %.2fx faster than %s", name2, speedup, name1)
+	for i, check := range checks {
+		if !check(speedup) {
+			b.Errorf("%s: %.2fx faster than %s fails threshold check %d", name2, speedup, name1, i)
+		}
+	}
+}
+
+// runTimed calls fn b.N times and returns the time per call in
+// nanoseconds. b.Run calls its function once per round with a growing
+// b.N, so the last return is the one from the measured round.
+func runTimed(b *testing.B, fn func()) float64 {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn()
+	}
+	b.StopTimer()
+	return float64(b.Elapsed().Nanoseconds()) / float64(b.N)
+}
@@ -0,0 +1,66 @@
+package speedup
+
+import (
+	"flag"
+	"testing"
+	"time"
+)
+
+func TestAtLeast(t *testing.T) {
+	check := AtLeast(2)
+	for _, tc := range []struct {
+		speedup float64
+		want    bool
+	}{{1.5, false}, {2, true}, {3, true}} {
+		if got := check(tc.speedup); got != tc.want {
+			t.Errorf("AtLeast(2)(%.1f) = %v, expected %v", tc.speedup, got, tc.want)
+		}
+	}
+}
+
+func slow() { time.Sleep(200 * time.Microsecond) }
+func fast() {}
+
+// shortBenchtime makes testing.Benchmark run each function a fixed 20
+// times instead of for a second, for the rest of t.
+func shortBenchtime(t *testing.T) {
+	t.Helper()
+	f := flag.Lookup("test.benchtime")
+	old := f.Value.String()
+	if err := f.Value.Set("20x"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
+
+func TestCompareTwo(t *testing.T) {
+	shortBenchtime(t)
+	var checked []float64
+	record := func(speedup float64) bool {
+		checked = append(checked, speedup)
+		return true
+	}
+	failed := false
+	testing.Benchmark(func(b *testing.B) {
+		CompareTwo(b, "slow", slow, "fast", fast, record)
+		failed = b.Failed()
+	})
+	if len(checked) != 1 || checked[0] < 100 {
+		t.Errorf("expected one check with a speedup of at least 100x, got %v", checked)
+	}
+	if failed {
+		t.Error("expected a passing check not to fail the benchmark")
+	}
+}
+
+func TestCompareTwoFailsThreshold(t *testing.T) {
+	shortBenchtime(t)
+	failed := false
+	testing.Benchmark(func(b *testing.B) {
+		CompareTwo(b, "fast", fast, "slow", slow, AtLeast(1))
+		failed = b.Failed()
+	})
+	if !failed {
+		t.Error("expected a slowdown to fail AtLeast(1)")
+	}
+}
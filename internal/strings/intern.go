@@ -0,0 +1,36 @@
+// Package strings deduplicates strings built from byte slices, such as
+// header names and column names parsed from the wire, so every occurrence
+// of a value shares one allocation.
+package strings
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// InternPool maps each distinct string to one canonical copy. The zero
+// value is ready to use, and it's safe for concurrent use. Entries are
+// never removed, so only intern values from a bounded set.
+type InternPool struct {
+	m sync.Map // string -> string, the canonical copy
+}
+
+// Intern returns the canonical string equal to b. A value seen before
+// costs no allocation; the first occurrence allocates its copy.
+func (p *InternPool) Intern(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	// Load only hashes and compares the key, and keeps nothing, so a view
+	// of the caller's bytes finds an existing entry without copying them
+	if v, ok := p.m.Load(unsafe.String(unsafe.SliceData(b), len(b))); ok {
+		return v.(string)
+	}
+
+	// A stored key must own its bytes: the caller may reuse b as soon as
+	// Intern returns
+	s := string(b)
+	v, _ := p.m.LoadOrStore(s, s)
+	return v.(string)
+}
@@ -0,0 +1,25 @@
+//go:build !race
+
+package strings
+
+import (
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/testutil"
+)
+
+// The race detector makes allocations of its own, so this can only hold
+// in a normal build.
+
+func Test_InternSeenValueDoesNotAllocate(t *testing.T) {
+	var pool InternPool
+	corpus := makeCorpus()
+	for _, s := range corpus {
+		pool.Intern(s)
+	}
+	testutil.AssertMaxAllocs(t, "Intern of a seen value", 0, func() {
+		for _, s := range corpus {
+			sink = pool.Intern(s)
+		}
+	})
+}
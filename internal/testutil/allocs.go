@@ -0,0 +1,25 @@
+// Package testutil holds the assertions the days' tests share.
+package testutil
+
+import "testing"
+
+// allocRuns is how many times AssertMaxAllocs calls fn. AllocsPerRun
+// averages over them, after one warm-up call that isn't counted.
+const allocRuns = 10
+
+// AssertMaxAllocs fails t if fn allocates more than maxAllocs times per
+// call on average, so a test that knows a day's allocation count guards it
+// instead of only logging it. It returns the measured average for tests
+// that also log or compare it. Under the race detector, which allocates on
+// its own, it skips t instead.
+func AssertMaxAllocs(t testing.TB, name string, maxAllocs float64, fn func()) float64 {
+	t.Helper()
+	if raceEnabled {
+		t.Skipf("%s: alloc counts don't hold under the race detector", name)
+	}
+	allocs := testing.AllocsPerRun(allocRuns, fn)
+	if allocs > maxAllocs {
+		t.Errorf("%s: %.1f allocs per run, expected at most %.0f", name, allocs, maxAllocs)
+	}
+	return allocs
+}
@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"fmt"
+	"testing"
+)
+
+// recordingTB captures Errorf calls instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// Global variable to prevent compiler optimizations
+var sink []byte
+
+func TestAssertMaxAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("alloc counts don't hold under the race detector")
+	}
+	allocate := func() { sink = make([]byte, 64) }
+
+	var r recordingTB
+	if got := AssertMaxAllocs(&r, "within", 1, allocate); got != 1 || len(r.errors) != 0 {
+		t.Errorf("1 alloc against a max of 1: got %.1f allocs, errors %q", got, r.errors)
+	}
+	if got := AssertMaxAllocs(&r, "no-op", 0, func() {}); got != 0 || len(r.errors) != 0 {
+		t.Errorf("no-op against a max of 0: got %.1f allocs, errors %q", got, r.errors)
+	}
+
+	AssertMaxAllocs(&r, "over", 0, allocate)
+	want := "over: 1.0 allocs per run, expected at most 0"
+	if len(r.errors) != 1 || r.errors[0] != want {
+		t.Errorf("expected one error %q, got %q", want, r.errors)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build race
+
+package testutil
+
+// raceEnabled reports whether the race detector is on. It adds allocations
+// of its own and makes sync.Pool drop items at random, so alloc counts
+// measured under it say nothing about the code.
+const raceEnabled = true
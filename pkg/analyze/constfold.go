@@ -0,0 +1,340 @@
+// Package analyze contains static analyzers that find cost-relevant
+// patterns in Go source code.
+package analyze
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConstCandidate is a variable that is initialized from a constant
+// expression and never modified, so it could be declared as a const.
+type ConstCandidate struct {
+	Name  string
+	File  string
+	Line  int
+	Scope string // Enclosing function name, or "package"
+	Expr  string // Initializer as written in the source
+	Value string // Value computed at analysis time via go/constant
+}
+
+// FindExpensiveRuntimeConsts scans the non-test Go files in dir and returns
+// every var (or :=) declaration whose initializer can be folded at compile
+// time and which is never reassigned, incremented, or address-taken.
+// Parse errors yield a nil result.
+func FindExpensiveRuntimeConsts(dir string) []ConstCandidate {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil
+	}
+
+	// Package-level vars may be mutated from any function in any file
+	env := make(map[string]constant.Value)
+	pkgMutated := make(map[string]bool)
+	for _, f := range files {
+		addPackageConsts(f, env)
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
+				collectMutations(fn.Body, pkgMutated)
+			}
+		}
+	}
+
+	var candidates []ConstCandidate
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Tok == token.VAR {
+					candidates = append(candidates, varSpecCandidates(fset, d, "package", env, pkgMutated)...)
+				}
+			case *ast.FuncDecl:
+				if d.Body != nil {
+					candidates = append(candidates, functionCandidates(fset, d, env)...)
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].File != candidates[j].File {
+			return candidates[i].File < candidates[j].File
+		}
+		return candidates[i].Line < candidates[j].Line
+	})
+	return candidates
+}
+
+// EvalConstExpr folds expr using go/constant. Identifiers are resolved
+// through env. It reports false if expr is not a compile-time constant.
+func EvalConstExpr(expr ast.Expr, env map[string]constant.Value) (constant.Value, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		v := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		return v, v.Kind() != constant.Unknown
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return constant.MakeBool(true), true
+		case "false":
+			return constant.MakeBool(false), true
+		}
+		v, ok := env[e.Name]
+		return v, ok
+	case *ast.ParenExpr:
+		return EvalConstExpr(e.X, env)
+	case *ast.UnaryExpr:
+		x, ok := EvalConstExpr(e.X, env)
+		if !ok || e.Op == token.AND || e.Op == token.ARROW {
+			return nil, false
+		}
+		return constant.UnaryOp(e.Op, x, 0), true
+	case *ast.BinaryExpr:
+		x, ok := EvalConstExpr(e.X, env)
+		if !ok {
+			return nil, false
+		}
+		y, ok := EvalConstExpr(e.Y, env)
+		if !ok {
+			return nil, false
+		}
+		return foldBinary(e.Op, x, y)
+	case *ast.CallExpr:
+		// Conversions such as float64(3) are constant when the argument is
+		fun, ok := e.Fun.(*ast.Ident)
+		if !ok || len(e.Args) != 1 || !isBasicTypeName(fun.Name) {
+			return nil, false
+		}
+		v, ok := EvalConstExpr(e.Args[0], env)
+		if !ok {
+			return nil, false
+		}
+		switch fun.Name {
+		case "float32", "float64":
+			v = constant.ToFloat(v)
+		case "string":
+		default:
+			v = constant.ToInt(v)
+		}
+		return v, v.Kind() != constant.Unknown
+	}
+	return nil, false
+}
+
+func foldBinary(op token.Token, x, y constant.Value) (v constant.Value, ok bool) {
+	defer func() {
+		// go/constant panics on invalid operations (e.g. string * int)
+		if recover() != nil {
+			v, ok = nil, false
+		}
+	}()
+
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return constant.MakeBool(constant.Compare(x, op, y)), true
+	case token.SHL, token.SHR:
+		s, exact := constant.Uint64Val(y)
+		if !exact {
+			return nil, false
+		}
+		return constant.Shift(x, op, uint(s)), true
+	case token.QUO:
+		if constant.Sign(y) == 0 {
+			return nil, false
+		}
+		if x.Kind() == constant.Int && y.Kind() == constant.Int {
+			op = token.QUO_ASSIGN // Integer division, as the compiler would do
+		}
+	}
+	return constant.BinaryOp(x, op, y), true
+}
+
+func isBasicTypeName(name string) bool {
+	switch name {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "byte", "rune", "string":
+		return true
+	}
+	return false
+}
+
+// addPackageConsts evaluates the file's top-level const declarations into env.
+func addPackageConsts(f *ast.File, env map[string]constant.Value) {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		addConstSpecs(gen, env)
+	}
+}
+
+func addConstSpecs(gen *ast.GenDecl, env map[string]constant.Value) {
+	for _, spec := range gen.Specs {
+		vs := spec.(*ast.ValueSpec)
+		for i, name := range vs.Names {
+			if i < len(vs.Values) {
+				if v, ok := EvalConstExpr(vs.Values[i], env); ok {
+					env[name.Name] = v
+				}
+			}
+		}
+	}
+}
+
+func functionCandidates(fset *token.FileSet, fn *ast.FuncDecl, pkgEnv map[string]constant.Value) []ConstCandidate {
+	mutated := make(map[string]bool)
+	collectMutations(fn.Body, mutated)
+
+	env := make(map[string]constant.Value, len(pkgEnv))
+	for k, v := range pkgEnv {
+		env[k] = v
+	}
+
+	var out []ConstCandidate
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.FuncLit:
+			return false // Closures have their own lifetime
+		case *ast.DeclStmt:
+			gen, ok := s.Decl.(*ast.GenDecl)
+			if !ok {
+				return true
+			}
+			switch gen.Tok {
+			case token.CONST:
+				addConstSpecs(gen, env)
+			case token.VAR:
+				out = append(out, varSpecCandidates(fset, gen, fn.Name.Name, env, mutated)...)
+			}
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE || len(s.Lhs) != len(s.Rhs) {
+				return true
+			}
+			for i, lhs := range s.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name == "_" || mutated[id.Name] {
+					continue
+				}
+				v, ok := EvalConstExpr(s.Rhs[i], env)
+				if !ok {
+					continue
+				}
+				env[id.Name] = v
+				out = append(out, newCandidate(fset, id, fn.Name.Name, s.Rhs[i], v))
+			}
+		}
+		return true
+	})
+	return out
+}
+
+func varSpecCandidates(fset *token.FileSet, gen *ast.GenDecl, scope string, env map[string]constant.Value, mutated map[string]bool) []ConstCandidate {
+	var out []ConstCandidate
+	for _, spec := range gen.Specs {
+		vs := spec.(*ast.ValueSpec)
+		if len(vs.Values) != len(vs.Names) {
+			continue
+		}
+		for i, name := range vs.Names {
+			if name.Name == "_" || mutated[name.Name] {
+				continue
+			}
+			v, ok := EvalConstExpr(vs.Values[i], env)
+			if !ok {
+				continue
+			}
+			env[name.Name] = v
+			out = append(out, newCandidate(fset, name, scope, vs.Values[i], v))
+		}
+	}
+	return out
+}
+
+func newCandidate(fset *token.FileSet, id *ast.Ident, scope string, expr ast.Expr, v constant.Value) ConstCandidate {
+	pos := fset.Position(id.Pos())
+	return ConstCandidate{
+		Name:  id.Name,
+		File:  pos.Filename,
+		Line:  pos.Line,
+		Scope: scope,
+		Expr:  exprString(fset, expr),
+		Value: v.String(),
+	}
+}
+
+// collectMutations records every identifier that is assigned after its
+// declaration, incremented, or has its address taken.
+func collectMutations(body ast.Node, mutated map[string]bool) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				return true
+			}
+			for _, lhs := range s.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					mutated[id.Name] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if id, ok := s.X.(*ast.Ident); ok {
+				mutated[id.Name] = true
+			}
+		case *ast.UnaryExpr:
+			if id, ok := s.X.(*ast.Ident); ok && s.Op == token.AND {
+				mutated[id.Name] = true
+			}
+		case *ast.RangeStmt:
+			if s.Tok == token.ASSIGN {
+				if id, ok := s.Key.(*ast.Ident); ok {
+					mutated[id.Name] = true
+				}
+				if id, ok := s.Value.(*ast.Ident); ok {
+					mutated[id.Name] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+func exprString(fset *token.FileSet, node ast.Node) string {
+	start := fset.Position(node.Pos())
+	end := fset.Position(node.End())
+	src, err := os.ReadFile(start.Filename)
+	if err != nil || end.Offset > len(src) {
+		return ""
+	}
+	return string(src[start.Offset:end.Offset])
+}
+
+// parseDir parses every non-test .go file in dir.
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,145 @@
+package analyze
+
+import (
+	"go/constant"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func findCandidate(candidates []ConstCandidate, name string) (ConstCandidate, bool) {
+	for _, c := range candidates {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ConstCandidate{}, false
+}
+
+func TestFindExpensiveRuntimeConsts_Day02(t *testing.T) {
+	candidates := FindExpensiveRuntimeConsts("../../day-02")
+	if len(candidates) == 0 {
+		t.Fatal("expected const candidates in day-02")
+	}
+
+	// requestsPerDay is computed from requestsPerSecond and two literals,
+	// so the whole chain folds to the daily request count
+	c, ok := findCandidate(candidates, "requestsPerDay")
+	if !ok {
+		t.Fatalf("expected requestsPerDay to be recommended as const, got %+v", candidates)
+	}
+	if c.Value != "8.64e+06" {
+		t.Errorf("expected requestsPerDay to fold to 8.64e+06, got %s", c.Value)
+	}
+	if c.Scope != "calculateCostImpact" || filepath.Base(c.File) != "main.go" {
+		t.Errorf("unexpected location: %s in %s", c.Scope, c.File)
+	}
+	t.Logf("%s:%d %s := %s → const %s = %s", c.File, c.Line, c.Name, c.Expr, c.Name, c.Value)
+}
+
+func TestFindExpensiveRuntimeConsts_LambdaPricing(t *testing.T) {
+	// day-06 prices Lambda with its own literals: PricingModel only covers
+	// instances, so these stay in calculateWorkerPoolCostImpact
+	candidates := FindExpensiveRuntimeConsts("../../day-06")
+	c, ok := findCandidate(candidates, "lambdaPerGBSecond")
+	if !ok {
+		t.Fatal("expected lambdaPerGBSecond to be recommended as const")
+	}
+	if c.Value != "1.66667e-05" {
+		t.Errorf("expected value 1.66667e-05, got %s", c.Value)
+	}
+
+	// requestFee folds through invocations, itself folded from two literals
+	c, ok = findCandidate(candidates, "requestFee")
+	if !ok {
+		t.Fatal("expected requestFee to be recommended as const")
+	}
+	if c.Value != "518.4" {
+		t.Errorf("expected requestFee to fold to 518.4, got %s", c.Value)
+	}
+}
+
+func TestFindExpensiveRuntimeConsts_SkipsMutatedAndRuntimeValues(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sample
+
+import "time"
+
+const kb = 1024
+
+var pkgLimit = 10 * kb
+var pkgCounter = 0
+
+func bump() { pkgCounter++ }
+
+func work() int {
+	mb := kb * kb
+	start := time.Now()
+	total := 0
+	for i := 0; i < 10; i++ {
+		total += i
+	}
+	ptr := 5
+	p := &ptr
+	_ = start
+	_ = p
+	return mb + total
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	candidates := FindExpensiveRuntimeConsts(dir)
+
+	for _, want := range []string{"pkgLimit", "mb"} {
+		if _, ok := findCandidate(candidates, want); !ok {
+			t.Errorf("expected %s to be a candidate", want)
+		}
+	}
+	for _, reject := range []string{"pkgCounter", "start", "total", "i", "ptr"} {
+		if _, ok := findCandidate(candidates, reject); ok {
+			t.Errorf("did not expect %s to be a candidate", reject)
+		}
+	}
+}
+
+func TestEvalConstExpr(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"1<<20 / 1024", "1024"},
+		{"30.0 / 8.0", "3.75"},
+		{"float64(7) / 2", "3.5"},
+		{"7 / 2", "3"}, // Integer division
+		{"3600 * 24 * 30", "2592000"},
+	}
+
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, ok := EvalConstExpr(expr, nil)
+		if !ok {
+			t.Errorf("%s: expected constant", tt.expr)
+			continue
+		}
+		if v.String() != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.expr, tt.want, v.String())
+		}
+	}
+
+	notConst := []string{"time.Now()", "x + 1", "1 / 0", `"a" * 2`}
+	for _, src := range notConst {
+		expr, err := parser.ParseExpr(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := EvalConstExpr(expr, map[string]constant.Value{}); ok {
+			t.Errorf("%s: expected non-constant", src)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package analyze
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+// DivisionSite is an integer division (or /=) whose divisor is a
+// compile-time constant.
+type DivisionSite struct {
+	File       string
+	Line       int
+	Expr       string // Division as written in the source
+	Divisor    uint64 // Absolute value of the constant divisor
+	Signed     bool   // Dividend is a signed integer type
+	PowerOfTwo bool
+	Shift      int // log2(Divisor) when PowerOfTwo
+}
+
+// Suggestion describes the cheaper equivalent of the division.
+func (s DivisionSite) Suggestion() string {
+	switch {
+	case s.PowerOfTwo && s.Signed:
+		// The compiler must round toward zero for negative dividends,
+		// which costs an extra add and shift around the SAR
+		return ">> " + strconv.Itoa(s.Shift) + " if the dividend is never negative"
+	case s.PowerOfTwo:
+		return ">> " + strconv.Itoa(s.Shift)
+	default:
+		return "reciprocal multiply (automatic while the divisor stays const)"
+	}
+}
+
+// FindConstantDivisions type-checks the non-test Go files in dir and returns
+// every integer division by a constant divisor, sorted by file and line.
+// Float divisions and divisions by variables are not reported.
+func FindConstantDivisions(dir string) ([]DivisionSite, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {}, // Partial type info is enough for most sites
+	}
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	if len(files) > 0 {
+		_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+	}
+
+	var sites []DivisionSite
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var x, y ast.Expr
+			switch e := n.(type) {
+			case *ast.BinaryExpr:
+				if e.Op != token.QUO {
+					return true
+				}
+				x, y = e.X, e.Y
+			case *ast.AssignStmt:
+				if e.Tok != token.QUO_ASSIGN || len(e.Lhs) != 1 {
+					return true
+				}
+				x, y = e.Lhs[0], e.Rhs[0]
+			default:
+				return true
+			}
+
+			site, ok := constantDivision(info, x, y)
+			if !ok {
+				return true
+			}
+			pos := fset.Position(n.Pos())
+			site.File = pos.Filename
+			site.Line = pos.Line
+			site.Expr = exprString(fset, n)
+			sites = append(sites, site)
+			return true
+		})
+	}
+
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+	return sites, nil
+}
+
+// constantDivision reports whether x / y is a runtime integer division by
+// a constant. Divisions the compiler folds entirely (both sides constant)
+// are skipped, since they cost nothing at runtime.
+func constantDivision(info *types.Info, x, y ast.Expr) (DivisionSite, bool) {
+	xt, ok := info.Types[x]
+	if !ok || xt.Value != nil {
+		return DivisionSite{}, false
+	}
+	basic, ok := xt.Type.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return DivisionSite{}, false
+	}
+
+	yt, ok := info.Types[y]
+	if !ok || yt.Value == nil || yt.Value.Kind() != constant.Int {
+		return DivisionSite{}, false
+	}
+	if constant.Sign(yt.Value) < 0 {
+		yt.Value = constant.UnaryOp(token.SUB, yt.Value, 0)
+	}
+	d, exact := constant.Uint64Val(yt.Value)
+	if !exact || d == 0 {
+		return DivisionSite{}, false
+	}
+
+	pow2 := d&(d-1) == 0
+	site := DivisionSite{
+		Divisor:    d,
+		Signed:     basic.Info()&types.IsUnsigned == 0,
+		PowerOfTwo: pow2,
+	}
+	if pow2 {
+		site.Shift = bits.TrailingZeros64(d)
+	}
+	return site, true
+}
@@ -0,0 +1,60 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConstantDivisions(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sample
+
+const bytesPerGB = 1024 * 1024 * 1024
+
+func costs(bytes uint64, seconds int64, ratio float64, n int) (uint64, int64, float64, int) {
+	gb := bytes / bytesPerGB
+	hours := seconds / 3600
+	half := seconds / 2
+	f := ratio / 1024
+	v := n / n
+	n /= 8
+	folded := bytesPerGB / 1024
+	return gb, hours + half, f, v + n + folded
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sites, err := FindConstantDivisions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		expr    string
+		divisor uint64
+		signed  bool
+		shift   int
+		pow2    bool
+	}{
+		{"bytes / bytesPerGB", 1 << 30, false, 30, true},
+		{"seconds / 3600", 3600, true, 0, false},
+		{"seconds / 2", 2, true, 1, true},
+		{"n /= 8", 8, true, 3, true},
+	}
+	if len(sites) != len(want) {
+		t.Fatalf("expected %d sites, got %d: %+v", len(want), len(sites), sites)
+	}
+	for i, w := range want {
+		s := sites[i]
+		if s.Expr != w.expr || s.Divisor != w.divisor || s.Signed != w.signed ||
+			s.PowerOfTwo != w.pow2 || s.Shift != w.shift {
+			t.Errorf("site %d: expected %+v, got %+v", i, w, s)
+		}
+	}
+	if got := sites[0].Suggestion(); got != ">> 30" {
+		t.Errorf("expected \">> 30\", got %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+package analyze
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"runtime"
+	"sort"
+)
+
+// StructPaddingReport describes the memory layout of one struct type.
+type StructPaddingReport struct {
+	Name             string
+	File             string
+	Line             int
+	SizeBytes        int // unsafe.Sizeof equivalent for the current field order
+	DataBytes        int // Sum of field sizes
+	PaddingBytes     int // SizeBytes - DataBytes
+	OptimalSizeBytes int // Size after reordering fields by descending alignment
+}
+
+// ReclaimableBytes returns how many bytes per instance a field reorder saves.
+// Some padding (e.g. trailing bytes after an odd-sized field) can never be
+// removed, so this is often smaller than PaddingBytes.
+func (r StructPaddingReport) ReclaimableBytes() int {
+	return r.SizeBytes - r.OptimalSizeBytes
+}
+
+// AnalyzePackage type-checks the non-test Go files in dir and reports every
+// named struct type, including types declared inside functions. Results are
+// sorted by descending padding bytes.
+func AnalyzePackage(dir string) ([]StructPaddingReport, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := types.SizesFor("gc", runtime.GOARCH)
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Sizes:    sizes,
+		Error:    func(error) {}, // Keep going: layouts are still computable
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	if len(files) > 0 {
+		_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+	}
+
+	var reports []StructPaddingReport
+	for ident, obj := range info.Defs {
+		tn, ok := obj.(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		st, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok || !hasKnownLayout(st) {
+			continue
+		}
+
+		pos := fset.Position(ident.Pos())
+		size := int(sizes.Sizeof(st))
+		data := structDataBytes(sizes, st)
+		reports = append(reports, StructPaddingReport{
+			Name:             tn.Name(),
+			File:             pos.Filename,
+			Line:             pos.Line,
+			SizeBytes:        size,
+			DataBytes:        data,
+			PaddingBytes:     size - data,
+			OptimalSizeBytes: optimalStructSize(sizes, st),
+		})
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].PaddingBytes != reports[j].PaddingBytes {
+			return reports[i].PaddingBytes > reports[j].PaddingBytes
+		}
+		if reports[i].File != reports[j].File {
+			return reports[i].File < reports[j].File
+		}
+		return reports[i].Line < reports[j].Line
+	})
+	return reports, nil
+}
+
+// hasKnownLayout reports whether every field has a concrete size. Generic
+// structs (fields of type parameter type) and fields whose type failed to
+// type-check have no layout, and types.Sizes panics on them.
+func hasKnownLayout(t types.Type) bool {
+	if _, ok := t.(*types.TypeParam); ok {
+		return false
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Kind() != types.Invalid
+	case *types.Array:
+		return hasKnownLayout(u.Elem())
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if !hasKnownLayout(u.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func structDataBytes(sizes types.Sizes, st *types.Struct) int {
+	total := 0
+	for i := 0; i < st.NumFields(); i++ {
+		total += int(sizes.Sizeof(st.Field(i).Type()))
+	}
+	return total
+}
+
+// optimalStructSize simulates reordering fields from largest to smallest
+// alignment (ties broken by size), the same rule day-01 teaches.
+func optimalStructSize(sizes types.Sizes, st *types.Struct) int {
+	fields := make([]*types.Var, st.NumFields())
+	for i := range fields {
+		fields[i] = st.Field(i)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		ai, aj := sizes.Alignof(fields[i].Type()), sizes.Alignof(fields[j].Type())
+		if ai != aj {
+			return ai > aj
+		}
+		return sizes.Sizeof(fields[i].Type()) > sizes.Sizeof(fields[j].Type())
+	})
+
+	// Zero-size fields at the end force padding, so keep them first
+	sort.SliceStable(fields, func(i, j int) bool {
+		return sizes.Sizeof(fields[i].Type()) == 0 && sizes.Sizeof(fields[j].Type()) != 0
+	})
+
+	return int(sizes.Sizeof(types.NewStruct(fields, nil)))
+}
@@ -0,0 +1,90 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func findReport(reports []StructPaddingReport, name string) (StructPaddingReport, bool) {
+	for _, r := range reports {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return StructPaddingReport{}, false
+}
+
+func TestAnalyzePackage_Day01(t *testing.T) {
+	reports, err := AnalyzePackage("../../day-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad, ok := findReport(reports, "BadUser")
+	if !ok {
+		t.Fatalf("expected BadUser in report, got %+v", reports)
+	}
+	good, ok := findReport(reports, "GoodUser")
+	if !ok {
+		t.Fatalf("expected GoodUser in report, got %+v", reports)
+	}
+
+	// Reordering BadUser's fields saves 8 bytes; GoodUser is already optimal.
+	// Both keep 2 bytes of unavoidable tail padding after the bools.
+	if got := bad.ReclaimableBytes(); got != 8 {
+		t.Errorf("BadUser: expected 8 reclaimable bytes, got %d (%+v)", got, bad)
+	}
+	if got := good.ReclaimableBytes(); got != 0 {
+		t.Errorf("GoodUser: expected 0 reclaimable bytes, got %d (%+v)", got, good)
+	}
+	if bad.PaddingBytes-good.PaddingBytes != 8 {
+		t.Errorf("expected BadUser to carry 8 more padding bytes than GoodUser, got %d vs %d",
+			bad.PaddingBytes, good.PaddingBytes)
+	}
+	if bad.OptimalSizeBytes != good.SizeBytes {
+		t.Errorf("expected BadUser optimal size %d to equal GoodUser size %d", bad.OptimalSizeBytes, good.SizeBytes)
+	}
+	if filepath.Base(bad.File) != "main.go" || bad.Line == 0 {
+		t.Errorf("unexpected location %s:%d", bad.File, bad.Line)
+	}
+
+	for _, r := range reports {
+		t.Logf("%-10s size=%d data=%d padding=%d optimal=%d", r.Name, r.SizeBytes, r.DataBytes, r.PaddingBytes, r.OptimalSizeBytes)
+	}
+}
+
+func TestAnalyzePackage_SkipsGenericStructs(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sample
+
+type Box[T any] struct {
+	ok bool
+	v  T
+}
+
+type Flags struct {
+	a bool
+	n int64
+	b bool
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := AnalyzePackage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findReport(reports, "Box"); ok {
+		t.Error("generic struct has no fixed layout and should be skipped")
+	}
+	flags, ok := findReport(reports, "Flags")
+	if !ok {
+		t.Fatal("expected Flags in report")
+	}
+	if flags.SizeBytes != 24 || flags.OptimalSizeBytes != 16 {
+		t.Errorf("expected Flags 24 → 16 bytes, got %d → %d", flags.SizeBytes, flags.OptimalSizeBytes)
+	}
+}
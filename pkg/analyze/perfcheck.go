@@ -0,0 +1,440 @@
+package analyze
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// Check identifiers reported in Finding.Check.
+const (
+	CheckStructPadding   = "struct-padding"
+	CheckSliceNoCap      = "slice-no-cap"
+	CheckMapNoHint       = "map-no-hint"
+	CheckTimeAfterInLoop = "time-after-in-loop"
+	CheckErrorfInLoop    = "errorf-in-loop"
+	CheckAppendInLoop    = "append-in-loop"
+	CheckMarshalAny      = "json-marshal-interface"
+	CheckPoolGetNoNil    = "pool-get-no-nil-check"
+)
+
+// CheckDays maps each check to the day directory that measures its cost,
+// or to "" if no day benchmarks it yet. Its keys are the full checklist.
+var CheckDays = map[string]string{
+	CheckStructPadding:   "day-01",
+	CheckSliceNoCap:      "day-02",
+	CheckMapNoHint:       "day-03",
+	CheckTimeAfterInLoop: "day-185",
+	CheckErrorfInLoop:    "day-25",
+	CheckAppendInLoop:    "day-02",
+	CheckMarshalAny:      "",
+	CheckPoolGetNoNil:    "day-09",
+}
+
+// Finding is one performance anti-pattern found by RunPerfChecks.
+type Finding struct {
+	Check   string
+	File    string
+	Line    int
+	Message string
+}
+
+// Day returns the day directory that covers f.Check, or "" if none does.
+func (f Finding) Day() string { return CheckDays[f.Check] }
+
+// RunPerfChecks type-checks the non-test Go files in dir and reports every
+// anti-pattern from the checklist, sorted by file and line:
+//
+//   - structs with padding bytes
+//   - make([]T, 0) without a capacity
+//   - make(map[K]V) without a size hint
+//   - time.After inside a loop (one timer per iteration)
+//   - fmt.Errorf inside a loop
+//   - append in a loop to a slice declared without capacity
+//   - json.Marshal of an interface{} value (or a map/slice of them)
+//   - sync.Pool.Get results used without a nil check on a pool with no New
+func RunPerfChecks(dir string) ([]Finding, error) {
+	var findings []Finding
+
+	reports, err := AnalyzePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reports {
+		if r.PaddingBytes > 0 {
+			findings = append(findings, Finding{
+				Check: CheckStructPadding,
+				File:  r.File,
+				Line:  r.Line,
+				Message: fmt.Sprintf("struct %s has %d padding bytes (%d reclaimable by reordering fields)",
+					r.Name, r.PaddingBytes, r.ReclaimableBytes()),
+			})
+		}
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {}, // Partial type info is enough for most checks
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	if len(files) > 0 {
+		_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+	}
+
+	c := &perfChecker{fset: fset, info: info, poolsWithNew: poolsWithNew(files, info)}
+	for _, f := range files {
+		c.walk(f)
+	}
+	findings = append(findings, c.findings...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+type perfChecker struct {
+	fset         *token.FileSet
+	info         *types.Info
+	poolsWithNew map[types.Object]bool
+	findings     []Finding
+
+	stack   []ast.Node
+	noCap   map[types.Object]bool // Slices declared in the current function without capacity
+	checked map[types.Object]bool // Vars compared against nil in the current function
+}
+
+func (c *perfChecker) report(n ast.Node, check, format string, args ...any) {
+	pos := c.fset.Position(n.Pos())
+	c.findings = append(c.findings, Finding{
+		Check:   check,
+		File:    pos.Filename,
+		Line:    pos.Line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *perfChecker) walk(f *ast.File) {
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		c.noCap = slicesWithoutCap(fn.Body, c.info)
+		c.checked = nilCheckedVars(fn.Body, c.info)
+		c.stack = c.stack[:0]
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if n == nil {
+				c.stack = c.stack[:len(c.stack)-1]
+				return true
+			}
+			c.visit(n)
+			c.stack = append(c.stack, n)
+			return true
+		})
+	}
+}
+
+func (c *perfChecker) visit(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		c.visitCall(n)
+	case *ast.AssignStmt:
+		c.visitAssign(n)
+	}
+}
+
+func (c *perfChecker) visitCall(call *ast.CallExpr) {
+	if isBuiltin(c.info, call.Fun, "make") && len(call.Args) > 0 {
+		switch c.info.TypeOf(call.Args[0]).(type) {
+		case *types.Map:
+			if len(call.Args) == 1 {
+				c.report(call, CheckMapNoHint, "make(map) without a size hint; pass the expected number of entries")
+			}
+		case *types.Slice:
+			if len(call.Args) == 2 && isConstZero(c.info, call.Args[1]) {
+				c.report(call, CheckSliceNoCap, "make([]T, 0) without capacity; pass the expected length as cap")
+			}
+		}
+		return
+	}
+
+	fn := calledFunc(c.info, call)
+	if fn == nil || fn.Pkg() == nil {
+		return
+	}
+	switch fn.Pkg().Path() + "." + fn.Name() {
+	case "time.After":
+		if c.inLoop() {
+			c.report(call, CheckTimeAfterInLoop, "time.After in a loop allocates a timer per iteration; reuse a time.Timer")
+		}
+	case "fmt.Errorf":
+		if c.inLoop() {
+			c.report(call, CheckErrorfInLoop, "fmt.Errorf in a loop formats and allocates per iteration; use a sentinel or wrap once")
+		}
+	case "encoding/json.Marshal", "encoding/json.MarshalIndent":
+		if len(call.Args) > 0 && holdsInterface(c.info.TypeOf(call.Args[0])) {
+			c.report(call, CheckMarshalAny, "%s of an interface{} value reflects on every element; marshal a concrete type", fn.Name())
+		}
+	case "sync.Get": // Methods print as pkg.Name too
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil && isNamed(recv.Type(), "sync", "Pool") {
+			c.visitPoolGet(call)
+		}
+	}
+}
+
+// visitPoolGet flags a Pool.Get result that can be nil and is used without
+// a nil check: asserted directly, or stored in a variable never compared
+// with nil. Pools whose New field is set never return nil.
+func (c *perfChecker) visitPoolGet(call *ast.CallExpr) {
+	sel, _ := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if sel == nil || c.poolsWithNew[referencedObject(c.info, sel.X)] {
+		return
+	}
+
+	depth := 1
+	for {
+		if _, ok := c.parent(depth).(*ast.ParenExpr); !ok {
+			break
+		}
+		depth++
+	}
+	switch p := c.parent(depth).(type) {
+	case *ast.TypeAssertExpr:
+		if as, ok := c.parent(depth + 1).(*ast.AssignStmt); ok && len(as.Lhs) == 2 {
+			return // v, ok := pool.Get().(T)
+		}
+		c.report(call, CheckPoolGetNoNil, "sync.Pool.Get asserted without a nil check; set Pool.New or use the comma-ok form")
+	case *ast.AssignStmt:
+		for i, rhs := range p.Rhs {
+			if ast.Unparen(rhs) != ast.Expr(call) || i >= len(p.Lhs) {
+				continue
+			}
+			if id, ok := p.Lhs[i].(*ast.Ident); ok && !c.checked[referencedObject(c.info, id)] {
+				c.report(call, CheckPoolGetNoNil, "sync.Pool.Get result %s is never checked for nil; set Pool.New", id.Name)
+			}
+		}
+	}
+}
+
+func (c *perfChecker) visitAssign(as *ast.AssignStmt) {
+	if !c.inLoop() {
+		return
+	}
+	for i, rhs := range as.Rhs {
+		call, ok := ast.Unparen(rhs).(*ast.CallExpr)
+		if !ok || !isBuiltin(c.info, call.Fun, "append") || len(call.Args) == 0 || i >= len(as.Lhs) {
+			continue
+		}
+		obj := referencedObject(c.info, call.Args[0])
+		if obj != nil && c.noCap[obj] && obj == referencedObject(c.info, as.Lhs[i]) {
+			c.report(call, CheckAppendInLoop, "append to %s in a loop, but %s was declared without capacity", obj.Name(), obj.Name())
+		}
+	}
+}
+
+// parent returns the ancestor depth levels above the node being visited.
+func (c *perfChecker) parent(depth int) ast.Node {
+	if len(c.stack) < depth {
+		return nil
+	}
+	return c.stack[len(c.stack)-depth]
+}
+
+// inLoop reports whether the node being visited is inside the body of a
+// for or range loop in the same function. Loops outside an enclosing
+// func literal do not count: the literal may run anywhere.
+func (c *perfChecker) inLoop() bool {
+	for i := len(c.stack) - 1; i >= 0; i-- {
+		switch c.stack[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		}
+	}
+	return false
+}
+
+// slicesWithoutCap finds local slices declared as var s []T, s := []T{},
+// or s := make([]T, 0).
+func slicesWithoutCap(body *ast.BlockStmt, info *types.Info) map[types.Object]bool {
+	objs := make(map[types.Object]bool)
+	noCapInit := func(e ast.Expr) bool {
+		switch e := ast.Unparen(e).(type) {
+		case *ast.CompositeLit:
+			_, isSlice := info.TypeOf(e).(*types.Slice)
+			return isSlice && len(e.Elts) == 0
+		case *ast.CallExpr:
+			return isBuiltin(info, e.Fun, "make") && len(e.Args) == 2 && isConstZero(info, e.Args[1])
+		}
+		return false
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				obj := info.Defs[name]
+				if obj == nil {
+					continue
+				}
+				if _, isSlice := obj.Type().(*types.Slice); !isSlice {
+					continue
+				}
+				if len(n.Values) == 0 || (i < len(n.Values) && noCapInit(n.Values[i])) {
+					objs[obj] = true
+				}
+			}
+		case *ast.AssignStmt:
+			if n.Tok != token.DEFINE || len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && info.Defs[id] != nil && noCapInit(n.Rhs[i]) {
+					objs[info.Defs[id]] = true
+				}
+			}
+		}
+		return true
+	})
+	return objs
+}
+
+// nilCheckedVars finds variables compared with nil anywhere in body.
+func nilCheckedVars(body *ast.BlockStmt, info *types.Info) map[types.Object]bool {
+	objs := make(map[types.Object]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		be, ok := n.(*ast.BinaryExpr)
+		if !ok || (be.Op != token.EQL && be.Op != token.NEQ) {
+			return true
+		}
+		for _, pair := range [][2]ast.Expr{{be.X, be.Y}, {be.Y, be.X}} {
+			if info.Types[pair[1]].IsNil() {
+				if obj := referencedObject(info, pair[0]); obj != nil {
+					objs[obj] = true
+				}
+			}
+		}
+		return true
+	})
+	return objs
+}
+
+// poolsWithNew finds sync.Pool variables initialized with a New field.
+func poolsWithNew(files []*ast.File, info *types.Info) map[types.Object]bool {
+	objs := make(map[types.Object]bool)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			vs, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := ast.Unparen(vs.Values[i]).(*ast.CompositeLit)
+				if !ok || !isNamed(info.TypeOf(lit), "sync", "Pool") {
+					continue
+				}
+				for _, elt := range lit.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "New" {
+							objs[info.Defs[name]] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	return objs
+}
+
+func calledFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	}
+	if id == nil {
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+func isBuiltin(info *types.Info, fun ast.Expr, name string) bool {
+	id, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := info.Uses[id].(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+func isConstZero(info *types.Info, e ast.Expr) bool {
+	v := info.Types[e].Value
+	return v != nil && v.Kind() == constant.Int && constant.Sign(v) == 0
+}
+
+// referencedObject returns the variable an identifier (or &ident) refers to.
+func referencedObject(info *types.Info, e ast.Expr) types.Object {
+	e = ast.Unparen(e)
+	if u, ok := e.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		e = ast.Unparen(u.X)
+	}
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if obj := info.Uses[id]; obj != nil {
+		return obj
+	}
+	return info.Defs[id]
+}
+
+func isNamed(t types.Type, pkg, name string) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == pkg && n.Obj().Name() == name
+}
+
+// holdsInterface reports whether t is an interface, or a map, slice, or
+// array whose elements are interfaces.
+func holdsInterface(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		return true
+	case *types.Map:
+		return types.IsInterface(u.Elem())
+	case *types.Slice:
+		return types.IsInterface(u.Elem())
+	case *types.Array:
+		return types.IsInterface(u.Elem())
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package analyze
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Each flagged line carries a "// want <check>" comment; the test derives
+// the expected findings from those markers.
+const perfcheckBadSrc = `package sample
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Padded struct { // want struct-padding
+	Active bool
+	ID     int64
+	Ready  bool
+}
+
+var bufPool sync.Pool
+
+func process(items []string, events <-chan string) ([]string, error) {
+	ids := make([]string, 0) // want slice-no-cap
+	seen := make(map[string]bool) // want map-no-hint
+	var out []string
+	for _, it := range items {
+		if seen[it] {
+			return nil, fmt.Errorf("duplicate item %q", it) // want errorf-in-loop
+		}
+		seen[it] = true
+		out = append(out, it) // want append-in-loop
+	}
+	for {
+		select {
+		case e := <-events:
+			ids = append(ids, e) // want append-in-loop
+		case <-time.After(time.Second): // want time-after-in-loop
+			return ids, nil
+		}
+	}
+}
+
+func encode(v interface{}) []byte {
+	b, _ := json.Marshal(v) // want json-marshal-interface
+	return b
+}
+
+func borrow() *[]byte {
+	return bufPool.Get().(*[]byte) // want pool-get-no-nil-check
+}
+`
+
+// Correct versions of the same code: nothing here may be reported.
+const perfcheckGoodSrc = `package sample
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+type Packed struct {
+	ID     int64
+	Count  int32
+	Flags  uint16
+	Active bool
+	Ready  bool
+}
+
+var errDuplicate = errors.New("duplicate item")
+
+var slabPool = sync.Pool{New: func() any { return new([64]byte) }}
+
+var rawPool sync.Pool
+
+type Item struct{ Name string }
+
+func processGood(items []string, events <-chan string) ([]string, error) {
+	seen := make(map[string]bool, len(items))
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if seen[it] {
+			return nil, errDuplicate
+		}
+		seen[it] = true
+		out = append(out, it)
+	}
+	timer := time.NewTimer(time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case e := <-events:
+			out = append(out, e)
+		case <-timer.C:
+			return out, nil
+		}
+	}
+}
+
+func encodeGood(it Item) []byte {
+	b, _ := json.Marshal(it)
+	return b
+}
+
+func borrowGood() (*[64]byte, *[]byte) {
+	slab := slabPool.Get().(*[64]byte)
+	raw, ok := rawPool.Get().(*[]byte)
+	if !ok {
+		raw = new([]byte)
+	}
+	v := rawPool.Get()
+	if v == nil {
+		return slab, raw
+	}
+	return slab, raw
+}
+`
+
+func TestRunPerfChecks(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range map[string]string{"bad.go": perfcheckBadSrc, "good.go": perfcheckGoodSrc} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var want []string
+	for i, line := range strings.Split(perfcheckBadSrc, "\n") {
+		if _, check, ok := strings.Cut(line, "// want "); ok {
+			want = append(want, fmt.Sprintf("bad.go:%d %s", i+1, check))
+		}
+	}
+
+	findings, err := RunPerfChecks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s:%d %s", filepath.Base(f.File), f.Line, f.Check))
+		if _, ok := CheckDays[f.Check]; !ok {
+			t.Errorf("%s is not in CheckDays", f.Check)
+		}
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings mismatch\nwant:\n  %s\ngot:\n  %s",
+			strings.Join(want, "\n  "), strings.Join(got, "\n  "))
+	}
+
+	// Every check in the checklist must be exercised by the sample
+	covered := map[string]bool{}
+	for _, f := range findings {
+		covered[f.Check] = true
+	}
+	for check := range CheckDays {
+		if !covered[check] {
+			t.Errorf("check %s not detected", check)
+		}
+	}
+}
+
+func TestCheckDaysExist(t *testing.T) {
+	for check, day := range CheckDays {
+		if day == "" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join("../..", day)); err != nil {
+			t.Errorf("%s links to %s: %v", check, day, err)
+		}
+	}
+}
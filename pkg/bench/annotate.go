@@ -0,0 +1,136 @@
+// Package bench post-processes `go test -bench` output.
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+// MinSavingsFraction is how much faster than the baseline a benchmark must
+// be before it is annotated. Smaller differences are within run-to-run noise.
+const MinSavingsFraction = 0.01
+
+// Result is one parsed benchmark line. Columns missing from the line
+// (B/op and allocs/op without -benchmem) are left at zero.
+type Result struct {
+	Name        string // Without the -GOMAXPROCS suffix
+	Procs       int
+	Iterations  int64
+	NsPerOp     float64
+	BytesPerOp  float64
+	AllocsPerOp float64
+}
+
+// ParseLine parses a line like
+//
+//	BenchmarkFoo-8   1234   56789 ns/op   128 B/op   2 allocs/op
+//
+// and reports false for anything that is not a benchmark result.
+func ParseLine(line string) (Result, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return Result{}, false
+	}
+
+	r := Result{Name: fields[0], Procs: 1}
+	if i := strings.LastIndexByte(r.Name, '-'); i > 0 {
+		if procs, err := strconv.Atoi(r.Name[i+1:]); err == nil {
+			r.Name, r.Procs = r.Name[:i], procs
+		}
+	}
+
+	iterations, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return Result{}, false
+	}
+	r.Iterations = iterations
+
+	// The rest are value/unit pairs; custom b.ReportMetric units are ignored
+	seenNs := false
+	for i := 2; i+1 < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, false
+		}
+		switch fields[i+1] {
+		case "ns/op":
+			r.NsPerOp, seenNs = v, true
+		case "B/op":
+			r.BytesPerOp = v
+		case "allocs/op":
+			r.AllocsPerOp = v
+		}
+	}
+	return r, seenNs
+}
+
+// AnnotateOutput copies benchOutput to w and appends a
+// "# $X.XX/month savings" comment to every benchmark that is measurably
+// faster than the first benchmark in the output. Savings are priced by
+// calc at rps operations per second.
+func AnnotateOutput(benchOutput io.Reader, calc cost.CostCalculator, rps float64, w io.Writer) error {
+	return AnnotateOutputWithBaseline(benchOutput, calc, rps, "", w)
+}
+
+// AnnotateOutputWithBaseline is AnnotateOutput with an explicit baseline
+// benchmark name (with or without the -N suffix). An empty name selects
+// the first benchmark.
+func AnnotateOutputWithBaseline(benchOutput io.Reader, calc cost.CostCalculator, rps float64, baseline string, w io.Writer) error {
+	// Buffer everything: the baseline may appear after the lines it is
+	// compared against, and benchmark output is small
+	var lines []string
+	sc := bufio.NewScanner(benchOutput)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("reading benchmark output: %w", err)
+	}
+
+	base, ok := findBaseline(lines, baseline)
+	if !ok && baseline != "" {
+		return fmt.Errorf("baseline benchmark %q not found", baseline)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		bw.WriteString(line)
+		if r, isBench := ParseLine(line); ok && isBench {
+			if savings, measurable := monthlySavings(base, r, calc, rps); measurable {
+				fmt.Fprintf(bw, "  # $%.2f/month savings", savings)
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func findBaseline(lines []string, name string) (Result, bool) {
+	for _, line := range lines {
+		r, ok := ParseLine(line)
+		if !ok {
+			continue
+		}
+		if name == "" || name == r.Name || name == strings.Fields(line)[0] {
+			return r, true
+		}
+	}
+	return Result{}, false
+}
+
+// monthlySavings prices the ns/op difference between base and r. Only
+// savings that clear MinSavingsFraction and round to at least a cent
+// are measurable.
+func monthlySavings(base, r Result, calc cost.CostCalculator, rps float64) (float64, bool) {
+	nsSaved := base.NsPerOp - r.NsPerOp
+	if nsSaved <= base.NsPerOp*MinSavingsFraction {
+		return 0, false
+	}
+	savings := calc.MonthlyCPUCost(nsSaved, rps)
+	return savings, savings >= 0.005
+}
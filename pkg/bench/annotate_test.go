@@ -0,0 +1,90 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alpardfm/cost-aware-backend/internal/cost"
+)
+
+const sampleOutput = `goos: linux
+goarch: amd64
+pkg: github.com/alpardfm/cost-aware-backend/day-03
+cpu: Intel(R) Xeon(R) CPU @ 2.20GHz
+Benchmark_MapLookup-8     	 1000000	      1000 ns/op	     128 B/op	       2 allocs/op
+Benchmark_SliceLookup-8   	 2000000	       400 ns/op	       0 B/op	       0 allocs/op
+Benchmark_Noise-8         	 1000000	       995 ns/op	     128 B/op	       2 allocs/op
+Benchmark_Slower-8        	  500000	      2000 ns/op
+PASS
+ok  	github.com/alpardfm/cost-aware-backend/day-03	4.123s
+`
+
+func TestParseLine(t *testing.T) {
+	r, ok := ParseLine("Benchmark_MapLookup-8     	 1000000	      1000 ns/op	     128 B/op	       2 allocs/op")
+	if !ok {
+		t.Fatal("expected a benchmark line")
+	}
+	want := Result{Name: "Benchmark_MapLookup", Procs: 8, Iterations: 1000000, NsPerOp: 1000, BytesPerOp: 128, AllocsPerOp: 2}
+	if r != want {
+		t.Errorf("expected %+v, got %+v", want, r)
+	}
+
+	// Without -benchmem, and with a custom metric from b.ReportMetric
+	r, ok = ParseLine("BenchmarkFoo 	 300	 4.5e+06 ns/op	 12.00 MB/s")
+	if !ok || r.Name != "BenchmarkFoo" || r.Procs != 1 || r.NsPerOp != 4.5e6 || r.AllocsPerOp != 0 {
+		t.Errorf("unexpected parse of line without -benchmem: %+v, %v", r, ok)
+	}
+
+	for _, line := range []string{
+		"goos: linux",
+		"PASS",
+		"BenchmarkFoo-8 --- FAIL: oops",
+		"--- BENCH: BenchmarkFoo-8",
+	} {
+		if _, ok := ParseLine(line); ok {
+			t.Errorf("expected %q not to parse", line)
+		}
+	}
+}
+
+func TestAnnotateOutput_OnlyMeasurableSavings(t *testing.T) {
+	var out strings.Builder
+	err := AnnotateOutput(strings.NewReader(sampleOutput), cost.DefaultCalculator(), 100_000, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != strings.Count(sampleOutput, "\n") {
+		t.Fatalf("expected every input line to be copied, got:\n%s", out.String())
+	}
+
+	// 600 ns saved × 100k rps = 0.06 vCPU = $1.80/month
+	for _, line := range lines {
+		annotated := strings.Contains(line, "# $")
+		switch {
+		case strings.HasPrefix(line, "Benchmark_SliceLookup"):
+			if !strings.HasSuffix(line, "# $1.80/month savings") {
+				t.Errorf("expected $1.80/month annotation, got %q", line)
+			}
+		case annotated:
+			t.Errorf("unexpected annotation on %q", line)
+		}
+	}
+}
+
+func TestAnnotateOutput_ExplicitBaseline(t *testing.T) {
+	var out strings.Builder
+	err := AnnotateOutputWithBaseline(strings.NewReader(sampleOutput), cost.DefaultCalculator(), 100_000, "Benchmark_Slower", &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(out.String(), "/month savings"); got != 3 {
+		t.Errorf("expected 3 benchmarks faster than Benchmark_Slower, got %d:\n%s", got, out.String())
+	}
+
+	err = AnnotateOutputWithBaseline(strings.NewReader(sampleOutput), cost.DefaultCalculator(), 100_000, "BenchmarkMissing", &out)
+	if err == nil {
+		t.Error("expected error for unknown baseline")
+	}
+}
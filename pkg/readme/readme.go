@@ -0,0 +1,163 @@
+// Package readme drafts a day's README.md from what the day already
+// prints: its demo's narrative and cost analysis, and `go test -bench`
+// output for the results table.
+package readme
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alpardfm/cost-aware-backend/pkg/bench"
+)
+
+//go:embed readme.md.tmpl
+var readmeTemplate string
+
+var tmpl = template.Must(template.New("readme").Funcs(template.FuncMap{
+	"trimBenchmark": func(name string) string {
+		return strings.TrimPrefix(strings.TrimPrefix(name, "Benchmark"), "_")
+	},
+	// dollars keeps sub-cent savings visible instead of rounding to $0.00
+	"dollars": func(v float64) string {
+		if v != 0 && v < 0.01 {
+			return fmt.Sprintf("$%.6f", v)
+		}
+		return fmt.Sprintf("$%.2f", v)
+	},
+}).Parse(readmeTemplate))
+
+// Day is everything a generated README shows.
+type Day struct {
+	Number      int
+	Dir         string // e.g. day-25, for the run commands
+	Title       string
+	Discovery   string   // The 🎯 SHOCKING DISCOVERY headline
+	Explanation []string // The 💡 paragraphs, one per entry
+	Benchmarks  []bench.Result
+	Cost        CostAnalysis
+}
+
+// CostAnalysis is what the demo's 💰 COST IMPACT ANALYSIS printed. Found
+// is false when the output has no savings lines to extract.
+type CostAnalysis struct {
+	Found       bool
+	Assumptions []string
+	Monthly     float64
+	Annual      float64
+}
+
+var (
+	headerRe    = regexp.MustCompile(`🔬 DAY (\d+): (.+)`)
+	discoveryRe = regexp.MustCompile(`🎯 SHOCKING DISCOVERY: (.+)`)
+	monthlyRe   = regexp.MustCompile(`Monthly savings:\s+\$([0-9.,]+)`)
+	annualRe    = regexp.MustCompile(`Annual savings:\s+\$([0-9.,]+)`)
+)
+
+// ParseDemo extracts the title, the discovery and its explanation, and the
+// cost analysis from a day's demo output. Only the header is required.
+func ParseDemo(out string) (Day, error) {
+	m := headerRe.FindStringSubmatch(out)
+	if m == nil {
+		return Day{}, fmt.Errorf("no \"🔬 DAY N: Title\" header in the demo output")
+	}
+	var d Day
+	d.Number, _ = strconv.Atoi(m[1])
+	d.Title = strings.TrimSpace(m[2])
+	if m := discoveryRe.FindStringSubmatch(out); m != nil {
+		d.Discovery = strings.TrimSpace(m[1])
+	}
+
+	// 💡 paragraphs continue on indented lines until a blank or new section
+	lines := strings.Split(out, "\n")
+	for i := 0; i < len(lines); i++ {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(lines[i]), "💡 ")
+		if !ok {
+			continue
+		}
+		para := []string{rest}
+		for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "   ") && strings.TrimSpace(lines[i+1]) != "" {
+			i++
+			para = append(para, strings.TrimSpace(lines[i]))
+		}
+		d.Explanation = append(d.Explanation, strings.Join(para, " "))
+	}
+
+	d.Cost = parseCost(lines)
+	return d, nil
+}
+
+// parseCost reads the ☁️ ASSUMPTIONS bullets and the first Monthly and
+// Annual savings lines after the 💰 COST IMPACT ANALYSIS header.
+func parseCost(lines []string) CostAnalysis {
+	start := -1
+	for i, line := range lines {
+		if strings.Contains(line, "💰 COST IMPACT ANALYSIS") {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return CostAnalysis{}
+	}
+	lines = lines[start:]
+
+	var c CostAnalysis
+	inAssumptions := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.Contains(line, "ASSUMPTIONS:"):
+			inAssumptions = true
+		case inAssumptions && strings.HasPrefix(trimmed, "• "):
+			c.Assumptions = append(c.Assumptions, strings.TrimPrefix(trimmed, "• "))
+		default:
+			inAssumptions = false
+		}
+	}
+
+	section := strings.Join(lines, "\n")
+	monthly, okM := parseDollars(monthlyRe, section)
+	annual, okA := parseDollars(annualRe, section)
+	c.Found = okM && okA
+	c.Monthly, c.Annual = monthly, annual
+	return c
+}
+
+func parseDollars(re *regexp.Regexp, s string) (float64, bool) {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+	return v, err == nil
+}
+
+// ParseBenchmarks returns every benchmark result line in `go test -bench`
+// output, in order.
+func ParseBenchmarks(r io.Reader) ([]bench.Result, error) {
+	var results []bench.Result
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if res, ok := bench.ParseLine(sc.Text()); ok {
+			results = append(results, res)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading benchmark output: %w", err)
+	}
+	return results, nil
+}
+
+// Write renders d as a README.md draft in the layout the days use.
+func Write(w io.Writer, d Day) error {
+	if d.Dir == "" {
+		d.Dir = fmt.Sprintf("day-%02d", d.Number)
+	}
+	return tmpl.Execute(w, d)
+}
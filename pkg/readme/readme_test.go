@@ -0,0 +1,163 @@
+package readme
+
+import (
+	"strings"
+	"testing"
+)
+
+// sampleDemo is trimmed day-25 output, with the JSON summary left in.
+const sampleDemo = `🔬 DAY 25: Error Allocation Cost
+============================================================
+📅 Date: 2026-10-16
+
+🎯 SHOCKING DISCOVERY: fmt.Errorf allocates a new wrapper every call!
+----------------------------------------
+  Pattern                      dynamic type               allocs    bytes
+  errors.New("invalid age")    *errors.errorString             1       16
+
+💡 fmt.Errorf formats its message into a new string, then wraps it
+   with the original error in a new *fmt.wrapError.
+
+📊 BENCHMARK: 1000000 error creations
+----------------------------------------
+1. errors.New("invalid age"):   33.611649ms   16000005 B 1000000 allocs
+
+📄 JSON summary:
+{
+  "results": [
+    {"name": "errors.New", "ns_per_op": 33611649}
+  ]
+}
+
+💡 A sentinel is allocated once.
+
+💰 COST IMPACT ANALYSIS
+============================================================
+☁️  ASSUMPTIONS:
+  • 1000000 requests/day, 1 in 10 failing validation
+  • AWS us-east-1 t3.medium: $0.0416/hour per vCPU
+
+🧮 CALCULATIONS:
+  Monthly savings: $1,234.50
+  Annual savings:  $14814.00
+
+📈 SCALING PROJECTIONS:
+  •   1M errors/day: $      0.0001/month linear
+  Monthly savings: $9.99
+
+✅ DAY 25 COMPLETED! 🎉
+`
+
+const sampleBench = `goos: linux
+goarch: amd64
+pkg: github.com/alpardfm/cost-aware-backend/day-25
+Benchmark_ErrorsNew-8                     	42505369	        33.68 ns/op	      16 B/op	       1 allocs/op
+Benchmark_Sentinel-8                      	800358630	         1.964 ns/op	       0 B/op	       0 allocs/op
+Benchmark_Decoder/100KB-8                 	     692	   1611454 ns/op	  63.60 MB/s	  560020 B/op	    3765 allocs/op
+PASS
+ok  	github.com/alpardfm/cost-aware-backend/day-25	4.123s
+`
+
+func TestParseDemo(t *testing.T) {
+	d, err := ParseDemo(sampleDemo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Number != 25 || d.Title != "Error Allocation Cost" {
+		t.Errorf("header: got day %d %q", d.Number, d.Title)
+	}
+	if d.Discovery != "fmt.Errorf allocates a new wrapper every call!" {
+		t.Errorf("discovery: got %q", d.Discovery)
+	}
+	wantExplanation := []string{
+		"fmt.Errorf formats its message into a new string, then wraps it with the original error in a new *fmt.wrapError.",
+		"A sentinel is allocated once.",
+	}
+	if len(d.Explanation) != len(wantExplanation) {
+		t.Fatalf("explanation: got %q", d.Explanation)
+	}
+	for i := range wantExplanation {
+		if d.Explanation[i] != wantExplanation[i] {
+			t.Errorf("paragraph %d: got %q, expected %q", i, d.Explanation[i], wantExplanation[i])
+		}
+	}
+
+	c := d.Cost
+	if !c.Found || c.Monthly != 1234.5 || c.Annual != 14814 {
+		t.Errorf("cost: expected the first savings lines, got %+v", c)
+	}
+	if len(c.Assumptions) != 2 || c.Assumptions[1] != "AWS us-east-1 t3.medium: $0.0416/hour per vCPU" {
+		t.Errorf("assumptions: got %q", c.Assumptions)
+	}
+}
+
+func TestParseDemo_Partial(t *testing.T) {
+	if _, err := ParseDemo("no header here"); err == nil {
+		t.Error("expected an error without a DAY header")
+	}
+	d, err := ParseDemo("🔬 DAY 3: Maps\n\n💰 COST IMPACT ANALYSIS\n  nothing to see\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Cost.Found || d.Discovery != "" || len(d.Explanation) != 0 {
+		t.Errorf("expected only the header, got %+v", d)
+	}
+}
+
+func TestParseBenchmarks(t *testing.T) {
+	results, err := ParseBenchmarks(strings.NewReader(sampleBench))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	if r := results[2]; r.Name != "Benchmark_Decoder/100KB" || r.NsPerOp != 1611454 || r.BytesPerOp != 560020 {
+		t.Errorf("MB/s column misparsed: %+v", r)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	d, err := ParseDemo(sampleDemo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Benchmarks, err = ParseBenchmarks(strings.NewReader(sampleBench)); err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := Write(&sb, d); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"# Day 25: Error Allocation Cost\n",
+		"## 🎯 Problem\n**fmt.Errorf allocates a new wrapper every call!**\n",
+		"\nA sentinel is allocated once.\n",
+		"| ErrorsNew | 33.68 | 16 | 1 |\n",
+		"| Sentinel | 1.96 | 0 | 0 |\n",
+		"| Decoder/100KB | 1611454.00 | 560020 | 3765 |\n",
+		"- 1000000 requests/day, 1 in 10 failing validation\n",
+		"Monthly savings: $1234.50\nAnnual savings:  $14814.00\n",
+		"go run ./day-25\n",
+		"#Day25 ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWrite_MissingSections(t *testing.T) {
+	var sb strings.Builder
+	if err := Write(&sb, Day{Number: 7, Title: "Empty", Cost: CostAnalysis{Found: true, Monthly: 0.000011}}); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{"No benchmark results", "$0.000011", "go run ./day-07"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}